@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	gErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/params"
+)
+
+// swagger:route POST /apply apply Apply
+//
+// Converge endpoints, credentials, entities and pools to the state described by a declarative document.
+//
+//	Parameters:
+//	  + name: dryRun
+//	    description: If true, nothing is changed and the response only describes the plan.
+//	    type: boolean
+//	    in: query
+//	    required: false
+//	  + name: prune
+//	    description: If true, repositories, organizations, enterprises and pools missing from the document are removed.
+//	    type: boolean
+//	    in: query
+//	    required: false
+//	  + name: Body
+//	    description: The declarative document to apply.
+//	    type: ApplyDocument
+//	    in: body
+//	    required: true
+//
+//	Responses:
+//	  200: ApplyResult
+//	  default: APIErrorResponse
+func (a *APIController) ApplyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var doc params.ApplyDocument
+	if err := json.NewDecoder(r.Body).Decode(&doc); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to decode request")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+	prune, _ := strconv.ParseBool(r.URL.Query().Get("prune"))
+
+	result, err := a.r.Apply(ctx, doc, dryRun, prune)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to apply document")
+		handleError(ctx, w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route GET /export apply Export
+//
+// Export endpoints, credentials, entities and pools as a declarative document. Secrets are not included.
+//
+//	Responses:
+//	  200: ApplyDocument
+//	  default: APIErrorResponse
+func (a *APIController) ExportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	doc, err := a.r.Export(ctx)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to export state")
+		handleError(ctx, w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(doc); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	gErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/params"
+)
+
+// swagger:route POST /backup backup CreateBackup
+//
+// Create a backup of the GARM database. Secrets are sealed with the given passphrase.
+//
+//	Parameters:
+//	  + name: Body
+//	    description: Parameters used when creating the backup.
+//	    type: CreateBackupParams
+//	    in: body
+//	    required: true
+//
+//	Responses:
+//	  200: Backup
+//	  default: APIErrorResponse
+func (a *APIController) CreateBackupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var param params.CreateBackupParams
+	if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to decode request")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	backup, err := a.r.CreateBackup(ctx, param)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to create backup")
+		handleError(ctx, w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(backup); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route POST /backup/restore backup RestoreBackup
+//
+// Restore a backup into a controller that has no credentials, entities, pool templates or pools.
+//
+// GARM must be restarted after a successful restore.
+//
+//	Parameters:
+//	  + name: Body
+//	    description: The backup and the passphrase used to create it.
+//	    type: RestoreBackupParams
+//	    in: body
+//	    required: true
+//
+//	Responses:
+//	  default: APIErrorResponse
+func (a *APIController) RestoreBackupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var param params.RestoreBackupParams
+	if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to decode request")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	if err := a.r.RestoreBackup(ctx, param); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to restore backup")
+		handleError(ctx, w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -106,15 +106,18 @@ func (a *APIController) handleWorkflowJobEvent(ctx context.Context, w http.Respo
 
 	signature := r.Header.Get("X-Hub-Signature-256")
 	hookType := r.Header.Get("X-Github-Hook-Installation-Target-Type")
+	deliveryID := r.Header.Get("X-Github-Delivery")
 
-	if err := a.r.DispatchWorkflowJob(hookType, signature, body); err != nil {
+	// The workflow job is persisted and processed asynchronously. This allows us to
+	// respond quickly to GitHub, which times out deliveries that take too long.
+	if err := a.r.EnqueueWorkflowJob(deliveryID, hookType, signature, body); err != nil {
 		switch {
 		case errors.Is(err, gErrors.ErrNotFound):
 			metrics.WebhooksReceived.WithLabelValues(
 				"false",         // label: valid
 				"owner_unknown", // label: reason
 			).Inc()
-			slog.With(slog.Any("error", err)).ErrorContext(ctx, "got not found error from DispatchWorkflowJob. webhook not meant for us?")
+			slog.With(slog.Any("error", err)).ErrorContext(ctx, "got not found error from EnqueueWorkflowJob. webhook not meant for us?")
 			return
 		case strings.Contains(err.Error(), "signature"):
 			// nolint:golangci-lint,godox TODO: check error type
@@ -136,6 +139,7 @@ func (a *APIController) handleWorkflowJobEvent(ctx context.Context, w http.Respo
 		"true", // label: valid
 		"",     // label: reason
 	).Inc()
+	w.WriteHeader(http.StatusAccepted)
 }
 
 func (a *APIController) WebhookHandler(w http.ResponseWriter, r *http.Request) {
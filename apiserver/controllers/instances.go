@@ -164,6 +164,135 @@ func (a *APIController) DeleteInstanceHandler(w http.ResponseWriter, r *http.Req
 	w.WriteHeader(http.StatusOK)
 }
 
+// swagger:route POST /instances/{instanceName}/drain instances DrainInstance
+//
+// Drain a runner instance. The runner is removed once it is no longer running a job.
+//
+//	Parameters:
+//	  + name: instanceName
+//	    description: Runner instance name.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+// Responses:
+//
+//	default: APIErrorResponse
+func (a *APIController) DrainInstanceHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	instanceName, ok := vars["instanceName"]
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		if err := json.NewEncoder(w).Encode(params.APIErrorResponse{
+			Error:   "Bad Request",
+			Details: "No instance name specified",
+		}); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+		}
+		return
+	}
+
+	if err := a.r.DrainRunner(ctx, instanceName); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "draining runner")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+}
+
+// swagger:route GET /instances/{instanceName}/console instances GetInstanceConsoleOutput
+//
+// Get the console output of a runner instance.
+//
+// If the provider cannot return the console output, the output captured when the instance
+// failed is returned.
+//
+//	Parameters:
+//	  + name: instanceName
+//	    description: Runner instance name.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+//	Responses:
+//	  200: InstanceConsoleOutput
+//	  default: APIErrorResponse
+func (a *APIController) GetInstanceConsoleOutputHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	instanceName, ok := vars["instanceName"]
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		if err := json.NewEncoder(w).Encode(params.APIErrorResponse{
+			Error:   "Bad Request",
+			Details: "No runner name specified",
+		}); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+		}
+		return
+	}
+
+	output, err := a.r.GetInstanceConsoleOutput(ctx, instanceName)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "fetching console output")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(output); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route GET /instances/{instanceName}/timeline instances GetInstanceTimeline
+//
+// Get the timeline of a runner instance.
+//
+// The timeline holds the lifecycle stages the instance reached and the events recorded
+// for it, including the reason garm removed it, in the order in which they happened.
+//
+//	Parameters:
+//	  + name: instanceName
+//	    description: Runner instance name.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+//	Responses:
+//	  200: InstanceTimeline
+//	  default: APIErrorResponse
+func (a *APIController) GetInstanceTimelineHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	instanceName, ok := vars["instanceName"]
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		if err := json.NewEncoder(w).Encode(params.APIErrorResponse{
+			Error:   "Bad Request",
+			Details: "No runner name specified",
+		}); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+		}
+		return
+	}
+
+	timeline, err := a.r.GetInstanceTimeline(ctx, instanceName)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "fetching instance timeline")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(timeline); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
 // swagger:route GET /repositories/{repoID}/instances repositories instances ListRepoInstances
 //
 // List repository instances.
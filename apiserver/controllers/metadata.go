@@ -109,6 +109,21 @@ func (a *APIController) SystemdUnitFileHandler(w http.ResponseWriter, r *http.Re
 	}
 }
 
+func (a *APIController) RunnerConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	runnerConfig, err := a.r.GetRunnerConfig(ctx)
+	if err != nil {
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(runnerConfig); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
 func (a *APIController) RootCertificateBundleHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
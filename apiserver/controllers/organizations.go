@@ -625,3 +625,60 @@ func (a *APIController) GetOrgWebhookInfoHandler(w http.ResponseWriter, r *http.
 		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
 	}
 }
+
+// swagger:route POST /organizations/{orgID}/webhook/rotate-secret organizations hooks RotateOrgWebhookSecret
+//
+// Rotate the webhook secret of an organization. The previous secret will still be accepted
+// for the duration of the configured grace period, after which it is retired.
+//
+//	Parameters:
+//	  + name: orgID
+//	    description: Organization ID.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+//	  + name: Body
+//	    description: Parameters used when rotating the organization webhook secret.
+//	    type: RotateWebhookSecretParams
+//	    in: body
+//	    required: true
+//
+//	Responses:
+//	  200: HookInfo
+//	  default: APIErrorResponse
+func (a *APIController) RotateOrgWebhookSecretHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	orgID, ok := vars["orgID"]
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		if err := json.NewEncoder(w).Encode(params.APIErrorResponse{
+			Error:   "Bad Request",
+			Details: "No org ID specified",
+		}); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+		}
+		return
+	}
+
+	var rotateParam runnerParams.RotateWebhookSecretParams
+	if err := json.NewDecoder(r.Body).Decode(&rotateParam); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to decode")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	info, err := a.r.RotateOrgWebhookSecret(ctx, orgID, rotateParam)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "rotating webhook secret")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(info); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
@@ -0,0 +1,232 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	gErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/params"
+)
+
+// swagger:route GET /pool-templates pool-templates ListPoolTemplates
+//
+// List all pool templates.
+//
+//	Responses:
+//	  200: PoolTemplates
+//	  default: APIErrorResponse
+func (a *APIController) ListPoolTemplatesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	templates, err := a.r.ListPoolTemplates(ctx)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to list pool templates")
+		handleError(ctx, w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(templates); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route POST /pool-templates pool-templates CreatePoolTemplate
+//
+// Create a pool template.
+//
+//	Parameters:
+//	  + name: Body
+//	    description: Parameters used when creating a pool template.
+//	    type: CreatePoolTemplateParams
+//	    in: body
+//	    required: true
+//
+//	Responses:
+//	  200: PoolTemplate
+//	  default: APIErrorResponse
+func (a *APIController) CreatePoolTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var params params.CreatePoolTemplateParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to decode request")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	template, err := a.r.CreatePoolTemplate(ctx, params)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to create pool template")
+		handleError(ctx, w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(template); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route GET /pool-templates/{templateID} pool-templates GetPoolTemplate
+//
+// Get a pool template.
+//
+//	Parameters:
+//	  + name: templateID
+//	    description: The ID of the pool template.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+//	Responses:
+//	  200: PoolTemplate
+//	  default: APIErrorResponse
+func (a *APIController) GetPoolTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	templateID, ok := vars["templateID"]
+	if !ok {
+		slog.ErrorContext(ctx, "missing template ID in request")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	template, err := a.r.GetPoolTemplate(ctx, templateID)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to get pool template")
+		handleError(ctx, w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(template); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route DELETE /pool-templates/{templateID} pool-templates DeletePoolTemplate
+//
+// Delete a pool template. Templates that still have pools derived from them cannot be removed.
+//
+//	Parameters:
+//	  + name: templateID
+//	    description: The ID of the pool template.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+//	Responses:
+//	  default: APIErrorResponse
+func (a *APIController) DeletePoolTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	templateID, ok := vars["templateID"]
+	if !ok {
+		slog.ErrorContext(ctx, "missing template ID in request")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	if err := a.r.DeletePoolTemplate(ctx, templateID); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to delete pool template")
+		handleError(ctx, w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// swagger:route PUT /pool-templates/{templateID} pool-templates UpdatePoolTemplate
+//
+// Update a pool template and all pools derived from it.
+//
+//	Parameters:
+//	  + name: templateID
+//	    description: The ID of the pool template.
+//	    type: string
+//	    in: path
+//	    required: true
+//	  + name: dryRun
+//	    description: If true, nothing is saved and the response only describes the changes that would be made.
+//	    type: boolean
+//	    in: query
+//	    required: false
+//	  + name: Body
+//	    description: Parameters used when updating a pool template.
+//	    type: UpdatePoolTemplateParams
+//	    in: body
+//	    required: true
+//
+//	Responses:
+//	  200: PoolTemplateUpdateResult
+//	  default: APIErrorResponse
+func (a *APIController) UpdatePoolTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	templateID, ok := vars["templateID"]
+	if !ok {
+		slog.ErrorContext(ctx, "missing template ID in request")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	var params params.UpdatePoolTemplateParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to decode request")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+	result, err := a.r.UpdatePoolTemplate(ctx, templateID, params, dryRun)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to update pool template")
+		handleError(ctx, w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route GET /pool-templates/{templateID}/pools pool-templates ListPoolTemplatePools
+//
+// List all pools derived from a pool template.
+//
+//	Parameters:
+//	  + name: templateID
+//	    description: The ID of the pool template.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+//	Responses:
+//	  200: Pools
+//	  default: APIErrorResponse
+func (a *APIController) ListPoolTemplatePoolsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	templateID, ok := vars["templateID"]
+	if !ok {
+		slog.ErrorContext(ctx, "missing template ID in request")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	pools, err := a.r.ListPoolTemplatePools(ctx, templateID)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to list pool template pools")
+		handleError(ctx, w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(pools); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
@@ -15,6 +15,7 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
@@ -188,3 +189,179 @@ func (a *APIController) UpdatePoolByIDHandler(w http.ResponseWriter, r *http.Req
 		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
 	}
 }
+
+// poolMaintenanceHandler runs a pool maintenance operation on the pool in the
+// request path and writes the updated pool.
+// swagger:route POST /pools/{poolID}/adopt pools AdoptPoolRunners
+//
+// Adopt the runners of a pool that exist in the provider and are registered in GitHub, but are unknown to GARM.
+//
+//	Parameters:
+//	  + name: poolID
+//	    description: ID of the pool.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+//	  + name: Body
+//	    description: Parameters used when adopting runners.
+//	    type: AdoptRunnersParams
+//	    in: body
+//	    required: true
+//
+//	Responses:
+//	  200: AdoptRunnersResult
+//	  default: APIErrorResponse
+func (a *APIController) AdoptPoolRunnersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	poolID, ok := vars["poolID"]
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		if err := json.NewEncoder(w).Encode(params.APIErrorResponse{
+			Error:   "Bad Request",
+			Details: "No pool ID specified",
+		}); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+		}
+		return
+	}
+
+	var adoptData runnerParams.AdoptRunnersParams
+	if err := json.NewDecoder(r.Body).Decode(&adoptData); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to decode")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	result, err := a.r.AdoptPoolRunners(ctx, poolID, adoptData)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "adopting runners")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+func (a *APIController) poolMaintenanceHandler(w http.ResponseWriter, r *http.Request, operation string, do func(ctx context.Context, poolID string) (runnerParams.Pool, error)) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	poolID, ok := vars["poolID"]
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		if err := json.NewEncoder(w).Encode(params.APIErrorResponse{
+			Error:   "Bad Request",
+			Details: "No pool ID specified",
+		}); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+		}
+		return
+	}
+
+	pool, err := do(ctx, poolID)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, operation)
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(pool); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route POST /pools/{poolID}/cordon pools CordonPool
+//
+// Stop creating new runners in a pool. Existing runners are kept.
+//
+//	Parameters:
+//	  + name: poolID
+//	    description: ID of the pool to cordon.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+//	Responses:
+//	  200: Pool
+//	  default: APIErrorResponse
+func (a *APIController) CordonPoolHandler(w http.ResponseWriter, r *http.Request) {
+	a.poolMaintenanceHandler(w, r, "cordoning pool", a.r.CordonPool)
+}
+
+// swagger:route POST /pools/{poolID}/uncordon pools UncordonPool
+//
+// Allow a cordoned pool to create new runners again.
+//
+//	Parameters:
+//	  + name: poolID
+//	    description: ID of the pool to uncordon.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+//	Responses:
+//	  200: Pool
+//	  default: APIErrorResponse
+func (a *APIController) UncordonPoolHandler(w http.ResponseWriter, r *http.Request) {
+	a.poolMaintenanceHandler(w, r, "uncordoning pool", a.r.UncordonPool)
+}
+
+// swagger:route POST /pools/{poolID}/drain pools DrainPool
+//
+// Cordon a pool and drain all its runners. Runners are removed once they are no longer running a job.
+//
+//	Parameters:
+//	  + name: poolID
+//	    description: ID of the pool to drain.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+//	Responses:
+//	  200: Pool
+//	  default: APIErrorResponse
+func (a *APIController) DrainPoolHandler(w http.ResponseWriter, r *http.Request) {
+	a.poolMaintenanceHandler(w, r, "draining pool", a.r.DrainPool)
+}
+
+// swagger:route POST /pools/{poolID}/recycle pools RecyclePool
+//
+// Replace all runners of a pool, a few at a time.
+//
+//	Parameters:
+//	  + name: poolID
+//	    description: ID of the pool to recycle.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+//	  + name: Body
+//	    description: Parameters used when recycling the pool.
+//	    type: RecyclePoolParams
+//	    in: body
+//	    required: true
+//
+//	Responses:
+//	  200: Pool
+//	  default: APIErrorResponse
+func (a *APIController) RecyclePoolHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var recycleData runnerParams.RecyclePoolParams
+	if err := json.NewDecoder(r.Body).Decode(&recycleData); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to decode")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	a.poolMaintenanceHandler(w, r, "recycling pool", func(ctx context.Context, poolID string) (runnerParams.Pool, error) {
+		return a.r.RecyclePool(ctx, poolID, recycleData)
+	})
+}
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	gErrors "github.com/cloudbase/garm-provider-common/errors"
+)
+
+// swagger:route GET /reports/cost reports GetCostReport
+//
+// Get the time runners were up and its cost, by entity, pool and repository.
+//
+//	Parameters:
+//	  + name: since
+//	    description: Only account for the usage that ended after this time (RFC3339). Defaults to all recorded usage.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	Responses:
+//	  200: CostReport
+//	  default: APIErrorResponse
+func (a *APIController) GetCostReportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var since time.Time
+	if value := r.URL.Query().Get("since"); value != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			handleError(ctx, w, gErrors.NewBadRequestError("invalid since parameter: %s", err))
+			return
+		}
+	}
+
+	report, err := a.r.GetCostReport(ctx, since)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to get cost report")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
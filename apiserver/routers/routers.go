@@ -146,6 +146,9 @@ func NewAPIRouter(han *controllers.APIController, authMiddleware, initMiddleware
 	metadataRouter.Handle("/systemd/unit-file", http.HandlerFunc(han.SystemdUnitFileHandler)).Methods("GET", "OPTIONS")
 	metadataRouter.Handle("/system/cert-bundle/", http.HandlerFunc(han.RootCertificateBundleHandler)).Methods("GET", "OPTIONS")
 	metadataRouter.Handle("/system/cert-bundle", http.HandlerFunc(han.RootCertificateBundleHandler)).Methods("GET", "OPTIONS")
+	// Runner agent configuration
+	metadataRouter.Handle("/runner-config/", http.HandlerFunc(han.RunnerConfigHandler)).Methods("GET", "OPTIONS")
+	metadataRouter.Handle("/runner-config", http.HandlerFunc(han.RunnerConfigHandler)).Methods("GET", "OPTIONS")
 
 	// Login
 	authRouter := apiSubRouter.PathPrefix("/auth").Subrouter()
@@ -195,6 +198,13 @@ func NewAPIRouter(han *controllers.APIController, authMiddleware, initMiddleware
 	apiRouter.Handle("/jobs/", http.HandlerFunc(han.ListAllJobs)).Methods("GET", "OPTIONS")
 	apiRouter.Handle("/jobs", http.HandlerFunc(han.ListAllJobs)).Methods("GET", "OPTIONS")
 
+	/////////////
+	// Reports //
+	/////////////
+	// Cost report
+	apiRouter.Handle("/reports/cost/", http.HandlerFunc(han.GetCostReportHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/reports/cost", http.HandlerFunc(han.GetCostReportHandler)).Methods("GET", "OPTIONS")
+
 	///////////
 	// Pools //
 	///////////
@@ -210,10 +220,47 @@ func NewAPIRouter(han *controllers.APIController, authMiddleware, initMiddleware
 	// Update one pool
 	apiRouter.Handle("/pools/{poolID}/", http.HandlerFunc(han.UpdatePoolByIDHandler)).Methods("PUT", "OPTIONS")
 	apiRouter.Handle("/pools/{poolID}", http.HandlerFunc(han.UpdatePoolByIDHandler)).Methods("PUT", "OPTIONS")
+	// Cordon pool
+	apiRouter.Handle("/pools/{poolID}/cordon/", http.HandlerFunc(han.CordonPoolHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/pools/{poolID}/cordon", http.HandlerFunc(han.CordonPoolHandler)).Methods("POST", "OPTIONS")
+	// Uncordon pool
+	apiRouter.Handle("/pools/{poolID}/uncordon/", http.HandlerFunc(han.UncordonPoolHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/pools/{poolID}/uncordon", http.HandlerFunc(han.UncordonPoolHandler)).Methods("POST", "OPTIONS")
+	// Drain pool
+	apiRouter.Handle("/pools/{poolID}/drain/", http.HandlerFunc(han.DrainPoolHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/pools/{poolID}/drain", http.HandlerFunc(han.DrainPoolHandler)).Methods("POST", "OPTIONS")
+	// Recycle pool
+	apiRouter.Handle("/pools/{poolID}/recycle/", http.HandlerFunc(han.RecyclePoolHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/pools/{poolID}/recycle", http.HandlerFunc(han.RecyclePoolHandler)).Methods("POST", "OPTIONS")
+	// Adopt runners
+	apiRouter.Handle("/pools/{poolID}/adopt/", http.HandlerFunc(han.AdoptPoolRunnersHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/pools/{poolID}/adopt", http.HandlerFunc(han.AdoptPoolRunnersHandler)).Methods("POST", "OPTIONS")
 	// List pool instances
 	apiRouter.Handle("/pools/{poolID}/instances/", http.HandlerFunc(han.ListPoolInstancesHandler)).Methods("GET", "OPTIONS")
 	apiRouter.Handle("/pools/{poolID}/instances", http.HandlerFunc(han.ListPoolInstancesHandler)).Methods("GET", "OPTIONS")
 
+	////////////////////
+	// Pool templates //
+	////////////////////
+	// List pool templates
+	apiRouter.Handle("/pool-templates/", http.HandlerFunc(han.ListPoolTemplatesHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/pool-templates", http.HandlerFunc(han.ListPoolTemplatesHandler)).Methods("GET", "OPTIONS")
+	// Create pool template
+	apiRouter.Handle("/pool-templates/", http.HandlerFunc(han.CreatePoolTemplateHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/pool-templates", http.HandlerFunc(han.CreatePoolTemplateHandler)).Methods("POST", "OPTIONS")
+	// Get pool template
+	apiRouter.Handle("/pool-templates/{templateID}/", http.HandlerFunc(han.GetPoolTemplateHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/pool-templates/{templateID}", http.HandlerFunc(han.GetPoolTemplateHandler)).Methods("GET", "OPTIONS")
+	// Delete pool template
+	apiRouter.Handle("/pool-templates/{templateID}/", http.HandlerFunc(han.DeletePoolTemplateHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/pool-templates/{templateID}", http.HandlerFunc(han.DeletePoolTemplateHandler)).Methods("DELETE", "OPTIONS")
+	// Update pool template
+	apiRouter.Handle("/pool-templates/{templateID}/", http.HandlerFunc(han.UpdatePoolTemplateHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/pool-templates/{templateID}", http.HandlerFunc(han.UpdatePoolTemplateHandler)).Methods("PUT", "OPTIONS")
+	// List pools derived from a template
+	apiRouter.Handle("/pool-templates/{templateID}/pools/", http.HandlerFunc(han.ListPoolTemplatePoolsHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/pool-templates/{templateID}/pools", http.HandlerFunc(han.ListPoolTemplatePoolsHandler)).Methods("GET", "OPTIONS")
+
 	/////////////
 	// Runners //
 	/////////////
@@ -223,6 +270,15 @@ func NewAPIRouter(han *controllers.APIController, authMiddleware, initMiddleware
 	// Delete runner
 	apiRouter.Handle("/instances/{instanceName}/", http.HandlerFunc(han.DeleteInstanceHandler)).Methods("DELETE", "OPTIONS")
 	apiRouter.Handle("/instances/{instanceName}", http.HandlerFunc(han.DeleteInstanceHandler)).Methods("DELETE", "OPTIONS")
+	// Drain runner
+	apiRouter.Handle("/instances/{instanceName}/drain/", http.HandlerFunc(han.DrainInstanceHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/instances/{instanceName}/drain", http.HandlerFunc(han.DrainInstanceHandler)).Methods("POST", "OPTIONS")
+	// Get runner console output
+	apiRouter.Handle("/instances/{instanceName}/console/", http.HandlerFunc(han.GetInstanceConsoleOutputHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/instances/{instanceName}/console", http.HandlerFunc(han.GetInstanceConsoleOutputHandler)).Methods("GET", "OPTIONS")
+	// Get runner timeline
+	apiRouter.Handle("/instances/{instanceName}/timeline/", http.HandlerFunc(han.GetInstanceTimelineHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/instances/{instanceName}/timeline", http.HandlerFunc(han.GetInstanceTimelineHandler)).Methods("GET", "OPTIONS")
 	// List runners
 	apiRouter.Handle("/instances/", http.HandlerFunc(han.ListAllInstancesHandler)).Methods("GET", "OPTIONS")
 	apiRouter.Handle("/instances", http.HandlerFunc(han.ListAllInstancesHandler)).Methods("GET", "OPTIONS")
@@ -246,6 +302,10 @@ func NewAPIRouter(han *controllers.APIController, authMiddleware, initMiddleware
 	apiRouter.Handle("/repositories/{repoID}/pools/", http.HandlerFunc(han.CreateRepoPoolHandler)).Methods("POST", "OPTIONS")
 	apiRouter.Handle("/repositories/{repoID}/pools", http.HandlerFunc(han.CreateRepoPoolHandler)).Methods("POST", "OPTIONS")
 
+	// Repo observed actions
+	apiRouter.Handle("/repositories/{repoID}/observed-actions/", http.HandlerFunc(han.ListRepoObservedActionsHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/observed-actions", http.HandlerFunc(han.ListRepoObservedActionsHandler)).Methods("GET", "OPTIONS")
+
 	// Repo instances list
 	apiRouter.Handle("/repositories/{repoID}/instances/", http.HandlerFunc(han.ListRepoInstancesHandler)).Methods("GET", "OPTIONS")
 	apiRouter.Handle("/repositories/{repoID}/instances", http.HandlerFunc(han.ListRepoInstancesHandler)).Methods("GET", "OPTIONS")
@@ -276,6 +336,9 @@ func NewAPIRouter(han *controllers.APIController, authMiddleware, initMiddleware
 		// Get webhook info
 		apiRouter.Handle("/repositories/{repoID}/webhook/", http.HandlerFunc(han.GetRepoWebhookInfoHandler)).Methods("GET", "OPTIONS")
 		apiRouter.Handle("/repositories/{repoID}/webhook", http.HandlerFunc(han.GetRepoWebhookInfoHandler)).Methods("GET", "OPTIONS")
+		// Rotate webhook secret
+		apiRouter.Handle("/repositories/{repoID}/webhook/rotate-secret/", http.HandlerFunc(han.RotateRepoWebhookSecretHandler)).Methods("POST", "OPTIONS")
+		apiRouter.Handle("/repositories/{repoID}/webhook/rotate-secret", http.HandlerFunc(han.RotateRepoWebhookSecretHandler)).Methods("POST", "OPTIONS")
 	}
 	/////////////////////////////
 	// Organizations and pools //
@@ -296,6 +359,10 @@ func NewAPIRouter(han *controllers.APIController, authMiddleware, initMiddleware
 	apiRouter.Handle("/organizations/{orgID}/pools/", http.HandlerFunc(han.CreateOrgPoolHandler)).Methods("POST", "OPTIONS")
 	apiRouter.Handle("/organizations/{orgID}/pools", http.HandlerFunc(han.CreateOrgPoolHandler)).Methods("POST", "OPTIONS")
 
+	// Org observed actions
+	apiRouter.Handle("/organizations/{orgID}/observed-actions/", http.HandlerFunc(han.ListOrgObservedActionsHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/observed-actions", http.HandlerFunc(han.ListOrgObservedActionsHandler)).Methods("GET", "OPTIONS")
+
 	// Org instances list
 	apiRouter.Handle("/organizations/{orgID}/instances/", http.HandlerFunc(han.ListOrgInstancesHandler)).Methods("GET", "OPTIONS")
 	apiRouter.Handle("/organizations/{orgID}/instances", http.HandlerFunc(han.ListOrgInstancesHandler)).Methods("GET", "OPTIONS")
@@ -326,6 +393,9 @@ func NewAPIRouter(han *controllers.APIController, authMiddleware, initMiddleware
 		// Get webhook info
 		apiRouter.Handle("/organizations/{orgID}/webhook/", http.HandlerFunc(han.GetOrgWebhookInfoHandler)).Methods("GET", "OPTIONS")
 		apiRouter.Handle("/organizations/{orgID}/webhook", http.HandlerFunc(han.GetOrgWebhookInfoHandler)).Methods("GET", "OPTIONS")
+		// Rotate webhook secret
+		apiRouter.Handle("/organizations/{orgID}/webhook/rotate-secret/", http.HandlerFunc(han.RotateOrgWebhookSecretHandler)).Methods("POST", "OPTIONS")
+		apiRouter.Handle("/organizations/{orgID}/webhook/rotate-secret", http.HandlerFunc(han.RotateOrgWebhookSecretHandler)).Methods("POST", "OPTIONS")
 	}
 	/////////////////////////////
 	//  Enterprises and pools  //
@@ -346,6 +416,10 @@ func NewAPIRouter(han *controllers.APIController, authMiddleware, initMiddleware
 	apiRouter.Handle("/enterprises/{enterpriseID}/pools/", http.HandlerFunc(han.CreateEnterprisePoolHandler)).Methods("POST", "OPTIONS")
 	apiRouter.Handle("/enterprises/{enterpriseID}/pools", http.HandlerFunc(han.CreateEnterprisePoolHandler)).Methods("POST", "OPTIONS")
 
+	// Enterprise observed actions
+	apiRouter.Handle("/enterprises/{enterpriseID}/observed-actions/", http.HandlerFunc(han.ListEnterpriseObservedActionsHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/observed-actions", http.HandlerFunc(han.ListEnterpriseObservedActionsHandler)).Methods("GET", "OPTIONS")
+
 	// Enterprise instances list
 	apiRouter.Handle("/enterprises/{enterpriseID}/instances/", http.HandlerFunc(han.ListEnterpriseInstancesHandler)).Methods("GET", "OPTIONS")
 	apiRouter.Handle("/enterprises/{enterpriseID}/instances", http.HandlerFunc(han.ListEnterpriseInstancesHandler)).Methods("GET", "OPTIONS")
@@ -411,6 +485,26 @@ func NewAPIRouter(han *controllers.APIController, authMiddleware, initMiddleware
 	apiRouter.Handle("/github/credentials/{id}/", http.HandlerFunc(han.UpdateGithubCredential)).Methods("PUT", "OPTIONS")
 	apiRouter.Handle("/github/credentials/{id}", http.HandlerFunc(han.UpdateGithubCredential)).Methods("PUT", "OPTIONS")
 
+	///////////////////////////////
+	// Declarative configuration //
+	///////////////////////////////
+	// Apply a declarative document
+	apiRouter.Handle("/apply/", http.HandlerFunc(han.ApplyHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/apply", http.HandlerFunc(han.ApplyHandler)).Methods("POST", "OPTIONS")
+	// Export the current state
+	apiRouter.Handle("/export/", http.HandlerFunc(han.ExportHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/export", http.HandlerFunc(han.ExportHandler)).Methods("GET", "OPTIONS")
+
+	////////////////////
+	// Backup/restore //
+	////////////////////
+	// Create a backup
+	apiRouter.Handle("/backup/", http.HandlerFunc(han.CreateBackupHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/backup", http.HandlerFunc(han.CreateBackupHandler)).Methods("POST", "OPTIONS")
+	// Restore a backup
+	apiRouter.Handle("/backup/restore/", http.HandlerFunc(han.RestoreBackupHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/backup/restore", http.HandlerFunc(han.RestoreBackupHandler)).Methods("POST", "OPTIONS")
+
 	/////////////////////////
 	// Websocket endpoints //
 	/////////////////////////
@@ -10,6 +10,8 @@ import (
 	httptransport "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
 
+	"github.com/cloudbase/garm/client/apply"
+	"github.com/cloudbase/garm/client/backup"
 	"github.com/cloudbase/garm/client/controller"
 	"github.com/cloudbase/garm/client/controller_info"
 	"github.com/cloudbase/garm/client/credentials"
@@ -21,8 +23,10 @@ import (
 	"github.com/cloudbase/garm/client/login"
 	"github.com/cloudbase/garm/client/metrics_token"
 	"github.com/cloudbase/garm/client/organizations"
+	"github.com/cloudbase/garm/client/pool_templates"
 	"github.com/cloudbase/garm/client/pools"
 	"github.com/cloudbase/garm/client/providers"
+	"github.com/cloudbase/garm/client/reports"
 	"github.com/cloudbase/garm/client/repositories"
 )
 
@@ -68,6 +72,8 @@ func New(transport runtime.ClientTransport, formats strfmt.Registry) *GarmAPI {
 
 	cli := new(GarmAPI)
 	cli.Transport = transport
+	cli.Apply = apply.New(transport, formats)
+	cli.Backup = backup.New(transport, formats)
 	cli.Controller = controller.New(transport, formats)
 	cli.ControllerInfo = controller_info.New(transport, formats)
 	cli.Credentials = credentials.New(transport, formats)
@@ -79,8 +85,10 @@ func New(transport runtime.ClientTransport, formats strfmt.Registry) *GarmAPI {
 	cli.Login = login.New(transport, formats)
 	cli.MetricsToken = metrics_token.New(transport, formats)
 	cli.Organizations = organizations.New(transport, formats)
+	cli.PoolTemplates = pool_templates.New(transport, formats)
 	cli.Pools = pools.New(transport, formats)
 	cli.Providers = providers.New(transport, formats)
+	cli.Reports = reports.New(transport, formats)
 	cli.Repositories = repositories.New(transport, formats)
 	return cli
 }
@@ -126,6 +134,10 @@ func (cfg *TransportConfig) WithSchemes(schemes []string) *TransportConfig {
 
 // GarmAPI is a client for garm API
 type GarmAPI struct {
+	Apply apply.ClientService
+
+	Backup backup.ClientService
+
 	Controller controller.ClientService
 
 	ControllerInfo controller_info.ClientService
@@ -148,10 +160,14 @@ type GarmAPI struct {
 
 	Organizations organizations.ClientService
 
+	PoolTemplates pool_templates.ClientService
+
 	Pools pools.ClientService
 
 	Providers providers.ClientService
 
+	Reports reports.ClientService
+
 	Repositories repositories.ClientService
 
 	Transport runtime.ClientTransport
@@ -160,6 +176,8 @@ type GarmAPI struct {
 // SetTransport changes the transport on the client and all its subresources
 func (c *GarmAPI) SetTransport(transport runtime.ClientTransport) {
 	c.Transport = transport
+	c.Apply.SetTransport(transport)
+	c.Backup.SetTransport(transport)
 	c.Controller.SetTransport(transport)
 	c.ControllerInfo.SetTransport(transport)
 	c.Credentials.SetTransport(transport)
@@ -171,7 +189,9 @@ func (c *GarmAPI) SetTransport(transport runtime.ClientTransport) {
 	c.Login.SetTransport(transport)
 	c.MetricsToken.SetTransport(transport)
 	c.Organizations.SetTransport(transport)
+	c.PoolTemplates.SetTransport(transport)
 	c.Pools.SetTransport(transport)
 	c.Providers.SetTransport(transport)
+	c.Reports.SetTransport(transport)
 	c.Repositories.SetTransport(transport)
 }
@@ -56,8 +56,14 @@ type ClientOption func(*runtime.ClientOperation)
 type ClientService interface {
 	DeleteInstance(params *DeleteInstanceParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) error
 
+	DrainInstance(params *DrainInstanceParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) error
+
 	GetInstance(params *GetInstanceParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetInstanceOK, error)
 
+	GetInstanceConsoleOutput(params *GetInstanceConsoleOutputParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetInstanceConsoleOutputOK, error)
+
+	GetInstanceTimeline(params *GetInstanceTimelineParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetInstanceTimelineOK, error)
+
 	ListInstances(params *ListInstancesParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListInstancesOK, error)
 
 	ListPoolInstances(params *ListPoolInstancesParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListPoolInstancesOK, error)
@@ -97,6 +103,38 @@ func (a *Client) DeleteInstance(params *DeleteInstanceParams, authInfo runtime.C
 	return nil
 }
 
+/*
+DrainInstance drains a runner instance the runner is removed once it is no longer running a job
+*/
+func (a *Client) DrainInstance(params *DrainInstanceParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) error {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDrainInstanceParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "DrainInstance",
+		Method:             "POST",
+		PathPattern:        "/instances/{instanceName}/drain",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &DrainInstanceReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	_, err := a.transport.Submit(op)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 /*
 GetInstance gets runner instance by name
 */
@@ -135,6 +173,87 @@ func (a *Client) GetInstance(params *GetInstanceParams, authInfo runtime.ClientA
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+GetInstanceConsoleOutput gets the console output of a runner instance
+
+If the provider cannot return the console output, the output captured when the instance failed is returned.
+*/
+func (a *Client) GetInstanceConsoleOutput(params *GetInstanceConsoleOutputParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetInstanceConsoleOutputOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetInstanceConsoleOutputParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "GetInstanceConsoleOutput",
+		Method:             "GET",
+		PathPattern:        "/instances/{instanceName}/console",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetInstanceConsoleOutputReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetInstanceConsoleOutputOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetInstanceConsoleOutputDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+GetInstanceTimeline gets the timeline of a runner instance
+
+The timeline holds the lifecycle stages the instance reached and the events recorded
+for it, including the reason garm removed it, in the order in which they happened.
+*/
+func (a *Client) GetInstanceTimeline(params *GetInstanceTimelineParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetInstanceTimelineOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetInstanceTimelineParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "GetInstanceTimeline",
+		Method:             "GET",
+		PathPattern:        "/instances/{instanceName}/timeline",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetInstanceTimelineReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetInstanceTimelineOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetInstanceTimelineDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 ListInstances gets all runners instances
 */
@@ -72,10 +72,14 @@ type ClientService interface {
 
 	ListOrgInstances(params *ListOrgInstancesParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListOrgInstancesOK, error)
 
+	ListOrgObservedActions(params *ListOrgObservedActionsParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListOrgObservedActionsOK, error)
+
 	ListOrgPools(params *ListOrgPoolsParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListOrgPoolsOK, error)
 
 	ListOrgs(params *ListOrgsParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListOrgsOK, error)
 
+	RotateOrgWebhookSecret(params *RotateOrgWebhookSecretParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*RotateOrgWebhookSecretOK, error)
+
 	UninstallOrgWebhook(params *UninstallOrgWebhookParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) error
 
 	UpdateOrg(params *UpdateOrgParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*UpdateOrgOK, error)
@@ -417,6 +421,44 @@ func (a *Client) ListOrgInstances(params *ListOrgInstancesParams, authInfo runti
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+ListOrgObservedActions lists the actions the pool manager of an organization would have taken in observe only mode
+*/
+func (a *Client) ListOrgObservedActions(params *ListOrgObservedActionsParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListOrgObservedActionsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListOrgObservedActionsParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "ListOrgObservedActions",
+		Method:             "GET",
+		PathPattern:        "/organizations/{orgID}/observed-actions",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ListOrgObservedActionsReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListOrgObservedActionsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ListOrgObservedActionsDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 ListOrgPools lists organization pools
 */
@@ -493,6 +535,46 @@ func (a *Client) ListOrgs(params *ListOrgsParams, authInfo runtime.ClientAuthInf
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+	RotateOrgWebhookSecret Rotate the webhook secret of an organization. The previous secret will still be accepted
+
+for the duration of the configured grace period, after which it is retired.
+*/
+func (a *Client) RotateOrgWebhookSecret(params *RotateOrgWebhookSecretParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*RotateOrgWebhookSecretOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewRotateOrgWebhookSecretParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "RotateOrgWebhookSecret",
+		Method:             "POST",
+		PathPattern:        "/organizations/{orgID}/webhook/rotate-secret",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &RotateOrgWebhookSecretReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*RotateOrgWebhookSecretOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*RotateOrgWebhookSecretDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 UninstallOrgWebhook uninstalls organization webhook
 */
@@ -54,17 +54,103 @@ type ClientOption func(*runtime.ClientOperation)
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	AdoptPoolRunners(params *AdoptPoolRunnersParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*AdoptPoolRunnersOK, error)
+
+	CordonPool(params *CordonPoolParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*CordonPoolOK, error)
+
 	DeletePool(params *DeletePoolParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) error
 
+	DrainPool(params *DrainPoolParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*DrainPoolOK, error)
+
 	GetPool(params *GetPoolParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetPoolOK, error)
 
 	ListPools(params *ListPoolsParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListPoolsOK, error)
 
+	RecyclePool(params *RecyclePoolParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*RecyclePoolOK, error)
+
+	UncordonPool(params *UncordonPoolParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*UncordonPoolOK, error)
+
 	UpdatePool(params *UpdatePoolParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*UpdatePoolOK, error)
 
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+AdoptPoolRunners adopts the runners of a pool that exist in the provider and are registered in GitHub but are unknown to GARM
+*/
+func (a *Client) AdoptPoolRunners(params *AdoptPoolRunnersParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*AdoptPoolRunnersOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewAdoptPoolRunnersParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "AdoptPoolRunners",
+		Method:             "POST",
+		PathPattern:        "/pools/{poolID}/adopt",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &AdoptPoolRunnersReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*AdoptPoolRunnersOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*AdoptPoolRunnersDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+CordonPool stops creating new runners in a pool existing runners are kept
+*/
+func (a *Client) CordonPool(params *CordonPoolParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*CordonPoolOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCordonPoolParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "CordonPool",
+		Method:             "POST",
+		PathPattern:        "/pools/{poolID}/cordon",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &CordonPoolReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CordonPoolOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*CordonPoolDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 DeletePool deletes pool by ID
 */
@@ -97,6 +183,44 @@ func (a *Client) DeletePool(params *DeletePoolParams, authInfo runtime.ClientAut
 	return nil
 }
 
+/*
+DrainPool cordons a pool and drains all its runners runners are removed once they are no longer running a job
+*/
+func (a *Client) DrainPool(params *DrainPoolParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*DrainPoolOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDrainPoolParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "DrainPool",
+		Method:             "POST",
+		PathPattern:        "/pools/{poolID}/drain",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &DrainPoolReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*DrainPoolOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*DrainPoolDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 GetPool gets pool by ID
 */
@@ -173,6 +297,82 @@ func (a *Client) ListPools(params *ListPoolsParams, authInfo runtime.ClientAuthI
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+RecyclePool replaces all runners of a pool a few at a time
+*/
+func (a *Client) RecyclePool(params *RecyclePoolParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*RecyclePoolOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewRecyclePoolParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "RecyclePool",
+		Method:             "POST",
+		PathPattern:        "/pools/{poolID}/recycle",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &RecyclePoolReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*RecyclePoolOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*RecyclePoolDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+UncordonPool allows a cordoned pool to create new runners again
+*/
+func (a *Client) UncordonPool(params *UncordonPoolParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*UncordonPoolOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewUncordonPoolParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "UncordonPool",
+		Method:             "POST",
+		PathPattern:        "/pools/{poolID}/uncordon",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &UncordonPoolReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*UncordonPoolOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*UncordonPoolDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 UpdatePool updates pool by ID
 */
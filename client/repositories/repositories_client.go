@@ -72,10 +72,14 @@ type ClientService interface {
 
 	ListRepoInstances(params *ListRepoInstancesParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListRepoInstancesOK, error)
 
+	ListRepoObservedActions(params *ListRepoObservedActionsParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListRepoObservedActionsOK, error)
+
 	ListRepoPools(params *ListRepoPoolsParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListRepoPoolsOK, error)
 
 	ListRepos(params *ListReposParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListReposOK, error)
 
+	RotateRepoWebhookSecret(params *RotateRepoWebhookSecretParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*RotateRepoWebhookSecretOK, error)
+
 	UninstallRepoWebhook(params *UninstallRepoWebhookParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) error
 
 	UpdateRepo(params *UpdateRepoParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*UpdateRepoOK, error)
@@ -417,6 +421,44 @@ func (a *Client) ListRepoInstances(params *ListRepoInstancesParams, authInfo run
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+ListRepoObservedActions lists the actions the pool manager of a repository would have taken in observe only mode
+*/
+func (a *Client) ListRepoObservedActions(params *ListRepoObservedActionsParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListRepoObservedActionsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListRepoObservedActionsParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "ListRepoObservedActions",
+		Method:             "GET",
+		PathPattern:        "/repositories/{repoID}/observed-actions",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ListRepoObservedActionsReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListRepoObservedActionsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ListRepoObservedActionsDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 ListRepoPools lists repository pools
 */
@@ -493,6 +535,46 @@ func (a *Client) ListRepos(params *ListReposParams, authInfo runtime.ClientAuthI
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+	RotateRepoWebhookSecret Rotate the webhook secret of a repository. The previous secret will still be accepted
+
+for the duration of the configured grace period, after which it is retired.
+*/
+func (a *Client) RotateRepoWebhookSecret(params *RotateRepoWebhookSecretParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*RotateRepoWebhookSecretOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewRotateRepoWebhookSecretParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "RotateRepoWebhookSecret",
+		Method:             "POST",
+		PathPattern:        "/repositories/{repoID}/webhook/rotate-secret",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &RotateRepoWebhookSecretReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*RotateRepoWebhookSecretOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*RotateRepoWebhookSecretDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 UninstallRepoWebhook uninstalls organization webhook
 */
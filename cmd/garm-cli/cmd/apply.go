@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	apiClientApply "github.com/cloudbase/garm/client/apply"
+	"github.com/cloudbase/garm/cmd/garm-cli/common"
+	"github.com/cloudbase/garm/params"
+)
+
+var (
+	applyFile   string
+	applyDryRun bool
+	applyPrune  bool
+)
+
+var applyCmd = &cobra.Command{
+	Use:          "apply",
+	SilenceUsage: true,
+	Short:        "Apply a declarative configuration",
+	Long: `Converge endpoints, credentials, repositories, organizations, enterprises
+and their pools to the state described in a YAML or JSON document.
+
+Credentials are only referenced by name and must be created beforehand using
+"garm-cli github credentials add". Pools are matched with existing pools by ID,
+if set, or by provider and tags otherwise.
+
+The planned changes are printed. Use --dry-run to only print the plan. Use --prune
+to also remove repositories, organizations, enterprises and pools that are not
+part of the document. Endpoints and credentials are never removed.
+
+The document has the same format as the output of "garm-cli export".`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		doc, err := readApplyDocument(applyFile)
+		if err != nil {
+			return err
+		}
+
+		applyReq := apiClientApply.NewApplyParams()
+		applyReq.Body = doc
+		applyReq.DryRun = &applyDryRun
+		applyReq.Prune = &applyPrune
+		response, err := apiCli.Apply.Apply(applyReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatApplyResult(response.Payload)
+		return nil
+	},
+}
+
+var exportCmd = &cobra.Command{
+	Use:          "export",
+	SilenceUsage: true,
+	Short:        "Export the current configuration",
+	Long: `Export endpoints, credentials, repositories, organizations, enterprises
+and their pools as a document that can be used with "garm-cli apply".
+
+Secrets are not exported. The document is printed as YAML, or as JSON if
+--format=json is used.`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		exportReq := apiClientApply.NewExportParams()
+		response, err := apiCli.Apply.Export(exportReq, authToken)
+		if err != nil {
+			return err
+		}
+
+		if outputFormat == common.OutputFormatJSON {
+			printAsJSON(response.Payload)
+			return nil
+		}
+		asYAML, err := yaml.Marshal(response.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal document: %w", err)
+		}
+		fmt.Print(string(asYAML))
+		return nil
+	},
+}
+
+func readApplyDocument(path string) (params.ApplyDocument, error) {
+	var reader io.Reader
+	if path == "-" {
+		reader = os.Stdin
+	} else {
+		fd, err := os.Open(path)
+		if err != nil {
+			return params.ApplyDocument{}, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer fd.Close()
+		reader = fd
+	}
+
+	// JSON is valid YAML, so both formats are handled by the YAML decoder.
+	var doc params.ApplyDocument
+	decoder := yaml.NewDecoder(reader)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&doc); err != nil && err != io.EOF {
+		return params.ApplyDocument{}, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return doc, nil
+}
+
+func formatApplyResult(result params.ApplyResult) {
+	if outputFormat == common.OutputFormatJSON {
+		printAsJSON(result)
+		return
+	}
+
+	if len(result.Actions) == 0 {
+		fmt.Println("No changes.")
+		return
+	}
+
+	t := table.NewWriter()
+	header := table.Row{"Action", "Kind", "Name", "ID", "Changes"}
+	t.AppendHeader(header)
+	for _, action := range result.Actions {
+		var changes []string
+		for _, change := range action.Changes {
+			if change.Old == "" && change.New == "" {
+				changes = append(changes, change.Field)
+				continue
+			}
+			changes = append(changes, fmt.Sprintf("%s: %q -> %q", change.Field, change.Old, change.New))
+		}
+		t.AppendRow(table.Row{action.Action, action.Kind, action.Name, action.ID, strings.Join(changes, "\n")})
+		t.AppendSeparator()
+	}
+	fmt.Println(t.Render())
+
+	if result.DryRun {
+		fmt.Println("Dry run: no changes were made.")
+	}
+}
+
+func init() {
+	applyCmd.Flags().StringVarP(&applyFile, "file", "f", "", "The YAML or JSON document to apply. Use - to read from standard input.")
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Only print the changes that would be made.")
+	applyCmd.Flags().BoolVar(&applyPrune, "prune", false, "Remove repositories, organizations, enterprises and pools that are not part of the document.")
+	applyCmd.MarkFlagRequired("file") //nolint
+
+	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	apiClientBackup "github.com/cloudbase/garm/client/backup"
+	"github.com/cloudbase/garm/cmd/garm-cli/common"
+	"github.com/cloudbase/garm/params"
+)
+
+var (
+	backupFile       string
+	backupPassphrase string
+)
+
+var backupCmd = &cobra.Command{
+	Use:          "backup",
+	SilenceUsage: true,
+	Short:        "Backup and restore the GARM database",
+	Long: `Backup and restore the GARM database.
+
+A backup holds the controller info, users, github endpoints, credentials,
+repositories, organizations, enterprises, pool templates and pools. Runners,
+jobs and pending webhook deliveries are not part of the backup.
+
+Credentials and webhook secrets are encrypted using a backup passphrase that
+must be exactly 32 characters long. The same passphrase is needed to restore
+the backup.`,
+	Run: nil,
+}
+
+var backupCreateCmd = &cobra.Command{
+	Use:          "create",
+	SilenceUsage: true,
+	Short:        "Create a backup",
+	Long:         `Create a backup of the GARM database and write it to a file.`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		passphrase, err := getBackupPassphrase(true)
+		if err != nil {
+			return err
+		}
+
+		createReq := apiClientBackup.NewCreateBackupParams()
+		createReq.Body = params.CreateBackupParams{
+			Passphrase: passphrase,
+		}
+		response, err := apiCli.Backup.CreateBackup(createReq, authToken)
+		if err != nil {
+			return err
+		}
+
+		asJSON, err := json.MarshalIndent(response.Payload, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal backup: %w", err)
+		}
+		if backupFile == "-" {
+			fmt.Println(string(asJSON))
+			return nil
+		}
+		if err := os.WriteFile(backupFile, asJSON, 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", backupFile, err)
+		}
+		fmt.Printf("Backup written to %s\n", backupFile)
+		return nil
+	},
+}
+
+var backupRestoreCmd = &cobra.Command{
+	Use:          "restore",
+	SilenceUsage: true,
+	Short:        "Restore a backup",
+	Long: `Restore a backup created with "garm-cli backup create".
+
+A backup can only be restored into a controller that has no credentials,
+repositories, organizations, enterprises, pool templates or pools. Existing
+users and github endpoints are kept. The controller ID is replaced with the
+one in the backup, so GARM must be restarted once the restore is done.`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		if backupFile == "-" && backupPassphrase == "" {
+			return fmt.Errorf("--passphrase is required when reading the backup from standard input")
+		}
+
+		backup, err := readBackup(backupFile)
+		if err != nil {
+			return err
+		}
+
+		passphrase, err := getBackupPassphrase(false)
+		if err != nil {
+			return err
+		}
+
+		restoreReq := apiClientBackup.NewRestoreBackupParams()
+		restoreReq.Body = params.RestoreBackupParams{
+			Passphrase: passphrase,
+			Backup:     backup,
+		}
+		if err := apiCli.Backup.RestoreBackup(restoreReq, authToken); err != nil {
+			return err
+		}
+		fmt.Println("Backup restored. Restart GARM to load the restored controller.")
+		return nil
+	},
+}
+
+func getBackupPassphrase(confirm bool) (string, error) {
+	if backupPassphrase != "" {
+		return backupPassphrase, nil
+	}
+
+	passphrase, err := common.PromptPassword("Backup passphrase", "")
+	if err != nil {
+		return "", err
+	}
+	if confirm {
+		if _, err := common.PromptPassword("Confirm backup passphrase", passphrase); err != nil {
+			return "", err
+		}
+	}
+	return passphrase, nil
+}
+
+func readBackup(path string) (params.Backup, error) {
+	var reader io.Reader
+	if path == "-" {
+		reader = os.Stdin
+	} else {
+		fd, err := os.Open(path)
+		if err != nil {
+			return params.Backup{}, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer fd.Close()
+		reader = fd
+	}
+
+	var backup params.Backup
+	if err := json.NewDecoder(reader).Decode(&backup); err != nil {
+		return params.Backup{}, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return backup, nil
+}
+
+func init() {
+	backupCreateCmd.Flags().StringVarP(&backupFile, "output", "o", "", "The file to write the backup to. Use - to write to standard output.")
+	backupCreateCmd.Flags().StringVar(&backupPassphrase, "passphrase", "", "The 32 character passphrase used to encrypt secrets in the backup. If not set, you will be prompted for it.")
+	backupCreateCmd.MarkFlagRequired("output") //nolint
+
+	backupRestoreCmd.Flags().StringVarP(&backupFile, "file", "f", "", "The backup file to restore. Use - to read from standard input.")
+	backupRestoreCmd.Flags().StringVar(&backupPassphrase, "passphrase", "", "The passphrase used when the backup was created. If not set, you will be prompted for it.")
+	backupRestoreCmd.MarkFlagRequired("file") //nolint
+
+	backupCmd.AddCommand(
+		backupCreateCmd,
+		backupRestoreCmd,
+	)
+
+	rootCmd.AddCommand(backupCmd)
+}
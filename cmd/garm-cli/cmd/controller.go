@@ -16,6 +16,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
@@ -26,6 +27,11 @@ import (
 	"github.com/cloudbase/garm/params"
 )
 
+var (
+	partitionLabel        string
+	partitionRepositories []string
+)
+
 var controllerCmd = &cobra.Command{
 	Use:          "controller",
 	Aliases:      []string{"controller-info"},
@@ -118,9 +124,21 @@ up the GARM controller URLs as:
 			params.MinimumJobAgeBackoff = &minimumJobAgeBackoff
 		}
 
-		if params.WebhookURL == nil && params.MetadataURL == nil && params.CallbackURL == nil && params.MinimumJobAgeBackoff == nil {
+		if cmd.Flags().Changed("observe-only") {
+			params.ObserveOnly = &observeOnly
+		}
+
+		if cmd.Flags().Changed("partition-label") {
+			params.PartitionLabel = &partitionLabel
+		}
+
+		if cmd.Flags().Changed("partition-repositories") {
+			params.PartitionRepositories = nonEmptyValues(partitionRepositories)
+		}
+
+		if params.WebhookURL == nil && params.MetadataURL == nil && params.CallbackURL == nil && params.MinimumJobAgeBackoff == nil && params.ObserveOnly == nil && params.PartitionLabel == nil && params.PartitionRepositories == nil {
 			cmd.Help()
-			return fmt.Errorf("at least one of minimum-job-age-backoff, metadata-url, callback-url or webhook-url must be provided")
+			return fmt.Errorf("at least one of minimum-job-age-backoff, metadata-url, callback-url, webhook-url, observe-only, partition-label or partition-repositories must be provided")
 		}
 
 		updateUrlsReq := apiClientController.NewUpdateControllerParams()
@@ -160,6 +178,13 @@ func renderControllerInfoTable(info params.ControllerInfo) string {
 	t.AppendRow(table.Row{"Webhook Base URL", info.WebhookURL})
 	t.AppendRow(table.Row{"Controller Webhook URL", info.ControllerWebhookURL})
 	t.AppendRow(table.Row{"Minimum Job Age Backoff", info.MinimumJobAgeBackoff})
+	t.AppendRow(table.Row{"Observe Only", info.ObserveOnly})
+	if info.PartitionLabel != "" {
+		t.AppendRow(table.Row{"Partition Label", info.PartitionLabel})
+	}
+	if len(info.PartitionRepositories) > 0 {
+		t.AppendRow(table.Row{"Partition Repositories", strings.Join(info.PartitionRepositories, "\n")})
+	}
 	t.AppendRow(table.Row{"Version", serverVersion})
 	return t.Render()
 }
@@ -178,6 +203,9 @@ func init() {
 	controllerUpdateCmd.Flags().StringVarP(&callbackURL, "callback-url", "c", "", "The callback URL for the controller (ie. https://garm.example.com/api/v1/callbacks)")
 	controllerUpdateCmd.Flags().StringVarP(&webhookURL, "webhook-url", "w", "", "The webhook URL for the controller (ie. https://garm.example.com/webhooks)")
 	controllerUpdateCmd.Flags().UintVarP(&minimumJobAgeBackoff, "minimum-job-age-backoff", "b", 0, "The minimum job age backoff for the controller")
+	controllerUpdateCmd.Flags().BoolVar(&observeOnly, "observe-only", false, "Only record the actions the pool managers of all entities would take, without creating or removing runners.")
+	controllerUpdateCmd.Flags().StringVar(&partitionLabel, "partition-label", "", "Only handle jobs that request this label. Runners of this controller get the label as well. Set to an empty string to disable.")
+	controllerUpdateCmd.Flags().StringSliceVar(&partitionRepositories, "partition-repositories", nil, "Only handle jobs of these repositories (owner/name), as a comma separated list. Set to an empty string to disable.")
 
 	controllerCmd.AddCommand(
 		controllerShowCmd,
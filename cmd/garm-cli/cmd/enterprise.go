@@ -149,7 +149,7 @@ var enterpriseUpdateCmd = &cobra.Command{
 	Short:        "Update enterprise",
 	Long:         `Update enterprise credentials or webhook secret.`,
 	SilenceUsage: true,
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if needsInit {
 			return errNeedsInitError
 		}
@@ -167,6 +167,29 @@ var enterpriseUpdateCmd = &cobra.Command{
 			CredentialsName:  repoCreds,
 			PoolBalancerType: params.PoolBalancerType(poolBalancerType),
 		}
+		if cmd.Flags().Changed("observe-only") {
+			updateEnterpriseReq.Body.ObserveOnly = &observeOnly
+		}
+
+		if jobSchedulingFlagsChanged(cmd) {
+			// The job scheduling settings are replaced as a whole. Keep the values
+			// that were not changed on the command line.
+			getReq := apiClientEnterprises.NewGetEnterpriseParams()
+			getReq.EnterpriseID = args[0]
+			current, err := apiCli.Enterprises.GetEnterprise(getReq, authToken)
+			if err != nil {
+				return err
+			}
+			var settings params.JobScheduling
+			if current.Payload.JobScheduling != nil {
+				settings = *current.Payload.JobScheduling
+			}
+			scheduling, err := jobSchedulingFromFlags(cmd, settings)
+			if err != nil {
+				return err
+			}
+			updateEnterpriseReq.Body.JobScheduling = &scheduling
+		}
 		updateEnterpriseReq.EnterpriseID = args[0]
 		response, err := apiCli.Enterprises.UpdateEnterprise(updateEnterpriseReq, authToken)
 		if err != nil {
@@ -177,6 +200,34 @@ var enterpriseUpdateCmd = &cobra.Command{
 	},
 }
 
+var enterpriseObservedActionsCmd = &cobra.Command{
+	Use:   "observed-actions",
+	Short: "List observed actions",
+	Long: `List the actions the pool manager of an enterprise would have taken
+while running in observe only mode.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("requires an enterprise ID")
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		listActionsReq := apiClientEnterprises.NewListEnterpriseObservedActionsParams()
+		listActionsReq.EnterpriseID = args[0]
+		response, err := apiCli.Enterprises.ListEnterpriseObservedActions(listActionsReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatObservedActions(response.Payload)
+		return nil
+	},
+}
+
 func init() {
 	enterpriseAddCmd.Flags().StringVar(&enterpriseName, "name", "", "The name of the enterprise")
 	enterpriseAddCmd.Flags().StringVar(&enterpriseWebhookSecret, "webhook-secret", "", "The webhook secret for this enterprise")
@@ -188,6 +239,8 @@ func init() {
 	enterpriseUpdateCmd.Flags().StringVar(&enterpriseWebhookSecret, "webhook-secret", "", "The webhook secret for this enterprise")
 	enterpriseUpdateCmd.Flags().StringVar(&enterpriseCreds, "credentials", "", "Credentials name. See credentials list.")
 	enterpriseUpdateCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", "", "The balancing strategy to use when creating runners in pools matching requested labels.")
+	enterpriseUpdateCmd.Flags().BoolVar(&observeOnly, "observe-only", false, "Only record the actions the pool manager would take, without creating or removing runners.")
+	addJobSchedulingFlags(enterpriseUpdateCmd)
 
 	enterpriseCmd.AddCommand(
 		enterpriseListCmd,
@@ -195,6 +248,7 @@ func init() {
 		enterpriseShowCmd,
 		enterpriseDeleteCmd,
 		enterpriseUpdateCmd,
+		enterpriseObservedActionsCmd,
 	)
 
 	rootCmd.AddCommand(enterpriseCmd)
@@ -228,11 +282,16 @@ func formatOneEnterprise(enterprise params.Enterprise) {
 	t.AppendRow(table.Row{"Name", enterprise.Name})
 	t.AppendRow(table.Row{"Endpoint", enterprise.Endpoint.Name})
 	t.AppendRow(table.Row{"Pool balancer type", enterprise.GetBalancerType()})
+	t.AppendRow(table.Row{"Observe only", enterprise.ObserveOnly})
+	appendJobSchedulingRows(t, enterprise.JobScheduling)
 	t.AppendRow(table.Row{"Credentials", enterprise.Credentials.Name})
 	t.AppendRow(table.Row{"Pool manager running", enterprise.PoolManagerStatus.IsRunning})
 	if !enterprise.PoolManagerStatus.IsRunning {
 		t.AppendRow(table.Row{"Failure reason", enterprise.PoolManagerStatus.FailureReason})
 	}
+	for _, conflict := range enterprise.PoolManagerStatus.ConflictingControllers {
+		t.AppendRow(table.Row{"Conflicting controllers", fmt.Sprintf("%s (%d runners)", conflict.ControllerID, conflict.Runners)}, rowConfigAutoMerge)
+	}
 
 	if len(enterprise.Pools) > 0 {
 		for _, pool := range enterprise.Pools {
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/cloudbase/garm/params"
+)
+
+var (
+	jobSchedulingPolicy     string
+	jobRepositoryWeights    []string
+	jobLabelPriorities      []string
+	jobSchedulingFlagsNames = []string{"job-scheduling", "repository-weights", "label-priorities"}
+)
+
+func addJobSchedulingFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&jobSchedulingPolicy, "job-scheduling", "", "The order in which queued jobs get runners (fifo, roundrobin, weighted).")
+	cmd.Flags().StringSliceVar(&jobRepositoryWeights, "repository-weights", nil, "A comma separated list of owner/name=weight pairs used by the weighted job scheduling policy. Pass an empty value to remove all weights.")
+	cmd.Flags().StringSliceVar(&jobLabelPriorities, "label-priorities", nil, "A comma separated list of label=priority pairs. Jobs requesting a label with a higher priority get runners first. Pass an empty value to remove all priorities.")
+}
+
+func jobSchedulingFlagsChanged(cmd *cobra.Command) bool {
+	for _, flag := range jobSchedulingFlagsNames {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// jobSchedulingFromFlags returns the job scheduling settings with the values passed
+// on the command line replaced.
+func jobSchedulingFromFlags(cmd *cobra.Command, scheduling params.JobScheduling) (params.JobScheduling, error) {
+	if cmd.Flags().Changed("job-scheduling") {
+		scheduling.Policy = params.JobSchedulingPolicy(jobSchedulingPolicy)
+	}
+	if cmd.Flags().Changed("repository-weights") {
+		scheduling.RepositoryWeights = map[string]uint{}
+		for _, pair := range nonEmptyValues(jobRepositoryWeights) {
+			key, val, err := splitKeyValue(pair)
+			if err != nil {
+				return params.JobScheduling{}, err
+			}
+			weight, err := strconv.ParseUint(val, 10, 32)
+			if err != nil {
+				return params.JobScheduling{}, fmt.Errorf("invalid weight for %s: %w", key, err)
+			}
+			scheduling.RepositoryWeights[key] = uint(weight)
+		}
+	}
+	if cmd.Flags().Changed("label-priorities") {
+		scheduling.LabelPriorities = map[string]int{}
+		for _, pair := range nonEmptyValues(jobLabelPriorities) {
+			key, val, err := splitKeyValue(pair)
+			if err != nil {
+				return params.JobScheduling{}, err
+			}
+			priority, err := strconv.Atoi(val)
+			if err != nil {
+				return params.JobScheduling{}, fmt.Errorf("invalid priority for %s: %w", key, err)
+			}
+			scheduling.LabelPriorities[key] = priority
+		}
+	}
+	return scheduling, nil
+}
+
+func splitKeyValue(pair string) (string, string, error) {
+	key, val, ok := strings.Cut(pair, "=")
+	if !ok || key == "" {
+		return "", "", fmt.Errorf("invalid value %q; expected key=value", pair)
+	}
+	return key, val, nil
+}
+
+func appendJobSchedulingRows(t table.Writer, settings *params.JobScheduling) {
+	scheduling := params.GithubEntity{JobScheduling: settings}.GetJobScheduling()
+	rowConfigAutoMerge := table.RowConfig{AutoMerge: true}
+	t.AppendRow(table.Row{"Job scheduling", scheduling.Policy})
+	repos := make([]string, 0, len(scheduling.RepositoryWeights))
+	for repo := range scheduling.RepositoryWeights {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	for _, repo := range repos {
+		t.AppendRow(table.Row{"Repository weights", fmt.Sprintf("%s=%d", repo, scheduling.RepositoryWeights[repo])}, rowConfigAutoMerge)
+	}
+	labels := make([]string, 0, len(scheduling.LabelPriorities))
+	for label := range scheduling.LabelPriorities {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		t.AppendRow(table.Row{"Label priorities", fmt.Sprintf("%s=%d", label, scheduling.LabelPriorities[label])}, rowConfigAutoMerge)
+	}
+}
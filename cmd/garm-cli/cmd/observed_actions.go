@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+
+	"github.com/cloudbase/garm/cmd/garm-cli/common"
+	"github.com/cloudbase/garm/params"
+)
+
+func formatObservedActions(actions params.ObservedActions) {
+	if outputFormat == common.OutputFormatJSON {
+		printAsJSON(actions)
+		return
+	}
+	t := table.NewWriter()
+	header := table.Row{"Action", "Pool ID", "Target", "Reason", "Count", "First Seen", "Last Seen"}
+	t.AppendHeader(header)
+	for _, action := range actions {
+		t.AppendRow(table.Row{
+			action.Action, action.PoolID, action.Target, action.Reason, action.Count,
+			action.FirstSeenAt.Format("2006-01-02T15:04:05"), action.LastSeenAt.Format("2006-01-02T15:04:05"),
+		})
+		t.AppendSeparator()
+	}
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 4, WidthMax: 60},
+	})
+	fmt.Println(t.Render())
+}
@@ -116,6 +116,39 @@ var orgHookInfoShowCmd = &cobra.Command{
 	},
 }
 
+var orgWebhookRotateSecretCmd = &cobra.Command{
+	Use:   "rotate-secret",
+	Short: "Rotate webhook secret",
+	Long: `Rotate the webhook secret for a organization.
+
+A new secret is set on the organization and the GARM managed webhook is updated in
+GitHub to use it. The previous secret is still accepted for the duration of
+the grace period configured on the GARM server, after which it is retired.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("requires a organization ID")
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		rotateSecretReq := apiClientOrgs.NewRotateOrgWebhookSecretParams()
+		rotateSecretReq.OrgID = args[0]
+		rotateSecretReq.Body.WebhookSecret = orgWebhookSecret
+
+		response, err := apiCli.Organizations.RotateOrgWebhookSecret(rotateSecretReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOneHookInfo(response.Payload)
+		return nil
+	},
+}
+
 var orgWebhookUninstallCmd = &cobra.Command{
 	Use:          "uninstall",
 	Short:        "Uninstall webhook",
@@ -201,7 +234,7 @@ var orgUpdateCmd = &cobra.Command{
 	Short:        "Update organization",
 	Long:         `Update organization credentials or webhook secret.`,
 	SilenceUsage: true,
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if needsInit {
 			return errNeedsInitError
 		}
@@ -219,6 +252,29 @@ var orgUpdateCmd = &cobra.Command{
 			CredentialsName:  orgCreds,
 			PoolBalancerType: params.PoolBalancerType(poolBalancerType),
 		}
+		if cmd.Flags().Changed("observe-only") {
+			updateOrgReq.Body.ObserveOnly = &observeOnly
+		}
+
+		if jobSchedulingFlagsChanged(cmd) {
+			// The job scheduling settings are replaced as a whole. Keep the values
+			// that were not changed on the command line.
+			getReq := apiClientOrgs.NewGetOrgParams()
+			getReq.OrgID = args[0]
+			current, err := apiCli.Organizations.GetOrg(getReq, authToken)
+			if err != nil {
+				return err
+			}
+			var settings params.JobScheduling
+			if current.Payload.JobScheduling != nil {
+				settings = *current.Payload.JobScheduling
+			}
+			scheduling, err := jobSchedulingFromFlags(cmd, settings)
+			if err != nil {
+				return err
+			}
+			updateOrgReq.Body.JobScheduling = &scheduling
+		}
 		updateOrgReq.OrgID = args[0]
 		response, err := apiCli.Organizations.UpdateOrg(updateOrgReq, authToken)
 		if err != nil {
@@ -302,6 +358,34 @@ var orgDeleteCmd = &cobra.Command{
 	},
 }
 
+var orgObservedActionsCmd = &cobra.Command{
+	Use:   "observed-actions",
+	Short: "List observed actions",
+	Long: `List the actions the pool manager of an organization would have taken
+while running in observe only mode.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("requires an organization ID")
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		listActionsReq := apiClientOrgs.NewListOrgObservedActionsParams()
+		listActionsReq.OrgID = args[0]
+		response, err := apiCli.Organizations.ListOrgObservedActions(listActionsReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatObservedActions(response.Payload)
+		return nil
+	},
+}
+
 func init() {
 	orgAddCmd.Flags().StringVar(&orgName, "name", "", "The name of the organization")
 	orgAddCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", string(params.PoolBalancerTypeRoundRobin), "The balancing strategy to use when creating runners in pools matching requested labels.")
@@ -320,11 +404,15 @@ func init() {
 	orgUpdateCmd.Flags().StringVar(&orgWebhookSecret, "webhook-secret", "", "The webhook secret for this organization")
 	orgUpdateCmd.Flags().StringVar(&orgCreds, "credentials", "", "Credentials name. See credentials list.")
 	orgUpdateCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", "", "The balancing strategy to use when creating runners in pools matching requested labels.")
+	orgUpdateCmd.Flags().BoolVar(&observeOnly, "observe-only", false, "Only record the actions the pool manager would take, without creating or removing runners.")
+	addJobSchedulingFlags(orgUpdateCmd)
 
 	orgWebhookInstallCmd.Flags().BoolVar(&insecureOrgWebhook, "insecure", false, "Ignore self signed certificate errors.")
+	orgWebhookRotateSecretCmd.Flags().StringVar(&orgWebhookSecret, "webhook-secret", "", "The new webhook secret. If not set, the GARM server generates a random secret.")
 	orgWebhookCmd.AddCommand(
 		orgWebhookInstallCmd,
 		orgWebhookUninstallCmd,
+		orgWebhookRotateSecretCmd,
 		orgHookInfoShowCmd,
 	)
 
@@ -334,6 +422,7 @@ func init() {
 		orgShowCmd,
 		orgDeleteCmd,
 		orgUpdateCmd,
+		orgObservedActionsCmd,
 		orgWebhookCmd,
 	)
 
@@ -368,11 +457,16 @@ func formatOneOrganization(org params.Organization) {
 	t.AppendRow(table.Row{"Name", org.Name})
 	t.AppendRow(table.Row{"Endpoint", org.Endpoint.Name})
 	t.AppendRow(table.Row{"Pool balancer type", org.GetBalancerType()})
+	t.AppendRow(table.Row{"Observe only", org.ObserveOnly})
+	appendJobSchedulingRows(t, org.JobScheduling)
 	t.AppendRow(table.Row{"Credentials", org.CredentialsName})
 	t.AppendRow(table.Row{"Pool manager running", org.PoolManagerStatus.IsRunning})
 	if !org.PoolManagerStatus.IsRunning {
 		t.AppendRow(table.Row{"Failure reason", org.PoolManagerStatus.FailureReason})
 	}
+	for _, conflict := range org.PoolManagerStatus.ConflictingControllers {
+		t.AppendRow(table.Row{"Conflicting controllers", fmt.Sprintf("%s (%d runners)", conflict.ControllerID, conflict.Runners)}, rowConfigAutoMerge)
+	}
 	if len(org.Pools) > 0 {
 		for _, pool := range org.Pools {
 			t.AppendRow(table.Row{"Pools", pool.ID}, rowConfigAutoMerge)
@@ -53,6 +53,30 @@ var (
 	poolAll                    bool
 	poolGitHubRunnerGroup      string
 	priority                   uint
+	poolTemplate               string
+	poolResetTemplateOverrides string
+	poolMaxUnavailable         uint
+	poolAdoptDryRun            bool
+	poolAdoptControllerID      string
+	poolAdoptSourcePoolID      string
+	poolAdoptLabels            []string
+	poolRolloutMaxSurge        uint
+	poolRolloutMaxUnavailable  uint
+	poolReusable               bool
+	poolRunnerMaxJobs          uint
+	poolRunnerMaxLifetime      uint
+	poolJobCompletedHookFile   string
+	poolClearProviderFailure   bool
+	poolIncludedRepositories   []string
+	poolExcludedRepositories   []string
+	poolPolicyBranches         []string
+	poolPolicyWorkflows        []string
+	poolPolicyWorkflowPaths    []string
+	poolPolicyEvents           []string
+	poolPolicyActors           []string
+	poolPolicyMaxRunAttempt    uint
+	poolHourlyCost             float64
+	poolMaxRunnersPerRepo      uint
 )
 
 type poolsPayloadGetter interface {
@@ -193,6 +217,184 @@ var poolDeleteCmd = &cobra.Command{
 	},
 }
 
+var poolCordonCmd = &cobra.Command{
+	Use:   "cordon",
+	Short: "Stop creating new runners in a pool",
+	Long: `Cordon a pool.
+
+A cordoned pool does not create new runners. Existing runners are kept
+and will still pick up jobs. Use "uncordon" to allow the pool to create
+runners again.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		if len(args) == 0 {
+			return fmt.Errorf("requires a pool ID")
+		}
+
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		cordonPoolReq := apiClientPools.NewCordonPoolParams()
+		cordonPoolReq.PoolID = args[0]
+		response, err := apiCli.Pools.CordonPool(cordonPoolReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOnePool(response.Payload)
+		return nil
+	},
+}
+
+var poolUncordonCmd = &cobra.Command{
+	Use:          "uncordon",
+	Short:        "Allow a cordoned pool to create runners",
+	Long:         `Allow a cordoned pool to create new runners again.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		if len(args) == 0 {
+			return fmt.Errorf("requires a pool ID")
+		}
+
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		uncordonPoolReq := apiClientPools.NewUncordonPoolParams()
+		uncordonPoolReq.PoolID = args[0]
+		response, err := apiCli.Pools.UncordonPool(uncordonPoolReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOnePool(response.Payload)
+		return nil
+	},
+}
+
+var poolDrainCmd = &cobra.Command{
+	Use:   "drain",
+	Short: "Cordon a pool and remove all its runners",
+	Long: `Drain a pool.
+
+This command cordons the pool and drains all its runners. Idle runners
+are removed right away. Runners that are running a job are removed once
+the job is done. The pool stays cordoned until you uncordon it.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		if len(args) == 0 {
+			return fmt.Errorf("requires a pool ID")
+		}
+
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		drainPoolReq := apiClientPools.NewDrainPoolParams()
+		drainPoolReq.PoolID = args[0]
+		response, err := apiCli.Pools.DrainPool(drainPoolReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOnePool(response.Payload)
+		return nil
+	},
+}
+
+var poolRecycleCmd = &cobra.Command{
+	Use:   "recycle",
+	Short: "Replace all runners of a pool",
+	Long: `Recycle a pool.
+
+This command replaces all runners that exist in the pool when it is run,
+draining at most --max-unavailable of them at a time. Idle runners are
+replaced first. Changing the image of a pool already replaces idle
+runners. Recycling is useful when the image behind the same image name
+was updated.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		if len(args) == 0 {
+			return fmt.Errorf("requires a pool ID")
+		}
+
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		recyclePoolReq := apiClientPools.NewRecyclePoolParams()
+		recyclePoolReq.PoolID = args[0]
+		recyclePoolReq.Body = params.RecyclePoolParams{
+			MaxUnavailable: poolMaxUnavailable,
+		}
+		response, err := apiCli.Pools.RecyclePool(recyclePoolReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOnePool(response.Payload)
+		return nil
+	},
+}
+
+var poolAdoptCmd = &cobra.Command{
+	Use:   "adopt",
+	Short: "Adopt runners GARM lost track of",
+	Long: `Adopt the runners of a pool that GARM lost track of.
+
+Instances that the provider of the pool reports and that run a runner
+registered in GitHub by this controller, are added back to the pool
+instead of being removed as orphans. This is useful if the database
+was lost. Use --dry-run to see which runners would be adopted.
+
+If the controller ID changed, pass the ID of the controller that created
+the runners with --controller-id, and the ID of the pool they were created
+for with --source-pool-id. Runners that were not created by GARM can be
+selected by their labels with --labels instead.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		if len(args) == 0 {
+			return fmt.Errorf("requires a pool ID")
+		}
+
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		adoptReq := apiClientPools.NewAdoptPoolRunnersParams()
+		adoptReq.PoolID = args[0]
+		adoptReq.Body = params.AdoptRunnersParams{
+			DryRun:       poolAdoptDryRun,
+			ControllerID: poolAdoptControllerID,
+			SourcePoolID: poolAdoptSourcePoolID,
+			Labels:       poolAdoptLabels,
+		}
+		response, err := apiCli.Pools.AdoptPoolRunners(adoptReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatAdoptRunnersResult(response.Payload)
+		return nil
+	},
+}
+
 type poolPayloadGetter interface {
 	GetPayload() params.Pool
 }
@@ -208,23 +410,33 @@ var poolAddCmd = &cobra.Command{
 			return errNeedsInitError
 		}
 
-		tags := strings.Split(poolTags, ",")
-		newPoolParams := params.CreatePoolParams{
-			RunnerPrefix: params.RunnerPrefix{
-				Prefix: poolRunnerPrefix,
-			},
-			ProviderName:           poolProvider,
-			MaxRunners:             poolMaxRunners,
-			MinIdleRunners:         poolMinIdleRunners,
-			Image:                  poolImage,
-			Flavor:                 poolFlavor,
-			OSType:                 commonParams.OSType(poolOSType),
-			OSArch:                 commonParams.OSArch(poolOSArch),
-			Tags:                   tags,
-			Enabled:                poolEnabled,
-			RunnerBootstrapTimeout: poolRunnerBootstrapTimeout,
-			GitHubRunnerGroup:      poolGitHubRunnerGroup,
-			Priority:               priority,
+		var newPoolParams params.CreatePoolParams
+		if cmd.Flags().Changed("template") {
+			newPoolParams = createPoolParamsFromTemplateFlags(cmd)
+		} else {
+			for _, flag := range []string{"provider-name", "image", "flavor", "tags"} {
+				if !cmd.Flags().Changed(flag) {
+					return fmt.Errorf("required flag \"%s\" not set", flag)
+				}
+			}
+			tags := strings.Split(poolTags, ",")
+			newPoolParams = params.CreatePoolParams{
+				RunnerPrefix: params.RunnerPrefix{
+					Prefix: poolRunnerPrefix,
+				},
+				ProviderName:           poolProvider,
+				MaxRunners:             poolMaxRunners,
+				MinIdleRunners:         poolMinIdleRunners,
+				Image:                  poolImage,
+				Flavor:                 poolFlavor,
+				OSType:                 commonParams.OSType(poolOSType),
+				OSArch:                 commonParams.OSArch(poolOSArch),
+				Tags:                   tags,
+				Enabled:                poolEnabled,
+				RunnerBootstrapTimeout: poolRunnerBootstrapTimeout,
+				GitHubRunnerGroup:      poolGitHubRunnerGroup,
+				Priority:               priority,
+			}
 		}
 
 		if cmd.Flags().Changed("extra-specs") {
@@ -243,8 +455,57 @@ var poolAddCmd = &cobra.Command{
 			newPoolParams.ExtraSpecs = data
 		}
 
-		if err := newPoolParams.Validate(); err != nil {
-			return err
+		if cmd.Flags().Changed("rollout-max-surge") {
+			newPoolParams.RolloutMaxSurge = &poolRolloutMaxSurge
+		}
+
+		if cmd.Flags().Changed("rollout-max-unavailable") {
+			newPoolParams.RolloutMaxUnavailable = &poolRolloutMaxUnavailable
+		}
+
+		newPoolParams.Reusable = poolReusable
+		newPoolParams.RunnerMaxJobs = poolRunnerMaxJobs
+		newPoolParams.RunnerMaxLifetime = poolRunnerMaxLifetime
+		if poolJobCompletedHookFile != "" {
+			hook, err := os.ReadFile(poolJobCompletedHookFile)
+			if err != nil {
+				return errors.Wrap(err, "reading job completed hook")
+			}
+			newPoolParams.JobCompletedHook = string(hook)
+		}
+
+		if cmd.Flags().Changed("included-repositories") {
+			newPoolParams.IncludedRepositories = nonEmptyValues(poolIncludedRepositories)
+		}
+
+		if cmd.Flags().Changed("excluded-repositories") {
+			newPoolParams.ExcludedRepositories = nonEmptyValues(poolExcludedRepositories)
+		}
+
+		hasRepositoryFilters := len(newPoolParams.IncludedRepositories) > 0 || len(newPoolParams.ExcludedRepositories) > 0
+		if hasRepositoryFilters && !cmd.Flags().Changed("min-idle-runners") {
+			// Pools with repository filters can not have idle runners.
+			newPoolParams.MinIdleRunners = 0
+		}
+
+		if policyFlagsChanged(cmd) {
+			policy := poolPolicyFromFlags(cmd, params.PoolPolicy{})
+			newPoolParams.Policy = &policy
+			if !cmd.Flags().Changed("min-idle-runners") {
+				// Pools with a policy can not have idle runners.
+				newPoolParams.MinIdleRunners = 0
+			}
+		}
+
+		newPoolParams.HourlyCost = poolHourlyCost
+		newPoolParams.MaxRunnersPerRepository = poolMaxRunnersPerRepo
+
+		// Pools created from a template are validated by the server, after
+		// the template is applied.
+		if newPoolParams.TemplateID == "" {
+			if err := newPoolParams.Validate(); err != nil {
+				return err
+			}
 		}
 
 		var err error
@@ -283,9 +544,10 @@ var poolUpdateCmd = &cobra.Command{
 	Short: "Update one pool",
 	Long: `Updates pool characteristics.
 
-This command updates the pool characteristics. Runners already created prior to updating
-the pool, will not be recreated. If they no longer suit your needs, you will need to
-explicitly remove them using the runner delete command.
+This command updates the pool characteristics. Changing the image, flavor or extra specs
+starts a new generation of the pool. Idle runners created with a previous generation are
+replaced automatically, within the limits set by --rollout-max-surge and
+--rollout-max-unavailable. Runners that are running a job are removed once the job is done.
 	`,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -353,6 +615,75 @@ explicitly remove them using the runner delete command.
 			poolUpdateParams.RunnerBootstrapTimeout = &poolRunnerBootstrapTimeout
 		}
 
+		if cmd.Flags().Changed("rollout-max-surge") {
+			poolUpdateParams.RolloutMaxSurge = &poolRolloutMaxSurge
+		}
+
+		if cmd.Flags().Changed("rollout-max-unavailable") {
+			poolUpdateParams.RolloutMaxUnavailable = &poolRolloutMaxUnavailable
+		}
+
+		if cmd.Flags().Changed("reusable") {
+			poolUpdateParams.Reusable = &poolReusable
+		}
+
+		if cmd.Flags().Changed("runner-max-jobs") {
+			poolUpdateParams.RunnerMaxJobs = &poolRunnerMaxJobs
+		}
+
+		if cmd.Flags().Changed("clear-provider-failure") {
+			poolUpdateParams.ClearProviderFailure = &poolClearProviderFailure
+		}
+
+		if cmd.Flags().Changed("runner-max-lifetime") {
+			poolUpdateParams.RunnerMaxLifetime = &poolRunnerMaxLifetime
+		}
+
+		if cmd.Flags().Changed("hourly-cost") {
+			poolUpdateParams.HourlyCost = &poolHourlyCost
+		}
+
+		if cmd.Flags().Changed("max-runners-per-repository") {
+			poolUpdateParams.MaxRunnersPerRepository = &poolMaxRunnersPerRepo
+		}
+
+		if cmd.Flags().Changed("job-completed-hook-file") {
+			var hook string
+			if poolJobCompletedHookFile != "" {
+				data, err := os.ReadFile(poolJobCompletedHookFile)
+				if err != nil {
+					return errors.Wrap(err, "reading job completed hook")
+				}
+				hook = string(data)
+			}
+			poolUpdateParams.JobCompletedHook = &hook
+		}
+
+		if cmd.Flags().Changed("included-repositories") {
+			poolUpdateParams.IncludedRepositories = nonEmptyValues(poolIncludedRepositories)
+		}
+
+		if cmd.Flags().Changed("excluded-repositories") {
+			poolUpdateParams.ExcludedRepositories = nonEmptyValues(poolExcludedRepositories)
+		}
+
+		if policyFlagsChanged(cmd) {
+			// The policy is replaced as a whole. Keep the rules that were not
+			// changed on the command line.
+			getPoolReq := apiClientPools.NewGetPoolParams()
+			getPoolReq.PoolID = args[0]
+			pool, err := apiCli.Pools.GetPool(getPoolReq, authToken)
+			if err != nil {
+				return err
+			}
+			var current params.PoolPolicy
+			if pool.Payload.Policy != nil {
+				current = *pool.Payload.Policy
+			}
+			policy := poolPolicyFromFlags(cmd, current)
+			poolUpdateParams.Policy = &policy
+		}
+
 		if cmd.Flags().Changed("extra-specs") {
 			data, err := asRawMessage([]byte(poolExtraSpecs))
 			if err != nil {
@@ -369,6 +700,10 @@ explicitly remove them using the runner delete command.
 			poolUpdateParams.ExtraSpecs = data
 		}
 
+		if cmd.Flags().Changed("reset-template-overrides") {
+			poolUpdateParams.ResetTemplateOverrides = strings.Split(poolResetTemplateOverrides, ",")
+		}
+
 		updatePoolReq.PoolID = args[0]
 		updatePoolReq.Body = poolUpdateParams
 		response, err := apiCli.Pools.UpdatePool(updatePoolReq, authToken)
@@ -402,6 +737,19 @@ func init() {
 	poolUpdateCmd.Flags().UintVar(&poolRunnerBootstrapTimeout, "runner-bootstrap-timeout", 20, "Duration in minutes after which a runner is considered failed if it does not join Github.")
 	poolUpdateCmd.Flags().StringVar(&poolExtraSpecsFile, "extra-specs-file", "", "A file containing a valid json which will be passed to the IaaS provider managing the pool.")
 	poolUpdateCmd.Flags().StringVar(&poolExtraSpecs, "extra-specs", "", "A valid json which will be passed to the IaaS provider managing the pool.")
+	poolUpdateCmd.Flags().UintVar(&poolRolloutMaxSurge, "rollout-max-surge", 1, "The number of runners that may be created on top of the existing ones to replace outdated idle runners.")
+	poolUpdateCmd.Flags().UintVar(&poolRolloutMaxUnavailable, "rollout-max-unavailable", 0, "The number of outdated idle runners that may be removed before their replacement is ready. Set both rollout limits to 0 to disable automatic replacement.")
+	poolUpdateCmd.Flags().BoolVar(&poolReusable, "reusable", false, "Keep runners after a job completes, instead of replacing them. Requires --runner-max-jobs, --runner-max-lifetime or both.")
+	poolUpdateCmd.Flags().UintVar(&poolRunnerMaxJobs, "runner-max-jobs", 0, "The number of jobs a runner of a reusable pool runs before it is replaced. 0 means no limit.")
+	poolUpdateCmd.Flags().UintVar(&poolRunnerMaxLifetime, "runner-max-lifetime", 0, "Duration in minutes after which a runner of a reusable pool is replaced. 0 means no limit.")
+	poolUpdateCmd.Flags().StringVar(&poolJobCompletedHookFile, "job-completed-hook-file", "", "A script runners of a reusable pool run after every job, to clean up the workspace. Pass an empty value to remove the hook.")
+	poolUpdateCmd.Flags().StringSliceVar(&poolIncludedRepositories, "included-repositories", nil, "A comma separated list of owner/name glob patterns. Only jobs of matching repositories are handled by this pool. Filters do not apply to idle runners, so the pool must not have --min-idle-runners. Pass an empty value to remove the filter.")
+	poolUpdateCmd.Flags().StringSliceVar(&poolExcludedRepositories, "excluded-repositories", nil, "A comma separated list of owner/name glob patterns. Jobs of matching repositories are never handled by this pool. Filters do not apply to idle runners, so the pool must not have --min-idle-runners. Pass an empty value to remove the filter.")
+	addPoolPolicyFlags(poolUpdateCmd)
+	poolUpdateCmd.Flags().Float64Var(&poolHourlyCost, "hourly-cost", 0, "The cost of running a runner of this pool for one hour, used to report the cost of runners.")
+	poolUpdateCmd.Flags().UintVar(&poolMaxRunnersPerRepo, "max-runners-per-repository", 0, "The maximum number of runners of an org or enterprise pool that jobs of a single repository can use. 0 means no limit.")
+	poolUpdateCmd.Flags().BoolVar(&poolClearProviderFailure, "clear-provider-failure", false, "Allow a pool that was flagged after a provider failure to create runners again.")
+	poolUpdateCmd.Flags().StringVar(&poolResetTemplateOverrides, "reset-template-overrides", "", "A comma separated list of fields that should once again be kept in sync with the pool template.")
 	poolUpdateCmd.MarkFlagsMutuallyExclusive("extra-specs-file", "extra-specs")
 
 	poolAddCmd.Flags().StringVar(&poolProvider, "provider-name", "", "The name of the provider where runners will be created.")
@@ -419,10 +767,18 @@ func init() {
 	poolAddCmd.Flags().UintVar(&poolRunnerBootstrapTimeout, "runner-bootstrap-timeout", 20, "Duration in minutes after which a runner is considered failed if it does not join Github.")
 	poolAddCmd.Flags().UintVar(&poolMinIdleRunners, "min-idle-runners", 1, "Attempt to maintain a minimum of idle self-hosted runners of this type.")
 	poolAddCmd.Flags().BoolVar(&poolEnabled, "enabled", false, "Enable this pool.")
-	poolAddCmd.MarkFlagRequired("provider-name") //nolint
-	poolAddCmd.MarkFlagRequired("image")         //nolint
-	poolAddCmd.MarkFlagRequired("flavor")        //nolint
-	poolAddCmd.MarkFlagRequired("tags")          //nolint
+	poolAddCmd.Flags().UintVar(&poolRolloutMaxSurge, "rollout-max-surge", 1, "The number of runners that may be created on top of the existing ones to replace outdated idle runners.")
+	poolAddCmd.Flags().UintVar(&poolRolloutMaxUnavailable, "rollout-max-unavailable", 0, "The number of outdated idle runners that may be removed before their replacement is ready. Set both rollout limits to 0 to disable automatic replacement.")
+	poolAddCmd.Flags().BoolVar(&poolReusable, "reusable", false, "Keep runners after a job completes, instead of replacing them. Requires --runner-max-jobs, --runner-max-lifetime or both.")
+	poolAddCmd.Flags().UintVar(&poolRunnerMaxJobs, "runner-max-jobs", 0, "The number of jobs a runner of a reusable pool runs before it is replaced. 0 means no limit.")
+	poolAddCmd.Flags().UintVar(&poolRunnerMaxLifetime, "runner-max-lifetime", 0, "Duration in minutes after which a runner of a reusable pool is replaced. 0 means no limit.")
+	poolAddCmd.Flags().StringVar(&poolJobCompletedHookFile, "job-completed-hook-file", "", "A script runners of a reusable pool run after every job, to clean up the workspace.")
+	poolAddCmd.Flags().StringSliceVar(&poolIncludedRepositories, "included-repositories", nil, "A comma separated list of owner/name glob patterns. Only jobs of matching repositories are handled by this pool. Filters do not apply to idle runners, so --min-idle-runners defaults to 0.")
+	poolAddCmd.Flags().StringSliceVar(&poolExcludedRepositories, "excluded-repositories", nil, "A comma separated list of owner/name glob patterns. Jobs of matching repositories are never handled by this pool. Filters do not apply to idle runners, so --min-idle-runners defaults to 0.")
+	addPoolPolicyFlags(poolAddCmd)
+	poolAddCmd.Flags().Float64Var(&poolHourlyCost, "hourly-cost", 0, "The cost of running a runner of this pool for one hour, used to report the cost of runners.")
+	poolAddCmd.Flags().UintVar(&poolMaxRunnersPerRepo, "max-runners-per-repository", 0, "The maximum number of runners of an org or enterprise pool that jobs of a single repository can use. 0 means no limit.")
+	poolAddCmd.Flags().StringVar(&poolTemplate, "template", "", "The ID of a pool template. Settings not explicitly set are inherited from the template and kept in sync with it.")
 
 	poolAddCmd.Flags().StringVarP(&poolRepository, "repo", "r", "", "Add the new pool within this repository.")
 	poolAddCmd.Flags().StringVarP(&poolOrganization, "org", "o", "", "Add the new pool within this organization.")
@@ -430,17 +786,135 @@ func init() {
 	poolAddCmd.MarkFlagsMutuallyExclusive("repo", "org", "enterprise")
 	poolAddCmd.MarkFlagsMutuallyExclusive("extra-specs-file", "extra-specs")
 
+	poolRecycleCmd.Flags().UintVar(&poolMaxUnavailable, "max-unavailable", 1, "The maximum number of runners that are drained at the same time.")
+	poolAdoptCmd.Flags().BoolVar(&poolAdoptDryRun, "dry-run", false, "Only list the runners that would be adopted.")
+	poolAdoptCmd.Flags().StringVar(&poolAdoptControllerID, "controller-id", "", "The ID of the controller that created the runners. Defaults to the ID of this controller.")
+	poolAdoptCmd.Flags().StringVar(&poolAdoptSourcePoolID, "source-pool-id", "", "The ID of the pool the runners were created for. Defaults to the ID of the pool.")
+	poolAdoptCmd.Flags().StringSliceVar(&poolAdoptLabels, "labels", nil, "A comma separated list of labels. Adopt runners that have all of them, instead of the runners of a controller.")
+	poolAdoptCmd.MarkFlagsMutuallyExclusive("controller-id", "labels")
+
 	poolCmd.AddCommand(
 		poolListCmd,
 		poolShowCmd,
 		poolDeleteCmd,
 		poolUpdateCmd,
 		poolAddCmd,
+		poolCordonCmd,
+		poolUncordonCmd,
+		poolDrainCmd,
+		poolRecycleCmd,
+		poolAdoptCmd,
 	)
 
 	rootCmd.AddCommand(poolCmd)
 }
 
+// createPoolParamsFromTemplateFlags only sets the fields that were explicitly
+// passed on the command line. Everything else is inherited from the template.
+func createPoolParamsFromTemplateFlags(cmd *cobra.Command) params.CreatePoolParams {
+	ret := params.CreatePoolParams{
+		TemplateID: poolTemplate,
+		Enabled:    poolEnabled,
+	}
+	if cmd.Flags().Changed("provider-name") {
+		ret.ProviderName = poolProvider
+	}
+	if cmd.Flags().Changed("runner-prefix") {
+		ret.Prefix = poolRunnerPrefix
+	}
+	if cmd.Flags().Changed("max-runners") {
+		ret.MaxRunners = poolMaxRunners
+	}
+	if cmd.Flags().Changed("min-idle-runners") {
+		ret.MinIdleRunners = poolMinIdleRunners
+	}
+	if cmd.Flags().Changed("image") {
+		ret.Image = poolImage
+	}
+	if cmd.Flags().Changed("flavor") {
+		ret.Flavor = poolFlavor
+	}
+	if cmd.Flags().Changed("os-type") {
+		ret.OSType = commonParams.OSType(poolOSType)
+	}
+	if cmd.Flags().Changed("os-arch") {
+		ret.OSArch = commonParams.OSArch(poolOSArch)
+	}
+	if cmd.Flags().Changed("tags") {
+		ret.Tags = strings.Split(poolTags, ",")
+	}
+	if cmd.Flags().Changed("runner-bootstrap-timeout") {
+		ret.RunnerBootstrapTimeout = poolRunnerBootstrapTimeout
+	}
+	if cmd.Flags().Changed("runner-group") {
+		ret.GitHubRunnerGroup = poolGitHubRunnerGroup
+	}
+	if cmd.Flags().Changed("priority") {
+		ret.Priority = priority
+	}
+	return ret
+}
+
+// poolPolicyFlags are the flags that set the rules of a pool policy.
+var poolPolicyFlags = []string{
+	"policy-branches", "policy-workflows", "policy-workflow-paths",
+	"policy-events", "policy-actors", "policy-max-run-attempt",
+}
+
+func addPoolPolicyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&poolPolicyBranches, "policy-branches", nil, "A comma separated list of glob patterns. Only jobs of matching branches get a runner. Pass an empty value to remove the rule.")
+	cmd.Flags().StringSliceVar(&poolPolicyWorkflows, "policy-workflows", nil, "A comma separated list of glob patterns. Only jobs of matching workflow names get a runner. Pass an empty value to remove the rule.")
+	cmd.Flags().StringSliceVar(&poolPolicyWorkflowPaths, "policy-workflow-paths", nil, "A comma separated list of glob patterns (ie. .github/workflows/deploy-*.yml). Only jobs of matching workflow files get a runner. Pass an empty value to remove the rule.")
+	cmd.Flags().StringSliceVar(&poolPolicyEvents, "policy-events", nil, "A comma separated list of events (ie. push,workflow_dispatch). Only jobs of workflow runs triggered by these events get a runner. Pass an empty value to remove the rule.")
+	cmd.Flags().StringSliceVar(&poolPolicyActors, "policy-actors", nil, "A comma separated list of GitHub users. Only jobs of workflow runs triggered by these users get a runner. Pass an empty value to remove the rule.")
+	cmd.Flags().UintVar(&poolPolicyMaxRunAttempt, "policy-max-run-attempt", 0, "The maximum run attempt of a workflow run that gets a runner. 0 means no limit.")
+}
+
+func policyFlagsChanged(cmd *cobra.Command) bool {
+	for _, flag := range poolPolicyFlags {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// poolPolicyFromFlags returns the policy with the rules passed on the command
+// line replaced.
+func poolPolicyFromFlags(cmd *cobra.Command, policy params.PoolPolicy) params.PoolPolicy {
+	if cmd.Flags().Changed("policy-branches") {
+		policy.Branches = nonEmptyValues(poolPolicyBranches)
+	}
+	if cmd.Flags().Changed("policy-workflows") {
+		policy.Workflows = nonEmptyValues(poolPolicyWorkflows)
+	}
+	if cmd.Flags().Changed("policy-workflow-paths") {
+		policy.WorkflowPaths = nonEmptyValues(poolPolicyWorkflowPaths)
+	}
+	if cmd.Flags().Changed("policy-events") {
+		policy.Events = nonEmptyValues(poolPolicyEvents)
+	}
+	if cmd.Flags().Changed("policy-actors") {
+		policy.Actors = nonEmptyValues(poolPolicyActors)
+	}
+	if cmd.Flags().Changed("policy-max-run-attempt") {
+		policy.MaxRunAttempt = poolPolicyMaxRunAttempt
+	}
+	return policy
+}
+
+// nonEmptyValues drops empty values from a list passed on the command line. A
+// flag set to an empty value yields an empty, non nil list.
+func nonEmptyValues(values []string) []string {
+	ret := []string{}
+	for _, val := range values {
+		if val != "" {
+			ret = append(ret, val)
+		}
+	}
+	return ret
+}
+
 func extraSpecsFromFile(specsFile string) (json.RawMessage, error) {
 	data, err := os.ReadFile(specsFile)
 	if err != nil {
@@ -500,6 +974,25 @@ func formatPools(pools []params.Pool) {
 	fmt.Println(t.Render())
 }
 
+func formatAdoptRunnersResult(result params.AdoptRunnersResult) {
+	if outputFormat == common.OutputFormatJSON {
+		printAsJSON(result)
+		return
+	}
+	t := table.NewWriter()
+	header := table.Row{"Name", "Result", "Status", "Runner Status", "Reason"}
+	t.AppendHeader(header)
+	for _, instance := range result.Adopted {
+		t.AppendRow(table.Row{instance.Name, "adopted", instance.Status, instance.RunnerStatus, ""})
+		t.AppendSeparator()
+	}
+	for _, skipped := range result.Skipped {
+		t.AppendRow(table.Row{skipped.Name, "skipped", "", "", skipped.Reason})
+		t.AppendSeparator()
+	}
+	fmt.Println(t.Render())
+}
+
 func formatOnePool(pool params.Pool) {
 	if outputFormat == common.OutputFormatJSON {
 		printAsJSON(pool)
@@ -545,9 +1038,70 @@ func formatOnePool(pool params.Pool) {
 	t.AppendRow(table.Row{"Belongs to", belongsTo})
 	t.AppendRow(table.Row{"Level", level})
 	t.AppendRow(table.Row{"Enabled", pool.Enabled})
+	t.AppendRow(table.Row{"Cordoned", pool.Cordoned})
+	t.AppendRow(table.Row{"Generation", pool.Generation})
+	t.AppendRow(table.Row{"Outdated Runners", pool.OutdatedRunners})
+	for _, stage := range params.InstanceStages {
+		if count, ok := pool.RunnerStages[stage]; ok {
+			t.AppendRow(table.Row{"Runner Stages", fmt.Sprintf("%s: %d", stage, count)}, rowConfigAutoMerge)
+		}
+	}
+	t.AppendRow(table.Row{"Rollout Max Surge", pool.RolloutMaxSurge})
+	t.AppendRow(table.Row{"Rollout Max Unavailable", pool.RolloutMaxUnavailable})
+	t.AppendRow(table.Row{"Reusable", pool.Reusable})
+	if pool.Reusable {
+		t.AppendRow(table.Row{"Runner Max Jobs", pool.RunnerMaxJobs})
+		t.AppendRow(table.Row{"Runner Max Lifetime", pool.RunnerMaxLifetime})
+		t.AppendRow(table.Row{"Job Completed Hook", pool.JobCompletedHook != ""})
+	}
+	if len(pool.IncludedRepositories) > 0 {
+		t.AppendRow(table.Row{"Included Repositories", strings.Join(pool.IncludedRepositories, "\n")})
+	}
+	if len(pool.ExcludedRepositories) > 0 {
+		t.AppendRow(table.Row{"Excluded Repositories", strings.Join(pool.ExcludedRepositories, "\n")})
+	}
+	if pool.Policy != nil {
+		if len(pool.Policy.Branches) > 0 {
+			t.AppendRow(table.Row{"Policy Branches", strings.Join(pool.Policy.Branches, "\n")})
+		}
+		if len(pool.Policy.Workflows) > 0 {
+			t.AppendRow(table.Row{"Policy Workflows", strings.Join(pool.Policy.Workflows, "\n")})
+		}
+		if len(pool.Policy.WorkflowPaths) > 0 {
+			t.AppendRow(table.Row{"Policy Workflow Paths", strings.Join(pool.Policy.WorkflowPaths, "\n")})
+		}
+		if len(pool.Policy.Events) > 0 {
+			t.AppendRow(table.Row{"Policy Events", strings.Join(pool.Policy.Events, "\n")})
+		}
+		if len(pool.Policy.Actors) > 0 {
+			t.AppendRow(table.Row{"Policy Actors", strings.Join(pool.Policy.Actors, "\n")})
+		}
+		if pool.Policy.MaxRunAttempt > 0 {
+			t.AppendRow(table.Row{"Policy Max Run Attempt", pool.Policy.MaxRunAttempt})
+		}
+	}
+	if pool.HourlyCost > 0 {
+		t.AppendRow(table.Row{"Hourly Cost", fmt.Sprintf("%.2f", pool.HourlyCost)})
+	}
+	if pool.MaxRunnersPerRepository > 0 {
+		t.AppendRow(table.Row{"Max Runners Per Repository", pool.MaxRunnersPerRepository})
+	}
+	if pool.ProviderFailed() {
+		t.AppendRow(table.Row{"Provider Failure Class", pool.ProviderFailureClass})
+		t.AppendRow(table.Row{"Provider Failure Reason", pool.ProviderFailureReason})
+		t.AppendRow(table.Row{"Provider Failure At", pool.ProviderFailureAt})
+	}
+	if pool.RecycleRequestedAt != nil {
+		t.AppendRow(table.Row{"Recycle Requested At", pool.RecycleRequestedAt})
+		t.AppendRow(table.Row{"Recycle Max Unavailable", pool.RecycleMaxUnavailable})
+	}
 	t.AppendRow(table.Row{"Runner Prefix", pool.GetRunnerPrefix()})
 	t.AppendRow(table.Row{"Extra specs", string(pool.ExtraSpecs)})
 	t.AppendRow(table.Row{"GitHub Runner Group", pool.GitHubRunnerGroup})
+	if pool.TemplateID != "" {
+		t.AppendRow(table.Row{"Template ID", pool.TemplateID})
+		t.AppendRow(table.Row{"Template Overrides", strings.Join(pool.TemplateOverrides, ", ")})
+	}
 
 	if len(pool.Instances) > 0 {
 		for _, instance := range pool.Instances {
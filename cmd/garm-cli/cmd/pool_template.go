@@ -0,0 +1,442 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	apiClientPoolTemplates "github.com/cloudbase/garm/client/pool_templates"
+	"github.com/cloudbase/garm/cmd/garm-cli/common"
+	"github.com/cloudbase/garm/params"
+)
+
+var (
+	poolTemplateName        string
+	poolTemplateDescription string
+	poolTemplateDryRun      bool
+)
+
+var poolTemplateCmd = &cobra.Command{
+	Use:          "pool-template",
+	Aliases:      []string{"pool-templates"},
+	SilenceUsage: true,
+	Short:        "Manage pool templates",
+	Long: `Manage pool templates.
+
+Pool templates hold a set of pool settings that can be shared by pools belonging
+to any repository, organization or enterprise. Pools created with the --template
+option inherit all settings they do not explicitly set, and are updated whenever
+the template changes.`,
+	Run: nil,
+}
+
+var poolTemplateListCmd = &cobra.Command{
+	Use:          "list",
+	Aliases:      []string{"ls"},
+	SilenceUsage: true,
+	Short:        "List pool templates",
+	Long:         `List all configured pool templates.`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		listReq := apiClientPoolTemplates.NewListPoolTemplatesParams()
+		response, err := apiCli.PoolTemplates.ListPoolTemplates(listReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatPoolTemplates(response.Payload)
+		return nil
+	},
+}
+
+var poolTemplateShowCmd = &cobra.Command{
+	Use:          "show",
+	Aliases:      []string{"get"},
+	SilenceUsage: true,
+	Short:        "Show pool template",
+	Long:         `Show details of a pool template.`,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("requires a template ID")
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		showReq := apiClientPoolTemplates.NewGetPoolTemplateParams()
+		showReq.TemplateID = args[0]
+		response, err := apiCli.PoolTemplates.GetPoolTemplate(showReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOnePoolTemplate(response.Payload)
+		return nil
+	},
+}
+
+var poolTemplatePoolsCmd = &cobra.Command{
+	Use:          "pools",
+	SilenceUsage: true,
+	Short:        "List pools derived from a pool template",
+	Long:         `List all pools derived from a pool template.`,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("requires a template ID")
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		listReq := apiClientPoolTemplates.NewListPoolTemplatePoolsParams()
+		listReq.TemplateID = args[0]
+		response, err := apiCli.PoolTemplates.ListPoolTemplatePools(listReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatPools(response.Payload)
+		return nil
+	},
+}
+
+var poolTemplateCreateCmd = &cobra.Command{
+	Use:          "create",
+	Aliases:      []string{"add"},
+	SilenceUsage: true,
+	Short:        "Create pool template",
+	Long:         `Create a new pool template.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		createParams := params.CreatePoolTemplateParams{
+			RunnerPrefix: params.RunnerPrefix{
+				Prefix: poolRunnerPrefix,
+			},
+			Name:                   poolTemplateName,
+			Description:            poolTemplateDescription,
+			ProviderName:           poolProvider,
+			MaxRunners:             poolMaxRunners,
+			MinIdleRunners:         poolMinIdleRunners,
+			Image:                  poolImage,
+			Flavor:                 poolFlavor,
+			OSType:                 commonParams.OSType(poolOSType),
+			OSArch:                 commonParams.OSArch(poolOSArch),
+			Tags:                   strings.Split(poolTags, ","),
+			RunnerBootstrapTimeout: poolRunnerBootstrapTimeout,
+			GitHubRunnerGroup:      poolGitHubRunnerGroup,
+			Priority:               priority,
+		}
+
+		if cmd.Flags().Changed("extra-specs") {
+			data, err := asRawMessage([]byte(poolExtraSpecs))
+			if err != nil {
+				return err
+			}
+			createParams.ExtraSpecs = data
+		}
+
+		if poolExtraSpecsFile != "" {
+			data, err := extraSpecsFromFile(poolExtraSpecsFile)
+			if err != nil {
+				return err
+			}
+			createParams.ExtraSpecs = data
+		}
+
+		if err := createParams.Validate(); err != nil {
+			return err
+		}
+
+		createReq := apiClientPoolTemplates.NewCreatePoolTemplateParams()
+		createReq.Body = createParams
+		response, err := apiCli.PoolTemplates.CreatePoolTemplate(createReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOnePoolTemplate(response.Payload)
+		return nil
+	},
+}
+
+var poolTemplateUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update pool template",
+	Long: `Update a pool template.
+
+The changes are propagated to all pools derived from the template, except for the
+fields those pools override. Use --dry-run to list the changes that would be made
+to each pool, without saving anything.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("requires a template ID")
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		updateParams := params.UpdatePoolTemplateParams{}
+
+		if cmd.Flags().Changed("name") {
+			updateParams.Name = &poolTemplateName
+		}
+
+		if cmd.Flags().Changed("description") {
+			updateParams.Description = &poolTemplateDescription
+		}
+
+		if cmd.Flags().Changed("image") {
+			updateParams.Image = poolImage
+		}
+
+		if cmd.Flags().Changed("flavor") {
+			updateParams.Flavor = poolFlavor
+		}
+
+		if cmd.Flags().Changed("tags") {
+			updateParams.Tags = strings.Split(poolTags, ",")
+		}
+
+		if cmd.Flags().Changed("os-type") {
+			updateParams.OSType = commonParams.OSType(poolOSType)
+		}
+
+		if cmd.Flags().Changed("os-arch") {
+			updateParams.OSArch = commonParams.OSArch(poolOSArch)
+		}
+
+		if cmd.Flags().Changed("max-runners") {
+			updateParams.MaxRunners = &poolMaxRunners
+		}
+
+		if cmd.Flags().Changed("min-idle-runners") {
+			updateParams.MinIdleRunners = &poolMinIdleRunners
+		}
+
+		if cmd.Flags().Changed("priority") {
+			updateParams.Priority = &priority
+		}
+
+		if cmd.Flags().Changed("runner-prefix") {
+			updateParams.RunnerPrefix = params.RunnerPrefix{
+				Prefix: poolRunnerPrefix,
+			}
+		}
+
+		if cmd.Flags().Changed("runner-group") {
+			updateParams.GitHubRunnerGroup = &poolGitHubRunnerGroup
+		}
+
+		if cmd.Flags().Changed("runner-bootstrap-timeout") {
+			updateParams.RunnerBootstrapTimeout = &poolRunnerBootstrapTimeout
+		}
+
+		if cmd.Flags().Changed("extra-specs") {
+			data, err := asRawMessage([]byte(poolExtraSpecs))
+			if err != nil {
+				return err
+			}
+			updateParams.ExtraSpecs = data
+		}
+
+		if poolExtraSpecsFile != "" {
+			data, err := extraSpecsFromFile(poolExtraSpecsFile)
+			if err != nil {
+				return err
+			}
+			updateParams.ExtraSpecs = data
+		}
+
+		updateReq := apiClientPoolTemplates.NewUpdatePoolTemplateParams()
+		updateReq.TemplateID = args[0]
+		updateReq.Body = updateParams
+		if poolTemplateDryRun {
+			updateReq.DryRun = &poolTemplateDryRun
+		}
+		response, err := apiCli.PoolTemplates.UpdatePoolTemplate(updateReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatPoolTemplateUpdateResult(response.Payload)
+		return nil
+	},
+}
+
+var poolTemplateDeleteCmd = &cobra.Command{
+	Use:          "delete",
+	Aliases:      []string{"remove", "rm"},
+	SilenceUsage: true,
+	Short:        "Delete pool template",
+	Long:         "Delete a pool template. Templates that still have pools derived from them cannot be removed.",
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("requires a template ID")
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		deleteReq := apiClientPoolTemplates.NewDeletePoolTemplateParams()
+		deleteReq.TemplateID = args[0]
+		if err := apiCli.PoolTemplates.DeletePoolTemplate(deleteReq, authToken); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+func init() {
+	poolTemplateCreateCmd.Flags().StringVar(&poolTemplateName, "name", "", "The name of the pool template.")
+	poolTemplateCreateCmd.Flags().StringVar(&poolTemplateDescription, "description", "", "A description for the pool template.")
+	poolTemplateCreateCmd.Flags().StringVar(&poolProvider, "provider-name", "", "The name of the provider where runners will be created.")
+	poolTemplateCreateCmd.Flags().UintVar(&priority, "priority", 0, "When multiple pools match the same labels, priority dictates the order by which they are returned, in descending order.")
+	poolTemplateCreateCmd.Flags().StringVar(&poolImage, "image", "", "The provider-specific image name to use for runners.")
+	poolTemplateCreateCmd.Flags().StringVar(&poolFlavor, "flavor", "", "The flavor to use for runners.")
+	poolTemplateCreateCmd.Flags().StringVar(&poolRunnerPrefix, "runner-prefix", "", "The name prefix to use for runners.")
+	poolTemplateCreateCmd.Flags().StringVar(&poolTags, "tags", "", "A comma separated list of tags to assign to runners.")
+	poolTemplateCreateCmd.Flags().StringVar(&poolOSType, "os-type", "linux", "Operating system type (windows, linux, etc).")
+	poolTemplateCreateCmd.Flags().StringVar(&poolOSArch, "os-arch", "amd64", "Operating system architecture (amd64, arm, etc).")
+	poolTemplateCreateCmd.Flags().StringVar(&poolExtraSpecsFile, "extra-specs-file", "", "A file containing a valid json which will be passed to the IaaS provider.")
+	poolTemplateCreateCmd.Flags().StringVar(&poolExtraSpecs, "extra-specs", "", "A valid json which will be passed to the IaaS provider.")
+	poolTemplateCreateCmd.Flags().StringVar(&poolGitHubRunnerGroup, "runner-group", "", "The GitHub runner group in which all runners will be added.")
+	poolTemplateCreateCmd.Flags().UintVar(&poolMaxRunners, "max-runners", 5, "The maximum number of runner a pool will create.")
+	poolTemplateCreateCmd.Flags().UintVar(&poolRunnerBootstrapTimeout, "runner-bootstrap-timeout", 20, "Duration in minutes after which a runner is considered failed if it does not join Github.")
+	poolTemplateCreateCmd.Flags().UintVar(&poolMinIdleRunners, "min-idle-runners", 1, "Attempt to maintain a minimum of idle self-hosted runners of this type.")
+	poolTemplateCreateCmd.MarkFlagRequired("name")          //nolint
+	poolTemplateCreateCmd.MarkFlagRequired("provider-name") //nolint
+	poolTemplateCreateCmd.MarkFlagRequired("image")         //nolint
+	poolTemplateCreateCmd.MarkFlagRequired("flavor")        //nolint
+	poolTemplateCreateCmd.MarkFlagRequired("tags")          //nolint
+	poolTemplateCreateCmd.MarkFlagsMutuallyExclusive("extra-specs-file", "extra-specs")
+
+	poolTemplateUpdateCmd.Flags().StringVar(&poolTemplateName, "name", "", "The name of the pool template.")
+	poolTemplateUpdateCmd.Flags().StringVar(&poolTemplateDescription, "description", "", "A description for the pool template.")
+	poolTemplateUpdateCmd.Flags().StringVar(&poolImage, "image", "", "The provider-specific image name to use for runners.")
+	poolTemplateUpdateCmd.Flags().UintVar(&priority, "priority", 0, "When multiple pools match the same labels, priority dictates the order by which they are returned, in descending order.")
+	poolTemplateUpdateCmd.Flags().StringVar(&poolFlavor, "flavor", "", "The flavor to use for runners.")
+	poolTemplateUpdateCmd.Flags().StringVar(&poolTags, "tags", "", "A comma separated list of tags to assign to runners.")
+	poolTemplateUpdateCmd.Flags().StringVar(&poolOSType, "os-type", "linux", "Operating system type (windows, linux, etc).")
+	poolTemplateUpdateCmd.Flags().StringVar(&poolOSArch, "os-arch", "amd64", "Operating system architecture (amd64, arm, etc).")
+	poolTemplateUpdateCmd.Flags().StringVar(&poolRunnerPrefix, "runner-prefix", "", "The name prefix to use for runners.")
+	poolTemplateUpdateCmd.Flags().UintVar(&poolMaxRunners, "max-runners", 5, "The maximum number of runner a pool will create.")
+	poolTemplateUpdateCmd.Flags().UintVar(&poolMinIdleRunners, "min-idle-runners", 1, "Attempt to maintain a minimum of idle self-hosted runners of this type.")
+	poolTemplateUpdateCmd.Flags().StringVar(&poolGitHubRunnerGroup, "runner-group", "", "The GitHub runner group in which all runners will be added.")
+	poolTemplateUpdateCmd.Flags().UintVar(&poolRunnerBootstrapTimeout, "runner-bootstrap-timeout", 20, "Duration in minutes after which a runner is considered failed if it does not join Github.")
+	poolTemplateUpdateCmd.Flags().StringVar(&poolExtraSpecsFile, "extra-specs-file", "", "A file containing a valid json which will be passed to the IaaS provider.")
+	poolTemplateUpdateCmd.Flags().StringVar(&poolExtraSpecs, "extra-specs", "", "A valid json which will be passed to the IaaS provider.")
+	poolTemplateUpdateCmd.Flags().BoolVar(&poolTemplateDryRun, "dry-run", false, "Show the changes that would be made to derived pools, without saving anything.")
+	poolTemplateUpdateCmd.MarkFlagsMutuallyExclusive("extra-specs-file", "extra-specs")
+
+	poolTemplateCmd.AddCommand(
+		poolTemplateListCmd,
+		poolTemplateShowCmd,
+		poolTemplatePoolsCmd,
+		poolTemplateCreateCmd,
+		poolTemplateUpdateCmd,
+		poolTemplateDeleteCmd,
+	)
+
+	rootCmd.AddCommand(poolTemplateCmd)
+}
+
+func formatPoolTemplates(templates params.PoolTemplates) {
+	if outputFormat == common.OutputFormatJSON {
+		printAsJSON(templates)
+		return
+	}
+	t := table.NewWriter()
+	header := table.Row{"ID", "Name", "Provider", "Image", "Flavor", "Tags", "Max Runners", "Min Idle Runners"}
+	t.AppendHeader(header)
+	for _, val := range templates {
+		tags := []string{}
+		for _, tag := range val.Tags {
+			tags = append(tags, tag.Name)
+		}
+		t.AppendRow(table.Row{val.ID, val.Name, val.ProviderName, val.Image, val.Flavor, strings.Join(tags, " "), val.MaxRunners, val.MinIdleRunners})
+		t.AppendSeparator()
+	}
+	fmt.Println(t.Render())
+}
+
+func formatOnePoolTemplate(template params.PoolTemplate) {
+	if outputFormat == common.OutputFormatJSON {
+		printAsJSON(template)
+		return
+	}
+	t := table.NewWriter()
+	header := table.Row{"Field", "Value"}
+
+	tags := []string{}
+	for _, tag := range template.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	t.AppendHeader(header)
+	t.AppendRow(table.Row{"ID", template.ID})
+	t.AppendRow(table.Row{"Name", template.Name})
+	t.AppendRow(table.Row{"Description", template.Description})
+	t.AppendRow(table.Row{"Provider Name", template.ProviderName})
+	t.AppendRow(table.Row{"Priority", template.Priority})
+	t.AppendRow(table.Row{"Image", template.Image})
+	t.AppendRow(table.Row{"Flavor", template.Flavor})
+	t.AppendRow(table.Row{"OS Type", template.OSType})
+	t.AppendRow(table.Row{"OS Architecture", template.OSArch})
+	t.AppendRow(table.Row{"Max Runners", template.MaxRunners})
+	t.AppendRow(table.Row{"Min Idle Runners", template.MinIdleRunners})
+	t.AppendRow(table.Row{"Runner Bootstrap Timeout", template.RunnerBootstrapTimeout})
+	t.AppendRow(table.Row{"Tags", strings.Join(tags, ", ")})
+	t.AppendRow(table.Row{"Runner Prefix", template.GetRunnerPrefix()})
+	t.AppendRow(table.Row{"Extra specs", string(template.ExtraSpecs)})
+	t.AppendRow(table.Row{"GitHub Runner Group", template.GitHubRunnerGroup})
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, AutoMerge: true},
+		{Number: 2, AutoMerge: false, WidthMax: 100},
+	})
+	fmt.Println(t.Render())
+}
+
+func formatPoolTemplateUpdateResult(result params.PoolTemplateUpdateResult) {
+	if outputFormat == common.OutputFormatJSON {
+		printAsJSON(result)
+		return
+	}
+
+	if !result.DryRun {
+		formatOnePoolTemplate(result.Template)
+	}
+
+	if len(result.Changes) == 0 {
+		fmt.Println("No derived pools are affected.")
+		return
+	}
+
+	t := table.NewWriter()
+	header := table.Row{"Pool ID", "Field", "Old Value", "New Value"}
+	t.AppendHeader(header)
+	for _, change := range result.Changes {
+		t.AppendRow(table.Row{change.PoolID, change.Field, change.Old, change.New})
+	}
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, AutoMerge: true},
+	})
+	fmt.Println(t.Render())
+}
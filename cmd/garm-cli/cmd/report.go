@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	apiClientReports "github.com/cloudbase/garm/client/reports"
+	"github.com/cloudbase/garm/cmd/garm-cli/common"
+	"github.com/cloudbase/garm/params"
+)
+
+var reportSince string
+
+var reportCmd = &cobra.Command{
+	Use:          "report",
+	SilenceUsage: true,
+	Short:        "Reports about runner usage",
+	Long:         `Reports about the usage of the runners GARM manages.`,
+	Run:          nil,
+}
+
+var reportCostCmd = &cobra.Command{
+	Use:          "cost",
+	SilenceUsage: true,
+	Short:        "Show runner time and cost",
+	Long: `Show the time runners were up and its cost, by entity, pool and repository.
+
+The cost is computed using the hourly cost of the pool of each runner. The
+time a runner was up until a job completed is attributed to the repository of
+the job. The time a runner was idle before it was removed is only attributed
+to the entity and the pool, unless the pool belongs to a repository.`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		reportReq := apiClientReports.NewGetCostReportParams()
+		if reportSince != "" {
+			since, err := parseSince(reportSince, time.Now())
+			if err != nil {
+				return err
+			}
+			asString := since.UTC().Format(time.RFC3339)
+			reportReq.Since = &asString
+		}
+		response, err := apiCli.Reports.GetCostReport(reportReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatCostReport(response.Payload)
+		return nil
+	},
+}
+
+// parseSince accepts a date (2006-01-02), an RFC3339 timestamp or a duration
+// relative to now. Besides the units time.ParseDuration knows about, durations
+// may be given in days, like 7d.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if since, err := time.Parse(time.RFC3339, value); err == nil {
+		return since, nil
+	}
+	if since, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return since, nil
+	}
+	if days, found := strings.CutSuffix(value, "d"); found {
+		asInt, err := strconv.ParseUint(days, 10, 32)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid --since value %q", value)
+		}
+		return now.AddDate(0, 0, -int(asInt)), nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return time.Time{}, fmt.Errorf("invalid --since value %q", value)
+	}
+	return now.Add(-duration), nil
+}
+
+func formatRunnerSeconds(seconds float64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+func formatCostReport(report params.CostReport) {
+	if outputFormat == common.OutputFormatJSON {
+		printAsJSON(report)
+		return
+	}
+
+	since := "the beginning"
+	if !report.Since.IsZero() {
+		since = report.Since.Local().Format("2006-01-02 15:04:05")
+	}
+	fmt.Printf("Runner usage since %s until %s\n", since, report.Until.Local().Format("2006-01-02 15:04:05"))
+	fmt.Printf("Jobs: %d, runner time: %s, cost: %.2f\n\n", report.Jobs, formatRunnerSeconds(report.Seconds), report.Cost)
+
+	sections := []struct {
+		title   string
+		name    string
+		entries []params.CostReportEntry
+		showID  bool
+	}{
+		{"Entities", "Name", report.Entities, true},
+		{"Pools", "Owner", report.Pools, true},
+		{"Repositories", "Name", report.Repositories, false},
+	}
+	for _, section := range sections {
+		if len(section.entries) == 0 {
+			continue
+		}
+		t := table.NewWriter()
+		t.SetTitle(section.title)
+		header := table.Row{section.name, "Jobs", "Runner Time", "Cost"}
+		if section.showID {
+			header = append(table.Row{"ID", "Type"}, header...)
+		}
+		t.AppendHeader(header)
+		for _, entry := range section.entries {
+			row := table.Row{entry.Name, entry.Jobs, formatRunnerSeconds(entry.Seconds), fmt.Sprintf("%.2f", entry.Cost)}
+			if section.showID {
+				row = append(table.Row{entry.ID, entry.Type}, row...)
+			}
+			t.AppendRow(row)
+		}
+		fmt.Println(t.Render())
+	}
+}
+
+func init() {
+	reportCostCmd.Flags().StringVar(&reportSince, "since", "", "Only account for the usage since this time. Accepts a date (2006-01-02), an RFC3339 timestamp or a duration, like 24h or 7d. Defaults to all recorded usage.")
+
+	reportCmd.AddCommand(reportCostCmd)
+
+	rootCmd.AddCommand(reportCmd)
+}
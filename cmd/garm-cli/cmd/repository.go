@@ -117,6 +117,39 @@ var repoHookInfoShowCmd = &cobra.Command{
 	},
 }
 
+var repoWebhookRotateSecretCmd = &cobra.Command{
+	Use:   "rotate-secret",
+	Short: "Rotate webhook secret",
+	Long: `Rotate the webhook secret for a repository.
+
+A new secret is set on the repository and the GARM managed webhook is updated in
+GitHub to use it. The previous secret is still accepted for the duration of
+the grace period configured on the GARM server, after which it is retired.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("requires a repository ID")
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		rotateSecretReq := apiClientRepos.NewRotateRepoWebhookSecretParams()
+		rotateSecretReq.RepoID = args[0]
+		rotateSecretReq.Body.WebhookSecret = repoWebhookSecret
+
+		response, err := apiCli.Repositories.RotateRepoWebhookSecret(rotateSecretReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOneHookInfo(response.Payload)
+		return nil
+	},
+}
+
 var repoWebhookUninstallCmd = &cobra.Command{
 	Use:          "uninstall",
 	Short:        "Uninstall webhook",
@@ -224,7 +257,7 @@ var repoUpdateCmd = &cobra.Command{
 	Short:        "Update repository",
 	Long:         `Update repository credentials or webhook secret.`,
 	SilenceUsage: true,
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if needsInit {
 			return errNeedsInitError
 		}
@@ -242,6 +275,29 @@ var repoUpdateCmd = &cobra.Command{
 			CredentialsName:  repoCreds,
 			PoolBalancerType: params.PoolBalancerType(poolBalancerType),
 		}
+		if cmd.Flags().Changed("observe-only") {
+			updateReposReq.Body.ObserveOnly = &observeOnly
+		}
+
+		if jobSchedulingFlagsChanged(cmd) {
+			// The job scheduling settings are replaced as a whole. Keep the values
+			// that were not changed on the command line.
+			getReq := apiClientRepos.NewGetRepoParams()
+			getReq.RepoID = args[0]
+			current, err := apiCli.Repositories.GetRepo(getReq, authToken)
+			if err != nil {
+				return err
+			}
+			var settings params.JobScheduling
+			if current.Payload.JobScheduling != nil {
+				settings = *current.Payload.JobScheduling
+			}
+			scheduling, err := jobSchedulingFromFlags(cmd, settings)
+			if err != nil {
+				return err
+			}
+			updateReposReq.Body.JobScheduling = &scheduling
+		}
 		updateReposReq.RepoID = args[0]
 
 		response, err := apiCli.Repositories.UpdateRepo(updateReposReq, authToken)
@@ -305,6 +361,34 @@ var repoDeleteCmd = &cobra.Command{
 	},
 }
 
+var repoObservedActionsCmd = &cobra.Command{
+	Use:   "observed-actions",
+	Short: "List observed actions",
+	Long: `List the actions the pool manager of a repository would have taken
+while running in observe only mode.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("requires a repository ID")
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		listActionsReq := apiClientRepos.NewListRepoObservedActionsParams()
+		listActionsReq.RepoID = args[0]
+		response, err := apiCli.Repositories.ListRepoObservedActions(listActionsReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatObservedActions(response.Payload)
+		return nil
+	},
+}
+
 func init() {
 	repoAddCmd.Flags().StringVar(&repoOwner, "owner", "", "The owner of this repository")
 	repoAddCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", string(params.PoolBalancerTypeRoundRobin), "The balancing strategy to use when creating runners in pools matching requested labels.")
@@ -325,12 +409,16 @@ func init() {
 	repoUpdateCmd.Flags().StringVar(&repoWebhookSecret, "webhook-secret", "", "The webhook secret for this repository. If you update this secret, you will have to manually update the secret in GitHub as well.")
 	repoUpdateCmd.Flags().StringVar(&repoCreds, "credentials", "", "Credentials name. See credentials list.")
 	repoUpdateCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", "", "The balancing strategy to use when creating runners in pools matching requested labels.")
+	repoUpdateCmd.Flags().BoolVar(&observeOnly, "observe-only", false, "Only record the actions the pool manager would take, without creating or removing runners.")
+	addJobSchedulingFlags(repoUpdateCmd)
 
 	repoWebhookInstallCmd.Flags().BoolVar(&insecureRepoWebhook, "insecure", false, "Ignore self signed certificate errors.")
+	repoWebhookRotateSecretCmd.Flags().StringVar(&repoWebhookSecret, "webhook-secret", "", "The new webhook secret. If not set, the GARM server generates a random secret.")
 
 	repoWebhookCmd.AddCommand(
 		repoWebhookInstallCmd,
 		repoWebhookUninstallCmd,
+		repoWebhookRotateSecretCmd,
 		repoHookInfoShowCmd,
 	)
 
@@ -340,6 +428,7 @@ func init() {
 		repoShowCmd,
 		repoDeleteCmd,
 		repoUpdateCmd,
+		repoObservedActionsCmd,
 		repoWebhookCmd,
 	)
 
@@ -375,11 +464,16 @@ func formatOneRepository(repo params.Repository) {
 	t.AppendRow(table.Row{"Name", repo.Name})
 	t.AppendRow(table.Row{"Endpoint", repo.Endpoint.Name})
 	t.AppendRow(table.Row{"Pool balancer type", repo.GetBalancerType()})
+	t.AppendRow(table.Row{"Observe only", repo.ObserveOnly})
+	appendJobSchedulingRows(t, repo.JobScheduling)
 	t.AppendRow(table.Row{"Credentials", repo.CredentialsName})
 	t.AppendRow(table.Row{"Pool manager running", repo.PoolManagerStatus.IsRunning})
 	if !repo.PoolManagerStatus.IsRunning {
 		t.AppendRow(table.Row{"Failure reason", repo.PoolManagerStatus.FailureReason})
 	}
+	for _, conflict := range repo.PoolManagerStatus.ConflictingControllers {
+		t.AppendRow(table.Row{"Conflicting controllers", fmt.Sprintf("%s (%d runners)", conflict.ControllerID, conflict.Runners)}, rowConfigAutoMerge)
+	}
 
 	if len(repo.Pools) > 0 {
 		for _, pool := range repo.Pools {
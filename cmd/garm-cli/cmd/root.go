@@ -39,6 +39,7 @@ var (
 	needsInit         bool
 	debug             bool
 	poolBalancerType  string
+	observeOnly       bool
 	outputFormat      common.OutputFormat = common.OutputFormatTable
 	errNeedsInitError                     = fmt.Errorf("please log into a garm installation first")
 )
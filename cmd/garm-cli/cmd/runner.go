@@ -37,6 +37,7 @@ var (
 	forceRemove          bool
 	bypassGHUnauthorized bool
 	long                 bool
+	runnerConsole        bool
 )
 
 // runnerCmd represents the runner command
@@ -155,6 +156,17 @@ var runnerShowCmd = &cobra.Command{
 			return fmt.Errorf("too many arguments")
 		}
 
+		if runnerConsole {
+			consoleReq := apiClientInstances.NewGetInstanceConsoleOutputParams()
+			consoleReq.InstanceName = args[0]
+			response, err := apiCli.Instances.GetInstanceConsoleOutput(consoleReq, authToken)
+			if err != nil {
+				return err
+			}
+			formatConsoleOutput(response.Payload)
+			return nil
+		}
+
 		showInstanceReq := apiClientInstances.NewGetInstanceParams()
 		showInstanceReq.InstanceName = args[0]
 		response, err := apiCli.Instances.GetInstance(showInstanceReq, authToken)
@@ -201,6 +213,70 @@ to either cancel the workflow or wait for it to finish.
 	},
 }
 
+var runnerDrainCmd = &cobra.Command{
+	Use:   "drain",
+	Short: "Remove a runner once it is idle",
+	Long: `Drain a runner.
+
+A drained runner is removed as soon as it is not running a job. If the
+runner is idle, it is removed right away. The pool will not count it
+towards its idle runners and may create a replacement.
+`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		if len(args) == 0 {
+			return fmt.Errorf("requires a runner name")
+		}
+
+		drainInstanceReq := apiClientInstances.NewDrainInstanceParams()
+		drainInstanceReq.InstanceName = args[0]
+		if err := apiCli.Instances.DrainInstance(drainInstanceReq, authToken); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var runnerTimelineCmd = &cobra.Command{
+	Use:   "timeline",
+	Short: "Show the timeline of a runner",
+	Long: `Show the timeline of a runner.
+
+The timeline lists the lifecycle stages the runner reached, from its
+creation to its termination, along with the events recorded for it. If
+garm removed the runner on its own, for example because it did not
+join GitHub in time, the reason is recorded as a reap event. The
+timeline of such a runner is kept for a week after it was removed.
+`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		if len(args) == 0 {
+			return fmt.Errorf("requires a runner name")
+		}
+
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		timelineReq := apiClientInstances.NewGetInstanceTimelineParams()
+		timelineReq.InstanceName = args[0]
+		response, err := apiCli.Instances.GetInstanceTimeline(timelineReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatInstanceTimeline(response.Payload)
+		return nil
+	},
+}
+
 func init() {
 	runnerListCmd.Flags().StringVarP(&runnerRepository, "repo", "r", "", "List all runners from all pools within this repository.")
 	runnerListCmd.Flags().StringVarP(&runnerOrganization, "org", "o", "", "List all runners from all pools within this organization.")
@@ -209,6 +285,8 @@ func init() {
 	runnerListCmd.Flags().BoolVarP(&long, "long", "l", false, "Include information about tasks.")
 	runnerListCmd.MarkFlagsMutuallyExclusive("repo", "org", "enterprise", "all")
 
+	runnerShowCmd.Flags().BoolVar(&runnerConsole, "console", false, "Show the console output of the runner instead of its details. If the provider cannot return it, the console output captured when the runner failed or was removed is shown.")
+
 	runnerDeleteCmd.Flags().BoolVarP(&forceRemove, "force-remove-runner", "f", false, "Forcefully remove a runner. If set to true, GARM will ignore provider errors when removing the runner.")
 	runnerDeleteCmd.Flags().BoolVarP(&bypassGHUnauthorized, "bypass-github-unauthorized", "b", false, "Ignore Unauthorized errors from GitHub and proceed with removing runner from provider and DB. This is useful when credentials are no longer valid and you want to remove your runners. Warning, this has the potential to leave orphaned runners in GitHub. You will need to update your credentials to properly consolidate.")
 	runnerDeleteCmd.MarkFlagsMutuallyExclusive("force-remove-runner")
@@ -217,6 +295,8 @@ func init() {
 		runnerListCmd,
 		runnerShowCmd,
 		runnerDeleteCmd,
+		runnerDrainCmd,
+		runnerTimelineCmd,
 	)
 
 	rootCmd.AddCommand(runnerCmd)
@@ -266,6 +346,9 @@ func formatSingleInstance(instance params.Instance) {
 	t.AppendRow(table.Row{"Status", instance.Status}, table.RowConfig{AutoMerge: false})
 	t.AppendRow(table.Row{"Runner Status", instance.RunnerStatus}, table.RowConfig{AutoMerge: false})
 	t.AppendRow(table.Row{"Pool ID", instance.PoolID}, table.RowConfig{AutoMerge: false})
+	t.AppendRow(table.Row{"Draining", instance.Draining}, table.RowConfig{AutoMerge: false})
+	t.AppendRow(table.Row{"Pool Generation", instance.PoolGeneration}, table.RowConfig{AutoMerge: false})
+	t.AppendRow(table.Row{"Jobs Completed", instance.JobsCompleted}, table.RowConfig{AutoMerge: false})
 
 	if len(instance.Addresses) > 0 {
 		for _, addr := range instance.Addresses {
@@ -273,6 +356,18 @@ func formatSingleInstance(instance params.Instance) {
 		}
 	}
 
+	if instance.ConsoleOutputCapturedAt != nil {
+		t.AppendRow(table.Row{"Console Output Captured At", instance.ConsoleOutputCapturedAt.Format("2006-01-02T15:04:05")}, table.RowConfig{AutoMerge: false})
+	}
+
+	if instance.ErrorClass != "" {
+		t.AppendRow(table.Row{"Error Class", instance.ErrorClass}, table.RowConfig{AutoMerge: false})
+	}
+
+	if instance.NextRetryAt != nil {
+		t.AppendRow(table.Row{"Next Retry At", instance.NextRetryAt.Format("2006-01-02T15:04:05")}, table.RowConfig{AutoMerge: false})
+	}
+
 	if len(instance.ProviderFault) > 0 {
 		t.AppendRow(table.Row{"Provider Fault", string(instance.ProviderFault)}, table.RowConfig{AutoMerge: true})
 	}
@@ -289,3 +384,37 @@ func formatSingleInstance(instance params.Instance) {
 	})
 	fmt.Println(t.Render())
 }
+
+func formatConsoleOutput(output params.InstanceConsoleOutput) {
+	if outputFormat == common.OutputFormatJSON {
+		printAsJSON(output)
+		return
+	}
+	if output.Stored {
+		fmt.Fprintf(os.Stderr, "Showing console output captured at %s\n", output.CapturedAt.Format("2006-01-02T15:04:05"))
+	}
+	fmt.Print(output.Output)
+}
+
+func formatInstanceTimeline(timeline params.InstanceTimeline) {
+	if outputFormat == common.OutputFormatJSON {
+		printAsJSON(timeline)
+		return
+	}
+	if timeline.Removed {
+		fmt.Printf("Runner %s was removed, last stage: %s\n", timeline.InstanceName, timeline.Stage)
+	} else {
+		fmt.Printf("Runner %s is %s (runner status: %s), last stage: %s\n", timeline.InstanceName, timeline.Status, timeline.RunnerStatus, timeline.Stage)
+	}
+	t := table.NewWriter()
+	header := table.Row{"Time", "Stage", "Event", "Level", "Message"}
+	t.AppendHeader(header)
+	for _, entry := range timeline.Entries {
+		t.AppendRow(table.Row{entry.Time.Format("2006-01-02T15:04:05"), entry.Stage, entry.EventType, entry.EventLevel, entry.Message})
+		t.AppendSeparator()
+	}
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 5, WidthMax: 80},
+	})
+	fmt.Println(t.Render())
+}
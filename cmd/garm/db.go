@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudbase/garm/config"
+	"github.com/cloudbase/garm/database"
+	"github.com/cloudbase/garm/database/sql"
+)
+
+// dbCommand implements the db subcommand, which manages the database schema.
+func dbCommand(args []string, defaultConfig string) error {
+	flags := flag.NewFlagSet("db", flag.ExitOnError)
+	cfgFile := flags.String("config", defaultConfig, "GARM config file")
+	to := flags.Uint("to", 0, "schema version to migrate or roll back to. Defaults to the latest version for migrate and to the previous version for rollback.")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: garm db <status|migrate|rollback> [--config <config>] [--to <version>]\n\n")
+		fmt.Fprintf(flags.Output(), "Manage the database schema. GARM applies pending migrations when it starts. Rolling back\n")
+		fmt.Fprintf(flags.Output(), "migrations allows downgrading to an older release. GARM must not be running to migrate or roll back.\n\n")
+		flags.PrintDefaults()
+	}
+	if len(args) == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	action := args[0]
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	cfg, err := config.NewConfig(*cfgFile)
+	if err != nil {
+		return errors.Wrapf(err, "loading %s", *cfgFile)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), signals...)
+	defer stop()
+
+	switch action {
+	case "status":
+		migrations, err := database.SchemaStatus(ctx, cfg.Database)
+		if err != nil {
+			return errors.Wrap(err, "fetching schema status")
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, migration := range migrations {
+			appliedAt := "pending"
+			if migration.AppliedAt != nil {
+				appliedAt = migration.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", migration.Version, migration.Name, appliedAt)
+		}
+		return w.Flush()
+	case "migrate":
+		applied, err := database.MigrateSchema(ctx, cfg.Database, *to)
+		printMigrations("Applied", applied)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("Nothing to do.")
+		}
+	case "rollback":
+		target := *to
+		if !isFlagSet(flags, "to") {
+			migrations, err := database.SchemaStatus(ctx, cfg.Database)
+			if err != nil {
+				return errors.Wrap(err, "fetching schema status")
+			}
+			target = previousSchemaVersion(migrations)
+		}
+		reverted, err := database.RollbackSchema(ctx, cfg.Database, target)
+		printMigrations("Rolled back", reverted)
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			fmt.Println("Nothing to do.")
+		}
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+	return nil
+}
+
+func printMigrations(verb string, migrations []sql.SchemaMigration) {
+	for _, migration := range migrations {
+		fmt.Printf("%s migration %d (%s)\n", verb, migration.Version, migration.Name)
+	}
+}
+
+// previousSchemaVersion returns the version preceding the last applied
+// migration.
+func previousSchemaVersion(migrations []sql.SchemaMigration) uint {
+	var current, previous uint
+	for _, migration := range migrations {
+		if migration.AppliedAt == nil {
+			continue
+		}
+		previous, current = current, migration.Version
+	}
+	return previous
+}
+
+func isFlagSet(flags *flag.FlagSet, name string) bool {
+	var found bool
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			found = true
+		}
+	})
+	return found
+}
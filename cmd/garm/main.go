@@ -72,6 +72,23 @@ func maybeInitController(db common.Store) error {
 	return nil
 }
 
+// recordHeartbeat periodically records in the database that this controller
+// is running, until the context is canceled.
+func recordHeartbeat(ctx context.Context, db common.Store) {
+	ticker := time.NewTicker(appdefaults.ControllerHeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		if err := db.UpdateControllerHeartbeat(); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to record controller heartbeat")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func setupLogging(ctx context.Context, logCfg config.Logging, hub *websocket.Hub) {
 	logWriter, err := util.GetLoggingWriter(logCfg.LogFile)
 	if err != nil {
@@ -180,6 +197,24 @@ func main() {
 		fmt.Println(appdefaults.GetVersion())
 		return
 	}
+	if flag.Arg(0) == "migrate-db" {
+		if err := migrateDB(flag.Args()[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if flag.Arg(0) == "db" {
+		if err := dbCommand(flag.Args()[1:], *conf); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if flag.Arg(0) == "rotate-passphrase" {
+		if err := rotatePassphrase(flag.Args()[1:], *conf); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 	ctx, stop := signal.NotifyContext(context.Background(), signals...)
 	defer stop()
 	watcher.InitWatcher(ctx)
@@ -218,6 +253,12 @@ func main() {
 		log.Fatal(err)
 	}
 
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		recordHeartbeat(ctx, db)
+	}()
+
 	runner, err := runner.NewRunner(ctx, *cfg, db)
 	if err != nil {
 		log.Fatalf("failed to create controller: %+v", err)
@@ -321,8 +362,15 @@ func main() {
 	}
 
 	slog.With(slog.Any("error", err)).InfoContext(ctx, "waiting for runner to stop")
-	if err := runner.Wait(); err != nil {
-		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to shutdown workers")
+	runnerErr := runner.Wait()
+
+	<-heartbeatDone
+	if err := db.ClearControllerHeartbeat(); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to clear controller heartbeat")
+	}
+
+	if runnerErr != nil {
+		slog.With(slog.Any("error", runnerErr)).ErrorContext(ctx, "failed to shutdown workers")
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudbase/garm/config"
+	"github.com/cloudbase/garm/database"
+)
+
+// migrateDB implements the migrate-db subcommand. It copies all data from the
+// database configured in one GARM config file to the database configured in
+// another.
+func migrateDB(args []string) error {
+	flags := flag.NewFlagSet("migrate-db", flag.ExitOnError)
+	from := flags.String("from", "", "GARM config file of the database to copy from")
+	to := flags.String("to", "", "GARM config file of the database to copy to. The database must be empty.")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: garm migrate-db --from <config> --to <config>\n\n")
+		fmt.Fprintf(flags.Output(), "Copy all data between two databases. GARM must not be running on the source database.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *from == "" || *to == "" {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	sourceCfg, err := config.NewConfig(*from)
+	if err != nil {
+		return errors.Wrapf(err, "loading %s", *from)
+	}
+	destinationCfg, err := config.NewConfig(*to)
+	if err != nil {
+		return errors.Wrapf(err, "loading %s", *to)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), signals...)
+	defer stop()
+
+	tables, err := database.MigrateBackend(ctx, sourceCfg.Database, destinationCfg.Database)
+	if err != nil {
+		return errors.Wrap(err, "migrating database")
+	}
+
+	var total int64
+	for _, table := range tables {
+		fmt.Printf("%-25s %d\n", table.Table, table.Rows)
+		total += table.Rows
+	}
+	fmt.Printf("Copied %d rows from the %s database to the %s database.\n", total, sourceCfg.Database.DbBackend, destinationCfg.Database.DbBackend)
+	return nil
+}
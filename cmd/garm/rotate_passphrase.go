@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os/signal"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudbase/garm/config"
+	"github.com/cloudbase/garm/database"
+)
+
+// rotatePassphrase implements the rotate-passphrase subcommand. It re-encrypts
+// all secrets in the database using the current passphrase from the config file.
+func rotatePassphrase(args []string, defaultConfig string) error {
+	flags := flag.NewFlagSet("rotate-passphrase", flag.ExitOnError)
+	cfgFile := flags.String("config", defaultConfig, "GARM config file")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: garm rotate-passphrase [--config <config>]\n\n")
+		fmt.Fprintf(flags.Output(), "Re-encrypt all secrets in the database using the configured passphrase. Secrets may be\n")
+		fmt.Fprintf(flags.Output(), "encrypted with the configured passphrase or with any of the old_passphrases. GARM must not be running.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.NewConfig(*cfgFile)
+	if err != nil {
+		return errors.Wrapf(err, "loading %s", *cfgFile)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), signals...)
+	defer stop()
+
+	tables, err := database.RotatePassphrase(ctx, cfg.Database)
+	if err != nil {
+		return errors.Wrap(err, "rotating passphrase")
+	}
+
+	var total int64
+	for _, table := range tables {
+		fmt.Printf("%-25s %d\n", table.Table, table.Rows)
+		total += table.Rows
+	}
+	fmt.Printf("Re-encrypted %d rows. The old_passphrases may now be removed from the config.\n", total)
+	return nil
+}
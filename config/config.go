@@ -204,6 +204,9 @@ type Default struct {
 	WebhookURL string `toml:"webhook_url" json:"webhook-url"`
 	// EnableWebhookManagement enables the webhook management API.
 	EnableWebhookManagement bool `toml:"enable_webhook_management" json:"enable-webhook-management"`
+	// WebhookSecretGracePeriod is the amount of time a rotated webhook secret is
+	// still accepted after a new one is set. Defaults to 1h.
+	WebhookSecretGracePeriod string `toml:"webhook_secret_grace_period,omitempty" json:"webhook-secret-grace-period,omitempty"`
 
 	// LogFile is the location of the log file.
 	LogFile           string `toml:"log_file,omitempty" json:"log-file"`
@@ -230,9 +233,29 @@ func (d *Default) Validate() error {
 			return fmt.Errorf("invalid webhook_url: %w", err)
 		}
 	}
+
+	if d.WebhookSecretGracePeriod != "" {
+		if _, err := time.ParseDuration(d.WebhookSecretGracePeriod); err != nil {
+			return fmt.Errorf("invalid webhook_secret_grace_period: %w", err)
+		}
+	}
 	return nil
 }
 
+// GetWebhookSecretGracePeriod returns the configured webhook secret grace period
+// or the default value if none is set.
+func (d *Default) GetWebhookSecretGracePeriod() time.Duration {
+	if d.WebhookSecretGracePeriod == "" {
+		return appdefaults.DefaultWebhookSecretGracePeriod
+	}
+	duration, err := time.ParseDuration(d.WebhookSecretGracePeriod)
+	if err != nil {
+		slog.With(slog.Any("error", err)).Error("failed to parse webhook secret grace period")
+		return appdefaults.DefaultWebhookSecretGracePeriod
+	}
+	return duration
+}
+
 type GithubPAT struct {
 	OAuth2Token string `toml:"oauth2_token" json:"oauth2-token"`
 }
@@ -459,6 +482,31 @@ type Provider struct {
 	// JIT configuration.
 	DisableJITConfig bool     `toml:"disable_jit_config" json:"disable-jit-config"`
 	External         External `toml:"external" json:"external"`
+	// Throttle limits the rate and the number of concurrent operations GARM
+	// sends to this provider.
+	Throttle ProviderThrottle `toml:"throttle" json:"throttle"`
+}
+
+// ProviderThrottle holds the rate and concurrency limits of a provider. The
+// operations of all pool managers using the provider are subject to the same
+// limits, and waiting operations are admitted in turn for each pool manager.
+type ProviderThrottle struct {
+	// MaxConcurrentOperations is the maximum number of operations that may run
+	// at the same time. A value of 0 means no limit.
+	MaxConcurrentOperations uint `toml:"max_concurrent_operations" json:"max-concurrent-operations"`
+	// OperationsPerSecond is the rate at which operations are started. A value
+	// of 0 means no limit.
+	OperationsPerSecond float64 `toml:"operations_per_second" json:"operations-per-second"`
+	// Burst is the number of operations that may be started at once when the
+	// rate limit allows it. Defaults to 1.
+	Burst uint `toml:"burst" json:"burst"`
+}
+
+func (t *ProviderThrottle) Validate() error {
+	if t.OperationsPerSecond < 0 {
+		return fmt.Errorf("operations_per_second must not be negative")
+	}
+	return nil
 }
 
 func (p *Provider) Validate() error {
@@ -474,6 +522,10 @@ func (p *Provider) Validate() error {
 	default:
 		return fmt.Errorf("unknown provider type: %s", p.ProviderType)
 	}
+
+	if err := p.Throttle.Validate(); err != nil {
+		return fmt.Errorf("invalid throttle config: %w", err)
+	}
 	return nil
 }
 
@@ -489,6 +541,11 @@ type Database struct {
 	// Don't lose or change this. It will invalidate all encrypted data
 	// in the DB. This field must be set and must be exactly 32 characters.
 	Passphrase string `toml:"passphrase"`
+	// OldPassphrases is a list of passphrases that were previously used to
+	// encrypt data in the database. They are only used for decryption, while
+	// the passphrase is being rotated. Once "garm rotate-passphrase" has been run,
+	// they can be removed.
+	OldPassphrases []string `toml:"old_passphrases"`
 
 	// MigrateCredentials is a list of github credentials that need to be migrated
 	// from the config file to the database. This field will be removed once GARM
@@ -534,6 +591,12 @@ func (d *Database) Validate() error {
 		return fmt.Errorf("database passphrase is too weak")
 	}
 
+	for idx, old := range d.OldPassphrases {
+		if len(old) != 32 {
+			return fmt.Errorf("old passphrase %d must be a string of 32 characters (aes 256)", idx)
+		}
+	}
+
 	switch d.DbBackend {
 	case MySQLBackend:
 		if err := d.MySQL.Validate(); err != nil {
@@ -158,6 +158,15 @@ func TestDefaultSectionConfig(t *testing.T) {
 			},
 			errString: "invalid metadata_url",
 		},
+		{
+			name: "WebhookSecretGracePeriod must be valid if set",
+			cfg: Default{
+				CallbackURL:              cfg.CallbackURL,
+				MetadataURL:              cfg.MetadataURL,
+				WebhookSecretGracePeriod: "bogus",
+			},
+			errString: "invalid webhook_secret_grace_period",
+		},
 	}
 
 	for _, tc := range tests {
@@ -173,6 +182,26 @@ func TestDefaultSectionConfig(t *testing.T) {
 	}
 }
 
+func TestWebhookSecretGracePeriod(t *testing.T) {
+	cfg := Default{}
+	require.Equal(t, appdefaults.DefaultWebhookSecretGracePeriod, cfg.GetWebhookSecretGracePeriod())
+
+	cfg.WebhookSecretGracePeriod = "30m"
+	require.Equal(t, 30*time.Minute, cfg.GetWebhookSecretGracePeriod())
+}
+
+func TestProviderThrottleConfig(t *testing.T) {
+	cfg := ProviderThrottle{}
+	require.Nil(t, cfg.Validate())
+
+	cfg.OperationsPerSecond = 2.5
+	cfg.MaxConcurrentOperations = 10
+	require.Nil(t, cfg.Validate())
+
+	cfg.OperationsPerSecond = -1
+	require.EqualError(t, cfg.Validate(), "operations_per_second must not be negative")
+}
+
 func TestValidateAPIServerConfig(t *testing.T) {
 	cfg := getDefaultAPIServerConfig()
 
@@ -332,6 +361,16 @@ func TestDatabaseConfig(t *testing.T) {
 			},
 			errString: "database passphrase is too weak",
 		},
+		{
+			name: "old passphrase has invalid length",
+			cfg: Database{
+				DbBackend:      cfg.DbBackend,
+				SQLite:         cfg.SQLite,
+				Passphrase:     cfg.Passphrase,
+				OldPassphrases: []string{"testing"},
+			},
+			errString: "old passphrase 0 must be a string of 32 characters*",
+		},
 		{
 			name: "sqlite3 backend is missconfigured",
 			cfg: Database{
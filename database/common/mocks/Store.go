@@ -7,6 +7,8 @@ import (
 
 	params "github.com/cloudbase/garm/params"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Store is an autogenerated mock type for the Store type
@@ -50,6 +52,24 @@ func (_m *Store) BreakLockJobIsQueued(ctx context.Context, jobID int64) error {
 	return r0
 }
 
+// ClearControllerHeartbeat provides a mock function with given fields:
+func (_m *Store) ClearControllerHeartbeat() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearControllerHeartbeat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ControllerInfo provides a mock function with given fields:
 func (_m *Store) ControllerInfo() (params.ControllerInfo, error) {
 	ret := _m.Called()
@@ -78,6 +98,34 @@ func (_m *Store) ControllerInfo() (params.ControllerInfo, error) {
 	return r0, r1
 }
 
+// CreateBackup provides a mock function with given fields: ctx, passphrase
+func (_m *Store) CreateBackup(ctx context.Context, passphrase string) (params.Backup, error) {
+	ret := _m.Called(ctx, passphrase)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBackup")
+	}
+
+	var r0 params.Backup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (params.Backup, error)); ok {
+		return rf(ctx, passphrase)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) params.Backup); ok {
+		r0 = rf(ctx, passphrase)
+	} else {
+		r0 = ret.Get(0).(params.Backup)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, passphrase)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateEnterprise provides a mock function with given fields: ctx, name, credentialsName, webhookSecret, poolBalancerType
 func (_m *Store) CreateEnterprise(ctx context.Context, name string, credentialsName string, webhookSecret string, poolBalancerType params.PoolBalancerType) (params.Enterprise, error) {
 	ret := _m.Called(ctx, name, credentialsName, webhookSecret, poolBalancerType)
@@ -274,6 +322,34 @@ func (_m *Store) CreateOrganization(ctx context.Context, name string, credential
 	return r0, r1
 }
 
+// CreatePoolTemplate provides a mock function with given fields: ctx, param
+func (_m *Store) CreatePoolTemplate(ctx context.Context, param params.CreatePoolTemplateParams) (params.PoolTemplate, error) {
+	ret := _m.Called(ctx, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePoolTemplate")
+	}
+
+	var r0 params.PoolTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.CreatePoolTemplateParams) (params.PoolTemplate, error)); ok {
+		return rf(ctx, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.CreatePoolTemplateParams) params.PoolTemplate); ok {
+		r0 = rf(ctx, param)
+	} else {
+		r0 = ret.Get(0).(params.PoolTemplate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.CreatePoolTemplateParams) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateRepository provides a mock function with given fields: ctx, owner, name, credentialsName, webhookSecret, poolBalancerType
 func (_m *Store) CreateRepository(ctx context.Context, owner string, name string, credentialsName string, webhookSecret string, poolBalancerType params.PoolBalancerType) (params.Repository, error) {
 	ret := _m.Called(ctx, owner, name, credentialsName, webhookSecret, poolBalancerType)
@@ -330,6 +406,34 @@ func (_m *Store) CreateUser(ctx context.Context, user params.NewUserParams) (par
 	return r0, r1
 }
 
+// CreateWebhookDelivery provides a mock function with given fields: ctx, param
+func (_m *Store) CreateWebhookDelivery(ctx context.Context, param params.CreateWebhookDeliveryParams) (params.WebhookDelivery, error) {
+	ret := _m.Called(ctx, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhookDelivery")
+	}
+
+	var r0 params.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.CreateWebhookDeliveryParams) (params.WebhookDelivery, error)); ok {
+		return rf(ctx, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.CreateWebhookDeliveryParams) params.WebhookDelivery); ok {
+		r0 = rf(ctx, param)
+	} else {
+		r0 = ret.Get(0).(params.WebhookDelivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.CreateWebhookDeliveryParams) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteCompletedJobs provides a mock function with given fields: ctx
 func (_m *Store) DeleteCompletedJobs(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -366,6 +470,24 @@ func (_m *Store) DeleteEnterprise(ctx context.Context, enterpriseID string) erro
 	return r0
 }
 
+// DeleteEntityObservedActions provides a mock function with given fields: ctx, entity, olderThan
+func (_m *Store) DeleteEntityObservedActions(ctx context.Context, entity params.GithubEntity, olderThan time.Time) error {
+	ret := _m.Called(ctx, entity, olderThan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEntityObservedActions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.GithubEntity, time.Time) error); ok {
+		r0 = rf(ctx, entity, olderThan)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteEntityPool provides a mock function with given fields: ctx, entity, poolID
 func (_m *Store) DeleteEntityPool(ctx context.Context, entity params.GithubEntity, poolID string) error {
 	ret := _m.Called(ctx, entity, poolID)
@@ -438,6 +560,24 @@ func (_m *Store) DeleteInstance(ctx context.Context, poolID string, instanceName
 	return r0
 }
 
+// DeleteInstanceReapsOlderThan provides a mock function with given fields: ctx, olderThan
+func (_m *Store) DeleteInstanceReapsOlderThan(ctx context.Context, olderThan time.Time) error {
+	ret := _m.Called(ctx, olderThan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInstanceReapsOlderThan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, olderThan)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteJob provides a mock function with given fields: ctx, jobID
 func (_m *Store) DeleteJob(ctx context.Context, jobID int64) error {
 	ret := _m.Called(ctx, jobID)
@@ -492,6 +632,24 @@ func (_m *Store) DeletePoolByID(ctx context.Context, poolID string) error {
 	return r0
 }
 
+// DeletePoolTemplate provides a mock function with given fields: ctx, templateID
+func (_m *Store) DeletePoolTemplate(ctx context.Context, templateID string) error {
+	ret := _m.Called(ctx, templateID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePoolTemplate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, templateID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteRepository provides a mock function with given fields: ctx, repoID
 func (_m *Store) DeleteRepository(ctx context.Context, repoID string) error {
 	ret := _m.Called(ctx, repoID)
@@ -510,6 +668,24 @@ func (_m *Store) DeleteRepository(ctx context.Context, repoID string) error {
 	return r0
 }
 
+// DeleteWebhookDeliveriesOlderThan provides a mock function with given fields: ctx, olderThan
+func (_m *Store) DeleteWebhookDeliveriesOlderThan(ctx context.Context, olderThan time.Time) error {
+	ret := _m.Called(ctx, olderThan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhookDeliveriesOlderThan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, olderThan)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindPoolsMatchingAllTags provides a mock function with given fields: ctx, entityType, entityID, tags
 func (_m *Store) FindPoolsMatchingAllTags(ctx context.Context, entityType params.GithubEntityType, entityID string, tags []string) ([]params.Pool, error) {
 	ret := _m.Called(ctx, entityType, entityID, tags)
@@ -568,6 +744,34 @@ func (_m *Store) GetAdminUser(ctx context.Context) (params.User, error) {
 	return r0, r1
 }
 
+// GetCostReport provides a mock function with given fields: ctx, since
+func (_m *Store) GetCostReport(ctx context.Context, since time.Time) (params.CostReport, error) {
+	ret := _m.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCostReport")
+	}
+
+	var r0 params.CostReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (params.CostReport, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) params.CostReport); ok {
+		r0 = rf(ctx, since)
+	} else {
+		r0 = ret.Get(0).(params.CostReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEnterprise provides a mock function with given fields: ctx, name, endpointName
 func (_m *Store) GetEnterprise(ctx context.Context, name string, endpointName string) (params.Enterprise, error) {
 	ret := _m.Called(ctx, name, endpointName)
@@ -904,6 +1108,34 @@ func (_m *Store) GetPoolInstanceByName(ctx context.Context, poolID string, insta
 	return r0, r1
 }
 
+// GetPoolTemplate provides a mock function with given fields: ctx, templateID
+func (_m *Store) GetPoolTemplate(ctx context.Context, templateID string) (params.PoolTemplate, error) {
+	ret := _m.Called(ctx, templateID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPoolTemplate")
+	}
+
+	var r0 params.PoolTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (params.PoolTemplate, error)); ok {
+		return rf(ctx, templateID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) params.PoolTemplate); ok {
+		r0 = rf(ctx, templateID)
+	} else {
+		r0 = ret.Get(0).(params.PoolTemplate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, templateID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRepository provides a mock function with given fields: ctx, owner, name, endpointName
 func (_m *Store) GetRepository(ctx context.Context, owner string, name string, endpointName string) (params.Repository, error) {
 	ret := _m.Called(ctx, owner, name, endpointName)
@@ -1016,6 +1248,34 @@ func (_m *Store) GetUserByID(ctx context.Context, userID string) (params.User, e
 	return r0, r1
 }
 
+// GetWebhookDelivery provides a mock function with given fields: ctx, deliveryID
+func (_m *Store) GetWebhookDelivery(ctx context.Context, deliveryID string) (params.WebhookDelivery, error) {
+	ret := _m.Called(ctx, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDelivery")
+	}
+
+	var r0 params.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (params.WebhookDelivery, error)); ok {
+		return rf(ctx, deliveryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) params.WebhookDelivery); ok {
+		r0 = rf(ctx, deliveryID)
+	} else {
+		r0 = ret.Get(0).(params.WebhookDelivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HasAdminUser provides a mock function with given fields: ctx
 func (_m *Store) HasAdminUser(ctx context.Context) bool {
 	ret := _m.Called(ctx)
@@ -1242,6 +1502,36 @@ func (_m *Store) ListEntityJobsByStatus(ctx context.Context, entityType params.G
 	return r0, r1
 }
 
+// ListEntityObservedActions provides a mock function with given fields: ctx, entity
+func (_m *Store) ListEntityObservedActions(ctx context.Context, entity params.GithubEntity) ([]params.ObservedAction, error) {
+	ret := _m.Called(ctx, entity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEntityObservedActions")
+	}
+
+	var r0 []params.ObservedAction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.GithubEntity) ([]params.ObservedAction, error)); ok {
+		return rf(ctx, entity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.GithubEntity) []params.ObservedAction); ok {
+		r0 = rf(ctx, entity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]params.ObservedAction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.GithubEntity) error); ok {
+		r1 = rf(ctx, entity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListEntityPools provides a mock function with given fields: ctx, entity
 func (_m *Store) ListEntityPools(ctx context.Context, entity params.GithubEntity) ([]params.Pool, error) {
 	ret := _m.Called(ctx, entity)
@@ -1332,6 +1622,36 @@ func (_m *Store) ListGithubEndpoints(ctx context.Context) ([]params.GithubEndpoi
 	return r0, r1
 }
 
+// ListInstanceReaps provides a mock function with given fields: ctx, instanceName
+func (_m *Store) ListInstanceReaps(ctx context.Context, instanceName string) ([]params.InstanceReap, error) {
+	ret := _m.Called(ctx, instanceName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInstanceReaps")
+	}
+
+	var r0 []params.InstanceReap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]params.InstanceReap, error)); ok {
+		return rf(ctx, instanceName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []params.InstanceReap); ok {
+		r0 = rf(ctx, instanceName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]params.InstanceReap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, instanceName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListJobsByStatus provides a mock function with given fields: ctx, status
 func (_m *Store) ListJobsByStatus(ctx context.Context, status params.JobStatus) ([]params.Job, error) {
 	ret := _m.Called(ctx, status)
@@ -1422,6 +1742,66 @@ func (_m *Store) ListPoolInstances(ctx context.Context, poolID string) ([]params
 	return r0, r1
 }
 
+// ListPoolTemplatePools provides a mock function with given fields: ctx, templateID
+func (_m *Store) ListPoolTemplatePools(ctx context.Context, templateID string) ([]params.Pool, error) {
+	ret := _m.Called(ctx, templateID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPoolTemplatePools")
+	}
+
+	var r0 []params.Pool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]params.Pool, error)); ok {
+		return rf(ctx, templateID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []params.Pool); ok {
+		r0 = rf(ctx, templateID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]params.Pool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, templateID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPoolTemplates provides a mock function with given fields: ctx
+func (_m *Store) ListPoolTemplates(ctx context.Context) ([]params.PoolTemplate, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPoolTemplates")
+	}
+
+	var r0 []params.PoolTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]params.PoolTemplate, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []params.PoolTemplate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]params.PoolTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListRepositories provides a mock function with given fields: ctx
 func (_m *Store) ListRepositories(ctx context.Context) ([]params.Repository, error) {
 	ret := _m.Called(ctx)
@@ -1452,6 +1832,36 @@ func (_m *Store) ListRepositories(ctx context.Context) ([]params.Repository, err
 	return r0, r1
 }
 
+// ListWebhookDeliveriesByStatus provides a mock function with given fields: ctx, status
+func (_m *Store) ListWebhookDeliveriesByStatus(ctx context.Context, status params.WebhookDeliveryStatus) ([]params.WebhookDelivery, error) {
+	ret := _m.Called(ctx, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveriesByStatus")
+	}
+
+	var r0 []params.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.WebhookDeliveryStatus) ([]params.WebhookDelivery, error)); ok {
+		return rf(ctx, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.WebhookDeliveryStatus) []params.WebhookDelivery); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]params.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.WebhookDeliveryStatus) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LockJob provides a mock function with given fields: ctx, jobID, entityID
 func (_m *Store) LockJob(ctx context.Context, jobID int64, entityID string) error {
 	ret := _m.Called(ctx, jobID, entityID)
@@ -1498,6 +1908,134 @@ func (_m *Store) PoolInstanceCount(ctx context.Context, poolID string) (int64, e
 	return r0, r1
 }
 
+// RecordInstanceReap provides a mock function with given fields: ctx, param
+func (_m *Store) RecordInstanceReap(ctx context.Context, param params.RecordInstanceReapParams) (params.InstanceReap, error) {
+	ret := _m.Called(ctx, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordInstanceReap")
+	}
+
+	var r0 params.InstanceReap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.RecordInstanceReapParams) (params.InstanceReap, error)); ok {
+		return rf(ctx, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.RecordInstanceReapParams) params.InstanceReap); ok {
+		r0 = rf(ctx, param)
+	} else {
+		r0 = ret.Get(0).(params.InstanceReap)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.RecordInstanceReapParams) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordObservedAction provides a mock function with given fields: ctx, entity, param
+func (_m *Store) RecordObservedAction(ctx context.Context, entity params.GithubEntity, param params.RecordObservedActionParams) error {
+	ret := _m.Called(ctx, entity, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordObservedAction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.GithubEntity, params.RecordObservedActionParams) error); ok {
+		r0 = rf(ctx, entity, param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordRunnerUsage provides a mock function with given fields: ctx, param
+func (_m *Store) RecordRunnerUsage(ctx context.Context, param params.RecordRunnerUsageParams) (params.RunnerUsage, error) {
+	ret := _m.Called(ctx, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordRunnerUsage")
+	}
+
+	var r0 params.RunnerUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.RecordRunnerUsageParams) (params.RunnerUsage, error)); ok {
+		return rf(ctx, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.RecordRunnerUsageParams) params.RunnerUsage); ok {
+		r0 = rf(ctx, param)
+	} else {
+		r0 = ret.Get(0).(params.RunnerUsage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.RecordRunnerUsageParams) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreBackup provides a mock function with given fields: ctx, backup, passphrase
+func (_m *Store) RestoreBackup(ctx context.Context, backup params.Backup, passphrase string) error {
+	ret := _m.Called(ctx, backup, passphrase)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreBackup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.Backup, string) error); ok {
+		r0 = rf(ctx, backup, passphrase)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetJobPolicyDenial provides a mock function with given fields: ctx, jobID, denial
+func (_m *Store) SetJobPolicyDenial(ctx context.Context, jobID int64, denial string) error {
+	ret := _m.Called(ctx, jobID, denial)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetJobPolicyDenial")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, jobID, denial)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetJobWorkflowRun provides a mock function with given fields: ctx, jobID, event, workflowPath
+func (_m *Store) SetJobWorkflowRun(ctx context.Context, jobID int64, event string, workflowPath string) error {
+	ret := _m.Called(ctx, jobID, event, workflowPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetJobWorkflowRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
+		r0 = rf(ctx, jobID, event, workflowPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UnlockJob provides a mock function with given fields: ctx, jobID, entityID
 func (_m *Store) UnlockJob(ctx context.Context, jobID int64, entityID string) error {
 	ret := _m.Called(ctx, jobID, entityID)
@@ -1544,6 +2082,24 @@ func (_m *Store) UpdateController(info params.UpdateControllerParams) (params.Co
 	return r0, r1
 }
 
+// UpdateControllerHeartbeat provides a mock function with given fields:
+func (_m *Store) UpdateControllerHeartbeat() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateControllerHeartbeat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateEnterprise provides a mock function with given fields: ctx, enterpriseID, param
 func (_m *Store) UpdateEnterprise(ctx context.Context, enterpriseID string, param params.UpdateEntityParams) (params.Enterprise, error) {
 	ret := _m.Called(ctx, enterpriseID, param)
@@ -1712,6 +2268,43 @@ func (_m *Store) UpdateOrganization(ctx context.Context, orgID string, param par
 	return r0, r1
 }
 
+// UpdatePoolTemplate provides a mock function with given fields: ctx, templateID, param
+func (_m *Store) UpdatePoolTemplate(ctx context.Context, templateID string, param params.UpdatePoolTemplateParams) (params.PoolTemplate, []params.PoolTemplateChange, error) {
+	ret := _m.Called(ctx, templateID, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePoolTemplate")
+	}
+
+	var r0 params.PoolTemplate
+	var r1 []params.PoolTemplateChange
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, params.UpdatePoolTemplateParams) (params.PoolTemplate, []params.PoolTemplateChange, error)); ok {
+		return rf(ctx, templateID, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, params.UpdatePoolTemplateParams) params.PoolTemplate); ok {
+		r0 = rf(ctx, templateID, param)
+	} else {
+		r0 = ret.Get(0).(params.PoolTemplate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, params.UpdatePoolTemplateParams) []params.PoolTemplateChange); ok {
+		r1 = rf(ctx, templateID, param)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]params.PoolTemplateChange)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, params.UpdatePoolTemplateParams) error); ok {
+		r2 = rf(ctx, templateID, param)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UpdateRepository provides a mock function with given fields: ctx, repoID, param
 func (_m *Store) UpdateRepository(ctx context.Context, repoID string, param params.UpdateEntityParams) (params.Repository, error) {
 	ret := _m.Called(ctx, repoID, param)
@@ -1768,6 +2361,34 @@ func (_m *Store) UpdateUser(ctx context.Context, user string, param params.Updat
 	return r0, r1
 }
 
+// UpdateWebhookDelivery provides a mock function with given fields: ctx, deliveryID, param
+func (_m *Store) UpdateWebhookDelivery(ctx context.Context, deliveryID string, param params.UpdateWebhookDeliveryParams) (params.WebhookDelivery, error) {
+	ret := _m.Called(ctx, deliveryID, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhookDelivery")
+	}
+
+	var r0 params.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, params.UpdateWebhookDeliveryParams) (params.WebhookDelivery, error)); ok {
+		return rf(ctx, deliveryID, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, params.UpdateWebhookDeliveryParams) params.WebhookDelivery); ok {
+		r0 = rf(ctx, deliveryID, param)
+	} else {
+		r0 = ret.Get(0).(params.WebhookDelivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, params.UpdateWebhookDeliveryParams) error); ok {
+		r1 = rf(ctx, deliveryID, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStore(t interface {
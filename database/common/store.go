@@ -16,6 +16,7 @@ package common
 
 import (
 	"context"
+	"time"
 
 	"github.com/cloudbase/garm/params"
 )
@@ -115,10 +116,50 @@ type JobsStore interface {
 	UnlockJob(ctx context.Context, jobID int64, entityID string) error
 	LockJob(ctx context.Context, jobID int64, entityID string) error
 	BreakLockJobIsQueued(ctx context.Context, jobID int64) error
+	SetJobWorkflowRun(ctx context.Context, jobID int64, event, workflowPath string) error
+	SetJobPolicyDenial(ctx context.Context, jobID int64, denial string) error
 
 	DeleteCompletedJobs(ctx context.Context) error
 }
 
+type WebhookDeliveryStore interface {
+	CreateWebhookDelivery(ctx context.Context, param params.CreateWebhookDeliveryParams) (params.WebhookDelivery, error)
+	GetWebhookDelivery(ctx context.Context, deliveryID string) (params.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, deliveryID string, param params.UpdateWebhookDeliveryParams) (params.WebhookDelivery, error)
+	ListWebhookDeliveriesByStatus(ctx context.Context, status params.WebhookDeliveryStatus) ([]params.WebhookDelivery, error)
+	// DeleteWebhookDeliveriesOlderThan removes completed and failed deliveries that
+	// were last updated before the given time.
+	DeleteWebhookDeliveriesOlderThan(ctx context.Context, olderThan time.Time) error
+}
+
+type ObservedActionStore interface {
+	// RecordObservedAction records an action a pool manager in observe only mode
+	// would have taken.
+	RecordObservedAction(ctx context.Context, entity params.GithubEntity, param params.RecordObservedActionParams) error
+	ListEntityObservedActions(ctx context.Context, entity params.GithubEntity) ([]params.ObservedAction, error)
+	// DeleteEntityObservedActions removes the actions of an entity that were last
+	// observed before the given time.
+	DeleteEntityObservedActions(ctx context.Context, entity params.GithubEntity, olderThan time.Time) error
+}
+
+type InstanceReapStore interface {
+	// RecordInstanceReap records why garm removes an instance. The record is not
+	// tied to the instance and is kept after the instance is deleted.
+	RecordInstanceReap(ctx context.Context, param params.RecordInstanceReapParams) (params.InstanceReap, error)
+	// ListInstanceReaps returns the reaps recorded for an instance, oldest first.
+	ListInstanceReaps(ctx context.Context, instanceName string) ([]params.InstanceReap, error)
+	// DeleteInstanceReapsOlderThan removes the reaps recorded before the given time.
+	DeleteInstanceReapsOlderThan(ctx context.Context, olderThan time.Time) error
+}
+
+type RunnerUsageStore interface {
+	// RecordRunnerUsage records the time a runner was up since its previous usage
+	// and the cost of that time.
+	RecordRunnerUsage(ctx context.Context, param params.RecordRunnerUsageParams) (params.RunnerUsage, error)
+	// GetCostReport sums up the runner usage that ended after the given time.
+	GetCostReport(ctx context.Context, since time.Time) (params.CostReport, error)
+}
+
 type EntityPoolStore interface {
 	CreateEntityPool(ctx context.Context, entity params.GithubEntity, param params.CreatePoolParams) (params.Pool, error)
 	GetEntityPool(ctx context.Context, entity params.GithubEntity, poolID string) (params.Pool, error)
@@ -129,10 +170,34 @@ type EntityPoolStore interface {
 	ListEntityInstances(ctx context.Context, entity params.GithubEntity) ([]params.Instance, error)
 }
 
+type PoolTemplateStore interface {
+	CreatePoolTemplate(ctx context.Context, param params.CreatePoolTemplateParams) (params.PoolTemplate, error)
+	GetPoolTemplate(ctx context.Context, templateID string) (params.PoolTemplate, error)
+	ListPoolTemplates(ctx context.Context) ([]params.PoolTemplate, error)
+	// UpdatePoolTemplate updates the template and propagates the changes to all pools
+	// derived from it. Fields overridden by a pool are left untouched.
+	UpdatePoolTemplate(ctx context.Context, templateID string, param params.UpdatePoolTemplateParams) (params.PoolTemplate, []params.PoolTemplateChange, error)
+	DeletePoolTemplate(ctx context.Context, templateID string) error
+	ListPoolTemplatePools(ctx context.Context, templateID string) ([]params.Pool, error)
+}
+
+type BackupStore interface {
+	// CreateBackup returns a copy of the database with all secrets sealed using
+	// the given passphrase.
+	CreateBackup(ctx context.Context, passphrase string) (params.Backup, error)
+	// RestoreBackup restores a backup created with CreateBackup. The database must
+	// not hold any credentials, entities, pool templates or pools.
+	RestoreBackup(ctx context.Context, backup params.Backup, passphrase string) error
+}
+
 type ControllerStore interface {
 	ControllerInfo() (params.ControllerInfo, error)
 	InitController() (params.ControllerInfo, error)
 	UpdateController(info params.UpdateControllerParams) (params.ControllerInfo, error)
+	// UpdateControllerHeartbeat records that a GARM server is using the database.
+	UpdateControllerHeartbeat() error
+	// ClearControllerHeartbeat is called when the GARM server shuts down.
+	ClearControllerHeartbeat() error
 }
 
 //go:generate mockery --name=Store
@@ -144,10 +209,16 @@ type Store interface {
 	UserStore
 	InstanceStore
 	JobsStore
+	WebhookDeliveryStore
+	ObservedActionStore
+	InstanceReapStore
+	RunnerUsageStore
 	GithubEndpointStore
 	GithubCredentialsStore
 	ControllerStore
 	EntityPoolStore
+	PoolTemplateStore
+	BackupStore
 
 	ControllerInfo() (params.ControllerInfo, error)
 	InitController() (params.ControllerInfo, error)
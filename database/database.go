@@ -32,3 +32,59 @@ func NewDatabase(ctx context.Context, cfg config.Database) (common.Store, error)
 		return nil, fmt.Errorf("db backend not available: %s", dbBackend)
 	}
 }
+
+// MigrateBackend copies all data from the source database to an empty
+// destination database. The two databases may use different backends.
+func MigrateBackend(ctx context.Context, source, destination config.Database) ([]sql.TableRows, error) {
+	for _, backend := range []config.DBBackendType{source.DbBackend, destination.DbBackend} {
+		switch backend {
+		case config.MySQLBackend, config.SQLiteBackend:
+		default:
+			return nil, fmt.Errorf("db backend not available: %s", backend)
+		}
+	}
+	return sql.MigrateBackend(ctx, source, destination)
+}
+
+// RotatePassphrase re-encrypts all secrets in the database using the current
+// passphrase. Secrets sealed with any of the old passphrases are decrypted first.
+func RotatePassphrase(ctx context.Context, cfg config.Database) ([]sql.TableRows, error) {
+	switch cfg.DbBackend {
+	case config.MySQLBackend, config.SQLiteBackend:
+		return sql.RotatePassphrase(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("db backend not available: %s", cfg.DbBackend)
+	}
+}
+
+// SchemaStatus returns all known schema migrations and whether they were
+// applied to the database.
+func SchemaStatus(ctx context.Context, cfg config.Database) ([]sql.SchemaMigration, error) {
+	switch cfg.DbBackend {
+	case config.MySQLBackend, config.SQLiteBackend:
+		return sql.SchemaStatus(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("db backend not available: %s", cfg.DbBackend)
+	}
+}
+
+// MigrateSchema applies pending schema migrations up to the target version. A
+// target of 0 migrates to the latest version.
+func MigrateSchema(ctx context.Context, cfg config.Database, target uint) ([]sql.SchemaMigration, error) {
+	switch cfg.DbBackend {
+	case config.MySQLBackend, config.SQLiteBackend:
+		return sql.MigrateSchema(ctx, cfg, target)
+	default:
+		return nil, fmt.Errorf("db backend not available: %s", cfg.DbBackend)
+	}
+}
+
+// RollbackSchema reverts schema migrations newer than the target version.
+func RollbackSchema(ctx context.Context, cfg config.Database, target uint) ([]sql.SchemaMigration, error) {
+	switch cfg.DbBackend {
+	case config.MySQLBackend, config.SQLiteBackend:
+		return sql.RollbackSchema(ctx, cfg, target)
+	default:
+		return nil, fmt.Errorf("db backend not available: %s", cfg.DbBackend)
+	}
+}
@@ -0,0 +1,713 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm-provider-common/util"
+	"github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+)
+
+var _ common.BackupStore = &sqlDatabase{}
+
+// reseal decrypts data using any of the given passphrases and encrypts it using
+// another one. Empty values are returned as is.
+func reseal(data []byte, fromPassphrases []string, toPassphrase string) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	decrypted, err := unsealWithAny(data, fromPassphrases)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting data")
+	}
+	sealed, err := util.Seal(decrypted, []byte(toPassphrase))
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypting data")
+	}
+	return sealed, nil
+}
+
+func tagNames(tags []*Tag) []string {
+	ret := make([]string, len(tags))
+	for idx, tag := range tags {
+		ret[idx] = tag.Name
+	}
+	return ret
+}
+
+func uuidPtrToString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func parseOptionalUUID(id string) (*uuid.UUID, error) {
+	if id == "" {
+		return nil, nil
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.Wrapf(runnerErrors.ErrBadRequest, "invalid ID %q", id)
+	}
+	return &parsed, nil
+}
+
+func (s *sqlDatabase) backupEntity(id uuid.UUID, owner, name string, credentialsID *uint, endpointName *string, balancer params.PoolBalancerType, scheduling datatypes.JSON, observeOnly bool, secret, previousSecret []byte, previousExpiresAt *time.Time, passphrase string) (params.BackupEntity, error) {
+	ret := params.BackupEntity{
+		ID:                             id.String(),
+		Owner:                          owner,
+		Name:                           name,
+		PoolBalancerType:               balancer,
+		ObserveOnly:                    observeOnly,
+		PreviousWebhookSecretExpiresAt: previousExpiresAt,
+	}
+	if credentialsID != nil {
+		ret.CredentialsID = *credentialsID
+	}
+	if endpointName != nil {
+		ret.Endpoint = *endpointName
+	}
+	if len(scheduling) > 0 {
+		if err := json.Unmarshal(scheduling, &ret.JobScheduling); err != nil {
+			return params.BackupEntity{}, errors.Wrap(err, "decoding job scheduling")
+		}
+	}
+
+	var err error
+	if ret.WebhookSecret, err = reseal(secret, decryptionPassphrases(s.cfg), passphrase); err != nil {
+		return params.BackupEntity{}, errors.Wrap(err, "sealing webhook secret")
+	}
+	if ret.PreviousWebhookSecret, err = reseal(previousSecret, decryptionPassphrases(s.cfg), passphrase); err != nil {
+		return params.BackupEntity{}, errors.Wrap(err, "sealing previous webhook secret")
+	}
+	return ret, nil
+}
+
+func (s *sqlDatabase) CreateBackup(_ context.Context, passphrase string) (params.Backup, error) {
+	backup := params.Backup{
+		Version:   params.BackupFormatVersion,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	var info ControllerInfo
+	if err := s.conn.First(&info).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return params.Backup{}, errors.Wrap(runnerErrors.ErrNotFound, "fetching controller info")
+		}
+		return params.Backup{}, errors.Wrap(err, "fetching controller info")
+	}
+	backup.Controller = params.BackupController{
+		ControllerID:         info.ControllerID.String(),
+		CallbackURL:          info.CallbackURL,
+		MetadataURL:          info.MetadataURL,
+		WebhookBaseURL:       info.WebhookBaseURL,
+		MinimumJobAgeBackoff: info.MinimumJobAgeBackoff,
+		ObserveOnly:          info.ObserveOnly,
+		PartitionLabel:       info.PartitionLabel,
+	}
+	if len(info.PartitionRepositories) > 0 {
+		if err := json.Unmarshal(info.PartitionRepositories, &backup.Controller.PartitionRepositories); err != nil {
+			return params.Backup{}, errors.Wrap(err, "decoding partition repositories")
+		}
+	}
+
+	var users []User
+	if err := s.conn.Find(&users).Error; err != nil {
+		return params.Backup{}, errors.Wrap(err, "fetching users")
+	}
+	for _, user := range users {
+		backup.Users = append(backup.Users, params.BackupUser{
+			ID:       user.ID.String(),
+			Username: user.Username,
+			FullName: user.FullName,
+			Email:    user.Email,
+			Password: user.Password,
+			IsAdmin:  user.IsAdmin,
+			Enabled:  user.Enabled,
+		})
+	}
+
+	var endpoints []GithubEndpoint
+	if err := s.conn.Find(&endpoints).Error; err != nil {
+		return params.Backup{}, errors.Wrap(err, "fetching github endpoints")
+	}
+	for _, ep := range endpoints {
+		backup.Endpoints = append(backup.Endpoints, params.BackupEndpoint{
+			Name:          ep.Name,
+			Description:   ep.Description,
+			APIBaseURL:    ep.APIBaseURL,
+			UploadBaseURL: ep.UploadBaseURL,
+			BaseURL:       ep.BaseURL,
+			CACertBundle:  ep.CACertBundle,
+		})
+	}
+
+	var creds []GithubCredentials
+	if err := s.conn.Find(&creds).Error; err != nil {
+		return params.Backup{}, errors.Wrap(err, "fetching github credentials")
+	}
+	for _, cred := range creds {
+		payload, err := reseal(cred.Payload, decryptionPassphrases(s.cfg), passphrase)
+		if err != nil {
+			return params.Backup{}, errors.Wrapf(err, "sealing credentials %s", cred.Name)
+		}
+		item := params.BackupCredentials{
+			ID:          cred.ID,
+			Name:        cred.Name,
+			Description: cred.Description,
+			AuthType:    cred.AuthType,
+			UserID:      uuidPtrToString(cred.UserID),
+			Payload:     payload,
+		}
+		if cred.EndpointName != nil {
+			item.Endpoint = *cred.EndpointName
+		}
+		backup.Credentials = append(backup.Credentials, item)
+	}
+
+	var repos []Repository
+	if err := s.conn.Find(&repos).Error; err != nil {
+		return params.Backup{}, errors.Wrap(err, "fetching repositories")
+	}
+	for _, repo := range repos {
+		item, err := s.backupEntity(repo.ID, repo.Owner, repo.Name, repo.CredentialsID, repo.EndpointName, repo.PoolBalancerType, repo.JobScheduling, repo.ObserveOnly, repo.WebhookSecret, repo.PreviousWebhookSecret, repo.PreviousWebhookSecretExpiresAt, passphrase)
+		if err != nil {
+			return params.Backup{}, errors.Wrapf(err, "backing up repository %s/%s", repo.Owner, repo.Name)
+		}
+		backup.Repositories = append(backup.Repositories, item)
+	}
+
+	var orgs []Organization
+	if err := s.conn.Find(&orgs).Error; err != nil {
+		return params.Backup{}, errors.Wrap(err, "fetching organizations")
+	}
+	for _, org := range orgs {
+		item, err := s.backupEntity(org.ID, "", org.Name, org.CredentialsID, org.EndpointName, org.PoolBalancerType, org.JobScheduling, org.ObserveOnly, org.WebhookSecret, org.PreviousWebhookSecret, org.PreviousWebhookSecretExpiresAt, passphrase)
+		if err != nil {
+			return params.Backup{}, errors.Wrapf(err, "backing up organization %s", org.Name)
+		}
+		backup.Organizations = append(backup.Organizations, item)
+	}
+
+	var enterprises []Enterprise
+	if err := s.conn.Find(&enterprises).Error; err != nil {
+		return params.Backup{}, errors.Wrap(err, "fetching enterprises")
+	}
+	for _, ent := range enterprises {
+		item, err := s.backupEntity(ent.ID, "", ent.Name, ent.CredentialsID, ent.EndpointName, ent.PoolBalancerType, ent.JobScheduling, ent.ObserveOnly, ent.WebhookSecret, ent.PreviousWebhookSecret, ent.PreviousWebhookSecretExpiresAt, passphrase)
+		if err != nil {
+			return params.Backup{}, errors.Wrapf(err, "backing up enterprise %s", ent.Name)
+		}
+		backup.Enterprises = append(backup.Enterprises, item)
+	}
+
+	var templates []PoolTemplate
+	if err := s.conn.Preload("Tags").Find(&templates).Error; err != nil {
+		return params.Backup{}, errors.Wrap(err, "fetching pool templates")
+	}
+	for _, tpl := range templates {
+		backup.PoolTemplates = append(backup.PoolTemplates, params.BackupPoolTemplate{
+			ID:                     tpl.ID.String(),
+			Name:                   tpl.Name,
+			Description:            tpl.Description,
+			ProviderName:           tpl.ProviderName,
+			RunnerPrefix:           tpl.RunnerPrefix,
+			MaxRunners:             tpl.MaxRunners,
+			MinIdleRunners:         tpl.MinIdleRunners,
+			RunnerBootstrapTimeout: tpl.RunnerBootstrapTimeout,
+			Image:                  tpl.Image,
+			Flavor:                 tpl.Flavor,
+			OSType:                 tpl.OSType,
+			OSArch:                 tpl.OSArch,
+			Tags:                   tagNames(tpl.Tags),
+			ExtraSpecs:             json.RawMessage(tpl.ExtraSpecs),
+			GitHubRunnerGroup:      tpl.GitHubRunnerGroup,
+			Priority:               tpl.Priority,
+		})
+	}
+
+	var pools []Pool
+	if err := s.conn.Preload("Tags").Find(&pools).Error; err != nil {
+		return params.Backup{}, errors.Wrap(err, "fetching pools")
+	}
+	for _, pool := range pools {
+		var overrides []string
+		if len(pool.TemplateOverrides) > 0 {
+			if err := json.Unmarshal(pool.TemplateOverrides, &overrides); err != nil {
+				return params.Backup{}, errors.Wrapf(err, "decoding template overrides of pool %s", pool.ID)
+			}
+		}
+		var included, excluded []string
+		var policy *params.PoolPolicy
+		if len(pool.Policy) > 0 {
+			if err := json.Unmarshal(pool.Policy, &policy); err != nil {
+				return params.Backup{}, errors.Wrapf(err, "decoding policy of pool %s", pool.ID)
+			}
+		}
+		if len(pool.IncludedRepositories) > 0 {
+			if err := json.Unmarshal(pool.IncludedRepositories, &included); err != nil {
+				return params.Backup{}, errors.Wrapf(err, "decoding included repositories of pool %s", pool.ID)
+			}
+		}
+		if len(pool.ExcludedRepositories) > 0 {
+			if err := json.Unmarshal(pool.ExcludedRepositories, &excluded); err != nil {
+				return params.Backup{}, errors.Wrapf(err, "decoding excluded repositories of pool %s", pool.ID)
+			}
+		}
+		backup.Pools = append(backup.Pools, params.BackupPool{
+			ID:                     pool.ID.String(),
+			RepoID:                 uuidPtrToString(pool.RepoID),
+			OrgID:                  uuidPtrToString(pool.OrgID),
+			EnterpriseID:           uuidPtrToString(pool.EnterpriseID),
+			TemplateID:             uuidPtrToString(pool.TemplateID),
+			TemplateOverrides:      overrides,
+			ProviderName:           pool.ProviderName,
+			RunnerPrefix:           pool.RunnerPrefix,
+			MaxRunners:             pool.MaxRunners,
+			MinIdleRunners:         pool.MinIdleRunners,
+			RunnerBootstrapTimeout: pool.RunnerBootstrapTimeout,
+			Image:                  pool.Image,
+			Flavor:                 pool.Flavor,
+			OSType:                 pool.OSType,
+			OSArch:                 pool.OSArch,
+			Tags:                   tagNames(pool.Tags),
+			Enabled:                pool.Enabled,
+			ExtraSpecs:             json.RawMessage(pool.ExtraSpecs),
+			GitHubRunnerGroup:      pool.GitHubRunnerGroup,
+			Priority:               pool.Priority,
+			IncludedRepositories:   included,
+			ExcludedRepositories:   excluded,
+			Policy:                 policy,
+			HourlyCost:             pool.HourlyCost,
+			Reusable:               pool.Reusable,
+			RunnerMaxJobs:          pool.RunnerMaxJobs,
+			RunnerMaxLifetime:      pool.RunnerMaxLifetime,
+			JobCompletedHook:       pool.JobCompletedHook,
+			Generation:             pool.Generation,
+			RolloutMaxSurge:        pool.RolloutMaxSurge,
+			RolloutMaxUnavailable:  pool.RolloutMaxUnavailable,
+
+			MaxRunnersPerRepository: pool.MaxRunnersPerRepository,
+		})
+	}
+
+	return backup, nil
+}
+
+// ensureEmptyForRestore makes sure there is nothing in the database that a
+// restore could collide with. Users and endpoints are merged instead.
+func ensureEmptyForRestore(tx *gorm.DB) error {
+	models := map[string]interface{}{
+		"credentials":    &GithubCredentials{},
+		"repositories":   &Repository{},
+		"organizations":  &Organization{},
+		"enterprises":    &Enterprise{},
+		"pool templates": &PoolTemplate{},
+		"pools":          &Pool{},
+	}
+	for name, model := range models {
+		var count int64
+		if err := tx.Model(model).Count(&count).Error; err != nil {
+			return errors.Wrapf(err, "counting %s", name)
+		}
+		if count > 0 {
+			return runnerErrors.NewConflictError("backups can only be restored into an empty controller; found existing %s", name)
+		}
+	}
+	return nil
+}
+
+func (s *sqlDatabase) restoreEntitySecrets(secret, previousSecret []byte, passphrase string) ([]byte, []byte, error) {
+	newSecret, err := reseal(secret, []string{passphrase}, s.cfg.Passphrase)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "sealing webhook secret")
+	}
+	newPrevious, err := reseal(previousSecret, []string{passphrase}, s.cfg.Passphrase)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "sealing previous webhook secret")
+	}
+	return newSecret, newPrevious, nil
+}
+
+func (s *sqlDatabase) restoreController(tx *gorm.DB, controller params.BackupController) error {
+	controllerID, err := uuid.Parse(controller.ControllerID)
+	if err != nil {
+		return errors.Wrap(runnerErrors.ErrBadRequest, "invalid controller ID")
+	}
+
+	var info ControllerInfo
+	if err := tx.First(&info).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.Wrap(err, "fetching controller info")
+	}
+	info.ControllerID = controllerID
+	info.CallbackURL = controller.CallbackURL
+	info.MetadataURL = controller.MetadataURL
+	info.WebhookBaseURL = controller.WebhookBaseURL
+	info.MinimumJobAgeBackoff = controller.MinimumJobAgeBackoff
+	info.ObserveOnly = controller.ObserveOnly
+	info.PartitionLabel = controller.PartitionLabel
+	if info.PartitionRepositories, err = repositoryPatternsToJSON(controller.PartitionRepositories); err != nil {
+		return errors.Wrap(err, "encoding partition repositories")
+	}
+	if err := tx.Save(&info).Error; err != nil {
+		return errors.Wrap(err, "saving controller info")
+	}
+	return nil
+}
+
+// restoreUsers creates the users in the backup that do not exist yet. It returns
+// a map of backed up user IDs to the IDs of the users in the database.
+func (s *sqlDatabase) restoreUsers(tx *gorm.DB, users []params.BackupUser) (map[string]uuid.UUID, error) {
+	userIDs := make(map[string]uuid.UUID, len(users))
+	for _, user := range users {
+		var existing User
+		q := tx.Where("username = ? or email = ?", user.Username, user.Email).First(&existing)
+		if q.Error == nil {
+			userIDs[user.ID] = existing.ID
+			continue
+		}
+		if !errors.Is(q.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.Wrap(q.Error, "fetching user")
+		}
+
+		userID, err := uuid.Parse(user.ID)
+		if err != nil {
+			return nil, errors.Wrapf(runnerErrors.ErrBadRequest, "invalid user ID %q", user.ID)
+		}
+		newUser := User{
+			Base:     Base{ID: userID},
+			Username: user.Username,
+			FullName: user.FullName,
+			Email:    user.Email,
+			Password: user.Password,
+			IsAdmin:  user.IsAdmin,
+			Enabled:  user.Enabled,
+		}
+		if err := tx.Create(&newUser).Error; err != nil {
+			return nil, errors.Wrapf(err, "creating user %s", user.Username)
+		}
+		userIDs[user.ID] = newUser.ID
+	}
+	return userIDs, nil
+}
+
+func (s *sqlDatabase) restoreEndpoints(tx *gorm.DB, endpoints []params.BackupEndpoint) error {
+	for _, ep := range endpoints {
+		var existing GithubEndpoint
+		q := tx.Unscoped().Where("name = ?", ep.Name).First(&existing)
+		if q.Error != nil && !errors.Is(q.Error, gorm.ErrRecordNotFound) {
+			return errors.Wrap(q.Error, "fetching github endpoint")
+		}
+		existing.Name = ep.Name
+		existing.Description = ep.Description
+		existing.APIBaseURL = ep.APIBaseURL
+		existing.UploadBaseURL = ep.UploadBaseURL
+		existing.BaseURL = ep.BaseURL
+		existing.CACertBundle = ep.CACertBundle
+		existing.DeletedAt = gorm.DeletedAt{}
+		if err := tx.Unscoped().Save(&existing).Error; err != nil {
+			return errors.Wrapf(err, "saving github endpoint %s", ep.Name)
+		}
+	}
+	return nil
+}
+
+func (s *sqlDatabase) restoreCredentials(tx *gorm.DB, creds []params.BackupCredentials, userIDs map[string]uuid.UUID, passphrase string) (map[uint]string, error) {
+	credNames := make(map[uint]string, len(creds))
+	for _, cred := range creds {
+		payload, err := reseal(cred.Payload, []string{passphrase}, s.cfg.Passphrase)
+		if err != nil {
+			return nil, errors.Wrapf(err, "sealing credentials %s", cred.Name)
+		}
+		newCreds := GithubCredentials{
+			Model:       gorm.Model{ID: cred.ID},
+			Name:        cred.Name,
+			Description: cred.Description,
+			AuthType:    cred.AuthType,
+			Payload:     payload,
+			KeyVersion:  s.currentKeyVersion(),
+		}
+		if cred.Endpoint != "" {
+			endpoint := cred.Endpoint
+			newCreds.EndpointName = &endpoint
+		}
+		if cred.UserID != "" {
+			userID, ok := userIDs[cred.UserID]
+			if !ok {
+				return nil, errors.Wrapf(runnerErrors.ErrBadRequest, "credentials %s reference unknown user %s", cred.Name, cred.UserID)
+			}
+			newCreds.UserID = &userID
+		}
+		if err := tx.Create(&newCreds).Error; err != nil {
+			return nil, errors.Wrapf(err, "creating credentials %s", cred.Name)
+		}
+		credNames[cred.ID] = cred.Name
+	}
+	return credNames, nil
+}
+
+func (s *sqlDatabase) restoreTemplates(tx *gorm.DB, templates []params.BackupPoolTemplate) error {
+	for _, tpl := range templates {
+		tplID, err := uuid.Parse(tpl.ID)
+		if err != nil {
+			return errors.Wrapf(runnerErrors.ErrBadRequest, "invalid pool template ID %q", tpl.ID)
+		}
+		newTemplate := PoolTemplate{
+			Base:                   Base{ID: tplID},
+			Name:                   tpl.Name,
+			Description:            tpl.Description,
+			ProviderName:           tpl.ProviderName,
+			RunnerPrefix:           tpl.RunnerPrefix,
+			MaxRunners:             tpl.MaxRunners,
+			MinIdleRunners:         tpl.MinIdleRunners,
+			RunnerBootstrapTimeout: tpl.RunnerBootstrapTimeout,
+			Image:                  tpl.Image,
+			Flavor:                 tpl.Flavor,
+			OSType:                 tpl.OSType,
+			OSArch:                 tpl.OSArch,
+			ExtraSpecs:             datatypes.JSON(tpl.ExtraSpecs),
+			GitHubRunnerGroup:      tpl.GitHubRunnerGroup,
+			Priority:               tpl.Priority,
+		}
+		if err := tx.Create(&newTemplate).Error; err != nil {
+			return errors.Wrapf(err, "creating pool template %s", tpl.Name)
+		}
+
+		tags, err := s.getTags(tx, tpl.Tags)
+		if err != nil {
+			return errors.Wrap(err, "fetching tags")
+		}
+		if err := tx.Model(&newTemplate).Association("Tags").Append(tags); err != nil {
+			return errors.Wrap(err, "associating tags")
+		}
+	}
+	return nil
+}
+
+func (s *sqlDatabase) restorePools(tx *gorm.DB, pools []params.BackupPool) error {
+	for _, pool := range pools {
+		poolID, err := uuid.Parse(pool.ID)
+		if err != nil {
+			return errors.Wrapf(runnerErrors.ErrBadRequest, "invalid pool ID %q", pool.ID)
+		}
+		newPool := Pool{
+			Base:                   Base{ID: poolID},
+			ProviderName:           pool.ProviderName,
+			RunnerPrefix:           pool.RunnerPrefix,
+			MaxRunners:             pool.MaxRunners,
+			MinIdleRunners:         pool.MinIdleRunners,
+			RunnerBootstrapTimeout: pool.RunnerBootstrapTimeout,
+			Image:                  pool.Image,
+			Flavor:                 pool.Flavor,
+			OSType:                 pool.OSType,
+			OSArch:                 pool.OSArch,
+			Enabled:                pool.Enabled,
+			ExtraSpecs:             datatypes.JSON(pool.ExtraSpecs),
+			GitHubRunnerGroup:      pool.GitHubRunnerGroup,
+			Priority:               pool.Priority,
+			HourlyCost:             pool.HourlyCost,
+			Reusable:               pool.Reusable,
+			RunnerMaxJobs:          pool.RunnerMaxJobs,
+			RunnerMaxLifetime:      pool.RunnerMaxLifetime,
+			JobCompletedHook:       pool.JobCompletedHook,
+			Generation:             pool.Generation,
+			RolloutMaxSurge:        pool.RolloutMaxSurge,
+			RolloutMaxUnavailable:  pool.RolloutMaxUnavailable,
+
+			MaxRunnersPerRepository: pool.MaxRunnersPerRepository,
+		}
+		if newPool.RepoID, err = parseOptionalUUID(pool.RepoID); err != nil {
+			return errors.Wrap(err, "parsing repository ID")
+		}
+		if newPool.OrgID, err = parseOptionalUUID(pool.OrgID); err != nil {
+			return errors.Wrap(err, "parsing organization ID")
+		}
+		if newPool.EnterpriseID, err = parseOptionalUUID(pool.EnterpriseID); err != nil {
+			return errors.Wrap(err, "parsing enterprise ID")
+		}
+		if newPool.TemplateID, err = parseOptionalUUID(pool.TemplateID); err != nil {
+			return errors.Wrap(err, "parsing pool template ID")
+		}
+		if len(pool.TemplateOverrides) > 0 {
+			overrides, err := json.Marshal(pool.TemplateOverrides)
+			if err != nil {
+				return errors.Wrap(err, "encoding template overrides")
+			}
+			newPool.TemplateOverrides = overrides
+		}
+		if newPool.IncludedRepositories, err = repositoryPatternsToJSON(pool.IncludedRepositories); err != nil {
+			return errors.Wrap(err, "encoding included repositories")
+		}
+		if newPool.ExcludedRepositories, err = repositoryPatternsToJSON(pool.ExcludedRepositories); err != nil {
+			return errors.Wrap(err, "encoding excluded repositories")
+		}
+		if newPool.Policy, err = poolPolicyToJSON(pool.Policy); err != nil {
+			return errors.Wrap(err, "encoding policy")
+		}
+		if err := tx.Create(&newPool).Error; err != nil {
+			return errors.Wrapf(err, "creating pool %s", pool.ID)
+		}
+
+		tags, err := s.getTags(tx, pool.Tags)
+		if err != nil {
+			return errors.Wrap(err, "fetching tags")
+		}
+		if err := tx.Model(&newPool).Association("Tags").Append(tags); err != nil {
+			return errors.Wrap(err, "associating tags")
+		}
+	}
+	return nil
+}
+
+func (s *sqlDatabase) RestoreBackup(_ context.Context, backup params.Backup, passphrase string) error {
+	err := s.conn.Transaction(func(tx *gorm.DB) error {
+		if err := ensureEmptyForRestore(tx); err != nil {
+			return err
+		}
+
+		if err := s.restoreController(tx, backup.Controller); err != nil {
+			return errors.Wrap(err, "restoring controller info")
+		}
+
+		userIDs, err := s.restoreUsers(tx, backup.Users)
+		if err != nil {
+			return errors.Wrap(err, "restoring users")
+		}
+
+		if err := s.restoreEndpoints(tx, backup.Endpoints); err != nil {
+			return errors.Wrap(err, "restoring github endpoints")
+		}
+
+		credNames, err := s.restoreCredentials(tx, backup.Credentials, userIDs, passphrase)
+		if err != nil {
+			return errors.Wrap(err, "restoring github credentials")
+		}
+
+		for _, repo := range backup.Repositories {
+			newRepo := Repository{
+				Owner:                          repo.Owner,
+				Name:                           repo.Name,
+				PoolBalancerType:               repo.PoolBalancerType,
+				ObserveOnly:                    repo.ObserveOnly,
+				PreviousWebhookSecretExpiresAt: repo.PreviousWebhookSecretExpiresAt,
+				KeyVersion:                     s.currentKeyVersion(),
+			}
+			if newRepo.JobScheduling, err = jobSchedulingToJSON(repo.JobScheduling); err != nil {
+				return errors.Wrap(err, "encoding job scheduling")
+			}
+			if err := s.fillRestoredEntity(&newRepo.Base, &newRepo.CredentialsID, &newRepo.CredentialsName, &newRepo.EndpointName, repo, credNames); err != nil {
+				return errors.Wrapf(err, "restoring repository %s/%s", repo.Owner, repo.Name)
+			}
+			if newRepo.WebhookSecret, newRepo.PreviousWebhookSecret, err = s.restoreEntitySecrets(repo.WebhookSecret, repo.PreviousWebhookSecret, passphrase); err != nil {
+				return errors.Wrapf(err, "restoring repository %s/%s", repo.Owner, repo.Name)
+			}
+			if err := tx.Create(&newRepo).Error; err != nil {
+				return errors.Wrapf(err, "creating repository %s/%s", repo.Owner, repo.Name)
+			}
+		}
+
+		for _, org := range backup.Organizations {
+			newOrg := Organization{
+				Name:                           org.Name,
+				PoolBalancerType:               org.PoolBalancerType,
+				ObserveOnly:                    org.ObserveOnly,
+				PreviousWebhookSecretExpiresAt: org.PreviousWebhookSecretExpiresAt,
+				KeyVersion:                     s.currentKeyVersion(),
+			}
+			if newOrg.JobScheduling, err = jobSchedulingToJSON(org.JobScheduling); err != nil {
+				return errors.Wrap(err, "encoding job scheduling")
+			}
+			if err := s.fillRestoredEntity(&newOrg.Base, &newOrg.CredentialsID, &newOrg.CredentialsName, &newOrg.EndpointName, org, credNames); err != nil {
+				return errors.Wrapf(err, "restoring organization %s", org.Name)
+			}
+			if newOrg.WebhookSecret, newOrg.PreviousWebhookSecret, err = s.restoreEntitySecrets(org.WebhookSecret, org.PreviousWebhookSecret, passphrase); err != nil {
+				return errors.Wrapf(err, "restoring organization %s", org.Name)
+			}
+			if err := tx.Create(&newOrg).Error; err != nil {
+				return errors.Wrapf(err, "creating organization %s", org.Name)
+			}
+		}
+
+		for _, ent := range backup.Enterprises {
+			newEnt := Enterprise{
+				Name:                           ent.Name,
+				PoolBalancerType:               ent.PoolBalancerType,
+				ObserveOnly:                    ent.ObserveOnly,
+				PreviousWebhookSecretExpiresAt: ent.PreviousWebhookSecretExpiresAt,
+				KeyVersion:                     s.currentKeyVersion(),
+			}
+			if newEnt.JobScheduling, err = jobSchedulingToJSON(ent.JobScheduling); err != nil {
+				return errors.Wrap(err, "encoding job scheduling")
+			}
+			if err := s.fillRestoredEntity(&newEnt.Base, &newEnt.CredentialsID, &newEnt.CredentialsName, &newEnt.EndpointName, ent, credNames); err != nil {
+				return errors.Wrapf(err, "restoring enterprise %s", ent.Name)
+			}
+			if newEnt.WebhookSecret, newEnt.PreviousWebhookSecret, err = s.restoreEntitySecrets(ent.WebhookSecret, ent.PreviousWebhookSecret, passphrase); err != nil {
+				return errors.Wrapf(err, "restoring enterprise %s", ent.Name)
+			}
+			if err := tx.Create(&newEnt).Error; err != nil {
+				return errors.Wrapf(err, "creating enterprise %s", ent.Name)
+			}
+		}
+
+		if err := s.restoreTemplates(tx, backup.PoolTemplates); err != nil {
+			return errors.Wrap(err, "restoring pool templates")
+		}
+
+		if err := s.restorePools(tx, backup.Pools); err != nil {
+			return errors.Wrap(err, "restoring pools")
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "restoring backup")
+	}
+	return nil
+}
+
+// fillRestoredEntity sets the fields shared by repositories, organizations and
+// enterprises.
+func (s *sqlDatabase) fillRestoredEntity(base *Base, credentialsID **uint, credentialsName *string, endpointName **string, entity params.BackupEntity, credNames map[uint]string) error {
+	entityID, err := uuid.Parse(entity.ID)
+	if err != nil {
+		return errors.Wrapf(runnerErrors.ErrBadRequest, "invalid ID %q", entity.ID)
+	}
+	base.ID = entityID
+
+	name, ok := credNames[entity.CredentialsID]
+	if !ok {
+		return errors.Wrapf(runnerErrors.ErrBadRequest, "unknown credentials ID %d", entity.CredentialsID)
+	}
+	credsID := entity.CredentialsID
+	*credentialsID = &credsID
+	*credentialsName = name
+
+	if entity.Endpoint != "" {
+		endpoint := entity.Endpoint
+		*endpointName = &endpoint
+	}
+	return nil
+}
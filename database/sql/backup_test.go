@@ -0,0 +1,258 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
+)
+
+const testBackupPassphrase = "backup-passphrase-0123456789abcd"
+
+type BackupTestSuite struct {
+	suite.Suite
+	Store    dbCommon.Store
+	adminCtx context.Context
+	org      params.Organization
+	pool     params.Pool
+	template params.PoolTemplate
+}
+
+func (s *BackupTestSuite) newStore(passphrase string) (dbCommon.Store, context.Context) {
+	cfg := garmTesting.GetTestSqliteDBConfig(s.T())
+	if passphrase != "" {
+		cfg.Passphrase = passphrase
+	}
+	db, err := NewSQLDatabase(context.Background(), cfg)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	if _, err := db.InitController(); err != nil {
+		s.FailNow(fmt.Sprintf("failed to init controller: %s", err))
+	}
+	return db, garmTesting.ImpersonateAdminContext(context.Background(), db, s.T())
+}
+
+func (s *BackupTestSuite) SetupTest() {
+	s.Store, s.adminCtx = s.newStore("")
+
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(s.adminCtx, s.Store, s.T())
+	creds := garmTesting.CreateTestGithubCredentials(s.adminCtx, "test-creds", s.Store, s.T(), endpoint)
+	org, err := s.Store.CreateOrganization(s.adminCtx, "test-org", creds.Name, "test-webhookSecret", params.PoolBalancerTypeRoundRobin)
+	s.Require().Nil(err)
+	s.org = org
+
+	s.template, err = s.Store.CreatePoolTemplate(s.adminCtx, params.CreatePoolTemplateParams{
+		Name:         "linux-small",
+		ProviderName: "test-provider",
+		MaxRunners:   4,
+		Image:        "ubuntu:22.04",
+		Flavor:       "small",
+		OSType:       "linux",
+		OSArch:       "amd64",
+		Tags:         []string{"self-hosted", "linux"},
+	})
+	s.Require().Nil(err)
+
+	entity, err := org.GetEntity()
+	s.Require().Nil(err)
+	poolParams := params.CreatePoolParams{
+		ProviderName: "test-provider",
+		MaxRunners:   4,
+		Image:        "ubuntu:22.04",
+		Flavor:       "medium",
+		OSType:       "linux",
+		OSArch:       "amd64",
+		Tags:         []string{"self-hosted", "linux"},
+	}
+	s.Require().Nil(poolParams.ApplyTemplate(s.template))
+	poolParams.Flavor = "medium"
+	s.pool, err = s.Store.CreateEntityPool(s.adminCtx, entity, poolParams)
+	s.Require().Nil(err)
+}
+
+func (s *BackupTestSuite) TestCreateBackup() {
+	backup, err := s.Store.CreateBackup(s.adminCtx, testBackupPassphrase)
+
+	s.Require().Nil(err)
+	s.Require().Equal(params.BackupFormatVersion, backup.Version)
+	s.Require().Len(backup.Users, 1)
+	s.Require().Len(backup.Endpoints, 1)
+	s.Require().Len(backup.Credentials, 1)
+	s.Require().Len(backup.Organizations, 1)
+	s.Require().Len(backup.PoolTemplates, 1)
+	s.Require().Len(backup.Pools, 1)
+	s.Require().Equal(s.template.ID, backup.Pools[0].TemplateID)
+	s.Require().NotEqual("test-webhookSecret", string(backup.Organizations[0].WebhookSecret))
+}
+
+func (s *BackupTestSuite) TestRestoreBackupIntoEmptyController() {
+	backup, err := s.Store.CreateBackup(s.adminCtx, testBackupPassphrase)
+	s.Require().Nil(err)
+
+	target, targetCtx := s.newStore("another-db-passphrase-0123456789")
+	err = target.RestoreBackup(targetCtx, backup, testBackupPassphrase)
+	s.Require().Nil(err)
+
+	org, err := target.GetOrganizationByID(targetCtx, s.org.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("test-webhookSecret", org.WebhookSecret)
+	s.Require().Equal(s.org.CredentialsName, org.CredentialsName)
+
+	_, err = target.GetGithubCredentialsByName(targetCtx, "test-creds", true)
+	s.Require().Nil(err)
+
+	pool, err := target.GetPoolByID(targetCtx, s.pool.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(s.template.ID, pool.TemplateID)
+	s.Require().Equal(s.pool.TemplateOverrides, pool.TemplateOverrides)
+	s.Require().Len(pool.Tags, 2)
+
+	info, err := target.ControllerInfo()
+	s.Require().Nil(err)
+	s.Require().Equal(backup.Controller.ControllerID, info.ControllerID.String())
+}
+
+func (s *BackupTestSuite) TestRestoreBackupWrongPassphrase() {
+	backup, err := s.Store.CreateBackup(s.adminCtx, testBackupPassphrase)
+	s.Require().Nil(err)
+
+	target, targetCtx := s.newStore("")
+	err = target.RestoreBackup(targetCtx, backup, "wrong-passphrase-0123456789abcde")
+
+	s.Require().NotNil(err)
+	orgs, err := target.ListOrganizations(targetCtx)
+	s.Require().Nil(err)
+	s.Require().Len(orgs, 0)
+}
+
+func (s *BackupTestSuite) TestRestoreBackupNotEmpty() {
+	backup, err := s.Store.CreateBackup(s.adminCtx, testBackupPassphrase)
+	s.Require().Nil(err)
+
+	err = s.Store.RestoreBackup(s.adminCtx, backup, testBackupPassphrase)
+
+	var conflict *runnerErrors.ConflictError
+	s.Require().ErrorAs(err, &conflict)
+}
+
+// restoreIntoNewStore backs up the test store and restores the backup into a new,
+// empty one.
+func (s *BackupTestSuite) restoreIntoNewStore() (dbCommon.Store, context.Context) {
+	backup, err := s.Store.CreateBackup(s.adminCtx, testBackupPassphrase)
+	s.Require().Nil(err)
+
+	target, targetCtx := s.newStore("another-db-passphrase-0123456789")
+	err = target.RestoreBackup(targetCtx, backup, testBackupPassphrase)
+	s.Require().Nil(err)
+	return target, targetCtx
+}
+
+func (s *BackupTestSuite) TestRestoreBackupObserveOnly() {
+	observeOnly := true
+	_, err := s.Store.UpdateController(params.UpdateControllerParams{ObserveOnly: &observeOnly})
+	s.Require().Nil(err)
+	_, err = s.Store.UpdateOrganization(s.adminCtx, s.org.ID, params.UpdateEntityParams{
+		PoolBalancerType: params.PoolBalancerTypeRoundRobin,
+		ObserveOnly:      &observeOnly,
+	})
+	s.Require().Nil(err)
+
+	target, targetCtx := s.restoreIntoNewStore()
+
+	info, err := target.ControllerInfo()
+	s.Require().Nil(err)
+	s.Require().True(info.ObserveOnly)
+	org, err := target.GetOrganizationByID(targetCtx, s.org.ID)
+	s.Require().Nil(err)
+	s.Require().True(org.ObserveOnly)
+}
+
+func (s *BackupTestSuite) TestRestoreBackupReusablePool() {
+	entity, err := s.org.GetEntity()
+	s.Require().Nil(err)
+	reusable := true
+	maxJobs := uint(10)
+	maxLifetime := uint(120)
+	hook := "#!/bin/bash\nrm -rf /tmp/work"
+	_, err = s.Store.UpdateEntityPool(s.adminCtx, entity, s.pool.ID, params.UpdatePoolParams{
+		Reusable:          &reusable,
+		RunnerMaxJobs:     &maxJobs,
+		RunnerMaxLifetime: &maxLifetime,
+		JobCompletedHook:  &hook,
+	})
+	s.Require().Nil(err)
+
+	target, targetCtx := s.restoreIntoNewStore()
+
+	pool, err := target.GetPoolByID(targetCtx, s.pool.ID)
+	s.Require().Nil(err)
+	s.Require().True(pool.Reusable)
+	s.Require().Equal(maxJobs, pool.RunnerMaxJobs)
+	s.Require().Equal(maxLifetime, pool.RunnerMaxLifetime)
+	s.Require().Equal(hook, pool.JobCompletedHook)
+}
+
+func (s *BackupTestSuite) TestRestoreBackupPoolRollout() {
+	entity, err := s.org.GetEntity()
+	s.Require().Nil(err)
+	maxSurge := uint(2)
+	maxUnavailable := uint(1)
+	image := "ubuntu:24.04"
+	updated, err := s.Store.UpdateEntityPool(s.adminCtx, entity, s.pool.ID, params.UpdatePoolParams{
+		Image:                 image,
+		RolloutMaxSurge:       &maxSurge,
+		RolloutMaxUnavailable: &maxUnavailable,
+	})
+	s.Require().Nil(err)
+	s.Require().Greater(updated.Generation, s.pool.Generation)
+
+	target, targetCtx := s.restoreIntoNewStore()
+
+	pool, err := target.GetPoolByID(targetCtx, s.pool.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(updated.Generation, pool.Generation)
+	s.Require().Equal(maxSurge, pool.RolloutMaxSurge)
+	s.Require().Equal(maxUnavailable, pool.RolloutMaxUnavailable)
+}
+
+func (s *BackupTestSuite) TestRestoreBackupPartition() {
+	label := "garm-east"
+	repos := []string{"example-org/app", "example-org/api"}
+	_, err := s.Store.UpdateController(params.UpdateControllerParams{
+		PartitionLabel:        &label,
+		PartitionRepositories: repos,
+	})
+	s.Require().Nil(err)
+
+	target, _ := s.restoreIntoNewStore()
+
+	info, err := target.ControllerInfo()
+	s.Require().Nil(err)
+	s.Require().Equal(label, info.PartitionLabel)
+	s.Require().Equal(repos, info.PartitionRepositories)
+}
+
+func TestBackupTestSuite(t *testing.T) {
+	suite.Run(t, new(BackupTestSuite))
+}
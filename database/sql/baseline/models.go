@@ -0,0 +1,295 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+// Package baseline holds a frozen copy of the models that make up version 1 of
+// the database schema. They are used by the baseline migration only, and must
+// never change. Later schema changes are made by the migrations that follow it.
+//
+// The models live in their own package so they keep the names of the models they
+// were copied from. GORM derives the names of join table columns and constraints
+// from the model names, and renamed copies would not match existing databases.
+package baseline
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type Base struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+type Tag struct {
+	Base
+
+	Name  string  `gorm:"type:varchar(64);uniqueIndex"`
+	Pools []*Pool `gorm:"many2many:pool_tags;constraint:OnDelete:CASCADE,OnUpdate:CASCADE;"`
+}
+
+type Pool struct {
+	Base
+
+	ProviderName           string `gorm:"index:idx_pool_type"`
+	RunnerPrefix           string
+	MaxRunners             uint
+	MinIdleRunners         uint
+	RunnerBootstrapTimeout uint
+	Image                  string `gorm:"index:idx_pool_type"`
+	Flavor                 string `gorm:"index:idx_pool_type"`
+	OSType                 string
+	OSArch                 string
+	Tags                   []*Tag `gorm:"many2many:pool_tags;constraint:OnDelete:CASCADE,OnUpdate:CASCADE;"`
+	Enabled                bool
+	ExtraSpecs             datatypes.JSON
+	GitHubRunnerGroup      string
+
+	RepoID     *uuid.UUID `gorm:"index"`
+	Repository Repository `gorm:"foreignKey:RepoID;"`
+
+	OrgID        *uuid.UUID   `gorm:"index"`
+	Organization Organization `gorm:"foreignKey:OrgID"`
+
+	EnterpriseID *uuid.UUID `gorm:"index"`
+	Enterprise   Enterprise `gorm:"foreignKey:EnterpriseID"`
+
+	Instances []Instance `gorm:"foreignKey:PoolID"`
+	Priority  uint       `gorm:"index:idx_pool_priority"`
+}
+
+type Repository struct {
+	Base
+
+	CredentialsName string
+
+	CredentialsID *uint             `gorm:"index"`
+	Credentials   GithubCredentials `gorm:"foreignKey:CredentialsID;constraint:OnDelete:SET NULL"`
+
+	Owner            string `gorm:"index:idx_owner_nocase,unique,collate:nocase"`
+	Name             string `gorm:"index:idx_owner_nocase,unique,collate:nocase"`
+	WebhookSecret    []byte
+	Pools            []Pool        `gorm:"foreignKey:RepoID"`
+	Jobs             []WorkflowJob `gorm:"foreignKey:RepoID;constraint:OnDelete:SET NULL"`
+	PoolBalancerType string        `gorm:"type:varchar(64)"`
+
+	EndpointName *string        `gorm:"index:idx_owner_nocase,unique,collate:nocase"`
+	Endpoint     GithubEndpoint `gorm:"foreignKey:EndpointName;constraint:OnDelete:SET NULL"`
+}
+
+type Organization struct {
+	Base
+
+	CredentialsName string
+
+	CredentialsID *uint             `gorm:"index"`
+	Credentials   GithubCredentials `gorm:"foreignKey:CredentialsID;constraint:OnDelete:SET NULL"`
+
+	Name             string `gorm:"index:idx_org_name_nocase,collate:nocase"`
+	WebhookSecret    []byte
+	Pools            []Pool        `gorm:"foreignKey:OrgID"`
+	Jobs             []WorkflowJob `gorm:"foreignKey:OrgID;constraint:OnDelete:SET NULL"`
+	PoolBalancerType string        `gorm:"type:varchar(64)"`
+
+	EndpointName *string        `gorm:"index:idx_org_name_nocase,collate:nocase"`
+	Endpoint     GithubEndpoint `gorm:"foreignKey:EndpointName;constraint:OnDelete:SET NULL"`
+}
+
+type Enterprise struct {
+	Base
+
+	CredentialsName string
+
+	CredentialsID *uint             `gorm:"index"`
+	Credentials   GithubCredentials `gorm:"foreignKey:CredentialsID;constraint:OnDelete:SET NULL"`
+
+	Name             string `gorm:"index:idx_ent_name_nocase,collate:nocase"`
+	WebhookSecret    []byte
+	Pools            []Pool        `gorm:"foreignKey:EnterpriseID"`
+	Jobs             []WorkflowJob `gorm:"foreignKey:EnterpriseID;constraint:OnDelete:SET NULL"`
+	PoolBalancerType string        `gorm:"type:varchar(64)"`
+
+	EndpointName *string        `gorm:"index:idx_ent_name_nocase,collate:nocase"`
+	Endpoint     GithubEndpoint `gorm:"foreignKey:EndpointName;constraint:OnDelete:SET NULL"`
+}
+
+type Address struct {
+	Base
+
+	Address string
+	Type    string
+
+	InstanceID uuid.UUID
+	Instance   Instance `gorm:"foreignKey:InstanceID"`
+}
+
+type InstanceStatusUpdate struct {
+	Base
+
+	EventType  string `gorm:"index:eventType"`
+	EventLevel string
+	Message    string `gorm:"type:text"`
+
+	InstanceID uuid.UUID `gorm:"index:idx_instance_status_updates_instance_id"`
+	Instance   Instance  `gorm:"foreignKey:InstanceID"`
+}
+
+type Instance struct {
+	Base
+
+	ProviderID        *string `gorm:"uniqueIndex"`
+	Name              string  `gorm:"uniqueIndex"`
+	AgentID           int64
+	OSType            string
+	OSArch            string
+	OSName            string
+	OSVersion         string
+	Addresses         []Address `gorm:"foreignKey:InstanceID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE;"`
+	Status            string
+	RunnerStatus      string
+	CallbackURL       string
+	MetadataURL       string
+	ProviderFault     []byte `gorm:"type:longblob"`
+	CreateAttempt     int
+	TokenFetched      bool
+	JitConfiguration  []byte `gorm:"type:longblob"`
+	GitHubRunnerGroup string
+	AditionalLabels   datatypes.JSON
+
+	PoolID uuid.UUID
+	Pool   Pool `gorm:"foreignKey:PoolID"`
+
+	StatusMessages []InstanceStatusUpdate `gorm:"foreignKey:InstanceID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE;"`
+
+	Job *WorkflowJob `gorm:"foreignKey:InstanceID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE;"`
+}
+
+type User struct {
+	Base
+
+	Username   string `gorm:"uniqueIndex;varchar(64)"`
+	FullName   string `gorm:"type:varchar(254)"`
+	Email      string `gorm:"type:varchar(254);unique;index:idx_email"`
+	Password   string `gorm:"type:varchar(60)"`
+	Generation uint
+	IsAdmin    bool
+	Enabled    bool
+}
+
+type ControllerInfo struct {
+	Base
+
+	ControllerID uuid.UUID
+
+	CallbackURL          string
+	MetadataURL          string
+	WebhookBaseURL       string
+	MinimumJobAgeBackoff uint
+}
+
+type WorkflowJob struct {
+	ID         int64 `gorm:"index"`
+	RunID      int64
+	Action     string `gorm:"type:varchar(254);index"`
+	Conclusion string
+	Status     string
+	Name       string
+
+	StartedAt   time.Time
+	CompletedAt time.Time
+
+	GithubRunnerID int64
+
+	InstanceID *uuid.UUID `gorm:"index:idx_instance_job"`
+	Instance   Instance   `gorm:"foreignKey:InstanceID"`
+
+	RunnerGroupID   int64
+	RunnerGroupName string
+
+	RepositoryName  string
+	RepositoryOwner string
+
+	Labels datatypes.JSON
+
+	RepoID     *uuid.UUID `gorm:"index"`
+	Repository Repository `gorm:"foreignKey:RepoID"`
+
+	OrgID        *uuid.UUID   `gorm:"index"`
+	Organization Organization `gorm:"foreignKey:OrgID"`
+
+	EnterpriseID *uuid.UUID `gorm:"index"`
+	Enterprise   Enterprise `gorm:"foreignKey:EnterpriseID"`
+
+	LockedBy uuid.UUID
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+type GithubEndpoint struct {
+	Name      string `gorm:"type:varchar(64) collate nocase;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	Description   string `gorm:"type:text"`
+	APIBaseURL    string `gorm:"type:text collate nocase"`
+	UploadBaseURL string `gorm:"type:text collate nocase"`
+	BaseURL       string `gorm:"type:text collate nocase"`
+	CACertBundle  []byte `gorm:"type:longblob"`
+}
+
+type GithubCredentials struct {
+	gorm.Model
+
+	Name   string     `gorm:"index:idx_github_credentials,unique;type:varchar(64) collate nocase"`
+	UserID *uuid.UUID `gorm:"index:idx_github_credentials,unique"`
+	User   User       `gorm:"foreignKey:UserID"`
+
+	Description string `gorm:"type:text"`
+	AuthType    string `gorm:"index"`
+	Payload     []byte `gorm:"type:longblob"`
+
+	Endpoint     GithubEndpoint `gorm:"foreignKey:EndpointName"`
+	EndpointName *string        `gorm:"index"`
+
+	Repositories  []Repository   `gorm:"foreignKey:CredentialsID"`
+	Organizations []Organization `gorm:"foreignKey:CredentialsID"`
+	Enterprises   []Enterprise   `gorm:"foreignKey:CredentialsID"`
+}
+
+// Models returns the models of the baseline schema, in the order in which they
+// are migrated.
+func Models() []interface{} {
+	return []interface{}{
+		&User{},
+		&GithubEndpoint{},
+		&GithubCredentials{},
+		&Tag{},
+		&Pool{},
+		&Repository{},
+		&Organization{},
+		&Enterprise{},
+		&Address{},
+		&InstanceStatusUpdate{},
+		&Instance{},
+		&ControllerInfo{},
+		&WorkflowJob{},
+	}
+}
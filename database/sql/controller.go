@@ -15,7 +15,9 @@
 package sql
 
 import (
+	"encoding/json"
 	"net/url"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -33,14 +35,24 @@ func dbControllerToCommonController(dbInfo ControllerInfo) (params.ControllerInf
 		return params.ControllerInfo{}, errors.Wrap(err, "joining webhook URL")
 	}
 
+	var partitionRepos []string
+	if len(dbInfo.PartitionRepositories) > 0 {
+		if err := json.Unmarshal(dbInfo.PartitionRepositories, &partitionRepos); err != nil {
+			return params.ControllerInfo{}, errors.Wrap(err, "unmarshaling partition repositories")
+		}
+	}
+
 	return params.ControllerInfo{
-		ControllerID:         dbInfo.ControllerID,
-		MetadataURL:          dbInfo.MetadataURL,
-		WebhookURL:           dbInfo.WebhookBaseURL,
-		ControllerWebhookURL: url,
-		CallbackURL:          dbInfo.CallbackURL,
-		MinimumJobAgeBackoff: dbInfo.MinimumJobAgeBackoff,
-		Version:              appdefaults.GetVersion(),
+		ControllerID:          dbInfo.ControllerID,
+		MetadataURL:           dbInfo.MetadataURL,
+		WebhookURL:            dbInfo.WebhookBaseURL,
+		ControllerWebhookURL:  url,
+		CallbackURL:           dbInfo.CallbackURL,
+		MinimumJobAgeBackoff:  dbInfo.MinimumJobAgeBackoff,
+		ObserveOnly:           dbInfo.ObserveOnly,
+		PartitionLabel:        dbInfo.PartitionLabel,
+		PartitionRepositories: partitionRepos,
+		Version:               appdefaults.GetVersion(),
 	}, nil
 }
 
@@ -72,9 +84,16 @@ func (s *sqlDatabase) InitController() (params.ControllerInfo, error) {
 		return params.ControllerInfo{}, errors.Wrap(err, "generating UUID")
 	}
 
+	keyVersion, keyCheck, err := nextKeyVersion(ControllerInfo{KeyVersion: s.keyVersion}, s.cfg.Passphrase)
+	if err != nil {
+		return params.ControllerInfo{}, errors.Wrap(err, "recording key version")
+	}
+
 	newInfo := ControllerInfo{
 		ControllerID:         newID,
 		MinimumJobAgeBackoff: 30,
+		KeyVersion:           keyVersion,
+		KeyCheck:             keyCheck,
 	}
 
 	q := s.conn.Save(&newInfo)
@@ -123,6 +142,25 @@ func (s *sqlDatabase) UpdateController(info params.UpdateControllerParams) (para
 			dbInfo.MinimumJobAgeBackoff = *info.MinimumJobAgeBackoff
 		}
 
+		if info.ObserveOnly != nil {
+			dbInfo.ObserveOnly = *info.ObserveOnly
+		}
+
+		if info.PartitionLabel != nil {
+			dbInfo.PartitionLabel = *info.PartitionLabel
+		}
+
+		if info.PartitionRepositories != nil {
+			dbInfo.PartitionRepositories = nil
+			if len(info.PartitionRepositories) > 0 {
+				asJSON, err := json.Marshal(info.PartitionRepositories)
+				if err != nil {
+					return errors.Wrap(err, "marshaling partition repositories")
+				}
+				dbInfo.PartitionRepositories = asJSON
+			}
+		}
+
 		q = tx.Save(&dbInfo)
 		if q.Error != nil {
 			return errors.Wrap(q.Error, "saving controller info")
@@ -139,3 +177,20 @@ func (s *sqlDatabase) UpdateController(info params.UpdateControllerParams) (para
 	}
 	return paramInfo, nil
 }
+
+func (s *sqlDatabase) setControllerHeartbeat(heartbeat *time.Time) error {
+	q := s.conn.Model(&ControllerInfo{}).Where("1 = 1").Update("heartbeat_at", heartbeat)
+	if q.Error != nil {
+		return errors.Wrap(q.Error, "updating controller heartbeat")
+	}
+	return nil
+}
+
+func (s *sqlDatabase) UpdateControllerHeartbeat() error {
+	now := time.Now().UTC()
+	return s.setControllerHeartbeat(&now)
+}
+
+func (s *sqlDatabase) ClearControllerHeartbeat() error {
+	return s.setControllerHeartbeat(nil)
+}
@@ -24,6 +24,7 @@ import (
 	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
 	dbCommon "github.com/cloudbase/garm/database/common"
 	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
 )
 
 type CtrlTestSuite struct {
@@ -68,6 +69,32 @@ func (s *CtrlTestSuite) TestInitControllerAlreadyInitialized() {
 	s.Require().Regexp(runnerErrors.NewConflictError("controller already initialized"), err)
 }
 
+func (s *CtrlTestSuite) TestUpdateControllerPartition() {
+	_, err := s.Store.InitController()
+	if err != nil {
+		s.FailNow(fmt.Sprintf("cannot init controller: %v", err))
+	}
+
+	label := "garm-east"
+	info, err := s.Store.UpdateController(params.UpdateControllerParams{
+		PartitionLabel:        &label,
+		PartitionRepositories: []string{"org/repo"},
+	})
+	s.Require().Nil(err)
+	s.Require().Equal(label, info.PartitionLabel)
+	s.Require().Equal([]string{"org/repo"}, info.PartitionRepositories)
+
+	// A nil list leaves the repositories unchanged, an empty one clears them.
+	info, err = s.Store.UpdateController(params.UpdateControllerParams{})
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"org/repo"}, info.PartitionRepositories)
+
+	info, err = s.Store.UpdateController(params.UpdateControllerParams{PartitionRepositories: []string{}})
+	s.Require().Nil(err)
+	s.Require().Empty(info.PartitionRepositories)
+	s.Require().Equal(label, info.PartitionLabel)
+}
+
 func TestCtrlTestSuite(t *testing.T) {
 	t.Parallel()
 	suite.Run(t, new(CtrlTestSuite))
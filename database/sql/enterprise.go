@@ -23,7 +23,6 @@ import (
 	"gorm.io/gorm"
 
 	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
-	"github.com/cloudbase/garm-provider-common/util"
 	"github.com/cloudbase/garm/database/common"
 	"github.com/cloudbase/garm/params"
 )
@@ -32,7 +31,7 @@ func (s *sqlDatabase) CreateEnterprise(ctx context.Context, name, credentialsNam
 	if webhookSecret == "" {
 		return params.Enterprise{}, errors.New("creating enterprise: missing secret")
 	}
-	secret, err := util.Seal([]byte(webhookSecret), []byte(s.cfg.Passphrase))
+	secret, err := s.seal([]byte(webhookSecret))
 	if err != nil {
 		return params.Enterprise{}, errors.Wrap(err, "encoding secret")
 	}
@@ -45,6 +44,7 @@ func (s *sqlDatabase) CreateEnterprise(ctx context.Context, name, credentialsNam
 	newEnterprise := Enterprise{
 		Name:             name,
 		WebhookSecret:    secret,
+		KeyVersion:       s.currentKeyVersion(),
 		CredentialsName:  credentialsName,
 		PoolBalancerType: poolBalancerType,
 	}
@@ -190,17 +190,43 @@ func (s *sqlDatabase) UpdateEnterprise(ctx context.Context, enterpriseID string,
 			enterprise.CredentialsID = &creds.ID
 		}
 		if param.WebhookSecret != "" {
-			secret, err := util.Seal([]byte(param.WebhookSecret), []byte(s.cfg.Passphrase))
+			secret, err := s.seal([]byte(param.WebhookSecret))
 			if err != nil {
 				return errors.Wrap(err, "encoding secret")
 			}
+			if param.PreviousWebhookSecretExpiresAt != nil {
+				// Keep the old secret around so in-flight deliveries signed with it
+				// are still accepted until it expires.
+				previous, err := s.resealWithCurrent(enterprise.WebhookSecret)
+				if err != nil {
+					return errors.Wrap(err, "re-encrypting previous webhook secret")
+				}
+				enterprise.PreviousWebhookSecret = previous
+				enterprise.PreviousWebhookSecretExpiresAt = param.PreviousWebhookSecretExpiresAt
+			} else {
+				enterprise.PreviousWebhookSecret = nil
+				enterprise.PreviousWebhookSecretExpiresAt = nil
+			}
 			enterprise.WebhookSecret = secret
+			enterprise.KeyVersion = s.currentKeyVersion()
 		}
 
 		if param.PoolBalancerType != "" {
 			enterprise.PoolBalancerType = param.PoolBalancerType
 		}
 
+		if param.ObserveOnly != nil {
+			enterprise.ObserveOnly = *param.ObserveOnly
+		}
+
+		if param.JobScheduling != nil {
+			scheduling, err := jobSchedulingToJSON(param.JobScheduling)
+			if err != nil {
+				return errors.Wrap(err, "encoding job scheduling")
+			}
+			enterprise.JobScheduling = scheduling
+		}
+
 		q := tx.Save(&enterprise)
 		if q.Error != nil {
 			return errors.Wrap(q.Error, "saving enterprise")
@@ -22,7 +22,6 @@ import (
 	"gorm.io/gorm"
 
 	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
-	"github.com/cloudbase/garm-provider-common/util"
 	"github.com/cloudbase/garm/auth"
 	"github.com/cloudbase/garm/database/common"
 	"github.com/cloudbase/garm/params"
@@ -36,7 +35,7 @@ func (s *sqlDatabase) sqlToCommonGithubCredentials(creds GithubCredentials) (par
 	if len(creds.Payload) == 0 {
 		return params.GithubCredentials{}, errors.New("empty credentials payload")
 	}
-	data, err := util.Unseal(creds.Payload, []byte(s.cfg.Passphrase))
+	data, err := s.unseal(creds.Payload)
 	if err != nil {
 		return params.GithubCredentials{}, errors.Wrap(err, "unsealing credentials")
 	}
@@ -341,6 +340,7 @@ func (s *sqlDatabase) CreateGithubCredentials(ctx context.Context, param params.
 			EndpointName: &endpoint.Name,
 			AuthType:     param.AuthType,
 			Payload:      data,
+			KeyVersion:   s.currentKeyVersion(),
 			UserID:       &userID,
 		}
 
@@ -517,6 +517,7 @@ func (s *sqlDatabase) UpdateGithubCredentials(ctx context.Context, id uint, para
 		}
 		if len(data) > 0 {
 			creds.Payload = data
+			creds.KeyVersion = s.currentKeyVersion()
 		}
 
 		if err := tx.Save(&creds).Error; err != nil {
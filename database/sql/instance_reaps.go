@@ -0,0 +1,104 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+)
+
+var _ common.InstanceReapStore = &sqlDatabase{}
+
+func sqlToParamsInstanceReap(reap InstanceReap) (params.InstanceReap, error) {
+	ret := params.InstanceReap{
+		ID:            reap.ID,
+		InstanceName:  reap.InstanceName,
+		PoolID:        reap.PoolID,
+		Reason:        reap.Reason,
+		ConsoleOutput: reap.ConsoleOutput,
+		CreatedAt:     reap.CreatedAt,
+	}
+	if len(reap.Timeline) > 0 {
+		if err := json.Unmarshal(reap.Timeline, &ret.Timeline); err != nil {
+			return params.InstanceReap{}, errors.Wrap(err, "decoding timeline")
+		}
+	}
+	return ret, nil
+}
+
+// RecordInstanceReap records why garm removes an instance, along with the timeline
+// and console output the instance had at that time. The reap is kept after the
+// instance is deleted.
+func (s *sqlDatabase) RecordInstanceReap(_ context.Context, param params.RecordInstanceReapParams) (params.InstanceReap, error) {
+	if param.InstanceName == "" {
+		return params.InstanceReap{}, runnerErrors.NewBadRequestError("missing instance name")
+	}
+
+	timeline, err := json.Marshal(param.Timeline)
+	if err != nil {
+		return params.InstanceReap{}, errors.Wrap(err, "encoding timeline")
+	}
+	reap := InstanceReap{
+		InstanceName:  param.InstanceName,
+		PoolID:        param.PoolID,
+		Reason:        param.Reason,
+		Timeline:      timeline,
+		ConsoleOutput: param.ConsoleOutput,
+	}
+	if err := s.conn.Create(&reap).Error; err != nil {
+		return params.InstanceReap{}, errors.Wrap(err, "creating instance reap")
+	}
+	return sqlToParamsInstanceReap(reap)
+}
+
+// ListInstanceReaps returns the reaps recorded for an instance, oldest first.
+func (s *sqlDatabase) ListInstanceReaps(_ context.Context, instanceName string) ([]params.InstanceReap, error) {
+	var reaps []InstanceReap
+	q := s.conn.
+		Where("instance_name = ?", instanceName).
+		Order("created_at asc, id asc").
+		Find(&reaps)
+	if q.Error != nil {
+		return nil, errors.Wrap(q.Error, "fetching instance reaps")
+	}
+
+	ret := make([]params.InstanceReap, len(reaps))
+	for idx, reap := range reaps {
+		asParams, err := sqlToParamsInstanceReap(reap)
+		if err != nil {
+			return nil, errors.Wrap(err, "converting instance reap")
+		}
+		ret[idx] = asParams
+	}
+	return ret, nil
+}
+
+// DeleteInstanceReapsOlderThan removes the reaps recorded before the given time.
+func (s *sqlDatabase) DeleteInstanceReapsOlderThan(_ context.Context, olderThan time.Time) error {
+	q := s.conn.Unscoped().
+		Where("created_at < ?", olderThan).
+		Delete(&InstanceReap{})
+	if q.Error != nil {
+		return errors.Wrap(q.Error, "deleting instance reaps")
+	}
+	return nil
+}
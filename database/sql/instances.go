@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -69,8 +70,11 @@ func (s *sqlDatabase) CreateInstance(_ context.Context, poolID string, param par
 		MetadataURL:       param.MetadataURL,
 		GitHubRunnerGroup: param.GitHubRunnerGroup,
 		JitConfiguration:  secret,
+		KeyVersion:        s.currentKeyVersion(),
 		AditionalLabels:   labels,
 		AgentID:           param.AgentID,
+		PoolGeneration:    pool.Generation,
+		Adopted:           param.Adopted,
 	}
 	q := s.conn.Create(&newInstance)
 	if q.Error != nil {
@@ -230,12 +234,34 @@ func (s *sqlDatabase) UpdateInstance(ctx context.Context, instanceName string, p
 		instance.TokenFetched = *param.TokenFetched
 	}
 
+	if param.Draining != nil {
+		instance.Draining = *param.Draining
+	}
+
+	if param.JobsCompleted != nil {
+		instance.JobsCompleted = *param.JobsCompleted
+	}
+
 	if param.JitConfiguration != nil {
 		secret, err := s.marshalAndSeal(param.JitConfiguration)
 		if err != nil {
 			return params.Instance{}, errors.Wrap(err, "marshalling jit config")
 		}
 		instance.JitConfiguration = secret
+		instance.KeyVersion = s.currentKeyVersion()
+	}
+
+	if param.Retry != nil {
+		instance.ErrorClass = param.Retry.ErrorClass
+		instance.NextRetryAt = param.Retry.NextRetryAt
+	}
+
+	recordInstanceStages(&instance, param.Stages, time.Now().UTC())
+
+	if len(param.ConsoleOutput) > 0 {
+		now := time.Now().UTC()
+		instance.ConsoleOutput = param.ConsoleOutput
+		instance.ConsoleOutputCapturedAt = &now
 	}
 
 	instance.ProviderFault = param.ProviderFault
@@ -319,3 +345,31 @@ func (s *sqlDatabase) PoolInstanceCount(_ context.Context, poolID string) (int64
 	}
 	return cnt, nil
 }
+
+// recordInstanceStages sets the time of the stages the instance reached for
+// the first time.
+func recordInstanceStages(instance *Instance, stages []params.InstanceStage, now time.Time) {
+	for _, stage := range stages {
+		var field **time.Time
+		switch stage {
+		case params.InstanceStageProviderCreated:
+			field = &instance.ProviderCreatedAt
+		case params.InstanceStageFirstCallback:
+			field = &instance.FirstCallbackAt
+		case params.InstanceStageJITFetched:
+			field = &instance.JitFetchedAt
+		case params.InstanceStageRegistered:
+			field = &instance.RegisteredAt
+		case params.InstanceStageJobAssigned:
+			field = &instance.JobAssignedAt
+		case params.InstanceStageTerminated:
+			field = &instance.TerminatedAt
+		default:
+			continue
+		}
+		if *field == nil {
+			at := now
+			*field = &at
+		}
+	}
+}
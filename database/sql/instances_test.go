@@ -21,6 +21,7 @@ import (
 	"regexp"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
@@ -425,6 +426,78 @@ func (s *InstancesTestSuite) TestUpdateInstance() {
 	s.Require().Equal(s.Fixtures.UpdateInstanceParams.CreateAttempt, instance.CreateAttempt)
 }
 
+func (s *InstancesTestSuite) TestUpdateInstanceConsoleOutput() {
+	name := s.Fixtures.Instances[0].Name
+	_, err := s.Store.UpdateInstance(s.adminCtx, name, params.UpdateInstanceParams{ConsoleOutput: []byte("cloud-init failed")})
+	s.Require().Nil(err)
+
+	// Updates without console output keep the captured output.
+	_, err = s.Store.UpdateInstance(s.adminCtx, name, params.UpdateInstanceParams{Status: commonParams.InstanceError})
+	s.Require().Nil(err)
+
+	instance, err := s.Store.GetInstanceByName(s.adminCtx, name)
+	s.Require().Nil(err)
+	s.Require().Equal([]byte("cloud-init failed"), instance.ConsoleOutput)
+	s.Require().NotNil(instance.ConsoleOutputCapturedAt)
+}
+
+func (s *InstancesTestSuite) TestUpdateInstanceStages() {
+	name := s.Fixtures.Instances[0].Name
+	instance, err := s.Store.UpdateInstance(s.adminCtx, name, params.UpdateInstanceParams{
+		Stages: []params.InstanceStage{params.InstanceStageProviderCreated},
+	})
+	s.Require().Nil(err)
+	s.Require().NotNil(instance.Lifecycle.ProviderCreatedAt)
+	s.Require().Equal(params.InstanceStageProviderCreated, instance.LastStage())
+	createdAt := *instance.Lifecycle.ProviderCreatedAt
+
+	// Stages are only recorded the first time they are reached.
+	instance, err = s.Store.UpdateInstance(s.adminCtx, name, params.UpdateInstanceParams{
+		Stages: []params.InstanceStage{params.InstanceStageProviderCreated, params.InstanceStageRegistered},
+	})
+	s.Require().Nil(err)
+	s.Require().True(createdAt.Equal(*instance.Lifecycle.ProviderCreatedAt))
+	s.Require().NotNil(instance.Lifecycle.RegisteredAt)
+	s.Require().Equal(params.InstanceStageRegistered, instance.LastStage())
+
+	err = s.Store.AddInstanceEvent(s.adminCtx, name, params.ReapEvent, params.EventWarning, "reaped")
+	s.Require().Nil(err)
+	instance, err = s.Store.GetInstanceByName(s.adminCtx, name)
+	s.Require().Nil(err)
+	timeline := instance.Timeline()
+	s.Require().Len(timeline.Entries, 4)
+	s.Require().Equal(params.InstanceStageCreated, timeline.Entries[0].Stage)
+	s.Require().Equal(params.ReapEvent, timeline.Entries[3].EventType)
+}
+
+func (s *InstancesTestSuite) TestInstanceReapOutlivesInstance() {
+	instance := s.Fixtures.Instances[0]
+	_, err := s.Store.RecordInstanceReap(s.adminCtx, params.RecordInstanceReapParams{
+		InstanceName: instance.Name,
+		PoolID:       instance.PoolID,
+		Reason:       "reaped",
+		Timeline:     instance.Timeline(),
+	})
+	s.Require().Nil(err)
+
+	err = s.Store.DeleteInstance(s.adminCtx, instance.PoolID, instance.Name)
+	s.Require().Nil(err)
+
+	reaps, err := s.Store.ListInstanceReaps(s.adminCtx, instance.Name)
+	s.Require().Nil(err)
+	s.Require().Len(reaps, 1)
+	s.Require().Equal("reaped", reaps[0].Reason)
+	s.Require().Equal(instance.PoolID, reaps[0].PoolID)
+	s.Require().Equal(instance.Name, reaps[0].Timeline.InstanceName)
+	s.Require().Equal(params.InstanceStageCreated, reaps[0].Timeline.Entries[0].Stage)
+
+	err = s.Store.DeleteInstanceReapsOlderThan(s.adminCtx, time.Now().UTC().Add(time.Minute))
+	s.Require().Nil(err)
+	reaps, err = s.Store.ListInstanceReaps(s.adminCtx, instance.Name)
+	s.Require().Nil(err)
+	s.Require().Empty(reaps)
+}
+
 func (s *InstancesTestSuite) TestUpdateInstanceDBUpdateInstanceErr() {
 	instance := s.Fixtures.Instances[0]
 
@@ -43,6 +43,13 @@ func sqlWorkflowJobToParamsJob(job WorkflowJob) (params.Job, error) {
 		OrgID:           job.OrgID,
 		EnterpriseID:    job.EnterpriseID,
 		Labels:          labels,
+		HeadBranch:      job.HeadBranch,
+		WorkflowName:    job.WorkflowName,
+		RunAttempt:      job.RunAttempt,
+		Actor:           job.Actor,
+		Event:           job.Event,
+		WorkflowPath:    job.WorkflowPath,
+		PolicyDenial:    job.PolicyDenial,
 		CreatedAt:       job.CreatedAt,
 		UpdatedAt:       job.UpdatedAt,
 		LockedBy:        job.LockedBy,
@@ -78,6 +85,12 @@ func (s *sqlDatabase) paramsJobToWorkflowJob(ctx context.Context, job params.Job
 		OrgID:           job.OrgID,
 		EnterpriseID:    job.EnterpriseID,
 		Labels:          asJSON,
+		HeadBranch:      job.HeadBranch,
+		WorkflowName:    job.WorkflowName,
+		RunAttempt:      job.RunAttempt,
+		Actor:           job.Actor,
+		Event:           job.Event,
+		WorkflowPath:    job.WorkflowPath,
 		LockedBy:        job.LockedBy,
 	}
 
@@ -212,6 +225,47 @@ func (s *sqlDatabase) UnlockJob(_ context.Context, jobID int64, entityID string)
 	return nil
 }
 
+// SetJobWorkflowRun records the event and the workflow path of the workflow run
+// the job belongs to.
+func (s *sqlDatabase) SetJobWorkflowRun(_ context.Context, jobID int64, event, workflowPath string) error {
+	return s.updateJob(jobID, func(job *WorkflowJob) {
+		job.Event = event
+		job.WorkflowPath = workflowPath
+	})
+}
+
+// SetJobPolicyDenial records why the pool policies did not allow a runner to be
+// created for the job. An empty denial clears it.
+func (s *sqlDatabase) SetJobPolicyDenial(_ context.Context, jobID int64, denial string) error {
+	return s.updateJob(jobID, func(job *WorkflowJob) {
+		job.PolicyDenial = denial
+	})
+}
+
+func (s *sqlDatabase) updateJob(jobID int64, update func(job *WorkflowJob)) error {
+	var workflowJob WorkflowJob
+	q := s.conn.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Instance").Where("id = ?", jobID).First(&workflowJob)
+
+	if q.Error != nil {
+		if errors.Is(q.Error, gorm.ErrRecordNotFound) {
+			return runnerErrors.ErrNotFound
+		}
+		return errors.Wrap(q.Error, "fetching job")
+	}
+
+	update(&workflowJob)
+	if err := s.conn.Save(&workflowJob).Error; err != nil {
+		return errors.Wrap(err, "saving job")
+	}
+
+	asParams, err := sqlWorkflowJobToParamsJob(workflowJob)
+	if err != nil {
+		return errors.Wrap(err, "converting job")
+	}
+	s.sendNotify(common.JobEntityType, common.UpdateOperation, asParams)
+	return nil
+}
+
 func (s *sqlDatabase) CreateOrUpdateJob(ctx context.Context, job params.Job) (params.Job, error) {
 	var workflowJob WorkflowJob
 	var err error
@@ -227,27 +281,50 @@ func (s *sqlDatabase) CreateOrUpdateJob(ctx context.Context, job params.Job) (pa
 		// Update workflowJob with values from job.
 		operation = common.UpdateOperation
 
-		workflowJob.Status = job.Status
-		workflowJob.Action = job.Action
-		workflowJob.Conclusion = job.Conclusion
-		workflowJob.StartedAt = job.StartedAt
-		workflowJob.CompletedAt = job.CompletedAt
-		workflowJob.GithubRunnerID = job.GithubRunnerID
-		workflowJob.RunnerGroupID = job.RunnerGroupID
-		workflowJob.RunnerGroupName = job.RunnerGroupName
+		// Deliveries may arrive out of order. Never move a job back to a previous
+		// status, but still record the entity that received the webhook.
+		if params.JobStatus(workflowJob.Status).CanTransitionTo(params.JobStatus(job.Status)) {
+			workflowJob.Status = job.Status
+			workflowJob.Action = job.Action
+			workflowJob.Conclusion = job.Conclusion
+			workflowJob.StartedAt = job.StartedAt
+			workflowJob.CompletedAt = job.CompletedAt
+			workflowJob.GithubRunnerID = job.GithubRunnerID
+			workflowJob.RunnerGroupID = job.RunnerGroupID
+			workflowJob.RunnerGroupName = job.RunnerGroupName
+
+			if job.RunnerName != "" {
+				instance, err := s.getInstanceByName(ctx, job.RunnerName)
+				if err == nil {
+					workflowJob.InstanceID = &instance.ID
+				} else {
+					// This usually is very normal as not all jobs run on our runners.
+					slog.DebugContext(ctx, "failed to get instance by name", "instance_name", job.RunnerName)
+				}
+			}
+		} else {
+			slog.DebugContext(
+				ctx, "ignoring stale job status",
+				"job_id", job.ID, "current_status", workflowJob.Status, "status", job.Status)
+		}
 
 		if job.LockedBy != uuid.Nil {
 			workflowJob.LockedBy = job.LockedBy
 		}
 
-		if job.RunnerName != "" {
-			instance, err := s.getInstanceByName(ctx, job.RunnerName)
-			if err == nil {
-				workflowJob.InstanceID = &instance.ID
-			} else {
-				// This usually is very normal as not all jobs run on our runners.
-				slog.DebugContext(ctx, "failed to get instance by name", "instance_name", job.RunnerName)
-			}
+		// Jobs recorded before the workflow run details were stored get them
+		// with the next webhook.
+		if job.HeadBranch != "" {
+			workflowJob.HeadBranch = job.HeadBranch
+		}
+		if job.WorkflowName != "" {
+			workflowJob.WorkflowName = job.WorkflowName
+		}
+		if job.RunAttempt != 0 {
+			workflowJob.RunAttempt = job.RunAttempt
+		}
+		if job.Actor != "" {
+			workflowJob.Actor = job.Actor
 		}
 
 		if job.RepoID != nil {
@@ -0,0 +1,165 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
+)
+
+type JobsTestSuite struct {
+	suite.Suite
+	Store dbCommon.Store
+	ctx   context.Context
+}
+
+func (s *JobsTestSuite) SetupTest() {
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+	s.ctx = context.Background()
+}
+
+func (s *JobsTestSuite) jobWithStatus(jobID int64, status params.JobStatus) params.Job {
+	job := params.Job{
+		ID:     jobID,
+		RunID:  1,
+		Name:   "test-job",
+		Action: string(status),
+		Status: string(status),
+		Labels: []string{"self-hosted"},
+	}
+	if status == params.JobStatusCompleted {
+		job.Conclusion = "success"
+	}
+	return job
+}
+
+func (s *JobsTestSuite) TestCreateOrUpdateJobReorderedDeliveries() {
+	orderings := [][]params.JobStatus{
+		{params.JobStatusQueued, params.JobStatusInProgress, params.JobStatusCompleted},
+		{params.JobStatusQueued, params.JobStatusCompleted, params.JobStatusInProgress},
+		{params.JobStatusInProgress, params.JobStatusQueued, params.JobStatusCompleted},
+		{params.JobStatusInProgress, params.JobStatusCompleted, params.JobStatusQueued},
+		{params.JobStatusCompleted, params.JobStatusQueued, params.JobStatusInProgress},
+		{params.JobStatusCompleted, params.JobStatusInProgress, params.JobStatusQueued},
+	}
+
+	for idx, ordering := range orderings {
+		jobID := int64(idx + 1)
+		names := make([]string, len(ordering))
+		for i, status := range ordering {
+			names[i] = string(status)
+		}
+		s.Run(strings.Join(names, "_"), func() {
+			for _, status := range ordering {
+				_, err := s.Store.CreateOrUpdateJob(s.ctx, s.jobWithStatus(jobID, status))
+				s.Require().Nil(err)
+			}
+
+			job, err := s.Store.GetJobByID(s.ctx, jobID)
+			s.Require().Nil(err)
+			s.Require().Equal(string(params.JobStatusCompleted), job.Status)
+			s.Require().Equal(string(params.JobStatusCompleted), job.Action)
+			s.Require().Equal("success", job.Conclusion)
+		})
+	}
+}
+
+func (s *JobsTestSuite) TestCreateOrUpdateJobIgnoresQueuedAfterInProgress() {
+	_, err := s.Store.CreateOrUpdateJob(s.ctx, s.jobWithStatus(1, params.JobStatusInProgress))
+	s.Require().Nil(err)
+
+	_, err = s.Store.CreateOrUpdateJob(s.ctx, s.jobWithStatus(1, params.JobStatusQueued))
+	s.Require().Nil(err)
+
+	job, err := s.Store.GetJobByID(s.ctx, 1)
+	s.Require().Nil(err)
+	s.Require().Equal(string(params.JobStatusInProgress), job.Status)
+	s.Require().Equal(string(params.JobStatusInProgress), job.Action)
+}
+
+func (s *JobsTestSuite) TestCreateOrUpdateJobStaleStatusRecordsEntity() {
+	adminCtx := garmTesting.ImpersonateAdminContext(s.ctx, s.Store, s.T())
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(adminCtx, s.Store, s.T())
+	creds := garmTesting.CreateTestGithubCredentials(adminCtx, "test-creds", s.Store, s.T(), endpoint)
+	org, err := s.Store.CreateOrganization(adminCtx, "test-org", creds.Name, "secret", params.PoolBalancerTypeNone)
+	s.Require().Nil(err)
+	orgID, err := uuid.Parse(org.ID)
+	s.Require().Nil(err)
+
+	_, err = s.Store.CreateOrUpdateJob(s.ctx, s.jobWithStatus(1, params.JobStatusCompleted))
+	s.Require().Nil(err)
+
+	// The org received the queued event after the job already completed.
+	stale := s.jobWithStatus(1, params.JobStatusQueued)
+	stale.OrgID = &orgID
+	_, err = s.Store.CreateOrUpdateJob(s.ctx, stale)
+	s.Require().Nil(err)
+
+	job, err := s.Store.GetJobByID(s.ctx, 1)
+	s.Require().Nil(err)
+	s.Require().Equal(string(params.JobStatusCompleted), job.Status)
+	s.Require().NotNil(job.OrgID)
+	s.Require().Equal(orgID, *job.OrgID)
+}
+
+func (s *JobsTestSuite) TestJobWorkflowRunAndPolicyDenial() {
+	queued := s.jobWithStatus(1, params.JobStatusQueued)
+	queued.HeadBranch = "main"
+	queued.Actor = "octocat"
+	_, err := s.Store.CreateOrUpdateJob(s.ctx, queued)
+	s.Require().Nil(err)
+
+	s.Require().Nil(s.Store.SetJobWorkflowRun(s.ctx, 1, "push", ".github/workflows/ci.yml"))
+	s.Require().Nil(s.Store.SetJobPolicyDenial(s.ctx, 1, "pool p1: branch \"main\" is not allowed"))
+
+	// Later webhooks keep the details of the workflow run.
+	_, err = s.Store.CreateOrUpdateJob(s.ctx, s.jobWithStatus(1, params.JobStatusInProgress))
+	s.Require().Nil(err)
+
+	job, err := s.Store.GetJobByID(s.ctx, 1)
+	s.Require().Nil(err)
+	s.Require().Equal("main", job.HeadBranch)
+	s.Require().Equal("octocat", job.Actor)
+	s.Require().Equal("push", job.Event)
+	s.Require().Equal(".github/workflows/ci.yml", job.WorkflowPath)
+	s.Require().Equal("pool p1: branch \"main\" is not allowed", job.PolicyDenial)
+
+	s.Require().Nil(s.Store.SetJobPolicyDenial(s.ctx, 1, ""))
+	job, err = s.Store.GetJobByID(s.ctx, 1)
+	s.Require().Nil(err)
+	s.Require().Empty(job.PolicyDenial)
+
+	err = s.Store.SetJobPolicyDenial(s.ctx, 2, "denied")
+	s.Require().ErrorIs(err, runnerErrors.ErrNotFound)
+}
+
+func TestJobsTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(JobsTestSuite))
+}
@@ -0,0 +1,364 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/config"
+	"github.com/cloudbase/garm/util/appdefaults"
+)
+
+// migrationBatchSize is the number of rows read and written at once when
+// copying a table to another database.
+const migrationBatchSize = 500
+
+// TableRows holds the number of rows of a table that were copied or updated.
+type TableRows struct {
+	Table string
+	Rows  int64
+}
+
+// backendMigration copies the contents of one database into another.
+type backendMigration struct {
+	source      *sqlDatabase
+	destination *sqlDatabase
+	// reseal is set when the source database may hold data sealed with a
+	// passphrase other than the one used by the destination.
+	reseal bool
+	// keyVersion is the version of the passphrase of the destination, recorded
+	// on the rows that are re-encrypted.
+	keyVersion uint
+}
+
+// openInactive opens a connection to a database that no GARM server is using and
+// brings its schema up to date. The heartbeat is checked before the schema is
+// touched, so the schema of a database an active server relies on is never changed.
+// Unlike NewSQLDatabase, no watcher producer is registered. It is used by maintenance
+// commands that run while GARM is stopped.
+func openInactive(ctx context.Context, cfg config.Database) (*sqlDatabase, error) {
+	db, err := openUnmigrated(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureControllerInactive(db.conn); err != nil {
+		return nil, errors.Wrap(err, "checking database")
+	}
+	if err := db.migrateDB(); err != nil {
+		return nil, errors.Wrap(err, "migrating database")
+	}
+	return db, nil
+}
+
+// MigrateBackend copies all data from the source database to the destination
+// database. The destination must not hold any data and no controller may be
+// active on the source. Sealed columns are re-encrypted if the two databases
+// use different passphrases. The copy is done in a single transaction and the
+// number of rows in each table is verified before it is committed.
+func MigrateBackend(ctx context.Context, source, destination config.Database) ([]TableRows, error) {
+	srcType, srcURI, err := source.GormParams()
+	if err != nil {
+		return nil, errors.Wrap(err, "validating source database config")
+	}
+	dstType, dstURI, err := destination.GormParams()
+	if err != nil {
+		return nil, errors.Wrap(err, "validating destination database config")
+	}
+	if srcType == dstType && srcURI == dstURI {
+		return nil, runnerErrors.NewBadRequestError("source and destination are the same database")
+	}
+
+	src, err := openInactive(ctx, source)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening source database")
+	}
+	dst, err := openInactive(ctx, destination)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening destination database")
+	}
+
+	m := &backendMigration{
+		source:      src,
+		destination: dst,
+		reseal:      source.Passphrase != destination.Passphrase || len(source.OldPassphrases) > 0,
+		keyVersion:  dst.currentKeyVersion(),
+	}
+
+	if err := m.ensureDestinationEmpty(); err != nil {
+		return nil, err
+	}
+
+	var tables []TableRows
+	err = dst.conn.Transaction(func(tx *gorm.DB) error {
+		// The default github.com endpoint is created when the schema is set up.
+		// It is replaced by the endpoints in the source database.
+		if err := tx.Unscoped().Where("1 = 1").Delete(&GithubEndpoint{}).Error; err != nil {
+			return errors.Wrap(err, "removing default github endpoint")
+		}
+
+		var err error
+		tables, err = m.copyTables(tx)
+		if err != nil {
+			return err
+		}
+		return m.verifyRowCounts(tx, tables)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "migrating database")
+	}
+	return tables, nil
+}
+
+// ensureControllerInactive returns a conflict error if a GARM server recorded a
+// heartbeat in the database recently.
+func ensureControllerInactive(conn *gorm.DB) error {
+	if !conn.Migrator().HasTable(&ControllerInfo{}) {
+		return nil
+	}
+	var info ControllerInfo
+	q := conn.First(&info)
+	if q.Error != nil {
+		if errors.Is(q.Error, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return errors.Wrap(q.Error, "fetching controller info")
+	}
+	if info.HeartbeatAt != nil && time.Since(*info.HeartbeatAt) < appdefaults.ControllerHeartbeatTimeout {
+		return runnerErrors.NewConflictError(
+			"controller %s is active on the database (last heartbeat at %s); stop it first",
+			info.ControllerID, info.HeartbeatAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (m *backendMigration) ensureDestinationEmpty() error {
+	for _, model := range migrationModels() {
+		if model.model == nil {
+			// Join tables can only hold rows if the tables they link are not empty.
+			continue
+		}
+		if _, ok := model.model.(*GithubEndpoint); ok {
+			continue
+		}
+		var count int64
+		if err := m.destination.conn.Unscoped().Model(model.model).Count(&count).Error; err != nil {
+			return errors.Wrapf(err, "counting %s in destination database", model.table)
+		}
+		if count > 0 {
+			return runnerErrors.NewConflictError("destination database is not empty (found rows in %s)", model.table)
+		}
+	}
+	return nil
+}
+
+type migrationModel struct {
+	table string
+	model interface{}
+	copy  func(m *backendMigration, tx *gorm.DB) (int64, error)
+}
+
+// migrationModels returns the tables to copy, in an order that satisfies
+// foreign key constraints.
+func migrationModels() []migrationModel {
+	return []migrationModel{
+		{"controller_infos", &ControllerInfo{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows(m.source.conn, tx, func(row *ControllerInfo) error {
+				// The controller is not running against the new database yet.
+				row.HeartbeatAt = nil
+				return m.resealKeyCheck(row)
+			})
+		}},
+		{"users", &User{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows[User](m.source.conn, tx, nil)
+		}},
+		{"github_endpoints", &GithubEndpoint{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows[GithubEndpoint](m.source.conn, tx, nil)
+		}},
+		{"github_credentials", &GithubCredentials{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows(m.source.conn, tx, func(row *GithubCredentials) error {
+				return m.resealColumns(&row.KeyVersion, &row.Payload)
+			})
+		}},
+		{"repositories", &Repository{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows(m.source.conn, tx, func(row *Repository) error {
+				return m.resealColumns(&row.KeyVersion, &row.WebhookSecret, &row.PreviousWebhookSecret)
+			})
+		}},
+		{"organizations", &Organization{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows(m.source.conn, tx, func(row *Organization) error {
+				return m.resealColumns(&row.KeyVersion, &row.WebhookSecret, &row.PreviousWebhookSecret)
+			})
+		}},
+		{"enterprises", &Enterprise{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows(m.source.conn, tx, func(row *Enterprise) error {
+				return m.resealColumns(&row.KeyVersion, &row.WebhookSecret, &row.PreviousWebhookSecret)
+			})
+		}},
+		{"tags", &Tag{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows[Tag](m.source.conn, tx, nil)
+		}},
+		{"pool_templates", &PoolTemplate{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows[PoolTemplate](m.source.conn, tx, nil)
+		}},
+		{"pool_template_tags", nil, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyJoinTable(m.source.conn, tx, "pool_template_tags")
+		}},
+		{"pools", &Pool{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows[Pool](m.source.conn, tx, nil)
+		}},
+		{"pool_tags", nil, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyJoinTable(m.source.conn, tx, "pool_tags")
+		}},
+		{"instances", &Instance{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows(m.source.conn, tx, func(row *Instance) error {
+				return m.resealColumns(&row.KeyVersion, &row.JitConfiguration)
+			})
+		}},
+		{"addresses", &Address{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows[Address](m.source.conn, tx, nil)
+		}},
+		{"instance_status_updates", &InstanceStatusUpdate{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows[InstanceStatusUpdate](m.source.conn, tx, nil)
+		}},
+		{"workflow_jobs", &WorkflowJob{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows[WorkflowJob](m.source.conn, tx, nil)
+		}},
+		{"webhook_deliveries", &WebhookDelivery{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows[WebhookDelivery](m.source.conn, tx, nil)
+		}},
+		{"observed_actions", &ObservedAction{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows[ObservedAction](m.source.conn, tx, nil)
+		}},
+		{"runner_usages", &RunnerUsage{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows[RunnerUsage](m.source.conn, tx, nil)
+		}},
+		{"instance_reaps", &InstanceReap{}, func(m *backendMigration, tx *gorm.DB) (int64, error) {
+			return copyRows[InstanceReap](m.source.conn, tx, nil)
+		}},
+	}
+}
+
+// resealColumns re-encrypts the sealed columns of a row using the passphrase of
+// the destination database and updates the key version of the row.
+func (m *backendMigration) resealColumns(version *uint, columns ...*[]byte) error {
+	if !m.reseal {
+		return nil
+	}
+	*version = m.keyVersion
+	for _, column := range columns {
+		sealed, err := reseal(*column, decryptionPassphrases(m.source.cfg), m.destination.cfg.Passphrase)
+		if err != nil {
+			return err
+		}
+		*column = sealed
+	}
+	return nil
+}
+
+// resealKeyCheck records the key version of the destination passphrase in the
+// controller info. The version is incremented if the passphrase of the
+// destination differs from the one of the source.
+func (m *backendMigration) resealKeyCheck(info *ControllerInfo) error {
+	if !m.reseal {
+		m.keyVersion = info.KeyVersion
+		return nil
+	}
+	version, check, err := nextKeyVersion(*info, m.destination.cfg.Passphrase)
+	if err != nil {
+		return err
+	}
+	if check != nil {
+		info.KeyVersion, info.KeyCheck = version, check
+	}
+	m.keyVersion = version
+	return nil
+}
+
+func (m *backendMigration) copyTables(tx *gorm.DB) ([]TableRows, error) {
+	var tables []TableRows
+	for _, model := range migrationModels() {
+		rows, err := model.copy(m, tx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "copying %s", model.table)
+		}
+		slog.InfoContext(m.source.ctx, "copied table", "table", model.table, "rows", rows)
+		tables = append(tables, TableRows{Table: model.table, Rows: rows})
+	}
+	return tables, nil
+}
+
+func (m *backendMigration) verifyRowCounts(tx *gorm.DB, tables []TableRows) error {
+	for _, table := range tables {
+		var srcCount, dstCount int64
+		if err := m.source.conn.Table(table.Table).Count(&srcCount).Error; err != nil {
+			return errors.Wrapf(err, "counting %s in source database", table.Table)
+		}
+		if err := tx.Table(table.Table).Count(&dstCount).Error; err != nil {
+			return errors.Wrapf(err, "counting %s in destination database", table.Table)
+		}
+		if srcCount != dstCount || srcCount != table.Rows {
+			return errors.Errorf("row count mismatch for %s: source has %d rows, destination has %d", table.Table, srcCount, dstCount)
+		}
+	}
+	return nil
+}
+
+// copyRows copies all rows of a model, including soft deleted ones. Associations
+// are not followed, as each table is copied on its own. The optional transform
+// function is applied to each row before it is written.
+func copyRows[T any](src, dst *gorm.DB, transform func(*T) error) (int64, error) {
+	var copied int64
+	var batch []T
+	q := src.Unscoped().FindInBatches(&batch, migrationBatchSize, func(_ *gorm.DB, _ int) error {
+		if transform != nil {
+			for idx := range batch {
+				if err := transform(&batch[idx]); err != nil {
+					return errors.Wrap(err, "transforming row")
+				}
+			}
+		}
+		if err := dst.Omit(clause.Associations).Create(&batch).Error; err != nil {
+			return errors.Wrap(err, "writing rows")
+		}
+		copied += int64(len(batch))
+		return nil
+	})
+	if q.Error != nil {
+		return 0, errors.Wrap(q.Error, "reading rows")
+	}
+	return copied, nil
+}
+
+// copyJoinTable copies a many to many join table that has no model of its own.
+func copyJoinTable(src, dst *gorm.DB, table string) (int64, error) {
+	var rows []map[string]interface{}
+	if err := src.Table(table).Find(&rows).Error; err != nil {
+		return 0, errors.Wrap(err, "reading rows")
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	if err := dst.Table(table).CreateInBatches(rows, migrationBatchSize).Error; err != nil {
+		return 0, errors.Wrap(err, "writing rows")
+	}
+	return int64(len(rows)), nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/config"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
+)
+
+type MigrateBackendTestSuite struct {
+	suite.Suite
+	Store     dbCommon.Store
+	adminCtx  context.Context
+	sourceCfg config.Database
+	org       params.Organization
+	pool      params.Pool
+}
+
+func (s *MigrateBackendTestSuite) SetupTest() {
+	s.sourceCfg = garmTesting.GetTestSqliteDBConfig(s.T())
+	db, err := NewSQLDatabase(context.Background(), s.sourceCfg)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	if _, err := db.InitController(); err != nil {
+		s.FailNow(fmt.Sprintf("failed to init controller: %s", err))
+	}
+	s.Store = db
+	s.adminCtx = garmTesting.ImpersonateAdminContext(context.Background(), db, s.T())
+
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(s.adminCtx, db, s.T())
+	creds := garmTesting.CreateTestGithubCredentials(s.adminCtx, "test-creds", db, s.T(), endpoint)
+	s.org, err = db.CreateOrganization(s.adminCtx, "test-org", creds.Name, "test-webhookSecret", params.PoolBalancerTypeRoundRobin)
+	s.Require().Nil(err)
+
+	entity, err := s.org.GetEntity()
+	s.Require().Nil(err)
+	s.pool, err = db.CreateEntityPool(s.adminCtx, entity, params.CreatePoolParams{
+		ProviderName: "test-provider",
+		MaxRunners:   4,
+		Image:        "ubuntu:22.04",
+		Flavor:       "small",
+		OSType:       "linux",
+		OSArch:       "amd64",
+		Tags:         []string{"self-hosted", "linux"},
+	})
+	s.Require().Nil(err)
+	_, err = db.CreateInstance(s.adminCtx, s.pool.ID, params.CreateInstanceParams{
+		Name:   "test-instance",
+		OSType: "linux",
+		OSArch: "amd64",
+		JitConfiguration: map[string]string{
+			".runner": "dGVzdA==",
+		},
+	})
+	s.Require().Nil(err)
+}
+
+func (s *MigrateBackendTestSuite) TestMigrateBackend() {
+	destinationCfg := garmTesting.GetTestSqliteDBConfig(s.T())
+	destinationCfg.Passphrase = "another-db-passphrase-0123456789"
+
+	tables, err := MigrateBackend(context.Background(), s.sourceCfg, destinationCfg)
+	s.Require().Nil(err)
+
+	copied := map[string]int64{}
+	for _, table := range tables {
+		copied[table.Table] = table.Rows
+	}
+	s.Require().Equal(int64(1), copied["organizations"])
+	s.Require().Equal(int64(1), copied["pools"])
+	s.Require().Equal(int64(2), copied["pool_tags"])
+	s.Require().Equal(int64(1), copied["instances"])
+
+	destination, err := NewSQLDatabase(context.Background(), destinationCfg)
+	s.Require().Nil(err)
+	ctx := garmTesting.ImpersonateAdminContext(context.Background(), destination, s.T())
+
+	org, err := destination.GetOrganizationByID(ctx, s.org.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("test-webhookSecret", org.WebhookSecret)
+
+	_, err = destination.GetGithubCredentialsByName(ctx, "test-creds", true)
+	s.Require().Nil(err)
+
+	pool, err := destination.GetPoolByID(ctx, s.pool.ID)
+	s.Require().Nil(err)
+	s.Require().Len(pool.Tags, 2)
+
+	instance, err := destination.GetInstanceByName(ctx, "test-instance")
+	s.Require().Nil(err)
+	s.Require().Equal("dGVzdA==", instance.JitConfiguration[".runner"])
+
+	srcInfo, err := s.Store.ControllerInfo()
+	s.Require().Nil(err)
+	dstInfo, err := destination.ControllerInfo()
+	s.Require().Nil(err)
+	s.Require().Equal(srcInfo.ControllerID, dstInfo.ControllerID)
+
+	// The passphrase of the destination gets a new key version.
+	dstDB := destination.(*sqlDatabase)
+	s.Require().Equal(uint(2), dstDB.currentKeyVersion())
+	for _, sealed := range sealedTables {
+		var stale int64
+		err := dstDB.conn.Table(sealed.table).Where("key_version <> ?", dstDB.currentKeyVersion()).Count(&stale).Error
+		s.Require().Nil(err)
+		s.Require().Equal(int64(0), stale, sealed.table)
+	}
+}
+
+func (s *MigrateBackendTestSuite) TestMigrateBackendActiveController() {
+	s.Require().Nil(s.Store.UpdateControllerHeartbeat())
+
+	_, err := MigrateBackend(context.Background(), s.sourceCfg, garmTesting.GetTestSqliteDBConfig(s.T()))
+
+	var conflict *runnerErrors.ConflictError
+	s.Require().ErrorAs(err, &conflict)
+}
+
+func (s *MigrateBackendTestSuite) TestMigrateBackendActiveControllerKeepsSchema() {
+	db := s.Store.(*sqlDatabase)
+	target := latestSchemaVersion() - 1
+	_, err := db.rollbackSchema(target)
+	s.Require().Nil(err)
+	s.Require().Nil(s.Store.UpdateControllerHeartbeat())
+
+	_, err = MigrateBackend(context.Background(), s.sourceCfg, garmTesting.GetTestSqliteDBConfig(s.T()))
+
+	var conflict *runnerErrors.ConflictError
+	s.Require().ErrorAs(err, &conflict)
+	version, err := db.schemaVersion()
+	s.Require().Nil(err)
+	s.Require().Equal(target, version)
+}
+
+func (s *MigrateBackendTestSuite) TestMigrateBackendStoppedController() {
+	s.Require().Nil(s.Store.UpdateControllerHeartbeat())
+	s.Require().Nil(s.Store.ClearControllerHeartbeat())
+
+	_, err := MigrateBackend(context.Background(), s.sourceCfg, garmTesting.GetTestSqliteDBConfig(s.T()))
+
+	s.Require().Nil(err)
+}
+
+func (s *MigrateBackendTestSuite) TestMigrateBackendDestinationNotEmpty() {
+	_, err := MigrateBackend(context.Background(), s.sourceCfg, s.sourceCfg)
+	var badRequest *runnerErrors.BadRequestError
+	s.Require().ErrorAs(err, &badRequest)
+
+	destinationCfg := garmTesting.GetTestSqliteDBConfig(s.T())
+	destination, err := NewSQLDatabase(context.Background(), destinationCfg)
+	s.Require().Nil(err)
+	garmTesting.ImpersonateAdminContext(context.Background(), destination, s.T())
+
+	_, err = MigrateBackend(context.Background(), s.sourceCfg, destinationCfg)
+
+	var conflict *runnerErrors.ConflictError
+	s.Require().ErrorAs(err, &conflict)
+}
+
+func TestMigrateBackendTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrateBackendTestSuite))
+}
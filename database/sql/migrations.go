@@ -0,0 +1,1055 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/config"
+)
+
+// SchemaVersion records a schema migration that was applied to the database.
+type SchemaVersion struct {
+	Version   uint `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (SchemaVersion) TableName() string {
+	return "schema_version"
+}
+
+// SchemaMigration describes a schema migration and whether it was applied.
+type SchemaMigration struct {
+	Version uint
+	Name    string
+	// AppliedAt is nil if the migration is pending.
+	AppliedAt *time.Time
+}
+
+// schemaMigration is a numbered change to the database schema. Migrations are
+// applied in order, each in its own transaction, unless noTx is set. The up
+// step must be safe to run against a schema that already holds the change, as
+// MySQL does not roll back schema changes made by a failed migration.
+// Migrations without a down step can not be rolled back.
+//
+// New migrations are appended to schemaMigrations and must never be renumbered
+// once released. Models used by a migration are frozen copies, defined next to
+// it, so later changes to the models in models.go do not alter old migrations.
+// The models of the baseline are frozen in the baseline package.
+type schemaMigration struct {
+	version uint
+	name    string
+	noTx    bool
+	up      func(s *sqlDatabase, tx *gorm.DB) error
+	down    func(s *sqlDatabase, tx *gorm.DB) error
+}
+
+var schemaMigrations = []schemaMigration{
+	{
+		version: 1,
+		name:    "baseline",
+		// The baseline toggles foreign keys on SQLite and recreates tables in
+		// transactions of its own.
+		noTx: true,
+		up: func(s *sqlDatabase, _ *gorm.DB) error {
+			return s.migrateBaseline()
+		},
+	},
+	{
+		version: 2,
+		name:    "webhook secret rotation",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			for _, table := range []string{"repositories", "organizations", "enterprises"} {
+				if err := addColumns(tx, table, &previousWebhookSecretV2{}, "PreviousWebhookSecret", "PreviousWebhookSecretExpiresAt"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			for _, table := range []string{"repositories", "organizations", "enterprises"} {
+				if err := dropColumns(tx, table, "previous_webhook_secret", "previous_webhook_secret_expires_at"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		version: 3,
+		name:    "controller heartbeat",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			return addColumns(tx, "controller_infos", &controllerHeartbeatV3{}, "HeartbeatAt")
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			return dropColumns(tx, "controller_infos", "heartbeat_at")
+		},
+	},
+	{
+		version: 4,
+		name:    "secret key versions",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			for _, sealed := range sealedTables {
+				if err := addColumns(tx, sealed.table, &keyVersionV4{}, "KeyVersion"); err != nil {
+					return err
+				}
+				if !tx.Table(sealed.table).Migrator().HasIndex(&keyVersionV4{}, "KeyVersion") {
+					if err := tx.Table(sealed.table).Migrator().CreateIndex(&keyVersionV4{}, "KeyVersion"); err != nil {
+						return errors.Wrapf(err, "creating key_version index on %s", sealed.table)
+					}
+				}
+			}
+			return nil
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			for _, sealed := range sealedTables {
+				if tx.Table(sealed.table).Migrator().HasIndex(&keyVersionV4{}, "KeyVersion") {
+					if err := tx.Table(sealed.table).Migrator().DropIndex(&keyVersionV4{}, "KeyVersion"); err != nil {
+						return errors.Wrapf(err, "dropping key_version index on %s", sealed.table)
+					}
+				}
+				if err := dropColumns(tx, sealed.table, "key_version"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		version: 5,
+		name:    "pool maintenance",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := addColumns(tx, "pools", &poolMaintenanceV5{}, "Cordoned", "RecycleRequestedAt", "RecycleMaxUnavailable"); err != nil {
+				return err
+			}
+			return addColumns(tx, "instances", &instanceMaintenanceV5{}, "Draining")
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := dropColumns(tx, "pools", "cordoned", "recycle_requested_at", "recycle_max_unavailable"); err != nil {
+				return err
+			}
+			return dropColumns(tx, "instances", "draining")
+		},
+	},
+	{
+		version: 6,
+		name:    "pool rollouts",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := addColumns(tx, "pools", &poolRolloutV6{}, "Generation", "RolloutMaxSurge", "RolloutMaxUnavailable"); err != nil {
+				return err
+			}
+			// Existing pools get the same defaults as new pools.
+			if err := tx.Table("pools").Where("1 = 1").Update("rollout_max_surge", 1).Error; err != nil {
+				return errors.Wrap(err, "setting rollout defaults")
+			}
+			return addColumns(tx, "instances", &instanceRolloutV6{}, "PoolGeneration")
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := dropColumns(tx, "pools", "generation", "rollout_max_surge", "rollout_max_unavailable"); err != nil {
+				return err
+			}
+			return dropColumns(tx, "instances", "pool_generation")
+		},
+	},
+	{
+		version: 7,
+		name:    "reusable runners",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := addColumns(tx, "pools", &poolReusableV7{}, "Reusable", "RunnerMaxJobs", "RunnerMaxLifetime", "JobCompletedHook"); err != nil {
+				return err
+			}
+			return addColumns(tx, "instances", &instanceReusableV7{}, "JobsCompleted")
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := dropColumns(tx, "pools", "reusable", "runner_max_jobs", "runner_max_lifetime", "job_completed_hook"); err != nil {
+				return err
+			}
+			return dropColumns(tx, "instances", "jobs_completed")
+		},
+	},
+	{
+		version: 8,
+		name:    "instance console output",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			return addColumns(tx, "instances", &instanceConsoleOutputV8{}, "ConsoleOutput", "ConsoleOutputCapturedAt")
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			return dropColumns(tx, "instances", "console_output", "console_output_captured_at")
+		},
+	},
+	{
+		version: 9,
+		name:    "instance create retries",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := addColumns(tx, "pools", &poolProviderFailureV9{}, "ProviderFailureClass", "ProviderFailureReason", "ProviderFailureAt"); err != nil {
+				return err
+			}
+			return addColumns(tx, "instances", &instanceRetryV9{}, "ErrorClass", "NextRetryAt")
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := dropColumns(tx, "pools", "provider_failure_class", "provider_failure_reason", "provider_failure_at"); err != nil {
+				return err
+			}
+			return dropColumns(tx, "instances", "error_class", "next_retry_at")
+		},
+	},
+	{
+		version: 10,
+		name:    "instance lifecycle",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			return addColumns(
+				tx, "instances", &instanceLifecycleV10{},
+				"ProviderCreatedAt", "FirstCallbackAt", "JitFetchedAt",
+				"RegisteredAt", "JobAssignedAt", "TerminatedAt")
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			return dropColumns(
+				tx, "instances",
+				"provider_created_at", "first_callback_at", "jit_fetched_at",
+				"registered_at", "job_assigned_at", "terminated_at")
+		},
+	},
+	{
+		version: 11,
+		name:    "observe only mode",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			for _, table := range []string{"repositories", "organizations", "enterprises", "controller_infos"} {
+				if err := addColumns(tx, table, &observeOnlyV11{}, "ObserveOnly"); err != nil {
+					return err
+				}
+			}
+			if tx.Migrator().HasTable(&observedActionV11{}) {
+				return nil
+			}
+			if err := tx.Migrator().CreateTable(&observedActionV11{}); err != nil {
+				return errors.Wrap(err, "creating observed_actions table")
+			}
+			return nil
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			for _, table := range []string{"repositories", "organizations", "enterprises", "controller_infos"} {
+				if err := dropColumns(tx, table, "observe_only"); err != nil {
+					return err
+				}
+			}
+			if err := tx.Migrator().DropTable(&observedActionV11{}); err != nil {
+				return errors.Wrap(err, "dropping observed_actions table")
+			}
+			return nil
+		},
+	},
+	{
+		version: 12,
+		name:    "controller partition",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			return addColumns(tx, "controller_infos", &controllerPartitionV12{}, "PartitionLabel", "PartitionRepositories")
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			return dropColumns(tx, "controller_infos", "partition_label", "partition_repositories")
+		},
+	},
+	{
+		version: 13,
+		name:    "pool repository filters",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			return addColumns(tx, "pools", &poolRepositoryFiltersV13{}, "IncludedRepositories", "ExcludedRepositories")
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			return dropColumns(tx, "pools", "included_repositories", "excluded_repositories")
+		},
+	},
+	{
+		version: 14,
+		name:    "workflow policies",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := addColumns(tx, "pools", &poolPolicyV14{}, "Policy"); err != nil {
+				return err
+			}
+			return addColumns(
+				tx, "workflow_jobs", &jobWorkflowRunV14{},
+				"HeadBranch", "WorkflowName", "RunAttempt", "Actor", "Event", "WorkflowPath", "PolicyDenial")
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := dropColumns(
+				tx, "workflow_jobs",
+				"head_branch", "workflow_name", "run_attempt", "actor", "event", "workflow_path", "policy_denial"); err != nil {
+				return err
+			}
+			return dropColumns(tx, "pools", "policy")
+		},
+	},
+	{
+		version: 15,
+		name:    "cost accounting",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := addColumns(tx, "pools", &poolHourlyCostV15{}, "HourlyCost"); err != nil {
+				return err
+			}
+			if tx.Migrator().HasTable(&runnerUsageV15{}) {
+				return nil
+			}
+			if err := tx.Migrator().CreateTable(&runnerUsageV15{}); err != nil {
+				return errors.Wrap(err, "creating runner_usages table")
+			}
+			return nil
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&runnerUsageV15{}); err != nil {
+				return errors.Wrap(err, "dropping runner_usages table")
+			}
+			return dropColumns(tx, "pools", "hourly_cost")
+		},
+	},
+	{
+		version: 16,
+		name:    "job scheduling",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			for _, table := range []string{"repositories", "organizations", "enterprises"} {
+				if err := addColumns(tx, table, &jobSchedulingV16{}, "JobScheduling"); err != nil {
+					return err
+				}
+			}
+			return addColumns(tx, "pools", &poolRepositoryLimitV16{}, "MaxRunnersPerRepository")
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := dropColumns(tx, "pools", "max_runners_per_repository"); err != nil {
+				return err
+			}
+			for _, table := range []string{"repositories", "organizations", "enterprises"} {
+				if err := dropColumns(tx, table, "job_scheduling"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		version: 17,
+		name:    "instance reaps",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&instanceReapV17{}) {
+				return nil
+			}
+			if err := tx.Migrator().CreateTable(&instanceReapV17{}); err != nil {
+				return errors.Wrap(err, "creating instance_reaps table")
+			}
+			return nil
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&instanceReapV17{}); err != nil {
+				return errors.Wrap(err, "dropping instance_reaps table")
+			}
+			return nil
+		},
+	},
+	{
+		version: 18,
+		name:    "instance reap console output",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			return addColumns(tx, "instance_reaps", &instanceReapConsoleOutputV18{}, "ConsoleOutput")
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			return dropColumns(tx, "instance_reaps", "console_output")
+		},
+	},
+	{
+		version: 19,
+		name:    "webhook deliveries",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&webhookDeliveryV19{}) {
+				return nil
+			}
+			if err := tx.Migrator().CreateTable(&webhookDeliveryV19{}); err != nil {
+				return errors.Wrap(err, "creating webhook_deliveries table")
+			}
+			return nil
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&webhookDeliveryV19{}); err != nil {
+				return errors.Wrap(err, "dropping webhook_deliveries table")
+			}
+			return nil
+		},
+	},
+	{
+		version: 20,
+		name:    "pool templates",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			for _, model := range []interface{}{&poolTemplateV20{}, &poolTemplateTagV20{}} {
+				if tx.Migrator().HasTable(model) {
+					continue
+				}
+				if err := tx.Migrator().CreateTable(model); err != nil {
+					return errors.Wrap(err, "creating pool templates tables")
+				}
+			}
+			// Adding a foreign key to pools would recreate the table on SQLite.
+			// Pools referencing a template are checked before deleting it.
+			if err := addColumns(tx, "pools", &poolTemplateRefV20{}, "TemplateID", "TemplateOverrides"); err != nil {
+				return err
+			}
+			if !tx.Migrator().HasIndex(&poolTemplateRefV20{}, "TemplateID") {
+				if err := tx.Migrator().CreateIndex(&poolTemplateRefV20{}, "TemplateID"); err != nil {
+					return errors.Wrap(err, "creating template_id index on pools")
+				}
+			}
+			return nil
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			if tx.Migrator().HasIndex(&poolTemplateRefV20{}, "TemplateID") {
+				if err := tx.Migrator().DropIndex(&poolTemplateRefV20{}, "TemplateID"); err != nil {
+					return errors.Wrap(err, "dropping template_id index on pools")
+				}
+			}
+			if err := dropColumns(tx, "pools", "template_id", "template_overrides"); err != nil {
+				return err
+			}
+			for _, model := range []interface{}{&poolTemplateTagV20{}, &poolTemplateV20{}} {
+				if err := tx.Migrator().DropTable(model); err != nil {
+					return errors.Wrap(err, "dropping pool templates tables")
+				}
+			}
+			return nil
+		},
+	},
+	{
+		version: 21,
+		name:    "integer key versions",
+		up: func(s *sqlDatabase, tx *gorm.DB) error {
+			// Rows sealed with the current passphrase get the first version.
+			// Rows sealed with an older passphrase get version 0.
+			fingerprint := passphraseFingerprintV4(s.cfg.Passphrase)
+			for _, sealed := range sealedTables {
+				if err := convertKeyVersion(tx, sealed.table, &keyVersionV21{}, fingerprint, uint(1)); err != nil {
+					return err
+				}
+			}
+			if err := addColumns(tx, "controller_infos", &controllerKeyVersionV21{}, "KeyVersion", "KeyCheck"); err != nil {
+				return err
+			}
+			if err := tx.Table("controller_infos").Where("key_version is NULL or key_version = 0").Update("key_version", 1).Error; err != nil {
+				return errors.Wrap(err, "setting controller key version")
+			}
+			return nil
+		},
+		down: func(s *sqlDatabase, tx *gorm.DB) error {
+			var versions []uint
+			if err := tx.Table("controller_infos").Pluck("key_version", &versions).Error; err != nil {
+				return errors.Wrap(err, "fetching controller key version")
+			}
+			current := uint(1)
+			if len(versions) > 0 {
+				current = versions[0]
+			}
+			fingerprint := passphraseFingerprintV4(s.cfg.Passphrase)
+			for _, sealed := range sealedTables {
+				if err := convertKeyVersion(tx, sealed.table, &keyVersionV4{}, current, fingerprint); err != nil {
+					return err
+				}
+			}
+			return dropColumns(tx, "controller_infos", "key_version", "key_check")
+		},
+	},
+	{
+		version: 22,
+		name:    "adopted instances",
+		up: func(_ *sqlDatabase, tx *gorm.DB) error {
+			return addColumns(tx, "instances", &instanceAdoptedV22{}, "Adopted")
+		},
+		down: func(_ *sqlDatabase, tx *gorm.DB) error {
+			return dropColumns(tx, "instances", "adopted")
+		},
+	},
+}
+
+type previousWebhookSecretV2 struct {
+	PreviousWebhookSecret          []byte
+	PreviousWebhookSecretExpiresAt *time.Time
+}
+
+type controllerHeartbeatV3 struct {
+	HeartbeatAt *time.Time
+}
+
+type keyVersionV4 struct {
+	KeyVersion string `gorm:"type:varchar(64);index"`
+}
+
+type poolMaintenanceV5 struct {
+	Cordoned              bool
+	RecycleRequestedAt    *time.Time
+	RecycleMaxUnavailable uint
+}
+
+type instanceMaintenanceV5 struct {
+	Draining bool
+}
+
+type poolRolloutV6 struct {
+	Generation            uint
+	RolloutMaxSurge       uint
+	RolloutMaxUnavailable uint
+}
+
+type instanceRolloutV6 struct {
+	PoolGeneration uint
+}
+
+type poolReusableV7 struct {
+	Reusable          bool
+	RunnerMaxJobs     uint
+	RunnerMaxLifetime uint
+	JobCompletedHook  string `gorm:"type:text"`
+}
+
+type instanceReusableV7 struct {
+	JobsCompleted uint
+}
+
+type instanceConsoleOutputV8 struct {
+	ConsoleOutput           []byte `gorm:"type:longblob"`
+	ConsoleOutputCapturedAt *time.Time
+}
+
+type poolProviderFailureV9 struct {
+	ProviderFailureClass  string
+	ProviderFailureReason string `gorm:"type:text"`
+	ProviderFailureAt     *time.Time
+}
+
+type instanceRetryV9 struct {
+	ErrorClass  string
+	NextRetryAt *time.Time
+}
+
+type instanceLifecycleV10 struct {
+	ProviderCreatedAt *time.Time
+	FirstCallbackAt   *time.Time
+	JitFetchedAt      *time.Time
+	RegisteredAt      *time.Time
+	JobAssignedAt     *time.Time
+	TerminatedAt      *time.Time
+}
+
+type observeOnlyV11 struct {
+	ObserveOnly bool
+}
+
+type observedActionV11 struct {
+	ID         uint   `gorm:"primarykey"`
+	EntityType string `gorm:"type:varchar(64)"`
+	EntityID   string `gorm:"type:varchar(64);uniqueIndex:idx_observed_action"`
+	PoolID     string `gorm:"type:varchar(64);uniqueIndex:idx_observed_action"`
+	Action     string `gorm:"type:varchar(64);uniqueIndex:idx_observed_action"`
+	Target     string `gorm:"type:varchar(255);uniqueIndex:idx_observed_action"`
+	Reason     string `gorm:"type:text"`
+	Count      uint
+
+	CreatedAt time.Time
+	UpdatedAt time.Time `gorm:"index"`
+}
+
+func (observedActionV11) TableName() string {
+	return "observed_actions"
+}
+
+type controllerPartitionV12 struct {
+	PartitionLabel        string
+	PartitionRepositories datatypes.JSON
+}
+
+type poolRepositoryFiltersV13 struct {
+	IncludedRepositories datatypes.JSON
+	ExcludedRepositories datatypes.JSON
+}
+
+type poolPolicyV14 struct {
+	Policy datatypes.JSON
+}
+
+type jobWorkflowRunV14 struct {
+	HeadBranch   string `gorm:"type:text"`
+	WorkflowName string `gorm:"type:text"`
+	RunAttempt   int64
+	Actor        string
+	Event        string
+	WorkflowPath string `gorm:"type:text"`
+	PolicyDenial string `gorm:"type:text"`
+}
+
+type poolHourlyCostV15 struct {
+	HourlyCost float64
+}
+
+type runnerUsageV15 struct {
+	ID           uint   `gorm:"primarykey"`
+	InstanceName string `gorm:"type:varchar(64);index"`
+	PoolID       string `gorm:"type:varchar(64);index"`
+
+	EntityType string `gorm:"type:varchar(64)"`
+	EntityID   string `gorm:"type:varchar(64);index"`
+	EntityName string `gorm:"type:varchar(255)"`
+
+	JobID           int64  `gorm:"index"`
+	RepositoryOwner string `gorm:"type:varchar(255)"`
+	RepositoryName  string `gorm:"type:varchar(255)"`
+
+	StartedAt time.Time
+	EndedAt   time.Time `gorm:"index"`
+	Seconds   float64
+	Cost      float64
+
+	CreatedAt time.Time
+}
+
+func (runnerUsageV15) TableName() string {
+	return "runner_usages"
+}
+
+type jobSchedulingV16 struct {
+	JobScheduling datatypes.JSON
+}
+
+type poolRepositoryLimitV16 struct {
+	MaxRunnersPerRepository uint
+}
+
+type instanceReapV17 struct {
+	ID           uint   `gorm:"primarykey"`
+	InstanceName string `gorm:"type:varchar(64);index"`
+	PoolID       string `gorm:"type:varchar(64);index"`
+	Reason       string `gorm:"type:text"`
+	Timeline     datatypes.JSON
+
+	CreatedAt time.Time `gorm:"index"`
+}
+
+func (instanceReapV17) TableName() string {
+	return "instance_reaps"
+}
+
+type instanceReapConsoleOutputV18 struct {
+	ConsoleOutput []byte `gorm:"type:longblob"`
+}
+
+type webhookDeliveryV19 struct {
+	ID             string `gorm:"type:varchar(64);primary_key;"`
+	HookTargetType string
+	Payload        []byte `gorm:"type:longblob"`
+	Status         string `gorm:"index:idx_webhook_delivery_status_next"`
+	Attempts       int
+	LastError      string    `gorm:"type:text"`
+	NextAttemptAt  time.Time `gorm:"index:idx_webhook_delivery_status_next"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time `gorm:"index"`
+}
+
+func (webhookDeliveryV19) TableName() string {
+	return "webhook_deliveries"
+}
+
+type poolTemplateV20 struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	Name                   string `gorm:"type:varchar(64);uniqueIndex"`
+	Description            string `gorm:"type:text"`
+	ProviderName           string
+	RunnerPrefix           string
+	MaxRunners             uint
+	MinIdleRunners         uint
+	RunnerBootstrapTimeout uint
+	Image                  string
+	Flavor                 string
+	OSType                 string
+	OSArch                 string
+	ExtraSpecs             datatypes.JSON
+	GitHubRunnerGroup      string
+	Priority               uint
+}
+
+func (poolTemplateV20) TableName() string {
+	return "pool_templates"
+}
+
+type tagV20 struct {
+	ID uuid.UUID `gorm:"type:uuid;primary_key;"`
+}
+
+func (tagV20) TableName() string {
+	return "tags"
+}
+
+// poolTemplateTagV20 is the join table of pool templates and tags. The names of
+// the fields match the ones of the join table GORM creates, so the constraints
+// get the same names.
+type poolTemplateTagV20 struct {
+	PoolTemplateID uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	PoolTemplate   poolTemplateV20 `gorm:"foreignKey:PoolTemplateID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE;"`
+	TagID          uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	Tag            tagV20          `gorm:"foreignKey:TagID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE;"`
+}
+
+func (poolTemplateTagV20) TableName() string {
+	return "pool_template_tags"
+}
+
+type poolTemplateRefV20 struct {
+	TemplateID        *uuid.UUID `gorm:"type:uuid;index"`
+	TemplateOverrides datatypes.JSON
+}
+
+func (poolTemplateRefV20) TableName() string {
+	return "pools"
+}
+
+type keyVersionV21 struct {
+	KeyVersion uint `gorm:"index"`
+}
+
+type controllerKeyVersionV21 struct {
+	KeyVersion uint
+	KeyCheck   []byte `gorm:"type:longblob"`
+}
+
+// passphraseFingerprintV4 is the key version recorded by schema versions 4 to 20.
+func passphraseFingerprintV4(passphrase string) string {
+	sum := sha256.Sum256([]byte(passphrase))
+	return hex.EncodeToString(sum[:8])
+}
+
+// convertKeyVersion replaces the key_version column of a table with the one of
+// model. Rows holding current get the value converted, the other rows get the
+// zero value of the new column. Tables that already have a key_version column
+// of the type of converted are skipped.
+func convertKeyVersion(tx *gorm.DB, table string, model interface{}, current, converted interface{}) error {
+	_, toText := converted.(string)
+	isText, err := isTextColumn(tx, table, "key_version")
+	if err != nil {
+		return err
+	}
+	if isText == toText {
+		return nil
+	}
+
+	var ids []string
+	if err := tx.Table(table).Where("key_version = ?", current).Pluck("id", &ids).Error; err != nil {
+		return errors.Wrapf(err, "fetching rows of %s", table)
+	}
+
+	migrator := tx.Table(table).Migrator()
+	for _, old := range []interface{}{&keyVersionV4{}, &keyVersionV21{}} {
+		if migrator.HasIndex(old, "KeyVersion") {
+			if err := migrator.DropIndex(old, "KeyVersion"); err != nil {
+				return errors.Wrapf(err, "dropping key_version index on %s", table)
+			}
+		}
+	}
+	if err := dropColumns(tx, table, "key_version"); err != nil {
+		return err
+	}
+	if err := addColumns(tx, table, model, "KeyVersion"); err != nil {
+		return err
+	}
+	if err := migrator.CreateIndex(model, "KeyVersion"); err != nil {
+		return errors.Wrapf(err, "creating key_version index on %s", table)
+	}
+
+	var zero interface{} = uint(0)
+	if toText {
+		zero = ""
+	}
+	if err := tx.Table(table).Where("1 = 1").Update("key_version", zero).Error; err != nil {
+		return errors.Wrapf(err, "updating key versions of %s", table)
+	}
+	for start := 0; start < len(ids); start += 500 {
+		end := min(start+500, len(ids))
+		if err := tx.Table(table).Where("id in ?", ids[start:end]).Update("key_version", converted).Error; err != nil {
+			return errors.Wrapf(err, "updating key versions of %s", table)
+		}
+	}
+	return nil
+}
+
+// isTextColumn returns true if a column of a table holds strings.
+func isTextColumn(tx *gorm.DB, table, column string) (bool, error) {
+	columns, err := tx.Migrator().ColumnTypes(table)
+	if err != nil {
+		return false, errors.Wrapf(err, "fetching columns of %s", table)
+	}
+	for _, col := range columns {
+		if col.Name() != column {
+			continue
+		}
+		typeName := strings.ToLower(col.DatabaseTypeName())
+		return strings.Contains(typeName, "char") || strings.Contains(typeName, "text"), nil
+	}
+	return false, errors.Errorf("column %s not found in %s", column, table)
+}
+
+type instanceAdoptedV22 struct {
+	Adopted bool
+}
+
+// addColumns adds the given fields of model to a table, if they are missing.
+func addColumns(tx *gorm.DB, table string, model interface{}, fields ...string) error {
+	migrator := tx.Table(table).Migrator()
+	for _, field := range fields {
+		if migrator.HasColumn(model, field) {
+			continue
+		}
+		if err := migrator.AddColumn(model, field); err != nil {
+			return errors.Wrapf(err, "adding column %s to %s", field, table)
+		}
+	}
+	return nil
+}
+
+// dropColumns removes columns from a table, if they exist. The gorm SQLite
+// migrator drops columns by recreating the table, which would cascade deletes to
+// dependent tables, so the columns are dropped directly.
+func dropColumns(tx *gorm.DB, table string, columns ...string) error {
+	for _, column := range columns {
+		if !tx.Migrator().HasColumn(table, column) {
+			continue
+		}
+		if err := tx.Exec("ALTER TABLE ? DROP COLUMN ?", clause.Table{Name: table}, clause.Column{Name: column}).Error; err != nil {
+			return errors.Wrapf(err, "dropping column %s from %s", column, table)
+		}
+	}
+	return nil
+}
+
+func latestSchemaVersion() uint {
+	return schemaMigrations[len(schemaMigrations)-1].version
+}
+
+// schemaVersion returns the version of the last migration applied to the
+// database, or 0 if the database predates schema versioning.
+func (s *sqlDatabase) schemaVersion() (uint, error) {
+	if err := s.conn.AutoMigrate(&SchemaVersion{}); err != nil {
+		return 0, errors.Wrap(err, "creating schema version table")
+	}
+
+	var version SchemaVersion
+	q := s.conn.Order("version desc").First(&version)
+	if q.Error != nil {
+		if errors.Is(q.Error, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, errors.Wrap(q.Error, "fetching schema version")
+	}
+	if version.Version > latestSchemaVersion() {
+		return 0, runnerErrors.NewConflictError(
+			"database schema version %d is newer than the latest version supported by this release (%d)",
+			version.Version, latestSchemaVersion())
+	}
+	return version.Version, nil
+}
+
+// runMigration runs one step of a migration and, in the same transaction where
+// possible, records or removes its schema version.
+func (s *sqlDatabase) runMigration(migration schemaMigration, step func(s *sqlDatabase, tx *gorm.DB) error, record func(tx *gorm.DB) error) error {
+	if migration.noTx {
+		if err := step(s, s.conn); err != nil {
+			return err
+		}
+		return record(s.conn)
+	}
+	return s.conn.Transaction(func(tx *gorm.DB) error {
+		if err := step(s, tx); err != nil {
+			return err
+		}
+		return record(tx)
+	})
+}
+
+// migrateSchema applies all pending migrations up to and including target.
+func (s *sqlDatabase) migrateSchema(target uint) ([]SchemaMigration, error) {
+	current, err := s.schemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	if target > latestSchemaVersion() {
+		return nil, runnerErrors.NewBadRequestError("unknown schema version %d (latest is %d)", target, latestSchemaVersion())
+	}
+	if target < current {
+		return nil, runnerErrors.NewBadRequestError("database is at schema version %d; use rollback to go back to version %d", current, target)
+	}
+
+	var applied []SchemaMigration
+	for _, migration := range schemaMigrations {
+		if migration.version <= current || migration.version > target {
+			continue
+		}
+		slog.Info("applying schema migration", "version", migration.version, "name", migration.name)
+		now := time.Now().UTC()
+		err := s.runMigration(migration, migration.up, func(tx *gorm.DB) error {
+			return tx.Create(&SchemaVersion{
+				Version:   migration.version,
+				Name:      migration.name,
+				AppliedAt: now,
+			}).Error
+		})
+		if err != nil {
+			return applied, errors.Wrapf(err, "applying migration %d (%s)", migration.version, migration.name)
+		}
+		applied = append(applied, SchemaMigration{
+			Version:   migration.version,
+			Name:      migration.name,
+			AppliedAt: &now,
+		})
+	}
+	return applied, nil
+}
+
+// rollbackSchema reverts all applied migrations newer than target, starting
+// with the most recent one.
+func (s *sqlDatabase) rollbackSchema(target uint) ([]SchemaMigration, error) {
+	current, err := s.schemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	if target > current {
+		return nil, runnerErrors.NewBadRequestError("database is at schema version %d; use migrate to go to version %d", current, target)
+	}
+
+	for _, migration := range schemaMigrations {
+		if migration.version > target && migration.version <= current && migration.down == nil {
+			return nil, runnerErrors.NewBadRequestError("migration %d (%s) can not be rolled back", migration.version, migration.name)
+		}
+	}
+
+	var reverted []SchemaMigration
+	for idx := len(schemaMigrations) - 1; idx >= 0; idx-- {
+		migration := schemaMigrations[idx]
+		if migration.version <= target || migration.version > current {
+			continue
+		}
+		slog.Info("rolling back schema migration", "version", migration.version, "name", migration.name)
+		err := s.runMigration(migration, migration.down, func(tx *gorm.DB) error {
+			return tx.Delete(&SchemaVersion{}, migration.version).Error
+		})
+		if err != nil {
+			return reverted, errors.Wrapf(err, "rolling back migration %d (%s)", migration.version, migration.name)
+		}
+		reverted = append(reverted, SchemaMigration{
+			Version: migration.version,
+			Name:    migration.name,
+		})
+	}
+	return reverted, nil
+}
+
+// openUnmigrated opens a database without touching its schema.
+func openUnmigrated(ctx context.Context, cfg config.Database) (*sqlDatabase, error) {
+	conn, err := newDBConn(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating DB connection")
+	}
+	return &sqlDatabase{
+		conn: conn,
+		ctx:  ctx,
+		cfg:  cfg,
+	}, nil
+}
+
+// SchemaStatus returns all known schema migrations, along with the time they
+// were applied to the database.
+func SchemaStatus(ctx context.Context, cfg config.Database) ([]SchemaMigration, error) {
+	db, err := openUnmigrated(ctx, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening database")
+	}
+	if _, err := db.schemaVersion(); err != nil {
+		return nil, errors.Wrap(err, "fetching schema version")
+	}
+
+	var versions []SchemaVersion
+	if err := db.conn.Find(&versions).Error; err != nil {
+		return nil, errors.Wrap(err, "fetching schema versions")
+	}
+	appliedAt := map[uint]time.Time{}
+	for _, version := range versions {
+		appliedAt[version.Version] = version.AppliedAt
+	}
+
+	ret := make([]SchemaMigration, len(schemaMigrations))
+	for idx, migration := range schemaMigrations {
+		ret[idx] = SchemaMigration{
+			Version: migration.version,
+			Name:    migration.name,
+		}
+		if at, ok := appliedAt[migration.version]; ok {
+			ret[idx].AppliedAt = &at
+		}
+	}
+	return ret, nil
+}
+
+// MigrateSchema applies all pending schema migrations up to and including the
+// target version. A target of 0 migrates to the latest version. No controller
+// may be active on the database.
+func MigrateSchema(ctx context.Context, cfg config.Database, target uint) ([]SchemaMigration, error) {
+	db, err := openUnmigrated(ctx, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening database")
+	}
+	if err := ensureControllerInactive(db.conn); err != nil {
+		return nil, errors.Wrap(err, "checking database")
+	}
+	if target == 0 {
+		target = latestSchemaVersion()
+	}
+	applied, err := db.migrateSchema(target)
+	if err != nil {
+		return applied, errors.Wrap(err, "migrating schema")
+	}
+	return applied, nil
+}
+
+// RollbackSchema reverts all schema migrations newer than the target version.
+// This allows downgrading to an older release of GARM. No controller may be
+// active on the database.
+func RollbackSchema(ctx context.Context, cfg config.Database, target uint) ([]SchemaMigration, error) {
+	db, err := openUnmigrated(ctx, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening database")
+	}
+	if err := ensureControllerInactive(db.conn); err != nil {
+		return nil, errors.Wrap(err, "checking database")
+	}
+	reverted, err := db.rollbackSchema(target)
+	if err != nil {
+		return reverted, errors.Wrap(err, "rolling back schema")
+	}
+	return reverted, nil
+}
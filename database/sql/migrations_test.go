@@ -0,0 +1,246 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/config"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+)
+
+type SchemaMigrationsTestSuite struct {
+	suite.Suite
+	cfg config.Database
+	db  *sqlDatabase
+}
+
+func (s *SchemaMigrationsTestSuite) SetupTest() {
+	s.cfg = garmTesting.GetTestSqliteDBConfig(s.T())
+	db, err := NewSQLDatabase(context.Background(), s.cfg)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	if _, err := db.InitController(); err != nil {
+		s.FailNow(fmt.Sprintf("failed to init controller: %s", err))
+	}
+	s.db = db.(*sqlDatabase)
+}
+
+// tableColumns returns the columns of all tables in a database.
+func (s *SchemaMigrationsTestSuite) tableColumns(db *sqlDatabase) map[string][]string {
+	tables, err := db.conn.Migrator().GetTables()
+	s.Require().Nil(err)
+
+	ret := map[string][]string{}
+	for _, table := range tables {
+		columns, err := db.conn.Migrator().ColumnTypes(table)
+		s.Require().Nil(err)
+		for _, column := range columns {
+			ret[table] = append(ret[table], column.Name())
+		}
+	}
+	return ret
+}
+
+func (s *SchemaMigrationsTestSuite) TestNewDatabaseIsAtLatestVersion() {
+	status, err := SchemaStatus(context.Background(), s.cfg)
+	s.Require().Nil(err)
+	s.Require().Len(status, len(schemaMigrations))
+	for _, migration := range status {
+		s.Require().NotNil(migration.AppliedAt, migration.Name)
+	}
+}
+
+// TestMigrateFixtures upgrades the database of each previous release found in
+// testdata/db and expects the schema of a new database.
+func (s *SchemaMigrationsTestSuite) TestMigrateFixtures() {
+	fixtures, err := filepath.Glob("../../testdata/db/*/garm.db")
+	s.Require().Nil(err)
+	s.Require().NotEmpty(fixtures)
+
+	expected := s.tableColumns(s.db)
+	for _, fixture := range fixtures {
+		cfg := garmTesting.GetTestSqliteDBConfig(s.T())
+		data, err := os.ReadFile(fixture)
+		s.Require().Nil(err)
+		s.Require().Nil(os.WriteFile(cfg.SQLite.DBFile, data, 0o600))
+
+		status, err := SchemaStatus(context.Background(), cfg)
+		s.Require().Nil(err)
+		for _, migration := range status {
+			s.Require().Nil(migration.AppliedAt, fixture)
+		}
+
+		applied, err := MigrateSchema(context.Background(), cfg, 0)
+		s.Require().Nil(err, fixture)
+		s.Require().Len(applied, len(schemaMigrations), fixture)
+
+		db, err := openUnmigrated(context.Background(), cfg)
+		s.Require().Nil(err)
+		migrated := s.tableColumns(db)
+		for table, columns := range expected {
+			s.Require().Subset(migrated[table], columns, "%s: %s", fixture, table)
+		}
+	}
+}
+
+// TestBaselineIsFrozen expects the baseline to create version 1 of the schema,
+// rather than the schema of the current models.
+func (s *SchemaMigrationsTestSuite) TestBaselineIsFrozen() {
+	cfg := garmTesting.GetTestSqliteDBConfig(s.T())
+
+	applied, err := MigrateSchema(context.Background(), cfg, 1)
+	s.Require().Nil(err)
+	s.Require().Len(applied, 1)
+
+	db, err := openUnmigrated(context.Background(), cfg)
+	s.Require().Nil(err)
+	s.Require().True(db.conn.Migrator().HasTable("pools"))
+	for _, table := range []string{"webhook_deliveries", "pool_templates", "pool_template_tags", "observed_actions", "runner_usages", "instance_reaps"} {
+		s.Require().False(db.conn.Migrator().HasTable(table), table)
+	}
+	s.Require().False(db.conn.Migrator().HasColumn("pools", "template_id"))
+	s.Require().False(db.conn.Migrator().HasColumn("repositories", "key_version"))
+	s.Require().False(db.conn.Migrator().HasColumn("pools", "cordoned"))
+	s.Require().False(db.conn.Migrator().HasColumn("instances", "console_output"))
+}
+
+// TestMigrationsMatchModels expects a database created by the migrations to
+// have the schema of the current models.
+func (s *SchemaMigrationsTestSuite) TestMigrationsMatchModels() {
+	cfg := garmTesting.GetTestSqliteDBConfig(s.T())
+	db, err := openUnmigrated(context.Background(), cfg)
+	s.Require().Nil(err)
+	for _, model := range migrationModels() {
+		if model.model == nil {
+			// Join tables are created along with their models.
+			continue
+		}
+		s.Require().Nil(db.conn.AutoMigrate(model.model), model.table)
+	}
+
+	expected := s.tableColumns(db)
+	migrated := s.tableColumns(s.db)
+	delete(migrated, "schema_version")
+	s.Require().Len(migrated, len(expected))
+	for table, columns := range expected {
+		s.Require().ElementsMatch(columns, migrated[table], table)
+	}
+}
+
+func (s *SchemaMigrationsTestSuite) TestRollbackAndMigrate() {
+	expected := s.tableColumns(s.db)
+
+	reverted, err := RollbackSchema(context.Background(), s.cfg, 1)
+	s.Require().Nil(err)
+	s.Require().Len(reverted, len(schemaMigrations)-1)
+	s.Require().Equal(latestSchemaVersion(), reverted[0].Version)
+
+	s.Require().False(s.db.conn.Migrator().HasColumn(&ControllerInfo{}, "heartbeat_at"))
+	s.Require().False(s.db.conn.Migrator().HasColumn(&Repository{}, "key_version"))
+	s.Require().False(s.db.conn.Migrator().HasColumn(&Organization{}, "previous_webhook_secret"))
+	s.Require().False(s.db.conn.Migrator().HasTable("webhook_deliveries"))
+	s.Require().False(s.db.conn.Migrator().HasTable("pool_templates"))
+	s.Require().False(s.db.conn.Migrator().HasColumn(&Pool{}, "template_id"))
+	version, err := s.db.schemaVersion()
+	s.Require().Nil(err)
+	s.Require().Equal(uint(1), version)
+
+	// Rows survive the rollback.
+	info, err := s.db.ControllerInfo()
+	s.Require().Nil(err)
+	s.Require().NotEmpty(info.ControllerID)
+
+	applied, err := MigrateSchema(context.Background(), s.cfg, 0)
+	s.Require().Nil(err)
+	s.Require().Len(applied, len(schemaMigrations)-1)
+	s.Require().True(s.db.conn.Migrator().HasColumn(&ControllerInfo{}, "heartbeat_at"))
+	s.Require().True(s.db.conn.Migrator().HasIndex(&Repository{}, "KeyVersion"))
+	migrated := s.tableColumns(s.db)
+	for table, columns := range expected {
+		s.Require().ElementsMatch(columns, migrated[table], table)
+	}
+}
+
+func (s *SchemaMigrationsTestSuite) TestKeyVersionMigration() {
+	adminCtx := garmTesting.ImpersonateAdminContext(context.Background(), s.db, s.T())
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(adminCtx, s.db, s.T())
+	creds := garmTesting.CreateTestGithubCredentials(adminCtx, "test-creds", s.db, s.T(), endpoint)
+	s.Require().Equal(uint(1), s.db.currentKeyVersion())
+
+	_, err := RollbackSchema(context.Background(), s.cfg, 20)
+	s.Require().Nil(err)
+	var fingerprint string
+	s.Require().Nil(s.db.conn.Table("github_credentials").Where("id = ?", creds.ID).Pluck("key_version", &fingerprint).Error)
+	s.Require().Equal(passphraseFingerprintV4(s.cfg.Passphrase), fingerprint)
+
+	_, err = MigrateSchema(context.Background(), s.cfg, 0)
+	s.Require().Nil(err)
+	var version uint
+	s.Require().Nil(s.db.conn.Table("github_credentials").Where("id = ?", creds.ID).Pluck("key_version", &version).Error)
+	s.Require().Equal(uint(1), version)
+
+	// Opening the database with the same passphrase keeps the key version.
+	db, err := NewSQLDatabase(context.Background(), s.cfg)
+	s.Require().Nil(err)
+	s.Require().Equal(uint(1), db.(*sqlDatabase).currentKeyVersion())
+}
+
+func (s *SchemaMigrationsTestSuite) TestRollbackBaseline() {
+	_, err := RollbackSchema(context.Background(), s.cfg, 0)
+
+	var badRequest *runnerErrors.BadRequestError
+	s.Require().ErrorAs(err, &badRequest)
+	version, err := s.db.schemaVersion()
+	s.Require().Nil(err)
+	s.Require().Equal(latestSchemaVersion(), version)
+}
+
+func (s *SchemaMigrationsTestSuite) TestMigrateBackwardsFails() {
+	_, err := s.db.migrateSchema(1)
+
+	var badRequest *runnerErrors.BadRequestError
+	s.Require().ErrorAs(err, &badRequest)
+}
+
+func (s *SchemaMigrationsTestSuite) TestNewerSchemaVersionIsRefused() {
+	s.Require().Nil(s.db.conn.Create(&SchemaVersion{Version: latestSchemaVersion() + 1, Name: "from the future"}).Error)
+
+	_, err := NewSQLDatabase(context.Background(), s.cfg)
+
+	var conflict *runnerErrors.ConflictError
+	s.Require().ErrorAs(err, &conflict)
+}
+
+func (s *SchemaMigrationsTestSuite) TestRollbackActiveController() {
+	s.Require().Nil(s.db.UpdateControllerHeartbeat())
+
+	_, err := RollbackSchema(context.Background(), s.cfg, 1)
+
+	var conflict *runnerErrors.ConflictError
+	s.Require().ErrorAs(err, &conflict)
+}
+
+func TestSchemaMigrationsTestSuite(t *testing.T) {
+	suite.Run(t, new(SchemaMigrationsTestSuite))
+}
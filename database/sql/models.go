@@ -84,6 +84,76 @@ type Pool struct {
 
 	Instances []Instance `gorm:"foreignKey:PoolID"`
 	Priority  uint       `gorm:"index:idx_pool_priority"`
+
+	TemplateID *uuid.UUID   `gorm:"index"`
+	Template   PoolTemplate `gorm:"foreignKey:TemplateID"`
+	// TemplateOverrides is a json list of fields that are not
+	// kept in sync with the pool template.
+	TemplateOverrides datatypes.JSON
+
+	// Cordoned pools do not create new runners. Existing runners are kept.
+	Cordoned bool
+	// RecycleRequestedAt is set while the runners created before it are
+	// replaced, at most RecycleMaxUnavailable at a time.
+	RecycleRequestedAt    *time.Time
+	RecycleMaxUnavailable uint
+
+	// Generation is incremented every time the image, flavor or extra specs
+	// of the pool change. Instances record the generation they were created with.
+	Generation uint
+	// RolloutMaxSurge and RolloutMaxUnavailable limit the automatic replacement
+	// of idle runners created with a previous generation.
+	RolloutMaxSurge       uint
+	RolloutMaxUnavailable uint
+
+	// Reusable pools keep runners after a job completes, until they served
+	// RunnerMaxJobs jobs or lived for RunnerMaxLifetime minutes.
+	Reusable          bool
+	RunnerMaxJobs     uint
+	RunnerMaxLifetime uint
+	// JobCompletedHook is a script reusable runners run after every job.
+	JobCompletedHook string `gorm:"type:text"`
+
+	// ProviderFailureClass is set when the provider returned an error that is
+	// not retryable. The pool does not create runners until it is cleared.
+	ProviderFailureClass  params.ProviderErrorClass
+	ProviderFailureReason string `gorm:"type:text"`
+	ProviderFailureAt     *time.Time
+
+	// IncludedRepositories and ExcludedRepositories are json lists of
+	// owner/name glob patterns that limit the repositories the pool serves.
+	IncludedRepositories datatypes.JSON
+	ExcludedRepositories datatypes.JSON
+
+	// Policy is a json encoded set of rules jobs must satisfy before the
+	// pool creates a runner for them.
+	Policy datatypes.JSON
+
+	// HourlyCost is the cost of running a runner of this pool for one hour.
+	HourlyCost float64
+	// MaxRunnersPerRepository limits the runners of the pool a single
+	// repository may use at the same time.
+	MaxRunnersPerRepository uint
+}
+
+type PoolTemplate struct {
+	Base
+
+	Name                   string `gorm:"type:varchar(64);uniqueIndex"`
+	Description            string `gorm:"type:text"`
+	ProviderName           string
+	RunnerPrefix           string
+	MaxRunners             uint
+	MinIdleRunners         uint
+	RunnerBootstrapTimeout uint
+	Image                  string
+	Flavor                 string
+	OSType                 commonParams.OSType
+	OSArch                 commonParams.OSArch
+	Tags                   []*Tag `gorm:"many2many:pool_template_tags;constraint:OnDelete:CASCADE,OnUpdate:CASCADE;"`
+	ExtraSpecs             datatypes.JSON
+	GitHubRunnerGroup      string
+	Priority               uint
 }
 
 type Repository struct {
@@ -100,9 +170,21 @@ type Repository struct {
 	Pools            []Pool                  `gorm:"foreignKey:RepoID"`
 	Jobs             []WorkflowJob           `gorm:"foreignKey:RepoID;constraint:OnDelete:SET NULL"`
 	PoolBalancerType params.PoolBalancerType `gorm:"type:varchar(64)"`
+	ObserveOnly      bool
+	// JobScheduling is a json encoded set of settings that order the
+	// queued jobs of the entity.
+	JobScheduling datatypes.JSON
+
+	// PreviousWebhookSecret holds the secret that was in use before the last
+	// rotation. It is accepted until PreviousWebhookSecretExpiresAt.
+	PreviousWebhookSecret          []byte
+	PreviousWebhookSecretExpiresAt *time.Time
 
 	EndpointName *string        `gorm:"index:idx_owner_nocase,unique,collate:nocase"`
 	Endpoint     GithubEndpoint `gorm:"foreignKey:EndpointName;constraint:OnDelete:SET NULL"`
+
+	// KeyVersion identifies the passphrase used to seal the secrets of this row.
+	KeyVersion uint `gorm:"index"`
 }
 
 type Organization struct {
@@ -118,9 +200,21 @@ type Organization struct {
 	Pools            []Pool                  `gorm:"foreignKey:OrgID"`
 	Jobs             []WorkflowJob           `gorm:"foreignKey:OrgID;constraint:OnDelete:SET NULL"`
 	PoolBalancerType params.PoolBalancerType `gorm:"type:varchar(64)"`
+	ObserveOnly      bool
+	// JobScheduling is a json encoded set of settings that order the
+	// queued jobs of the entity.
+	JobScheduling datatypes.JSON
+
+	// PreviousWebhookSecret holds the secret that was in use before the last
+	// rotation. It is accepted until PreviousWebhookSecretExpiresAt.
+	PreviousWebhookSecret          []byte
+	PreviousWebhookSecretExpiresAt *time.Time
 
 	EndpointName *string        `gorm:"index:idx_org_name_nocase,collate:nocase"`
 	Endpoint     GithubEndpoint `gorm:"foreignKey:EndpointName;constraint:OnDelete:SET NULL"`
+
+	// KeyVersion identifies the passphrase used to seal the secrets of this row.
+	KeyVersion uint `gorm:"index"`
 }
 
 type Enterprise struct {
@@ -136,9 +230,21 @@ type Enterprise struct {
 	Pools            []Pool                  `gorm:"foreignKey:EnterpriseID"`
 	Jobs             []WorkflowJob           `gorm:"foreignKey:EnterpriseID;constraint:OnDelete:SET NULL"`
 	PoolBalancerType params.PoolBalancerType `gorm:"type:varchar(64)"`
+	ObserveOnly      bool
+	// JobScheduling is a json encoded set of settings that order the
+	// queued jobs of the entity.
+	JobScheduling datatypes.JSON
+
+	// PreviousWebhookSecret holds the secret that was in use before the last
+	// rotation. It is accepted until PreviousWebhookSecretExpiresAt.
+	PreviousWebhookSecret          []byte
+	PreviousWebhookSecretExpiresAt *time.Time
 
 	EndpointName *string        `gorm:"index:idx_ent_name_nocase,collate:nocase"`
 	Endpoint     GithubEndpoint `gorm:"foreignKey:EndpointName;constraint:OnDelete:SET NULL"`
+
+	// KeyVersion identifies the passphrase used to seal the secrets of this row.
+	KeyVersion uint `gorm:"index"`
 }
 
 type Address struct {
@@ -190,6 +296,33 @@ type Instance struct {
 	StatusMessages []InstanceStatusUpdate `gorm:"foreignKey:InstanceID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE;"`
 
 	Job *WorkflowJob `gorm:"foreignKey:InstanceID;constraint:OnDelete:CASCADE,OnUpdate:CASCADE;"`
+
+	// Draining instances are removed once they are no longer running a job.
+	Draining bool
+	// PoolGeneration is the generation of the pool when the instance was created.
+	PoolGeneration uint
+	// Adopted is set for instances that were adopted instead of created by GARM.
+	Adopted bool
+	// JobsCompleted is the number of jobs a reusable runner has completed.
+	JobsCompleted uint
+	// ConsoleOutput is the console output of the instance, captured from the
+	// provider when the instance failed.
+	ConsoleOutput           []byte `gorm:"type:longblob"`
+	ConsoleOutputCapturedAt *time.Time
+	// ErrorClass is the class of the last error the provider returned when
+	// creating the instance. Creating it is retried after NextRetryAt.
+	ErrorClass  params.ProviderErrorClass
+	NextRetryAt *time.Time
+	// The time the instance reached each lifecycle stage.
+	ProviderCreatedAt *time.Time
+	FirstCallbackAt   *time.Time
+	JitFetchedAt      *time.Time
+	RegisteredAt      *time.Time
+	JobAssignedAt     *time.Time
+	TerminatedAt      *time.Time
+
+	// KeyVersion identifies the passphrase used to seal the secrets of this row.
+	KeyVersion uint `gorm:"index"`
 }
 
 type User struct {
@@ -218,6 +351,21 @@ type ControllerInfo struct {
 	// pick up the job. GARM would allow this amount of time for runners to react
 	// before spinning up a new one and potentially having to scale down later.
 	MinimumJobAgeBackoff uint
+	// ObserveOnly makes all pool managers record the actions they would take,
+	// instead of taking them.
+	ObserveOnly bool
+	// PartitionLabel and PartitionRepositories limit the jobs this controller
+	// handles, so multiple controllers can manage the same entity.
+	PartitionLabel        string
+	PartitionRepositories datatypes.JSON
+	// HeartbeatAt is periodically updated by the running GARM server and cleared
+	// on shutdown. It is used to detect a controller that is still using the database.
+	HeartbeatAt *time.Time
+	// KeyVersion is the version of the passphrase secrets are sealed with. It
+	// is incremented when GARM starts with a new passphrase. KeyCheck is a
+	// random value sealed with that passphrase, used to detect the change.
+	KeyVersion uint
+	KeyCheck   []byte `gorm:"type:longblob"`
 }
 
 type WorkflowJob struct {
@@ -254,6 +402,15 @@ type WorkflowJob struct {
 
 	Labels datatypes.JSON
 
+	// Details of the workflow run, evaluated by pool policies.
+	HeadBranch   string `gorm:"type:text"`
+	WorkflowName string `gorm:"type:text"`
+	RunAttempt   int64
+	Actor        string
+	Event        string
+	WorkflowPath string `gorm:"type:text"`
+	PolicyDenial string `gorm:"type:text"`
+
 	// The entity that received the hook.
 	//
 	// Webhooks may be configured on the repo, the org and/or the enterprise.
@@ -302,6 +459,8 @@ type GithubCredentials struct {
 	Description string                `gorm:"type:text"`
 	AuthType    params.GithubAuthType `gorm:"index"`
 	Payload     []byte                `gorm:"type:longblob"`
+	// KeyVersion identifies the passphrase used to seal the secrets of this row.
+	KeyVersion uint `gorm:"index"`
 
 	Endpoint     GithubEndpoint `gorm:"foreignKey:EndpointName"`
 	EndpointName *string        `gorm:"index"`
@@ -310,3 +469,75 @@ type GithubCredentials struct {
 	Organizations []Organization `gorm:"foreignKey:CredentialsID"`
 	Enterprises   []Enterprise   `gorm:"foreignKey:CredentialsID"`
 }
+
+type WebhookDelivery struct {
+	// ID is the GUID GitHub sends in the X-GitHub-Delivery header.
+	ID             string `gorm:"type:varchar(64);primary_key;"`
+	HookTargetType string
+	Payload        []byte                       `gorm:"type:longblob"`
+	Status         params.WebhookDeliveryStatus `gorm:"index:idx_webhook_delivery_status_next"`
+	Attempts       int
+	LastError      string    `gorm:"type:text"`
+	NextAttemptAt  time.Time `gorm:"index:idx_webhook_delivery_status_next"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time `gorm:"index"`
+}
+
+// RunnerUsage records the time a runner was up and the cost of that time. A
+// runner has one usage row for every job it ran, covering the time since the
+// previous job, and one for the time left between its last job and its removal.
+type RunnerUsage struct {
+	ID           uint   `gorm:"primarykey"`
+	InstanceName string `gorm:"type:varchar(64);index"`
+	PoolID       string `gorm:"type:varchar(64);index"`
+
+	// The entity, pool and job are not foreign keys. Usage is kept after
+	// the runner, the pool or the entity are removed.
+	EntityType params.GithubEntityType `gorm:"type:varchar(64)"`
+	EntityID   string                  `gorm:"type:varchar(64);index"`
+	EntityName string                  `gorm:"type:varchar(255)"`
+
+	JobID           int64  `gorm:"index"`
+	RepositoryOwner string `gorm:"type:varchar(255)"`
+	RepositoryName  string `gorm:"type:varchar(255)"`
+
+	StartedAt time.Time
+	EndedAt   time.Time `gorm:"index"`
+	Seconds   float64
+	Cost      float64
+
+	CreatedAt time.Time
+}
+
+// ObservedAction is an action a pool manager in observe only mode would have
+// taken. Repeated actions update the same row.
+type ObservedAction struct {
+	ID         uint                      `gorm:"primarykey"`
+	EntityType params.GithubEntityType   `gorm:"type:varchar(64)"`
+	EntityID   string                    `gorm:"type:varchar(64);uniqueIndex:idx_observed_action"`
+	PoolID     string                    `gorm:"type:varchar(64);uniqueIndex:idx_observed_action"`
+	Action     params.ObservedActionType `gorm:"type:varchar(64);uniqueIndex:idx_observed_action"`
+	Target     string                    `gorm:"type:varchar(255);uniqueIndex:idx_observed_action"`
+	Reason     string                    `gorm:"type:text"`
+	Count      uint
+
+	CreatedAt time.Time
+	UpdatedAt time.Time `gorm:"index"`
+}
+
+// InstanceReap records why garm removed an instance on its own. The instance is
+// not a foreign key, so the reap is kept after the instance is deleted.
+type InstanceReap struct {
+	ID           uint   `gorm:"primarykey"`
+	InstanceName string `gorm:"type:varchar(64);index"`
+	PoolID       string `gorm:"type:varchar(64);index"`
+	Reason       string `gorm:"type:text"`
+	// Timeline is the timeline of the instance at the time it was reaped.
+	Timeline datatypes.JSON
+	// ConsoleOutput is the console output of the instance, captured right
+	// before it was removed.
+	ConsoleOutput []byte `gorm:"type:longblob"`
+
+	CreatedAt time.Time `gorm:"index"`
+}
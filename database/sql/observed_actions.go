@@ -0,0 +1,125 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+)
+
+var _ common.ObservedActionStore = &sqlDatabase{}
+
+func sqlToParamsObservedAction(action ObservedAction) params.ObservedAction {
+	return params.ObservedAction{
+		ID:          action.ID,
+		EntityType:  action.EntityType,
+		EntityID:    action.EntityID,
+		PoolID:      action.PoolID,
+		Action:      action.Action,
+		Target:      action.Target,
+		Reason:      action.Reason,
+		Count:       action.Count,
+		FirstSeenAt: action.CreatedAt,
+		LastSeenAt:  action.UpdatedAt,
+	}
+}
+
+// RecordObservedAction records an action the pool manager of an entity would have
+// taken. If the same action was already observed for the same pool and target, its
+// count is incremented and the reason is updated.
+func (s *sqlDatabase) RecordObservedAction(_ context.Context, entity params.GithubEntity, param params.RecordObservedActionParams) error {
+	if entity.ID == "" {
+		return runnerErrors.NewBadRequestError("missing entity ID")
+	}
+	if param.Action == "" {
+		return runnerErrors.NewBadRequestError("missing action")
+	}
+
+	err := s.conn.Transaction(func(tx *gorm.DB) error {
+		var action ObservedAction
+		q := tx.Where("entity_id = ? and pool_id = ? and action = ? and target = ?",
+			entity.ID, param.PoolID, param.Action, param.Target).First(&action)
+		if q.Error != nil {
+			if !errors.Is(q.Error, gorm.ErrRecordNotFound) {
+				return errors.Wrap(q.Error, "fetching observed action")
+			}
+			action = ObservedAction{
+				EntityType: entity.EntityType,
+				EntityID:   entity.ID,
+				PoolID:     param.PoolID,
+				Action:     param.Action,
+				Target:     param.Target,
+				Reason:     param.Reason,
+				Count:      1,
+			}
+			if err := tx.Create(&action).Error; err != nil {
+				return errors.Wrap(err, "creating observed action")
+			}
+			return nil
+		}
+
+		action.Count++
+		action.Reason = param.Reason
+		if err := tx.Save(&action).Error; err != nil {
+			return errors.Wrap(err, "updating observed action")
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "recording observed action")
+	}
+	return nil
+}
+
+// ListEntityObservedActions returns the actions observed for an entity, most recent first.
+func (s *sqlDatabase) ListEntityObservedActions(_ context.Context, entity params.GithubEntity) ([]params.ObservedAction, error) {
+	if err := s.hasGithubEntity(s.conn, entity.EntityType, entity.ID); err != nil {
+		return nil, errors.Wrap(err, "checking entity existence")
+	}
+
+	var actions []ObservedAction
+	q := s.conn.
+		Where("entity_id = ?", entity.ID).
+		Order("updated_at desc").
+		Find(&actions)
+	if q.Error != nil {
+		return nil, errors.Wrap(q.Error, "fetching observed actions")
+	}
+
+	ret := make([]params.ObservedAction, len(actions))
+	for idx, action := range actions {
+		ret[idx] = sqlToParamsObservedAction(action)
+	}
+	return ret, nil
+}
+
+// DeleteEntityObservedActions removes the actions of an entity that were last observed
+// before the given time.
+func (s *sqlDatabase) DeleteEntityObservedActions(_ context.Context, entity params.GithubEntity, olderThan time.Time) error {
+	q := s.conn.Unscoped().
+		Where("entity_id = ? and updated_at < ?", entity.ID, olderThan).
+		Delete(&ObservedAction{})
+	if q.Error != nil {
+		return errors.Wrap(q.Error, "deleting observed actions")
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
+)
+
+type ObservedActionsTestSuite struct {
+	suite.Suite
+	Store    dbCommon.Store
+	adminCtx context.Context
+	entity   params.GithubEntity
+}
+
+func (s *ObservedActionsTestSuite) SetupTest() {
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+
+	s.adminCtx = garmTesting.ImpersonateAdminContext(context.Background(), db, s.T())
+	githubEndpoint := garmTesting.CreateDefaultGithubEndpoint(s.adminCtx, db, s.T())
+	creds := garmTesting.CreateTestGithubCredentials(s.adminCtx, "new-creds", db, s.T(), githubEndpoint)
+
+	org, err := s.Store.CreateOrganization(s.adminCtx, "test-org", creds.Name, "test-webhookSecret", params.PoolBalancerTypeRoundRobin)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create org: %s", err))
+	}
+	s.entity, err = org.GetEntity()
+	s.Require().Nil(err)
+}
+
+func (s *ObservedActionsTestSuite) TestRecordObservedAction() {
+	recordParams := params.RecordObservedActionParams{
+		PoolID: "pool-1",
+		Action: params.ObservedActionDeleteRunner,
+		Target: "garm-runner",
+		Reason: "first reason",
+	}
+	s.Require().Nil(s.Store.RecordObservedAction(s.adminCtx, s.entity, recordParams))
+	recordParams.Reason = "second reason"
+	s.Require().Nil(s.Store.RecordObservedAction(s.adminCtx, s.entity, recordParams))
+	recordParams.Target = "garm-other-runner"
+	s.Require().Nil(s.Store.RecordObservedAction(s.adminCtx, s.entity, recordParams))
+
+	actions, err := s.Store.ListEntityObservedActions(s.adminCtx, s.entity)
+	s.Require().Nil(err)
+	s.Require().Len(actions, 2)
+
+	counts := map[string]params.ObservedAction{}
+	for _, action := range actions {
+		counts[action.Target] = action
+	}
+	s.Require().Equal(uint(2), counts["garm-runner"].Count)
+	s.Require().Equal("second reason", counts["garm-runner"].Reason)
+	s.Require().Equal(params.GithubEntityTypeOrganization, counts["garm-runner"].EntityType)
+	s.Require().Equal(uint(1), counts["garm-other-runner"].Count)
+}
+
+func (s *ObservedActionsTestSuite) TestRecordObservedActionMissingAction() {
+	err := s.Store.RecordObservedAction(s.adminCtx, s.entity, params.RecordObservedActionParams{})
+
+	s.Require().Equal("missing action", err.Error())
+}
+
+func (s *ObservedActionsTestSuite) TestDeleteEntityObservedActions() {
+	err := s.Store.RecordObservedAction(s.adminCtx, s.entity, params.RecordObservedActionParams{
+		Action: params.ObservedActionCreateRunner,
+		Reason: "pool needs 1 more idle runners",
+	})
+	s.Require().Nil(err)
+
+	s.Require().Nil(s.Store.DeleteEntityObservedActions(s.adminCtx, s.entity, time.Now().UTC().Add(-time.Hour)))
+	actions, err := s.Store.ListEntityObservedActions(s.adminCtx, s.entity)
+	s.Require().Nil(err)
+	s.Require().Len(actions, 1)
+
+	s.Require().Nil(s.Store.DeleteEntityObservedActions(s.adminCtx, s.entity, time.Now().UTC().Add(time.Hour)))
+	actions, err = s.Store.ListEntityObservedActions(s.adminCtx, s.entity)
+	s.Require().Nil(err)
+	s.Require().Empty(actions)
+}
+
+func (s *ObservedActionsTestSuite) TestUpdateEntityObserveOnly() {
+	observeOnly := true
+	org, err := s.Store.UpdateOrganization(s.adminCtx, s.entity.ID, params.UpdateEntityParams{ObserveOnly: &observeOnly})
+	s.Require().Nil(err)
+	s.Require().True(org.ObserveOnly)
+
+	entity, err := org.GetEntity()
+	s.Require().Nil(err)
+	s.Require().True(entity.ObserveOnly)
+}
+
+func TestObservedActionsTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ObservedActionsTestSuite))
+}
@@ -24,7 +24,6 @@ import (
 	"gorm.io/gorm"
 
 	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
-	"github.com/cloudbase/garm-provider-common/util"
 	"github.com/cloudbase/garm/database/common"
 	"github.com/cloudbase/garm/params"
 )
@@ -33,7 +32,7 @@ func (s *sqlDatabase) CreateOrganization(ctx context.Context, name, credentialsN
 	if webhookSecret == "" {
 		return params.Organization{}, errors.New("creating org: missing secret")
 	}
-	secret, err := util.Seal([]byte(webhookSecret), []byte(s.cfg.Passphrase))
+	secret, err := s.seal([]byte(webhookSecret))
 	if err != nil {
 		return params.Organization{}, errors.Wrap(err, "encoding secret")
 	}
@@ -46,6 +45,7 @@ func (s *sqlDatabase) CreateOrganization(ctx context.Context, name, credentialsN
 	newOrg := Organization{
 		Name:             name,
 		WebhookSecret:    secret,
+		KeyVersion:       s.currentKeyVersion(),
 		CredentialsName:  credentialsName,
 		PoolBalancerType: poolBalancerType,
 	}
@@ -182,17 +182,43 @@ func (s *sqlDatabase) UpdateOrganization(ctx context.Context, orgID string, para
 		}
 
 		if param.WebhookSecret != "" {
-			secret, err := util.Seal([]byte(param.WebhookSecret), []byte(s.cfg.Passphrase))
+			secret, err := s.seal([]byte(param.WebhookSecret))
 			if err != nil {
 				return fmt.Errorf("saving org: failed to encrypt string: %w", err)
 			}
+			if param.PreviousWebhookSecretExpiresAt != nil {
+				// Keep the old secret around so in-flight deliveries signed with it
+				// are still accepted until it expires.
+				previous, err := s.resealWithCurrent(org.WebhookSecret)
+				if err != nil {
+					return errors.Wrap(err, "re-encrypting previous webhook secret")
+				}
+				org.PreviousWebhookSecret = previous
+				org.PreviousWebhookSecretExpiresAt = param.PreviousWebhookSecretExpiresAt
+			} else {
+				org.PreviousWebhookSecret = nil
+				org.PreviousWebhookSecretExpiresAt = nil
+			}
 			org.WebhookSecret = secret
+			org.KeyVersion = s.currentKeyVersion()
 		}
 
 		if param.PoolBalancerType != "" {
 			org.PoolBalancerType = param.PoolBalancerType
 		}
 
+		if param.ObserveOnly != nil {
+			org.ObserveOnly = *param.ObserveOnly
+		}
+
+		if param.JobScheduling != nil {
+			scheduling, err := jobSchedulingToJSON(param.JobScheduling)
+			if err != nil {
+				return errors.Wrap(err, "encoding job scheduling")
+			}
+			org.JobScheduling = scheduling
+		}
+
 		q := tx.Save(&org)
 		if q.Error != nil {
 			return errors.Wrap(q.Error, "saving org")
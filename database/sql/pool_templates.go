@@ -0,0 +1,390 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+)
+
+var _ common.PoolTemplateStore = &sqlDatabase{}
+
+func (s *sqlDatabase) sqlToCommonPoolTemplate(tpl PoolTemplate) params.PoolTemplate {
+	ret := params.PoolTemplate{
+		ID:          tpl.ID.String(),
+		Name:        tpl.Name,
+		Description: tpl.Description,
+		RunnerPrefix: params.RunnerPrefix{
+			Prefix: tpl.RunnerPrefix,
+		},
+		ProviderName:           tpl.ProviderName,
+		MaxRunners:             tpl.MaxRunners,
+		MinIdleRunners:         tpl.MinIdleRunners,
+		RunnerBootstrapTimeout: tpl.RunnerBootstrapTimeout,
+		Image:                  tpl.Image,
+		Flavor:                 tpl.Flavor,
+		OSType:                 tpl.OSType,
+		OSArch:                 tpl.OSArch,
+		Tags:                   make([]params.Tag, len(tpl.Tags)),
+		ExtraSpecs:             json.RawMessage(tpl.ExtraSpecs),
+		GitHubRunnerGroup:      tpl.GitHubRunnerGroup,
+		Priority:               tpl.Priority,
+		CreatedAt:              tpl.CreatedAt,
+		UpdatedAt:              tpl.UpdatedAt,
+	}
+
+	for idx, val := range tpl.Tags {
+		ret.Tags[idx] = s.sqlToCommonTags(*val)
+	}
+	return ret
+}
+
+func (s *sqlDatabase) getPoolTemplate(tx *gorm.DB, templateID string) (PoolTemplate, error) {
+	u, err := uuid.Parse(templateID)
+	if err != nil {
+		return PoolTemplate{}, errors.Wrap(runnerErrors.ErrBadRequest, "parsing id")
+	}
+
+	var tpl PoolTemplate
+	q := tx.Model(&PoolTemplate{}).Preload("Tags").Where("id = ?", u).First(&tpl)
+	if q.Error != nil {
+		if errors.Is(q.Error, gorm.ErrRecordNotFound) {
+			return PoolTemplate{}, errors.Wrap(runnerErrors.ErrNotFound, "fetching pool template")
+		}
+		return PoolTemplate{}, errors.Wrap(q.Error, "fetching pool template")
+	}
+	return tpl, nil
+}
+
+func (s *sqlDatabase) poolTemplateNameExists(tx *gorm.DB, name string) (bool, error) {
+	var cnt int64
+	if err := tx.Model(&PoolTemplate{}).Where("name = ?", name).Count(&cnt).Error; err != nil {
+		return false, errors.Wrap(err, "fetching pool templates")
+	}
+	return cnt > 0, nil
+}
+
+func (s *sqlDatabase) getTags(tx *gorm.DB, names []string) ([]Tag, error) {
+	tags := []Tag{}
+	for _, val := range names {
+		t, err := s.getOrCreateTag(tx, val)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetching tag")
+		}
+		tags = append(tags, t)
+	}
+	return tags, nil
+}
+
+// mergeTemplateOverrides adds the set fields to the list of template overrides and
+// removes the reset fields from it.
+func (s *sqlDatabase) mergeTemplateOverrides(current datatypes.JSON, set, reset []string) (datatypes.JSON, error) {
+	var overrides []string
+	if len(current) > 0 {
+		if err := json.Unmarshal(current, &overrides); err != nil {
+			return nil, errors.Wrap(err, "decoding template overrides")
+		}
+	}
+
+	asMap := make(map[string]struct{}, len(overrides))
+	for _, val := range overrides {
+		asMap[val] = struct{}{}
+	}
+	for _, val := range set {
+		asMap[val] = struct{}{}
+	}
+	for _, val := range reset {
+		if !params.IsPoolTemplateField(val) {
+			return nil, runnerErrors.NewBadRequestError("invalid template field %s", val)
+		}
+		delete(asMap, val)
+	}
+
+	merged := []string{}
+	for _, val := range params.PoolTemplateFields {
+		if _, ok := asMap[val]; ok {
+			merged = append(merged, val)
+		}
+	}
+
+	asJSON, err := json.Marshal(merged)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding template overrides")
+	}
+	return datatypes.JSON(asJSON), nil
+}
+
+// syncPoolWithTemplate updates all fields of the pool that are not overridden, to
+// match the template. The pool must have its tags preloaded.
+func (s *sqlDatabase) syncPoolWithTemplate(tx *gorm.DB, pool Pool, tpl params.PoolTemplate) (params.Pool, []params.PoolTemplateChange, error) {
+	commonPool, err := s.sqlToCommonPool(pool)
+	if err != nil {
+		return params.Pool{}, nil, errors.Wrap(err, "converting pool")
+	}
+
+	updateParams, changes, err := tpl.PoolUpdateParams(commonPool)
+	if err != nil {
+		return params.Pool{}, nil, errors.Wrap(err, "computing pool changes")
+	}
+	if len(changes) == 0 {
+		return commonPool, nil, nil
+	}
+
+	updatedPool, err := s.updatePool(tx, pool, updateParams)
+	if err != nil {
+		return params.Pool{}, nil, errors.Wrap(err, "updating pool")
+	}
+	return updatedPool, changes, nil
+}
+
+func (s *sqlDatabase) CreatePoolTemplate(_ context.Context, param params.CreatePoolTemplateParams) (params.PoolTemplate, error) {
+	if err := param.Validate(); err != nil {
+		return params.PoolTemplate{}, errors.Wrap(err, "validating pool template params")
+	}
+
+	newTemplate := PoolTemplate{
+		Name:                   param.Name,
+		Description:            param.Description,
+		ProviderName:           param.ProviderName,
+		RunnerPrefix:           param.GetRunnerPrefix(),
+		MaxRunners:             param.MaxRunners,
+		MinIdleRunners:         param.MinIdleRunners,
+		RunnerBootstrapTimeout: param.RunnerBootstrapTimeout,
+		Image:                  param.Image,
+		Flavor:                 param.Flavor,
+		OSType:                 param.OSType,
+		OSArch:                 param.OSArch,
+		GitHubRunnerGroup:      param.GitHubRunnerGroup,
+		Priority:               param.Priority,
+	}
+	if len(param.ExtraSpecs) > 0 {
+		newTemplate.ExtraSpecs = datatypes.JSON(param.ExtraSpecs)
+	}
+
+	err := s.conn.Transaction(func(tx *gorm.DB) error {
+		exists, err := s.poolTemplateNameExists(tx, param.Name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.Wrap(runnerErrors.ErrDuplicateEntity, "pool template already exists")
+		}
+
+		tags, err := s.getTags(tx, param.Tags)
+		if err != nil {
+			return errors.Wrap(err, "creating tags")
+		}
+
+		if err := tx.Create(&newTemplate).Error; err != nil {
+			return errors.Wrap(err, "creating pool template")
+		}
+
+		if err := tx.Model(&newTemplate).Association("Tags").Append(&tags); err != nil {
+			return errors.Wrap(err, "associating tags")
+		}
+		return nil
+	})
+	if err != nil {
+		return params.PoolTemplate{}, errors.Wrap(err, "creating pool template")
+	}
+
+	tpl, err := s.getPoolTemplate(s.conn, newTemplate.ID.String())
+	if err != nil {
+		return params.PoolTemplate{}, errors.Wrap(err, "fetching pool template")
+	}
+	return s.sqlToCommonPoolTemplate(tpl), nil
+}
+
+func (s *sqlDatabase) GetPoolTemplate(_ context.Context, templateID string) (params.PoolTemplate, error) {
+	tpl, err := s.getPoolTemplate(s.conn, templateID)
+	if err != nil {
+		return params.PoolTemplate{}, errors.Wrap(err, "fetching pool template")
+	}
+	return s.sqlToCommonPoolTemplate(tpl), nil
+}
+
+func (s *sqlDatabase) ListPoolTemplates(_ context.Context) ([]params.PoolTemplate, error) {
+	var templates []PoolTemplate
+	q := s.conn.Model(&PoolTemplate{}).Preload("Tags").Order("name asc").Find(&templates)
+	if q.Error != nil {
+		return nil, errors.Wrap(q.Error, "fetching pool templates")
+	}
+
+	ret := make([]params.PoolTemplate, len(templates))
+	for idx, val := range templates {
+		ret[idx] = s.sqlToCommonPoolTemplate(val)
+	}
+	return ret, nil
+}
+
+func (s *sqlDatabase) UpdatePoolTemplate(_ context.Context, templateID string, param params.UpdatePoolTemplateParams) (params.PoolTemplate, []params.PoolTemplateChange, error) {
+	if err := param.Validate(); err != nil {
+		return params.PoolTemplate{}, nil, errors.Wrap(err, "validating pool template params")
+	}
+
+	var updatedTemplate params.PoolTemplate
+	var changes []params.PoolTemplateChange
+	var updatedPools []params.Pool
+	err := s.conn.Transaction(func(tx *gorm.DB) error {
+		tpl, err := s.getPoolTemplate(tx, templateID)
+		if err != nil {
+			return errors.Wrap(err, "fetching pool template")
+		}
+
+		newValues, err := param.Apply(s.sqlToCommonPoolTemplate(tpl))
+		if err != nil {
+			return errors.Wrap(err, "applying pool template changes")
+		}
+
+		if newValues.Name != tpl.Name {
+			exists, err := s.poolTemplateNameExists(tx, newValues.Name)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return errors.Wrap(runnerErrors.ErrDuplicateEntity, "pool template already exists")
+			}
+		}
+
+		tpl.Name = newValues.Name
+		tpl.Description = newValues.Description
+		tpl.RunnerPrefix = newValues.GetRunnerPrefix()
+		tpl.MaxRunners = newValues.MaxRunners
+		tpl.MinIdleRunners = newValues.MinIdleRunners
+		tpl.RunnerBootstrapTimeout = newValues.RunnerBootstrapTimeout
+		tpl.Image = newValues.Image
+		tpl.Flavor = newValues.Flavor
+		tpl.OSType = newValues.OSType
+		tpl.OSArch = newValues.OSArch
+		tpl.ExtraSpecs = datatypes.JSON(newValues.ExtraSpecs)
+		tpl.GitHubRunnerGroup = newValues.GitHubRunnerGroup
+		tpl.Priority = newValues.Priority
+
+		if err := tx.Omit("Tags").Save(&tpl).Error; err != nil {
+			return errors.Wrap(err, "saving pool template")
+		}
+
+		if len(param.Tags) > 0 {
+			tags, err := s.getTags(tx, param.Tags)
+			if err != nil {
+				return errors.Wrap(err, "creating tags")
+			}
+			if err := tx.Model(&tpl).Association("Tags").Replace(&tags); err != nil {
+				return errors.Wrap(err, "replacing tags")
+			}
+		}
+
+		tpl, err = s.getPoolTemplate(tx, templateID)
+		if err != nil {
+			return errors.Wrap(err, "fetching pool template")
+		}
+		updatedTemplate = s.sqlToCommonPoolTemplate(tpl)
+
+		var pools []Pool
+		if err := tx.Model(&Pool{}).Preload("Tags").Where("template_id = ?", tpl.ID).Find(&pools).Error; err != nil {
+			return errors.Wrap(err, "fetching pools")
+		}
+
+		for _, pool := range pools {
+			updatedPool, poolChanges, err := s.syncPoolWithTemplate(tx, pool, updatedTemplate)
+			if err != nil {
+				return errors.Wrap(err, "syncing pool with template")
+			}
+			if len(poolChanges) > 0 {
+				changes = append(changes, poolChanges...)
+				updatedPools = append(updatedPools, updatedPool)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return params.PoolTemplate{}, nil, errors.Wrap(err, "updating pool template")
+	}
+
+	for _, pool := range updatedPools {
+		s.sendNotify(common.PoolEntityType, common.UpdateOperation, pool)
+	}
+	return updatedTemplate, changes, nil
+}
+
+func (s *sqlDatabase) DeletePoolTemplate(_ context.Context, templateID string) error {
+	err := s.conn.Transaction(func(tx *gorm.DB) error {
+		tpl, err := s.getPoolTemplate(tx, templateID)
+		if err != nil {
+			if errors.Is(err, runnerErrors.ErrNotFound) {
+				return nil
+			}
+			return errors.Wrap(err, "fetching pool template")
+		}
+
+		var poolCnt int64
+		if err := tx.Model(&Pool{}).Where("template_id = ?", tpl.ID).Count(&poolCnt).Error; err != nil {
+			return errors.Wrap(err, "fetching pools")
+		}
+		if poolCnt > 0 {
+			return runnerErrors.NewBadRequestError("pool template is used by %d pools", poolCnt)
+		}
+
+		if err := tx.Model(&tpl).Association("Tags").Clear(); err != nil {
+			return errors.Wrap(err, "removing tags")
+		}
+
+		if err := tx.Unscoped().Delete(&tpl).Error; err != nil {
+			return errors.Wrap(err, "deleting pool template")
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "deleting pool template")
+	}
+	return nil
+}
+
+func (s *sqlDatabase) ListPoolTemplatePools(_ context.Context, templateID string) ([]params.Pool, error) {
+	tpl, err := s.getPoolTemplate(s.conn, templateID)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching pool template")
+	}
+
+	var pools []Pool
+	q := s.conn.Model(&Pool{}).
+		Preload("Tags").
+		Preload("Organization").
+		Preload("Repository").
+		Preload("Enterprise").
+		Where("template_id = ?", tpl.ID).
+		Find(&pools)
+	if q.Error != nil {
+		return nil, errors.Wrap(q.Error, "fetching pools")
+	}
+
+	ret := make([]params.Pool, len(pools))
+	for idx, val := range pools {
+		ret[idx], err = s.sqlToCommonPool(val)
+		if err != nil {
+			return nil, errors.Wrap(err, "converting pool")
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,310 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
+)
+
+type PoolTemplatesTestSuite struct {
+	suite.Suite
+	Store    dbCommon.Store
+	adminCtx context.Context
+	entity   params.GithubEntity
+}
+
+func (s *PoolTemplatesTestSuite) SetupTest() {
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+	s.adminCtx = garmTesting.ImpersonateAdminContext(context.Background(), db, s.T())
+
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(s.adminCtx, db, s.T())
+	creds := garmTesting.CreateTestGithubCredentials(s.adminCtx, "test-creds", db, s.T(), endpoint)
+	org, err := db.CreateOrganization(s.adminCtx, "test-org", creds.Name, "test-webhookSecret", params.PoolBalancerTypeRoundRobin)
+	s.Require().Nil(err)
+	s.entity, err = org.GetEntity()
+	s.Require().Nil(err)
+}
+
+func (s *PoolTemplatesTestSuite) createTemplate(name string) params.PoolTemplate {
+	tpl, err := s.Store.CreatePoolTemplate(s.adminCtx, params.CreatePoolTemplateParams{
+		Name:                   name,
+		ProviderName:           "test-provider",
+		MaxRunners:             4,
+		MinIdleRunners:         1,
+		Image:                  "ubuntu:22.04",
+		Flavor:                 "small",
+		OSType:                 "linux",
+		OSArch:                 "amd64",
+		Tags:                   []string{"self-hosted", "linux"},
+		RunnerBootstrapTimeout: 20,
+	})
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create pool template: %s", err))
+	}
+	return tpl
+}
+
+func (s *PoolTemplatesTestSuite) createPoolFromTemplate(tpl params.PoolTemplate, param params.CreatePoolParams) params.Pool {
+	if err := param.ApplyTemplate(tpl); err != nil {
+		s.FailNow(fmt.Sprintf("failed to apply pool template: %s", err))
+	}
+	pool, err := s.Store.CreateEntityPool(s.adminCtx, s.entity, param)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create pool: %s", err))
+	}
+	return pool
+}
+
+func (s *PoolTemplatesTestSuite) TestCreatePoolTemplate() {
+	tpl := s.createTemplate("linux-small")
+
+	s.Require().Equal("linux-small", tpl.Name)
+	s.Require().Equal("ubuntu:22.04", tpl.Image)
+	s.Require().Len(tpl.Tags, 2)
+}
+
+func (s *PoolTemplatesTestSuite) TestCreatePoolTemplateDuplicateName() {
+	s.createTemplate("linux-small")
+
+	_, err := s.Store.CreatePoolTemplate(s.adminCtx, params.CreatePoolTemplateParams{
+		Name:         "linux-small",
+		ProviderName: "test-provider",
+		MaxRunners:   1,
+		Image:        "ubuntu:22.04",
+		Flavor:       "small",
+		Tags:         []string{"self-hosted"},
+	})
+
+	s.Require().ErrorIs(err, runnerErrors.ErrDuplicateEntity)
+}
+
+func (s *PoolTemplatesTestSuite) TestGetPoolTemplateNotFound() {
+	_, err := s.Store.GetPoolTemplate(s.adminCtx, "7f1a8b41-7c4b-4c3c-bb0e-9f1e32c3b7b1")
+
+	s.Require().ErrorIs(err, runnerErrors.ErrNotFound)
+}
+
+func (s *PoolTemplatesTestSuite) TestListPoolTemplates() {
+	s.createTemplate("b-template")
+	s.createTemplate("a-template")
+
+	templates, err := s.Store.ListPoolTemplates(s.adminCtx)
+
+	s.Require().Nil(err)
+	s.Require().Len(templates, 2)
+	s.Require().Equal("a-template", templates[0].Name)
+}
+
+func (s *PoolTemplatesTestSuite) TestCreatePoolFromTemplateRecordsOverrides() {
+	tpl := s.createTemplate("linux-small")
+
+	pool := s.createPoolFromTemplate(tpl, params.CreatePoolParams{Flavor: "large"})
+
+	s.Require().Equal(tpl.ID, pool.TemplateID)
+	s.Require().Equal("ubuntu:22.04", pool.Image)
+	s.Require().Equal("large", pool.Flavor)
+	s.Require().Equal([]string{params.PoolTemplateFieldFlavor}, pool.TemplateOverrides)
+}
+
+func (s *PoolTemplatesTestSuite) TestUpdatePoolTemplatePropagatesToPools() {
+	tpl := s.createTemplate("linux-small")
+	inherited := s.createPoolFromTemplate(tpl, params.CreatePoolParams{})
+	overridden := s.createPoolFromTemplate(tpl, params.CreatePoolParams{Image: "ubuntu:20.04"})
+
+	updated, changes, err := s.Store.UpdatePoolTemplate(s.adminCtx, tpl.ID, params.UpdatePoolTemplateParams{
+		Image: "ubuntu:24.04",
+		Tags:  []string{"self-hosted", "linux", "noble"},
+	})
+
+	s.Require().Nil(err)
+	s.Require().Equal("ubuntu:24.04", updated.Image)
+	s.Require().Len(updated.Tags, 3)
+	s.Require().Len(changes, 3)
+
+	pool, err := s.Store.GetPoolByID(s.adminCtx, inherited.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("ubuntu:24.04", pool.Image)
+	s.Require().Len(pool.Tags, 3)
+
+	pool, err = s.Store.GetPoolByID(s.adminCtx, overridden.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("ubuntu:20.04", pool.Image)
+	s.Require().Len(pool.Tags, 3)
+}
+
+func (s *PoolTemplatesTestSuite) TestUpdatePoolTemplateInvalidForPool() {
+	tpl := s.createTemplate("linux-small")
+	s.createPoolFromTemplate(tpl, params.CreatePoolParams{MaxRunners: 2})
+
+	minIdleRunners := uint(3)
+	_, _, err := s.Store.UpdatePoolTemplate(s.adminCtx, tpl.ID, params.UpdatePoolTemplateParams{
+		MinIdleRunners: &minIdleRunners,
+	})
+
+	s.Require().NotNil(err)
+	tpl, err = s.Store.GetPoolTemplate(s.adminCtx, tpl.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(uint(1), tpl.MinIdleRunners)
+}
+
+// requireIdleRunnersRejected checks that a template can not give idle runners to
+// a pool created from it with the given params.
+func (s *PoolTemplatesTestSuite) requireIdleRunnersRejected(param params.CreatePoolParams, expectedErr string) {
+	tpl := s.createTemplate("linux-small")
+	minIdleRunners := uint(0)
+	tpl, _, err := s.Store.UpdatePoolTemplate(s.adminCtx, tpl.ID, params.UpdatePoolTemplateParams{
+		MinIdleRunners: &minIdleRunners,
+	})
+	s.Require().Nil(err)
+	s.createPoolFromTemplate(tpl, param)
+
+	minIdleRunners = 1
+	_, _, err = s.Store.UpdatePoolTemplate(s.adminCtx, tpl.ID, params.UpdatePoolTemplateParams{
+		MinIdleRunners: &minIdleRunners,
+	})
+
+	s.Require().ErrorContains(err, expectedErr)
+	tpl, err = s.Store.GetPoolTemplate(s.adminCtx, tpl.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(uint(0), tpl.MinIdleRunners)
+}
+
+func (s *PoolTemplatesTestSuite) TestUpdatePoolTemplateIdleRunnersForPoolWithPolicy() {
+	s.requireIdleRunnersRejected(params.CreatePoolParams{
+		Policy: &params.PoolPolicy{Branches: []string{"main"}},
+	}, "min_idle_runners must be 0 for pools with a policy")
+}
+
+func (s *PoolTemplatesTestSuite) TestUpdatePoolTemplateIdleRunnersForPoolWithRepositoryFilters() {
+	s.requireIdleRunnersRejected(params.CreatePoolParams{
+		IncludedRepositories: []string{"test-org/*"},
+	}, "min_idle_runners must be 0 for pools with repository filters")
+}
+
+func (s *PoolTemplatesTestSuite) TestApplyTemplateOtherProvider() {
+	tpl := s.createTemplate("linux-small")
+
+	param := params.CreatePoolParams{ProviderName: "other-provider"}
+	err := param.ApplyTemplate(tpl)
+
+	s.Require().NotNil(err)
+	s.Require().Empty(param.TemplateID)
+}
+
+func (s *PoolTemplatesTestSuite) TestUpdateEntityPoolAddsOverride() {
+	tpl := s.createTemplate("linux-small")
+	pool := s.createPoolFromTemplate(tpl, params.CreatePoolParams{})
+
+	pool, err := s.Store.UpdateEntityPool(s.adminCtx, s.entity, pool.ID, params.UpdatePoolParams{
+		Image: "custom-image",
+	})
+
+	s.Require().Nil(err)
+	s.Require().Equal([]string{params.PoolTemplateFieldImage}, pool.TemplateOverrides)
+
+	_, _, err = s.Store.UpdatePoolTemplate(s.adminCtx, tpl.ID, params.UpdatePoolTemplateParams{
+		Image: "ubuntu:24.04",
+	})
+	s.Require().Nil(err)
+
+	pool, err = s.Store.GetPoolByID(s.adminCtx, pool.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("custom-image", pool.Image)
+}
+
+func (s *PoolTemplatesTestSuite) TestUpdateEntityPoolResetOverride() {
+	tpl := s.createTemplate("linux-small")
+	pool := s.createPoolFromTemplate(tpl, params.CreatePoolParams{Image: "custom-image"})
+
+	pool, err := s.Store.UpdateEntityPool(s.adminCtx, s.entity, pool.ID, params.UpdatePoolParams{
+		ResetTemplateOverrides: []string{params.PoolTemplateFieldImage},
+	})
+
+	s.Require().Nil(err)
+	s.Require().Empty(pool.TemplateOverrides)
+	s.Require().Equal("ubuntu:22.04", pool.Image)
+}
+
+func (s *PoolTemplatesTestSuite) TestUpdateEntityPoolResetOverrideWithoutTemplate() {
+	pool, err := s.Store.CreateEntityPool(s.adminCtx, s.entity, params.CreatePoolParams{
+		ProviderName: "test-provider",
+		MaxRunners:   4,
+		Image:        "ubuntu:22.04",
+		Flavor:       "small",
+		OSType:       "linux",
+		Tags:         []string{"self-hosted"},
+	})
+	s.Require().Nil(err)
+
+	_, err = s.Store.UpdateEntityPool(s.adminCtx, s.entity, pool.ID, params.UpdatePoolParams{
+		ResetTemplateOverrides: []string{params.PoolTemplateFieldImage},
+	})
+
+	var badRequest *runnerErrors.BadRequestError
+	s.Require().ErrorAs(err, &badRequest)
+}
+
+func (s *PoolTemplatesTestSuite) TestDeletePoolTemplateInUse() {
+	tpl := s.createTemplate("linux-small")
+	pool := s.createPoolFromTemplate(tpl, params.CreatePoolParams{})
+
+	err := s.Store.DeletePoolTemplate(s.adminCtx, tpl.ID)
+	var badRequest *runnerErrors.BadRequestError
+	s.Require().ErrorAs(err, &badRequest)
+
+	s.Require().Nil(s.Store.DeleteEntityPool(s.adminCtx, s.entity, pool.ID))
+	s.Require().Nil(s.Store.DeletePoolTemplate(s.adminCtx, tpl.ID))
+
+	_, err = s.Store.GetPoolTemplate(s.adminCtx, tpl.ID)
+	s.Require().ErrorIs(err, runnerErrors.ErrNotFound)
+}
+
+func (s *PoolTemplatesTestSuite) TestListPoolTemplatePools() {
+	tpl := s.createTemplate("linux-small")
+	pool := s.createPoolFromTemplate(tpl, params.CreatePoolParams{})
+	_, err := s.Store.CreateEntityPool(s.adminCtx, s.entity, params.CreatePoolParams{
+		ProviderName: "test-provider",
+		MaxRunners:   4,
+		Image:        "ubuntu:22.04",
+		Flavor:       "small",
+		OSType:       "linux",
+		Tags:         []string{"self-hosted"},
+	})
+	s.Require().Nil(err)
+
+	pools, err := s.Store.ListPoolTemplatePools(s.adminCtx, tpl.ID)
+
+	s.Require().Nil(err)
+	s.Require().Len(pools, 1)
+	s.Require().Equal(pool.ID, pools[0].ID)
+}
+
+func TestPoolTemplatesTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PoolTemplatesTestSuite))
+}
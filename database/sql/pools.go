@@ -16,6 +16,7 @@ package sql
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -278,11 +279,42 @@ func (s *sqlDatabase) CreateEntityPool(_ context.Context, entity params.GithubEn
 		RunnerBootstrapTimeout: param.RunnerBootstrapTimeout,
 		GitHubRunnerGroup:      param.GitHubRunnerGroup,
 		Priority:               param.Priority,
+		Reusable:               param.Reusable,
+		RunnerMaxJobs:          param.RunnerMaxJobs,
+		RunnerMaxLifetime:      param.RunnerMaxLifetime,
+		JobCompletedHook:       param.JobCompletedHook,
+		HourlyCost:             param.HourlyCost,
+
+		MaxRunnersPerRepository: param.MaxRunnersPerRepository,
+	}
+	newPool.RolloutMaxSurge, newPool.RolloutMaxUnavailable = param.RolloutLimits()
+	if newPool.IncludedRepositories, err = repositoryPatternsToJSON(param.IncludedRepositories); err != nil {
+		return params.Pool{}, errors.Wrap(err, "encoding included repositories")
+	}
+	if newPool.ExcludedRepositories, err = repositoryPatternsToJSON(param.ExcludedRepositories); err != nil {
+		return params.Pool{}, errors.Wrap(err, "encoding excluded repositories")
+	}
+	if newPool.Policy, err = poolPolicyToJSON(param.Policy); err != nil {
+		return params.Pool{}, errors.Wrap(err, "encoding policy")
 	}
 	if len(param.ExtraSpecs) > 0 {
 		newPool.ExtraSpecs = datatypes.JSON(param.ExtraSpecs)
 	}
 
+	if param.TemplateID != "" {
+		templateID, err := uuid.Parse(param.TemplateID)
+		if err != nil {
+			return params.Pool{}, errors.Wrap(runnerErrors.ErrBadRequest, "parsing template id")
+		}
+		newPool.TemplateID = &templateID
+
+		overrides, err := json.Marshal(param.TemplateOverrides)
+		if err != nil {
+			return params.Pool{}, errors.Wrap(err, "encoding template overrides")
+		}
+		newPool.TemplateOverrides = datatypes.JSON(overrides)
+	}
+
 	entityID, err := uuid.Parse(entity.ID)
 	if err != nil {
 		return params.Pool{}, errors.Wrap(runnerErrors.ErrBadRequest, "parsing id")
@@ -301,6 +333,12 @@ func (s *sqlDatabase) CreateEntityPool(_ context.Context, entity params.GithubEn
 			return errors.Wrap(err, "checking entity existence")
 		}
 
+		if newPool.TemplateID != nil {
+			if _, err := s.getPoolTemplate(tx, newPool.TemplateID.String()); err != nil {
+				return errors.Wrap(err, "fetching pool template")
+			}
+		}
+
 		tags := []Tag{}
 		for _, val := range param.Tags {
 			t, err := s.getOrCreateTag(tx, val)
@@ -391,10 +429,43 @@ func (s *sqlDatabase) UpdateEntityPool(_ context.Context, entity params.GithubEn
 			return errors.Wrap(err, "fetching pool")
 		}
 
+		if pool.TemplateID == nil {
+			if len(param.ResetTemplateOverrides) > 0 {
+				return runnerErrors.NewBadRequestError("pool is not derived from a template")
+			}
+			updatedPool, err = s.updatePool(tx, pool, param)
+			if err != nil {
+				return errors.Wrap(err, "updating pool")
+			}
+			return nil
+		}
+
+		// Fields explicitly set on a pool derived from a template are no longer
+		// kept in sync with the template, unless they are reset.
+		pool.TemplateOverrides, err = s.mergeTemplateOverrides(pool.TemplateOverrides, param.TemplateFields(), param.ResetTemplateOverrides)
+		if err != nil {
+			return errors.Wrap(err, "updating template overrides")
+		}
+
 		updatedPool, err = s.updatePool(tx, pool, param)
 		if err != nil {
 			return errors.Wrap(err, "updating pool")
 		}
+
+		if len(param.ResetTemplateOverrides) > 0 {
+			tpl, err := s.getPoolTemplate(tx, pool.TemplateID.String())
+			if err != nil {
+				return errors.Wrap(err, "fetching pool template")
+			}
+			pool, err = s.getEntityPool(tx, entity.EntityType, entity.ID, poolID, "Tags", "Instances")
+			if err != nil {
+				return errors.Wrap(err, "fetching pool")
+			}
+			updatedPool, _, err = s.syncPoolWithTemplate(tx, pool, s.sqlToCommonPoolTemplate(tpl))
+			if err != nil {
+				return errors.Wrap(err, "syncing pool with template")
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
@@ -137,7 +138,7 @@ func (s *PoolsTestSuite) TestListAllPools() {
 
 func (s *PoolsTestSuite) TestListAllPoolsDBFetchErr() {
 	s.Fixtures.SQLMock.
-		ExpectQuery(regexp.QuoteMeta("SELECT `pools`.`id`,`pools`.`created_at`,`pools`.`updated_at`,`pools`.`deleted_at`,`pools`.`provider_name`,`pools`.`runner_prefix`,`pools`.`max_runners`,`pools`.`min_idle_runners`,`pools`.`runner_bootstrap_timeout`,`pools`.`image`,`pools`.`flavor`,`pools`.`os_type`,`pools`.`os_arch`,`pools`.`enabled`,`pools`.`git_hub_runner_group`,`pools`.`repo_id`,`pools`.`org_id`,`pools`.`enterprise_id`,`pools`.`priority` FROM `pools` WHERE `pools`.`deleted_at` IS NULL")).
+		ExpectQuery(regexp.QuoteMeta("SELECT `pools`.`id`,`pools`.`created_at`,`pools`.`updated_at`,`pools`.`deleted_at`,`pools`.`provider_name`,`pools`.`runner_prefix`,`pools`.`max_runners`,`pools`.`min_idle_runners`,`pools`.`runner_bootstrap_timeout`,`pools`.`image`,`pools`.`flavor`,`pools`.`os_type`,`pools`.`os_arch`,`pools`.`enabled`,`pools`.`git_hub_runner_group`,`pools`.`repo_id`,`pools`.`org_id`,`pools`.`enterprise_id`,`pools`.`priority`,`pools`.`template_id`,`pools`.`template_overrides`,`pools`.`cordoned`,`pools`.`recycle_requested_at`,`pools`.`recycle_max_unavailable`,`pools`.`generation`,`pools`.`rollout_max_surge`,`pools`.`rollout_max_unavailable`,`pools`.`reusable`,`pools`.`runner_max_jobs`,`pools`.`runner_max_lifetime`,`pools`.`job_completed_hook`,`pools`.`provider_failure_class`,`pools`.`provider_failure_reason`,`pools`.`provider_failure_at`,`pools`.`included_repositories`,`pools`.`excluded_repositories`,`pools`.`policy`,`pools`.`hourly_cost`,`pools`.`max_runners_per_repository` FROM `pools` WHERE `pools`.`deleted_at` IS NULL")).
 		WillReturnError(fmt.Errorf("mocked fetching all pools error"))
 
 	_, err := s.StoreSQLMocked.ListAllPools(s.adminCtx)
@@ -194,6 +195,82 @@ func (s *PoolsTestSuite) TestDeletePoolByIDDBRemoveErr() {
 	s.Require().Equal("removing pool: mocked removing pool error", err.Error())
 }
 
+func (s *PoolsTestSuite) TestUpdatePoolBumpsGeneration() {
+	pool := s.Fixtures.Pools[0]
+	s.Require().Equal(uint(1), pool.RolloutMaxSurge)
+	s.Require().Equal(uint(0), pool.RolloutMaxUnavailable)
+
+	instance, err := s.Store.CreateInstance(s.adminCtx, pool.ID, params.CreateInstanceParams{Name: "old-runner"})
+	s.Require().Nil(err)
+	s.Require().Equal(uint(0), instance.PoolGeneration)
+
+	entity, err := s.Fixtures.Org.GetEntity()
+	s.Require().Nil(err)
+	// Setting the same image does not start a new generation.
+	updated, err := s.Store.UpdateEntityPool(s.adminCtx, entity, pool.ID, params.UpdatePoolParams{Image: pool.Image})
+	s.Require().Nil(err)
+	s.Require().Equal(uint(0), updated.Generation)
+
+	updated, err = s.Store.UpdateEntityPool(s.adminCtx, entity, pool.ID, params.UpdatePoolParams{Image: "new-image"})
+	s.Require().Nil(err)
+	s.Require().Equal(uint(1), updated.Generation)
+
+	instance, err = s.Store.CreateInstance(s.adminCtx, pool.ID, params.CreateInstanceParams{Name: "new-runner"})
+	s.Require().Nil(err)
+	s.Require().Equal(uint(1), instance.PoolGeneration)
+
+	fetched, err := s.Store.GetPoolByID(s.adminCtx, pool.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(uint(1), fetched.OutdatedRunners)
+}
+
+func (s *PoolsTestSuite) TestUpdatePoolProviderFailure() {
+	pool := s.Fixtures.Pools[0]
+	entity, err := s.Fixtures.Org.GetEntity()
+	s.Require().Nil(err)
+
+	failedAt := time.Now().UTC()
+	updated, err := s.Store.UpdateEntityPool(s.adminCtx, entity, pool.ID, params.UpdatePoolParams{
+		ProviderFailure: &params.PoolProviderFailure{
+			Class:  params.ProviderErrorImageNotFound,
+			Reason: "image not found",
+			At:     failedAt,
+		},
+	})
+	s.Require().Nil(err)
+	s.Require().True(updated.ProviderFailed())
+	s.Require().Equal("image not found", updated.ProviderFailureReason)
+	s.Require().NotNil(updated.ProviderFailureAt)
+
+	clearFailure := true
+	updated, err = s.Store.UpdateEntityPool(s.adminCtx, entity, pool.ID, params.UpdatePoolParams{ClearProviderFailure: &clearFailure})
+	s.Require().Nil(err)
+	s.Require().False(updated.ProviderFailed())
+	s.Require().Empty(updated.ProviderFailureReason)
+	s.Require().Nil(updated.ProviderFailureAt)
+}
+
+func (s *PoolsTestSuite) TestUpdatePoolRepositoryFilters() {
+	pool := s.Fixtures.Pools[0]
+	entity, err := s.Fixtures.Org.GetEntity()
+	s.Require().Nil(err)
+
+	updated, err := s.Store.UpdateEntityPool(s.adminCtx, entity, pool.ID, params.UpdatePoolParams{
+		IncludedRepositories: []string{"org/*"},
+		ExcludedRepositories: []string{"org/fork-*"},
+	})
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"org/*"}, updated.IncludedRepositories)
+	s.Require().Equal([]string{"org/fork-*"}, updated.ExcludedRepositories)
+
+	updated, err = s.Store.UpdateEntityPool(s.adminCtx, entity, pool.ID, params.UpdatePoolParams{
+		ExcludedRepositories: []string{},
+	})
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"org/*"}, updated.IncludedRepositories)
+	s.Require().Empty(updated.ExcludedRepositories)
+}
+
 func TestPoolsTestSuite(t *testing.T) {
 	t.Parallel()
 	suite.Run(t, new(PoolsTestSuite))
@@ -24,7 +24,6 @@ import (
 	"gorm.io/gorm"
 
 	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
-	"github.com/cloudbase/garm-provider-common/util"
 	"github.com/cloudbase/garm/database/common"
 	"github.com/cloudbase/garm/params"
 )
@@ -39,7 +38,7 @@ func (s *sqlDatabase) CreateRepository(ctx context.Context, owner, name, credent
 	if webhookSecret == "" {
 		return params.Repository{}, errors.New("creating repo: missing secret")
 	}
-	secret, err := util.Seal([]byte(webhookSecret), []byte(s.cfg.Passphrase))
+	secret, err := s.seal([]byte(webhookSecret))
 	if err != nil {
 		return params.Repository{}, fmt.Errorf("failed to encrypt string")
 	}
@@ -48,6 +47,7 @@ func (s *sqlDatabase) CreateRepository(ctx context.Context, owner, name, credent
 		Name:             name,
 		Owner:            owner,
 		WebhookSecret:    secret,
+		KeyVersion:       s.currentKeyVersion(),
 		PoolBalancerType: poolBalancerType,
 	}
 	err = s.conn.Transaction(func(tx *gorm.DB) error {
@@ -181,17 +181,43 @@ func (s *sqlDatabase) UpdateRepository(ctx context.Context, repoID string, param
 		}
 
 		if param.WebhookSecret != "" {
-			secret, err := util.Seal([]byte(param.WebhookSecret), []byte(s.cfg.Passphrase))
+			secret, err := s.seal([]byte(param.WebhookSecret))
 			if err != nil {
 				return fmt.Errorf("saving repo: failed to encrypt string: %w", err)
 			}
+			if param.PreviousWebhookSecretExpiresAt != nil {
+				// Keep the old secret around so in-flight deliveries signed with it
+				// are still accepted until it expires.
+				previous, err := s.resealWithCurrent(repo.WebhookSecret)
+				if err != nil {
+					return errors.Wrap(err, "re-encrypting previous webhook secret")
+				}
+				repo.PreviousWebhookSecret = previous
+				repo.PreviousWebhookSecretExpiresAt = param.PreviousWebhookSecretExpiresAt
+			} else {
+				repo.PreviousWebhookSecret = nil
+				repo.PreviousWebhookSecretExpiresAt = nil
+			}
 			repo.WebhookSecret = secret
+			repo.KeyVersion = s.currentKeyVersion()
 		}
 
 		if param.PoolBalancerType != "" {
 			repo.PoolBalancerType = param.PoolBalancerType
 		}
 
+		if param.ObserveOnly != nil {
+			repo.ObserveOnly = *param.ObserveOnly
+		}
+
+		if param.JobScheduling != nil {
+			scheduling, err := jobSchedulingToJSON(param.JobScheduling)
+			if err != nil {
+				return errors.Wrap(err, "encoding job scheduling")
+			}
+			repo.JobScheduling = scheduling
+		}
+
 		q := tx.Save(&repo)
 		if q.Error != nil {
 			return errors.Wrap(q.Error, "saving repo")
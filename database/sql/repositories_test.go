@@ -21,6 +21,7 @@ import (
 	"regexp"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
@@ -387,6 +388,58 @@ func (s *RepoTestSuite) TestUpdateRepository() {
 	s.Require().Equal(s.Fixtures.UpdateRepoParams.WebhookSecret, repo.WebhookSecret)
 }
 
+func (s *RepoTestSuite) TestUpdateRepositoryRotateWebhookSecret() {
+	expiresAt := time.Now().UTC().Add(1 * time.Hour)
+	param := params.UpdateEntityParams{
+		WebhookSecret:                  "rotated-webhook-secret",
+		PreviousWebhookSecretExpiresAt: &expiresAt,
+	}
+
+	repo, err := s.Store.UpdateRepository(s.adminCtx, s.Fixtures.Repos[0].ID, param)
+
+	s.Require().Nil(err)
+	s.Require().Equal(param.WebhookSecret, repo.WebhookSecret)
+	s.Require().Equal(s.Fixtures.Repos[0].WebhookSecret, repo.PreviousWebhookSecret)
+	s.Require().NotNil(repo.PreviousWebhookSecretExpiresAt)
+
+	entity, err := repo.GetEntity()
+	s.Require().Nil(err)
+	s.Require().Equal([]string{param.WebhookSecret, s.Fixtures.Repos[0].WebhookSecret}, entity.WebhookSecrets())
+}
+
+func (s *RepoTestSuite) TestUpdateRepositoryPreviousWebhookSecretExpired() {
+	expiresAt := time.Now().UTC().Add(-1 * time.Minute)
+	param := params.UpdateEntityParams{
+		WebhookSecret:                  "rotated-webhook-secret",
+		PreviousWebhookSecretExpiresAt: &expiresAt,
+	}
+
+	repo, err := s.Store.UpdateRepository(s.adminCtx, s.Fixtures.Repos[0].ID, param)
+
+	s.Require().Nil(err)
+	s.Require().Equal(param.WebhookSecret, repo.WebhookSecret)
+	s.Require().Equal("", repo.PreviousWebhookSecret)
+	s.Require().Nil(repo.PreviousWebhookSecretExpiresAt)
+}
+
+func (s *RepoTestSuite) TestUpdateRepositoryWebhookSecretClearsPrevious() {
+	expiresAt := time.Now().UTC().Add(1 * time.Hour)
+	_, err := s.Store.UpdateRepository(s.adminCtx, s.Fixtures.Repos[0].ID, params.UpdateEntityParams{
+		WebhookSecret:                  "rotated-webhook-secret",
+		PreviousWebhookSecretExpiresAt: &expiresAt,
+	})
+	s.Require().Nil(err)
+
+	repo, err := s.Store.UpdateRepository(s.adminCtx, s.Fixtures.Repos[0].ID, params.UpdateEntityParams{
+		WebhookSecret: "replaced-webhook-secret",
+	})
+
+	s.Require().Nil(err)
+	s.Require().Equal("replaced-webhook-secret", repo.WebhookSecret)
+	s.Require().Equal("", repo.PreviousWebhookSecret)
+	s.Require().Nil(repo.PreviousWebhookSecretExpiresAt)
+}
+
 func (s *RepoTestSuite) TestUpdateRepositoryInvalidRepoID() {
 	_, err := s.Store.UpdateRepository(s.adminCtx, "dummy-repo-id", s.Fixtures.UpdateRepoParams)
 
@@ -0,0 +1,116 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"github.com/cloudbase/garm/config"
+)
+
+// sealedTables lists the tables holding encrypted columns, along with those
+// columns. All of these tables have an id and a key_version column.
+var sealedTables = []struct {
+	table   string
+	columns []string
+}{
+	{"github_credentials", []string{"payload"}},
+	{"repositories", []string{"webhook_secret", "previous_webhook_secret"}},
+	{"organizations", []string{"webhook_secret", "previous_webhook_secret"}},
+	{"enterprises", []string{"webhook_secret", "previous_webhook_secret"}},
+	{"instances", []string{"jit_configuration"}},
+}
+
+// RotatePassphrase re-encrypts all sealed columns in the database using the
+// current passphrase in cfg. Data may be sealed with the current passphrase or
+// with any of the old passphrases. All rows are updated in a single transaction,
+// which is rolled back if any value can not be decrypted. No controller may be
+// active on the database.
+func RotatePassphrase(ctx context.Context, cfg config.Database) ([]TableRows, error) {
+	db, err := openInactive(ctx, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening database")
+	}
+
+	var tables []TableRows
+	err = db.conn.Transaction(func(tx *gorm.DB) error {
+		for _, sealed := range sealedTables {
+			rows, err := db.rotateTable(tx, sealed.table, sealed.columns)
+			if err != nil {
+				return errors.Wrapf(err, "rotating %s", sealed.table)
+			}
+			slog.InfoContext(ctx, "re-encrypted table", "table", sealed.table, "rows", rows)
+			tables = append(tables, TableRows{Table: sealed.table, Rows: rows})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "rotating passphrase")
+	}
+	return tables, nil
+}
+
+// rotateTable re-encrypts the given columns of all rows in a table, including
+// soft deleted ones, and records the current key version on each row.
+func (s *sqlDatabase) rotateTable(tx *gorm.DB, table string, columns []string) (int64, error) {
+	type sealedRow struct {
+		id     interface{}
+		values [][]byte
+	}
+
+	rows, err := tx.Table(table).Select(append([]string{"id"}, columns...)).Rows()
+	if err != nil {
+		return 0, errors.Wrap(err, "reading rows")
+	}
+	var sealedRows []sealedRow
+	for rows.Next() {
+		row := sealedRow{values: make([][]byte, len(columns))}
+		dest := []interface{}{&row.id}
+		for idx := range row.values {
+			dest = append(dest, &row.values[idx])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			rows.Close()
+			return 0, errors.Wrap(err, "scanning row")
+		}
+		sealedRows = append(sealedRows, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, errors.Wrap(err, "reading rows")
+	}
+
+	passphrases := decryptionPassphrases(s.cfg)
+	for _, row := range sealedRows {
+		updates := map[string]interface{}{
+			"key_version": s.currentKeyVersion(),
+		}
+		for idx, column := range columns {
+			sealed, err := reseal(row.values[idx], passphrases, s.cfg.Passphrase)
+			if err != nil {
+				return 0, errors.Wrapf(err, "re-encrypting %s of row %v", column, row.id)
+			}
+			updates[column] = sealed
+		}
+		if err := tx.Table(table).Where("id = ?", row.id).UpdateColumns(updates).Error; err != nil {
+			return 0, errors.Wrapf(err, "updating row %v", row.id)
+		}
+	}
+	return int64(len(sealedRows)), nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/config"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
+)
+
+const testNewPassphrase = "rotated-db-passphrase-0123456789"
+
+type RotatePassphraseTestSuite struct {
+	suite.Suite
+	Store    dbCommon.Store
+	adminCtx context.Context
+	cfg      config.Database
+	org      params.Organization
+}
+
+func (s *RotatePassphraseTestSuite) SetupTest() {
+	s.cfg = garmTesting.GetTestSqliteDBConfig(s.T())
+	db, err := NewSQLDatabase(context.Background(), s.cfg)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	if _, err := db.InitController(); err != nil {
+		s.FailNow(fmt.Sprintf("failed to init controller: %s", err))
+	}
+	s.Store = db
+	s.adminCtx = garmTesting.ImpersonateAdminContext(context.Background(), db, s.T())
+
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(s.adminCtx, db, s.T())
+	creds := garmTesting.CreateTestGithubCredentials(s.adminCtx, "test-creds", db, s.T(), endpoint)
+	org, err := db.CreateOrganization(s.adminCtx, "test-org", creds.Name, "test-webhookSecret", params.PoolBalancerTypeRoundRobin)
+	s.Require().Nil(err)
+	expiresAt := time.Now().UTC().Add(time.Hour)
+	s.org, err = db.UpdateOrganization(s.adminCtx, org.ID, params.UpdateEntityParams{
+		WebhookSecret:                  "new-webhookSecret",
+		PreviousWebhookSecretExpiresAt: &expiresAt,
+	})
+	s.Require().Nil(err)
+
+	entity, err := s.org.GetEntity()
+	s.Require().Nil(err)
+	pool, err := db.CreateEntityPool(s.adminCtx, entity, params.CreatePoolParams{
+		ProviderName: "test-provider",
+		MaxRunners:   4,
+		Image:        "ubuntu:22.04",
+		Flavor:       "small",
+		OSType:       "linux",
+		OSArch:       "amd64",
+		Tags:         []string{"self-hosted"},
+	})
+	s.Require().Nil(err)
+	_, err = db.CreateInstance(s.adminCtx, pool.ID, params.CreateInstanceParams{
+		Name:   "test-instance",
+		OSType: "linux",
+		OSArch: "amd64",
+		JitConfiguration: map[string]string{
+			".runner": "dGVzdA==",
+		},
+	})
+	s.Require().Nil(err)
+}
+
+func (s *RotatePassphraseTestSuite) rotatedConfig(oldPassphrases ...string) config.Database {
+	cfg := s.cfg
+	cfg.Passphrase = testNewPassphrase
+	cfg.OldPassphrases = oldPassphrases
+	return cfg
+}
+
+func (s *RotatePassphraseTestSuite) requireReadable(cfg config.Database) {
+	db, err := NewSQLDatabase(context.Background(), cfg)
+	s.Require().Nil(err)
+
+	org, err := db.GetOrganizationByID(s.adminCtx, s.org.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("new-webhookSecret", org.WebhookSecret)
+	s.Require().Equal("test-webhookSecret", org.PreviousWebhookSecret)
+
+	_, err = db.GetGithubCredentialsByName(s.adminCtx, "test-creds", true)
+	s.Require().Nil(err)
+
+	instance, err := db.GetInstanceByName(s.adminCtx, "test-instance")
+	s.Require().Nil(err)
+	s.Require().Equal("dGVzdA==", instance.JitConfiguration[".runner"])
+}
+
+func (s *RotatePassphraseTestSuite) TestRotatePassphrase() {
+	// While the passphrase is being rotated, data sealed with the old passphrase
+	// is still readable.
+	s.requireReadable(s.rotatedConfig(s.cfg.Passphrase))
+
+	tables, err := RotatePassphrase(context.Background(), s.rotatedConfig(s.cfg.Passphrase))
+	s.Require().Nil(err)
+	s.Require().Len(tables, len(sealedTables))
+
+	s.requireReadable(s.rotatedConfig())
+
+	// The new passphrase got a new key version when it was first used.
+	sqlDB := s.Store.(*sqlDatabase)
+	var info ControllerInfo
+	s.Require().Nil(sqlDB.conn.First(&info).Error)
+	s.Require().Equal(uint(2), info.KeyVersion)
+	for _, sealed := range sealedTables {
+		var stale int64
+		err := sqlDB.conn.Table(sealed.table).Where("key_version <> ?", info.KeyVersion).Count(&stale).Error
+		s.Require().Nil(err)
+		s.Require().Equal(int64(0), stale, sealed.table)
+	}
+}
+
+func (s *RotatePassphraseTestSuite) TestRotatePassphraseMissingOldPassphrase() {
+	_, err := RotatePassphrase(context.Background(), s.rotatedConfig())
+
+	s.Require().NotNil(err)
+	s.requireReadable(s.cfg)
+}
+
+func (s *RotatePassphraseTestSuite) TestRotatePassphraseActiveController() {
+	s.Require().Nil(s.Store.UpdateControllerHeartbeat())
+
+	_, err := RotatePassphrase(context.Background(), s.rotatedConfig(s.cfg.Passphrase))
+
+	var conflict *runnerErrors.ConflictError
+	s.Require().ErrorAs(err, &conflict)
+}
+
+func (s *RotatePassphraseTestSuite) TestRotatePassphraseActiveControllerKeepsSchema() {
+	db := s.Store.(*sqlDatabase)
+	target := latestSchemaVersion() - 1
+	_, err := db.rollbackSchema(target)
+	s.Require().Nil(err)
+	s.Require().Nil(s.Store.UpdateControllerHeartbeat())
+
+	_, err = RotatePassphrase(context.Background(), s.rotatedConfig(s.cfg.Passphrase))
+
+	var conflict *runnerErrors.ConflictError
+	s.Require().ErrorAs(err, &conflict)
+	version, err := db.schemaVersion()
+	s.Require().Nil(err)
+	s.Require().Equal(target, version)
+}
+
+func TestRotatePassphraseTestSuite(t *testing.T) {
+	suite.Run(t, new(RotatePassphraseTestSuite))
+}
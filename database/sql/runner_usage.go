@@ -0,0 +1,228 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+)
+
+var _ common.RunnerUsageStore = &sqlDatabase{}
+
+func sqlToParamsRunnerUsage(usage RunnerUsage) params.RunnerUsage {
+	return params.RunnerUsage{
+		ID:              usage.ID,
+		InstanceName:    usage.InstanceName,
+		PoolID:          usage.PoolID,
+		EntityType:      usage.EntityType,
+		EntityID:        usage.EntityID,
+		EntityName:      usage.EntityName,
+		JobID:           usage.JobID,
+		RepositoryOwner: usage.RepositoryOwner,
+		RepositoryName:  usage.RepositoryName,
+		StartedAt:       usage.StartedAt,
+		EndedAt:         usage.EndedAt,
+		Seconds:         usage.Seconds,
+		Cost:            usage.Cost,
+	}
+}
+
+// usageEntity returns the type, ID and name of the entity that owns a pool.
+func usageEntity(pool Pool) (params.GithubEntityType, string, string, error) {
+	switch {
+	case pool.RepoID != nil:
+		return params.GithubEntityTypeRepository, pool.RepoID.String(), fmt.Sprintf("%s/%s", pool.Repository.Owner, pool.Repository.Name), nil
+	case pool.OrgID != nil:
+		return params.GithubEntityTypeOrganization, pool.OrgID.String(), pool.Organization.Name, nil
+	case pool.EnterpriseID != nil:
+		return params.GithubEntityTypeEnterprise, pool.EnterpriseID.String(), pool.Enterprise.Name, nil
+	}
+	return "", "", "", errors.Wrap(runnerErrors.ErrBadRequest, "pool has no entity")
+}
+
+// RecordRunnerUsage records the time a runner was up since the end of its previous
+// usage, or since it was created, until param.EndedAt. The cost is computed using
+// the hourly cost of the pool of the runner. The usage of the runners of repository
+// pools is always attributed to the repository.
+func (s *sqlDatabase) RecordRunnerUsage(ctx context.Context, param params.RecordRunnerUsageParams) (params.RunnerUsage, error) {
+	instance, err := s.getInstanceByName(ctx, param.InstanceName, "Pool.Repository", "Pool.Organization", "Pool.Enterprise")
+	if err != nil {
+		return params.RunnerUsage{}, errors.Wrap(err, "fetching instance")
+	}
+
+	entityType, entityID, entityName, err := usageEntity(instance.Pool)
+	if err != nil {
+		return params.RunnerUsage{}, errors.Wrap(err, "fetching pool entity")
+	}
+
+	usage := RunnerUsage{
+		InstanceName:    instance.Name,
+		PoolID:          instance.PoolID.String(),
+		EntityType:      entityType,
+		EntityID:        entityID,
+		EntityName:      entityName,
+		JobID:           param.JobID,
+		RepositoryOwner: param.RepositoryOwner,
+		RepositoryName:  param.RepositoryName,
+		StartedAt:       instance.CreatedAt,
+		EndedAt:         param.EndedAt,
+	}
+	if entityType == params.GithubEntityTypeRepository {
+		usage.RepositoryOwner = instance.Pool.Repository.Owner
+		usage.RepositoryName = instance.Pool.Repository.Name
+	}
+	if usage.EndedAt.IsZero() {
+		usage.EndedAt = time.Now().UTC()
+	}
+
+	err = s.conn.Transaction(func(tx *gorm.DB) error {
+		if param.JobID != 0 {
+			var count int64
+			q := tx.Model(&RunnerUsage{}).
+				Where("instance_name = ? and job_id = ?", usage.InstanceName, param.JobID).
+				Count(&count)
+			if q.Error != nil {
+				return errors.Wrap(q.Error, "fetching job usage")
+			}
+			if count > 0 {
+				return errors.Wrap(runnerErrors.ErrDuplicateEntity, "job usage already recorded")
+			}
+		}
+
+		var previous RunnerUsage
+		q := tx.Where("instance_name = ? and pool_id = ?", usage.InstanceName, usage.PoolID).
+			Order("ended_at desc").
+			First(&previous)
+		if q.Error != nil {
+			if !errors.Is(q.Error, gorm.ErrRecordNotFound) {
+				return errors.Wrap(q.Error, "fetching previous usage")
+			}
+		} else {
+			usage.StartedAt = previous.EndedAt
+		}
+
+		if usage.EndedAt.Before(usage.StartedAt) {
+			usage.EndedAt = usage.StartedAt
+		}
+		usage.Seconds = usage.EndedAt.Sub(usage.StartedAt).Seconds()
+		usage.Cost = usage.Seconds / 3600 * instance.Pool.HourlyCost
+
+		if err := tx.Create(&usage).Error; err != nil {
+			return errors.Wrap(err, "creating runner usage")
+		}
+		return nil
+	})
+	if err != nil {
+		return params.RunnerUsage{}, errors.Wrap(err, "recording runner usage")
+	}
+	return sqlToParamsRunnerUsage(usage), nil
+}
+
+type costReportRow struct {
+	ID              string
+	Type            params.GithubEntityType
+	Name            string
+	RepositoryOwner string
+	RepositoryName  string
+	Jobs            uint
+	Seconds         float64
+	Cost            float64
+}
+
+func (r costReportRow) entry() params.CostReportEntry {
+	return params.CostReportEntry{
+		ID:      r.ID,
+		Type:    r.Type,
+		Name:    r.Name,
+		Jobs:    r.Jobs,
+		Seconds: r.Seconds,
+		Cost:    r.Cost,
+	}
+}
+
+const costReportTotals = "sum(case when job_id <> 0 then 1 else 0 end) as jobs, sum(seconds) as seconds, sum(cost) as cost"
+
+func (s *sqlDatabase) costReportRows(since time.Time, columns, group string) ([]costReportRow, error) {
+	var rows []costReportRow
+	q := s.conn.Model(&RunnerUsage{}).
+		Select(columns+", "+costReportTotals).
+		Where("ended_at >= ?", since).
+		Group(group).
+		Order("cost desc, seconds desc").
+		Scan(&rows)
+	if q.Error != nil {
+		return nil, q.Error
+	}
+	return rows, nil
+}
+
+// GetCostReport sums up the runner usage that ended after the given time, by entity,
+// by pool and by repository.
+func (s *sqlDatabase) GetCostReport(_ context.Context, since time.Time) (params.CostReport, error) {
+	report := params.CostReport{
+		Since:        since,
+		Until:        time.Now().UTC(),
+		Entities:     []params.CostReportEntry{},
+		Pools:        []params.CostReportEntry{},
+		Repositories: []params.CostReportEntry{},
+	}
+
+	entities, err := s.costReportRows(
+		since, "entity_id as id, entity_type as type, entity_name as name",
+		"entity_id, entity_type, entity_name")
+	if err != nil {
+		return params.CostReport{}, errors.Wrap(err, "fetching entity usage")
+	}
+	for _, row := range entities {
+		report.Jobs += row.Jobs
+		report.Seconds += row.Seconds
+		report.Cost += row.Cost
+		report.Entities = append(report.Entities, row.entry())
+	}
+
+	pools, err := s.costReportRows(
+		since, "pool_id as id, entity_type as type, entity_name as name",
+		"pool_id, entity_type, entity_name")
+	if err != nil {
+		return params.CostReport{}, errors.Wrap(err, "fetching pool usage")
+	}
+	for _, row := range pools {
+		report.Pools = append(report.Pools, row.entry())
+	}
+
+	repos, err := s.costReportRows(
+		since, "repository_owner, repository_name",
+		"repository_owner, repository_name")
+	if err != nil {
+		return params.CostReport{}, errors.Wrap(err, "fetching repository usage")
+	}
+	for _, row := range repos {
+		if row.RepositoryOwner == "" || row.RepositoryName == "" {
+			continue
+		}
+		row.Name = fmt.Sprintf("%s/%s", row.RepositoryOwner, row.RepositoryName)
+		report.Repositories = append(report.Repositories, row.entry())
+	}
+
+	return report, nil
+}
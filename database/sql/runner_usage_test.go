@@ -0,0 +1,160 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
+)
+
+type RunnerUsageTestSuite struct {
+	suite.Suite
+	Store    dbCommon.Store
+	adminCtx context.Context
+	org      params.Organization
+	pool     params.Pool
+	instance params.Instance
+}
+
+func (s *RunnerUsageTestSuite) SetupTest() {
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+
+	s.adminCtx = garmTesting.ImpersonateAdminContext(context.Background(), db, s.T())
+	githubEndpoint := garmTesting.CreateDefaultGithubEndpoint(s.adminCtx, db, s.T())
+	creds := garmTesting.CreateTestGithubCredentials(s.adminCtx, "new-creds", db, s.T(), githubEndpoint)
+
+	s.org, err = s.Store.CreateOrganization(s.adminCtx, "test-org", creds.Name, "test-webhookSecret", params.PoolBalancerTypeRoundRobin)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create org: %s", err))
+	}
+	entity, err := s.org.GetEntity()
+	s.Require().Nil(err)
+
+	s.pool, err = s.Store.CreateEntityPool(s.adminCtx, entity, params.CreatePoolParams{
+		ProviderName:   "test-provider",
+		MaxRunners:     4,
+		MinIdleRunners: 2,
+		Image:          "test-image",
+		Flavor:         "test-flavor",
+		OSType:         "linux",
+		Tags:           []string{"amd64", "linux"},
+		HourlyCost:     3.6,
+	})
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create org pool: %s", err))
+	}
+	s.Require().Equal(3.6, s.pool.HourlyCost)
+
+	s.instance, err = s.Store.CreateInstance(s.adminCtx, s.pool.ID, params.CreateInstanceParams{
+		Name:         "test-instance",
+		OSType:       "linux",
+		OSArch:       "amd64",
+		Status:       commonParams.InstanceRunning,
+		RunnerStatus: params.RunnerIdle,
+	})
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create instance: %s", err))
+	}
+}
+
+func (s *RunnerUsageTestSuite) recordUsage() {
+	usage, err := s.Store.RecordRunnerUsage(s.adminCtx, params.RecordRunnerUsageParams{
+		InstanceName:    s.instance.Name,
+		EndedAt:         s.instance.CreatedAt.Add(time.Hour),
+		JobID:           1,
+		RepositoryOwner: "test-org",
+		RepositoryName:  "test-repo",
+	})
+	s.Require().Nil(err)
+	s.Require().Equal(3600.0, usage.Seconds)
+	s.Require().InDelta(3.6, usage.Cost, 0.0001)
+	s.Require().Equal(params.GithubEntityTypeOrganization, usage.EntityType)
+	s.Require().Equal(s.org.Name, usage.EntityName)
+
+	usage, err = s.Store.RecordRunnerUsage(s.adminCtx, params.RecordRunnerUsageParams{
+		InstanceName: s.instance.Name,
+		EndedAt:      s.instance.CreatedAt.Add(90 * time.Minute),
+	})
+	s.Require().Nil(err)
+	s.Require().True(s.instance.CreatedAt.Add(time.Hour).Equal(usage.StartedAt))
+	s.Require().Equal(1800.0, usage.Seconds)
+	s.Require().InDelta(1.8, usage.Cost, 0.0001)
+	s.Require().Empty(usage.RepositoryName)
+}
+
+func (s *RunnerUsageTestSuite) TestRecordRunnerUsage() {
+	s.recordUsage()
+}
+
+func (s *RunnerUsageTestSuite) TestRecordRunnerUsageDuplicateJob() {
+	s.recordUsage()
+
+	_, err := s.Store.RecordRunnerUsage(s.adminCtx, params.RecordRunnerUsageParams{
+		InstanceName: s.instance.Name,
+		JobID:        1,
+	})
+	s.Require().ErrorIs(err, runnerErrors.ErrDuplicateEntity)
+}
+
+func (s *RunnerUsageTestSuite) TestRecordRunnerUsageMissingInstance() {
+	_, err := s.Store.RecordRunnerUsage(s.adminCtx, params.RecordRunnerUsageParams{
+		InstanceName: "missing-instance",
+	})
+	s.Require().ErrorIs(err, runnerErrors.ErrNotFound)
+}
+
+func (s *RunnerUsageTestSuite) TestGetCostReport() {
+	s.recordUsage()
+
+	report, err := s.Store.GetCostReport(s.adminCtx, time.Time{})
+	s.Require().Nil(err)
+	s.Require().Equal(uint(1), report.Jobs)
+	s.Require().Equal(5400.0, report.Seconds)
+	s.Require().InDelta(5.4, report.Cost, 0.0001)
+
+	s.Require().Len(report.Entities, 1)
+	s.Require().Equal(s.org.ID, report.Entities[0].ID)
+	s.Require().Equal(s.org.Name, report.Entities[0].Name)
+	s.Require().Len(report.Pools, 1)
+	s.Require().Equal(s.pool.ID, report.Pools[0].ID)
+	s.Require().Len(report.Repositories, 1)
+	s.Require().Equal("test-org/test-repo", report.Repositories[0].Name)
+	s.Require().Equal(3600.0, report.Repositories[0].Seconds)
+
+	report, err = s.Store.GetCostReport(s.adminCtx, s.instance.CreatedAt.Add(2*time.Hour))
+	s.Require().Nil(err)
+	s.Require().Empty(report.Entities)
+	s.Require().Empty(report.Pools)
+	s.Require().Empty(report.Repositories)
+}
+
+func TestRunnerUsageTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(RunnerUsageTestSuite))
+}
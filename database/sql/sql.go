@@ -31,6 +31,7 @@ import (
 	"github.com/cloudbase/garm/auth"
 	"github.com/cloudbase/garm/config"
 	"github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/database/sql/baseline"
 	"github.com/cloudbase/garm/database/watcher"
 	"github.com/cloudbase/garm/params"
 	"github.com/cloudbase/garm/util/appdefaults"
@@ -91,6 +92,8 @@ type sqlDatabase struct {
 	ctx      context.Context
 	cfg      config.Database
 	producer common.Producer
+	// keyVersion is the version of the passphrase in cfg.
+	keyVersion uint
 }
 
 var renameTemplate = `
@@ -179,19 +182,19 @@ func (s *sqlDatabase) cascadeMigrationSQLite(model interface{}, name string, jus
 func (s *sqlDatabase) cascadeMigration() error {
 	switch s.cfg.DbBackend {
 	case config.SQLiteBackend:
-		if err := s.cascadeMigrationSQLite(&Address{}, "addresses", true); err != nil {
+		if err := s.cascadeMigrationSQLite(&baseline.Address{}, "addresses", true); err != nil {
 			return fmt.Errorf("failed to drop table addresses: %w", err)
 		}
 
-		if err := s.cascadeMigrationSQLite(&InstanceStatusUpdate{}, "instance_status_updates", true); err != nil {
+		if err := s.cascadeMigrationSQLite(&baseline.InstanceStatusUpdate{}, "instance_status_updates", true); err != nil {
 			return fmt.Errorf("failed to drop table instance_status_updates: %w", err)
 		}
 
-		if err := s.cascadeMigrationSQLite(&Tag{}, "pool_tags", false); err != nil {
+		if err := s.cascadeMigrationSQLite(&baseline.Tag{}, "pool_tags", false); err != nil {
 			return fmt.Errorf("failed to migrate addresses: %w", err)
 		}
 
-		if err := s.cascadeMigrationSQLite(&WorkflowJob{}, "workflow_jobs", false); err != nil {
+		if err := s.cascadeMigrationSQLite(&baseline.WorkflowJob{}, "workflow_jobs", false); err != nil {
 			return fmt.Errorf("failed to migrate addresses: %w", err)
 		}
 	case config.MySQLBackend:
@@ -359,15 +362,47 @@ func (s *sqlDatabase) migrateCredentialsToDB() (err error) {
 	return nil
 }
 
+// migrateDB brings the database schema to the latest version, ensures the
+// default github endpoint exists and imports the credentials of databases that
+// predate storing them in the database.
 func (s *sqlDatabase) migrateDB() error {
-	if s.conn.Migrator().HasIndex(&Organization{}, "idx_organizations_name") {
-		if err := s.conn.Migrator().DropIndex(&Organization{}, "idx_organizations_name"); err != nil {
+	// The credentials are imported using the current models, so the schema must
+	// be up to date first.
+	needsCredentialMigration := !s.conn.Migrator().HasTable(&baseline.GithubCredentials{}) || !s.conn.Migrator().HasTable(&baseline.GithubEndpoint{})
+
+	if _, err := s.migrateSchema(latestSchemaVersion()); err != nil {
+		return errors.Wrap(err, "migrating schema")
+	}
+
+	if err := s.syncKeyVersion(); err != nil {
+		return errors.Wrap(err, "syncing key version")
+	}
+
+	if err := s.ensureGithubEndpoint(); err != nil {
+		return errors.Wrap(err, "ensuring github endpoint")
+	}
+
+	if needsCredentialMigration {
+		if err := s.migrateCredentialsToDB(); err != nil {
+			return errors.Wrap(err, "migrating credentials")
+		}
+	}
+	return nil
+}
+
+// migrateBaseline is the first schema migration. It brings databases created
+// before schema versioning was introduced to version 1 of the schema, and
+// creates the schema of new databases. It only uses the frozen models of the
+// baseline package.
+func (s *sqlDatabase) migrateBaseline() error {
+	if s.conn.Migrator().HasIndex(&baseline.Organization{}, "idx_organizations_name") {
+		if err := s.conn.Migrator().DropIndex(&baseline.Organization{}, "idx_organizations_name"); err != nil {
 			slog.With(slog.Any("error", err)).Error("failed to drop index idx_organizations_name")
 		}
 	}
 
-	if s.conn.Migrator().HasIndex(&Repository{}, "idx_owner") {
-		if err := s.conn.Migrator().DropIndex(&Repository{}, "idx_owner"); err != nil {
+	if s.conn.Migrator().HasIndex(&baseline.Repository{}, "idx_owner") {
+		if err := s.conn.Migrator().DropIndex(&baseline.Repository{}, "idx_owner"); err != nil {
 			slog.With(slog.Any("error", err)).Error("failed to drop index idx_owner")
 		}
 	}
@@ -376,7 +411,7 @@ func (s *sqlDatabase) migrateDB() error {
 		return errors.Wrap(err, "running cascade migration")
 	}
 
-	if s.conn.Migrator().HasTable(&Pool{}) {
+	if s.conn.Migrator().HasTable(&baseline.Pool{}) {
 		if err := s.conn.Exec("update pools set repo_id=NULL where repo_id='00000000-0000-0000-0000-000000000000'").Error; err != nil {
 			return errors.Wrap(err, "updating pools")
 		}
@@ -390,70 +425,33 @@ func (s *sqlDatabase) migrateDB() error {
 		}
 	}
 
-	if s.conn.Migrator().HasTable(&WorkflowJob{}) {
-		if s.conn.Migrator().HasColumn(&WorkflowJob{}, "runner_name") {
+	if s.conn.Migrator().HasTable(&baseline.WorkflowJob{}) {
+		if s.conn.Migrator().HasColumn(&baseline.WorkflowJob{}, "runner_name") {
 			// Remove jobs that are not in "queued" status. We really only care about queued jobs. Once they transition
 			// to something else, we don't really consume them anyway.
 			if err := s.conn.Exec("delete from workflow_jobs where status is not 'queued'").Error; err != nil {
 				return errors.Wrap(err, "updating workflow_jobs")
 			}
-			if err := s.conn.Migrator().DropColumn(&WorkflowJob{}, "runner_name"); err != nil {
+			if err := s.conn.Migrator().DropColumn(&baseline.WorkflowJob{}, "runner_name"); err != nil {
 				return errors.Wrap(err, "updating workflow_jobs")
 			}
 		}
 	}
 
-	var needsCredentialMigration bool
-	if !s.conn.Migrator().HasTable(&GithubCredentials{}) || !s.conn.Migrator().HasTable(&GithubEndpoint{}) {
-		needsCredentialMigration = true
-	}
-
 	var hasMinAgeField bool
-	if s.conn.Migrator().HasTable(&ControllerInfo{}) && s.conn.Migrator().HasColumn(&ControllerInfo{}, "minimum_job_age_backoff") {
+	if s.conn.Migrator().HasTable(&baseline.ControllerInfo{}) && s.conn.Migrator().HasColumn(&baseline.ControllerInfo{}, "minimum_job_age_backoff") {
 		hasMinAgeField = true
 	}
 
 	s.conn.Exec("PRAGMA foreign_keys = OFF")
-	if err := s.conn.AutoMigrate(
-		&User{},
-		&GithubEndpoint{},
-		&GithubCredentials{},
-		&Tag{},
-		&Pool{},
-		&Repository{},
-		&Organization{},
-		&Enterprise{},
-		&Address{},
-		&InstanceStatusUpdate{},
-		&Instance{},
-		&ControllerInfo{},
-		&WorkflowJob{},
-	); err != nil {
+	if err := s.conn.AutoMigrate(baseline.Models()...); err != nil {
 		return errors.Wrap(err, "running auto migrate")
 	}
 	s.conn.Exec("PRAGMA foreign_keys = ON")
 
 	if !hasMinAgeField {
-		var controller ControllerInfo
-		if err := s.conn.First(&controller).Error; err != nil {
-			if !errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.Wrap(err, "updating controller info")
-			}
-		} else {
-			controller.MinimumJobAgeBackoff = 30
-			if err := s.conn.Save(&controller).Error; err != nil {
-				return errors.Wrap(err, "updating controller info")
-			}
-		}
-	}
-
-	if err := s.ensureGithubEndpoint(); err != nil {
-		return errors.Wrap(err, "ensuring github endpoint")
-	}
-
-	if needsCredentialMigration {
-		if err := s.migrateCredentialsToDB(); err != nil {
-			return errors.Wrap(err, "migrating credentials")
+		if err := s.conn.Model(&baseline.ControllerInfo{}).Where("1 = 1").Update("minimum_job_age_backoff", 30).Error; err != nil {
+			return errors.Wrap(err, "updating controller info")
 		}
 	}
 	return nil
@@ -15,8 +15,10 @@
 package sql
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -26,6 +28,7 @@ import (
 	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
 	commonParams "github.com/cloudbase/garm-provider-common/params"
 	"github.com/cloudbase/garm-provider-common/util"
+	"github.com/cloudbase/garm/config"
 	dbCommon "github.com/cloudbase/garm/database/common"
 	"github.com/cloudbase/garm/params"
 )
@@ -65,11 +68,31 @@ func (s *sqlDatabase) sqlToParamsInstance(instance Instance) (params.Instance, e
 		MetadataURL:       instance.MetadataURL,
 		StatusMessages:    []params.StatusMessage{},
 		CreateAttempt:     instance.CreateAttempt,
+		CreatedAt:         instance.CreatedAt,
 		UpdatedAt:         instance.UpdatedAt,
 		TokenFetched:      instance.TokenFetched,
 		JitConfiguration:  jitConfig,
 		GitHubRunnerGroup: instance.GitHubRunnerGroup,
 		AditionalLabels:   labels,
+		Draining:          instance.Draining,
+		PoolGeneration:    instance.PoolGeneration,
+		Adopted:           instance.Adopted,
+		JobsCompleted:     instance.JobsCompleted,
+		ErrorClass:        instance.ErrorClass,
+		NextRetryAt:       instance.NextRetryAt,
+		Lifecycle: params.InstanceLifecycle{
+			ProviderCreatedAt: instance.ProviderCreatedAt,
+			FirstCallbackAt:   instance.FirstCallbackAt,
+			JITFetchedAt:      instance.JitFetchedAt,
+			RegisteredAt:      instance.RegisteredAt,
+			JobAssignedAt:     instance.JobAssignedAt,
+			TerminatedAt:      instance.TerminatedAt,
+		},
+	}
+
+	if len(instance.ConsoleOutput) > 0 {
+		ret.ConsoleOutput = instance.ConsoleOutput
+		ret.ConsoleOutputCapturedAt = instance.ConsoleOutputCapturedAt
 	}
 
 	if instance.Job != nil {
@@ -110,10 +133,14 @@ func (s *sqlDatabase) sqlToCommonOrganization(org Organization, detailed bool) (
 	if len(org.WebhookSecret) == 0 {
 		return params.Organization{}, errors.New("missing secret")
 	}
-	secret, err := util.Unseal(org.WebhookSecret, []byte(s.cfg.Passphrase))
+	secret, err := s.unseal(org.WebhookSecret)
 	if err != nil {
 		return params.Organization{}, errors.Wrap(err, "decrypting secret")
 	}
+	previousSecret, previousSecretExpiresAt, err := s.unsealPreviousWebhookSecret(org.PreviousWebhookSecret, org.PreviousWebhookSecretExpiresAt)
+	if err != nil {
+		return params.Organization{}, errors.Wrap(err, "decrypting previous secret")
+	}
 
 	endpoint, err := s.sqlToCommonGithubEndpoint(org.Endpoint)
 	if err != nil {
@@ -127,6 +154,16 @@ func (s *sqlDatabase) sqlToCommonOrganization(org Organization, detailed bool) (
 		WebhookSecret:    string(secret),
 		PoolBalancerType: org.PoolBalancerType,
 		Endpoint:         endpoint,
+		ObserveOnly:      org.ObserveOnly,
+
+		PreviousWebhookSecret:          previousSecret,
+		PreviousWebhookSecretExpiresAt: previousSecretExpiresAt,
+	}
+
+	if len(org.JobScheduling) > 0 {
+		if err := json.Unmarshal(org.JobScheduling, &ret.JobScheduling); err != nil {
+			return params.Organization{}, errors.Wrap(err, "decoding job scheduling")
+		}
 	}
 
 	if org.CredentialsID != nil {
@@ -155,14 +192,34 @@ func (s *sqlDatabase) sqlToCommonOrganization(org Organization, detailed bool) (
 	return ret, nil
 }
 
+// unsealPreviousWebhookSecret returns the previous webhook secret of an entity, if one
+// is set and it has not yet expired. Expired secrets are treated as retired.
+func (s *sqlDatabase) unsealPreviousWebhookSecret(sealed []byte, expiresAt *time.Time) (string, *time.Time, error) {
+	if len(sealed) == 0 || expiresAt == nil {
+		return "", nil, nil
+	}
+	if !time.Now().UTC().Before(expiresAt.UTC()) {
+		return "", nil, nil
+	}
+	secret, err := s.unseal(sealed)
+	if err != nil {
+		return "", nil, err
+	}
+	return string(secret), expiresAt, nil
+}
+
 func (s *sqlDatabase) sqlToCommonEnterprise(enterprise Enterprise, detailed bool) (params.Enterprise, error) {
 	if len(enterprise.WebhookSecret) == 0 {
 		return params.Enterprise{}, errors.New("missing secret")
 	}
-	secret, err := util.Unseal(enterprise.WebhookSecret, []byte(s.cfg.Passphrase))
+	secret, err := s.unseal(enterprise.WebhookSecret)
 	if err != nil {
 		return params.Enterprise{}, errors.Wrap(err, "decrypting secret")
 	}
+	previousSecret, previousSecretExpiresAt, err := s.unsealPreviousWebhookSecret(enterprise.PreviousWebhookSecret, enterprise.PreviousWebhookSecretExpiresAt)
+	if err != nil {
+		return params.Enterprise{}, errors.Wrap(err, "decrypting previous secret")
+	}
 
 	endpoint, err := s.sqlToCommonGithubEndpoint(enterprise.Endpoint)
 	if err != nil {
@@ -176,6 +233,16 @@ func (s *sqlDatabase) sqlToCommonEnterprise(enterprise Enterprise, detailed bool
 		WebhookSecret:    string(secret),
 		PoolBalancerType: enterprise.PoolBalancerType,
 		Endpoint:         endpoint,
+		ObserveOnly:      enterprise.ObserveOnly,
+
+		PreviousWebhookSecret:          previousSecret,
+		PreviousWebhookSecretExpiresAt: previousSecretExpiresAt,
+	}
+
+	if len(enterprise.JobScheduling) > 0 {
+		if err := json.Unmarshal(enterprise.JobScheduling, &ret.JobScheduling); err != nil {
+			return params.Enterprise{}, errors.Wrap(err, "decoding job scheduling")
+		}
 	}
 
 	if enterprise.CredentialsID != nil {
@@ -224,6 +291,22 @@ func (s *sqlDatabase) sqlToCommonPool(pool Pool) (params.Pool, error) {
 		ExtraSpecs:             json.RawMessage(pool.ExtraSpecs),
 		GitHubRunnerGroup:      pool.GitHubRunnerGroup,
 		Priority:               pool.Priority,
+		Cordoned:               pool.Cordoned,
+		RecycleRequestedAt:     pool.RecycleRequestedAt,
+		RecycleMaxUnavailable:  pool.RecycleMaxUnavailable,
+		Generation:             pool.Generation,
+		RolloutMaxSurge:        pool.RolloutMaxSurge,
+		RolloutMaxUnavailable:  pool.RolloutMaxUnavailable,
+		Reusable:               pool.Reusable,
+		RunnerMaxJobs:          pool.RunnerMaxJobs,
+		RunnerMaxLifetime:      pool.RunnerMaxLifetime,
+		JobCompletedHook:       pool.JobCompletedHook,
+		ProviderFailureClass:   pool.ProviderFailureClass,
+		ProviderFailureReason:  pool.ProviderFailureReason,
+		ProviderFailureAt:      pool.ProviderFailureAt,
+		HourlyCost:             pool.HourlyCost,
+
+		MaxRunnersPerRepository: pool.MaxRunnersPerRepository,
 	}
 
 	if pool.RepoID != nil {
@@ -243,6 +326,34 @@ func (s *sqlDatabase) sqlToCommonPool(pool Pool) (params.Pool, error) {
 		ret.EnterpriseName = pool.Enterprise.Name
 	}
 
+	if pool.TemplateID != nil {
+		ret.TemplateID = pool.TemplateID.String()
+	}
+
+	if len(pool.TemplateOverrides) > 0 {
+		if err := json.Unmarshal(pool.TemplateOverrides, &ret.TemplateOverrides); err != nil {
+			return params.Pool{}, errors.Wrap(err, "decoding template overrides")
+		}
+	}
+
+	if len(pool.IncludedRepositories) > 0 {
+		if err := json.Unmarshal(pool.IncludedRepositories, &ret.IncludedRepositories); err != nil {
+			return params.Pool{}, errors.Wrap(err, "decoding included repositories")
+		}
+	}
+
+	if len(pool.ExcludedRepositories) > 0 {
+		if err := json.Unmarshal(pool.ExcludedRepositories, &ret.ExcludedRepositories); err != nil {
+			return params.Pool{}, errors.Wrap(err, "decoding excluded repositories")
+		}
+	}
+
+	if len(pool.Policy) > 0 {
+		if err := json.Unmarshal(pool.Policy, &ret.Policy); err != nil {
+			return params.Pool{}, errors.Wrap(err, "decoding policy")
+		}
+	}
+
 	for idx, val := range pool.Tags {
 		ret.Tags[idx] = s.sqlToCommonTags(*val)
 	}
@@ -253,6 +364,13 @@ func (s *sqlDatabase) sqlToCommonPool(pool Pool) (params.Pool, error) {
 		if err != nil {
 			return params.Pool{}, errors.Wrap(err, "converting instance")
 		}
+		if inst.PoolGeneration < pool.Generation {
+			ret.OutdatedRunners++
+		}
+		if ret.RunnerStages == nil {
+			ret.RunnerStages = map[params.InstanceStage]uint{}
+		}
+		ret.RunnerStages[ret.Instances[idx].LastStage()]++
 	}
 
 	return ret, nil
@@ -269,10 +387,14 @@ func (s *sqlDatabase) sqlToCommonRepository(repo Repository, detailed bool) (par
 	if len(repo.WebhookSecret) == 0 {
 		return params.Repository{}, errors.New("missing secret")
 	}
-	secret, err := util.Unseal(repo.WebhookSecret, []byte(s.cfg.Passphrase))
+	secret, err := s.unseal(repo.WebhookSecret)
 	if err != nil {
 		return params.Repository{}, errors.Wrap(err, "decrypting secret")
 	}
+	previousSecret, previousSecretExpiresAt, err := s.unsealPreviousWebhookSecret(repo.PreviousWebhookSecret, repo.PreviousWebhookSecretExpiresAt)
+	if err != nil {
+		return params.Repository{}, errors.Wrap(err, "decrypting previous secret")
+	}
 	endpoint, err := s.sqlToCommonGithubEndpoint(repo.Endpoint)
 	if err != nil {
 		return params.Repository{}, errors.Wrap(err, "converting endpoint")
@@ -286,6 +408,16 @@ func (s *sqlDatabase) sqlToCommonRepository(repo Repository, detailed bool) (par
 		WebhookSecret:    string(secret),
 		PoolBalancerType: repo.PoolBalancerType,
 		Endpoint:         endpoint,
+		ObserveOnly:      repo.ObserveOnly,
+
+		PreviousWebhookSecret:          previousSecret,
+		PreviousWebhookSecretExpiresAt: previousSecretExpiresAt,
+	}
+
+	if len(repo.JobScheduling) > 0 {
+		if err := json.Unmarshal(repo.JobScheduling, &ret.JobScheduling); err != nil {
+			return params.Repository{}, errors.Wrap(err, "decoding job scheduling")
+		}
 	}
 
 	if repo.CredentialsID != nil {
@@ -353,12 +485,15 @@ func (s *sqlDatabase) updatePool(tx *gorm.DB, pool Pool, param params.UpdatePool
 		pool.Enabled = *param.Enabled
 	}
 
-	if param.Flavor != "" {
+	var newGeneration bool
+	if param.Flavor != "" && param.Flavor != pool.Flavor {
 		pool.Flavor = param.Flavor
+		newGeneration = true
 	}
 
-	if param.Image != "" {
+	if param.Image != "" && param.Image != pool.Image {
 		pool.Image = param.Image
+		newGeneration = true
 	}
 
 	if param.Prefix != "" {
@@ -381,8 +516,13 @@ func (s *sqlDatabase) updatePool(tx *gorm.DB, pool Pool, param params.UpdatePool
 		pool.OSType = param.OSType
 	}
 
-	if param.ExtraSpecs != nil {
+	if param.ExtraSpecs != nil && !bytes.Equal(param.ExtraSpecs, pool.ExtraSpecs) {
 		pool.ExtraSpecs = datatypes.JSON(param.ExtraSpecs)
+		newGeneration = true
+	}
+
+	if newGeneration {
+		pool.Generation++
 	}
 
 	if param.RunnerBootstrapTimeout != nil && *param.RunnerBootstrapTimeout > 0 {
@@ -397,6 +537,86 @@ func (s *sqlDatabase) updatePool(tx *gorm.DB, pool Pool, param params.UpdatePool
 		pool.Priority = *param.Priority
 	}
 
+	if param.RolloutMaxSurge != nil {
+		pool.RolloutMaxSurge = *param.RolloutMaxSurge
+	}
+
+	if param.RolloutMaxUnavailable != nil {
+		pool.RolloutMaxUnavailable = *param.RolloutMaxUnavailable
+	}
+
+	if param.Reusable != nil {
+		pool.Reusable = *param.Reusable
+	}
+
+	if param.RunnerMaxJobs != nil {
+		pool.RunnerMaxJobs = *param.RunnerMaxJobs
+	}
+
+	if param.RunnerMaxLifetime != nil {
+		pool.RunnerMaxLifetime = *param.RunnerMaxLifetime
+	}
+
+	if param.JobCompletedHook != nil {
+		pool.JobCompletedHook = *param.JobCompletedHook
+	}
+
+	if param.IncludedRepositories != nil {
+		included, err := repositoryPatternsToJSON(param.IncludedRepositories)
+		if err != nil {
+			return params.Pool{}, errors.Wrap(err, "encoding included repositories")
+		}
+		pool.IncludedRepositories = included
+	}
+
+	if param.ExcludedRepositories != nil {
+		excluded, err := repositoryPatternsToJSON(param.ExcludedRepositories)
+		if err != nil {
+			return params.Pool{}, errors.Wrap(err, "encoding excluded repositories")
+		}
+		pool.ExcludedRepositories = excluded
+	}
+
+	if param.Policy != nil {
+		policy, err := poolPolicyToJSON(param.Policy)
+		if err != nil {
+			return params.Pool{}, errors.Wrap(err, "encoding policy")
+		}
+		pool.Policy = policy
+	}
+
+	if param.HourlyCost != nil {
+		pool.HourlyCost = *param.HourlyCost
+	}
+
+	if param.MaxRunnersPerRepository != nil {
+		pool.MaxRunnersPerRepository = *param.MaxRunnersPerRepository
+	}
+
+	if param.Cordoned != nil {
+		pool.Cordoned = *param.Cordoned
+	}
+
+	if param.Recycle != nil {
+		pool.RecycleRequestedAt = param.Recycle.RequestedAt
+		pool.RecycleMaxUnavailable = param.Recycle.MaxUnavailable
+	}
+
+	// A new image, flavor or extra specs may fix the error that stopped the
+	// pool from creating runners.
+	if newGeneration || (param.ClearProviderFailure != nil && *param.ClearProviderFailure) {
+		pool.ProviderFailureClass = ""
+		pool.ProviderFailureReason = ""
+		pool.ProviderFailureAt = nil
+	}
+
+	if param.ProviderFailure != nil {
+		failedAt := param.ProviderFailure.At
+		pool.ProviderFailureClass = param.ProviderFailure.Class
+		pool.ProviderFailureReason = param.ProviderFailure.Reason
+		pool.ProviderFailureAt = &failedAt
+	}
+
 	if q := tx.Save(&pool); q.Error != nil {
 		return params.Pool{}, errors.Wrap(q.Error, "saving database entry")
 	}
@@ -470,16 +690,111 @@ func (s *sqlDatabase) hasGithubEntity(tx *gorm.DB, entityType params.GithubEntit
 	return nil
 }
 
+// syncKeyVersion loads the key version of the current passphrase from the
+// controller info. A new version is recorded if the passphrase changed. Until
+// the controller is initialized, the current passphrase has the first version.
+func (s *sqlDatabase) syncKeyVersion() error {
+	var info ControllerInfo
+	if err := s.conn.First(&info).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.keyVersion = 1
+			return nil
+		}
+		return errors.Wrap(err, "fetching controller info")
+	}
+
+	version, check, err := nextKeyVersion(info, s.cfg.Passphrase)
+	if err != nil {
+		return err
+	}
+	if check != nil {
+		updates := map[string]interface{}{
+			"key_version": version,
+			"key_check":   check,
+		}
+		if err := s.conn.Model(&info).UpdateColumns(updates).Error; err != nil {
+			return errors.Wrap(err, "updating key version")
+		}
+	}
+	s.keyVersion = version
+	return nil
+}
+
+// nextKeyVersion returns the key version of a passphrase, given the key version
+// recorded in the controller info. If the recorded key check can not be opened
+// with the passphrase, the passphrase changed and the version is incremented.
+// A new key check is returned if it needs to be recorded.
+func nextKeyVersion(info ControllerInfo, passphrase string) (uint, []byte, error) {
+	if len(info.KeyCheck) > 0 {
+		if _, err := util.Unseal(info.KeyCheck, []byte(passphrase)); err == nil {
+			return info.KeyVersion, nil, nil
+		}
+	}
+
+	version := info.KeyVersion
+	if len(info.KeyCheck) > 0 || version == 0 {
+		version++
+	}
+	random, err := util.GetRandomString(32)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "generating key check")
+	}
+	check, err := util.Seal([]byte(random), []byte(passphrase))
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "sealing key check")
+	}
+	return version, check, nil
+}
+
+// decryptionPassphrases returns the passphrases that may have been used to seal
+// data in the database, starting with the current one.
+func decryptionPassphrases(cfg config.Database) []string {
+	return append([]string{cfg.Passphrase}, cfg.OldPassphrases...)
+}
+
+// unsealWithAny decrypts data using the first passphrase that works.
+func unsealWithAny(data []byte, passphrases []string) ([]byte, error) {
+	err := errors.New("no passphrase available")
+	for _, passphrase := range passphrases {
+		var decrypted []byte
+		decrypted, err = util.Unseal(data, []byte(passphrase))
+		if err == nil {
+			return decrypted, nil
+		}
+	}
+	return nil, err
+}
+
+func (s *sqlDatabase) currentKeyVersion() uint {
+	return s.keyVersion
+}
+
+func (s *sqlDatabase) seal(data []byte) ([]byte, error) {
+	return util.Seal(data, []byte(s.cfg.Passphrase))
+}
+
+// unseal decrypts data sealed with the current passphrase or with any of the
+// old passphrases that are still configured.
+func (s *sqlDatabase) unseal(data []byte) ([]byte, error) {
+	return unsealWithAny(data, decryptionPassphrases(s.cfg))
+}
+
+// resealWithCurrent re-encrypts data that may have been sealed with an old
+// passphrase, using the current passphrase.
+func (s *sqlDatabase) resealWithCurrent(data []byte) ([]byte, error) {
+	return reseal(data, decryptionPassphrases(s.cfg), s.cfg.Passphrase)
+}
+
 func (s *sqlDatabase) marshalAndSeal(data interface{}) ([]byte, error) {
 	enc, err := json.Marshal(data)
 	if err != nil {
 		return nil, errors.Wrap(err, "marshalling data")
 	}
-	return util.Seal(enc, []byte(s.cfg.Passphrase))
+	return s.seal(enc)
 }
 
 func (s *sqlDatabase) unsealAndUnmarshal(data []byte, target interface{}) error {
-	decrypted, err := util.Unseal(data, []byte(s.cfg.Passphrase))
+	decrypted, err := s.unseal(data)
 	if err != nil {
 		return errors.Wrap(err, "decrypting data")
 	}
@@ -504,3 +819,42 @@ func (s *sqlDatabase) sendNotify(entityType dbCommon.DatabaseEntityType, op dbCo
 	}
 	return s.producer.Notify(message)
 }
+
+// repositoryPatternsToJSON encodes the repository filters of a pool. An empty
+// list is stored as NULL.
+func repositoryPatternsToJSON(patterns []string) (datatypes.JSON, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	asJSON, err := json.Marshal(patterns)
+	if err != nil {
+		return nil, err
+	}
+	return datatypes.JSON(asJSON), nil
+}
+
+// poolPolicyToJSON encodes the policy of a pool. A policy without rules is
+// stored as NULL.
+func poolPolicyToJSON(policy *params.PoolPolicy) (datatypes.JSON, error) {
+	if policy == nil || policy.IsEmpty() {
+		return nil, nil
+	}
+	asJSON, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+	return datatypes.JSON(asJSON), nil
+}
+
+// jobSchedulingToJSON encodes the job scheduling settings of an entity. The
+// default settings are stored as NULL.
+func jobSchedulingToJSON(scheduling *params.JobScheduling) (datatypes.JSON, error) {
+	if scheduling == nil || scheduling.IsEmpty() {
+		return nil, nil
+	}
+	asJSON, err := json.Marshal(scheduling)
+	if err != nil {
+		return nil, err
+	}
+	return datatypes.JSON(asJSON), nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+)
+
+var _ common.WebhookDeliveryStore = &sqlDatabase{}
+
+func sqlToParamsWebhookDelivery(delivery WebhookDelivery) params.WebhookDelivery {
+	return params.WebhookDelivery{
+		ID:             delivery.ID,
+		HookTargetType: delivery.HookTargetType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
+
+// CreateWebhookDelivery persists a new webhook delivery in the pending state. If a delivery
+// with the same ID already exists, ErrDuplicateEntity is returned.
+func (s *sqlDatabase) CreateWebhookDelivery(_ context.Context, param params.CreateWebhookDeliveryParams) (params.WebhookDelivery, error) {
+	if param.ID == "" {
+		return params.WebhookDelivery{}, runnerErrors.NewBadRequestError("missing delivery ID")
+	}
+
+	delivery := WebhookDelivery{
+		ID:             param.ID,
+		HookTargetType: param.HookTargetType,
+		Payload:        param.Payload,
+		Status:         params.WebhookDeliveryPending,
+		NextAttemptAt:  time.Now().UTC(),
+	}
+	// A delivery may be received more than once at the same time, for example
+	// when it is redelivered while it is being replayed. Only one of them is
+	// recorded, the others get ErrDuplicateEntity.
+	q := s.conn.Clauses(clause.OnConflict{DoNothing: true}).Create(&delivery)
+	if q.Error != nil {
+		return params.WebhookDelivery{}, errors.Wrap(q.Error, "creating webhook delivery")
+	}
+	if q.RowsAffected == 0 {
+		return params.WebhookDelivery{}, errors.Wrap(runnerErrors.ErrDuplicateEntity, "webhook delivery already exists")
+	}
+	return sqlToParamsWebhookDelivery(delivery), nil
+}
+
+func (s *sqlDatabase) getWebhookDelivery(tx *gorm.DB, deliveryID string) (WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	q := tx.Where("id = ?", deliveryID).First(&delivery)
+	if q.Error != nil {
+		if errors.Is(q.Error, gorm.ErrRecordNotFound) {
+			return WebhookDelivery{}, runnerErrors.ErrNotFound
+		}
+		return WebhookDelivery{}, errors.Wrap(q.Error, "fetching webhook delivery")
+	}
+	return delivery, nil
+}
+
+func (s *sqlDatabase) GetWebhookDelivery(_ context.Context, deliveryID string) (params.WebhookDelivery, error) {
+	delivery, err := s.getWebhookDelivery(s.conn, deliveryID)
+	if err != nil {
+		return params.WebhookDelivery{}, errors.Wrap(err, "fetching webhook delivery")
+	}
+	return sqlToParamsWebhookDelivery(delivery), nil
+}
+
+func (s *sqlDatabase) UpdateWebhookDelivery(_ context.Context, deliveryID string, param params.UpdateWebhookDeliveryParams) (params.WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	err := s.conn.Transaction(func(tx *gorm.DB) error {
+		var err error
+		delivery, err = s.getWebhookDelivery(tx, deliveryID)
+		if err != nil {
+			return errors.Wrap(err, "fetching webhook delivery")
+		}
+
+		if param.Status != nil {
+			delivery.Status = *param.Status
+		}
+		if param.Attempts != nil {
+			delivery.Attempts = *param.Attempts
+		}
+		if param.LastError != nil {
+			delivery.LastError = *param.LastError
+		}
+		if param.NextAttemptAt != nil {
+			delivery.NextAttemptAt = *param.NextAttemptAt
+		}
+
+		if err := tx.Save(&delivery).Error; err != nil {
+			return errors.Wrap(err, "saving webhook delivery")
+		}
+		return nil
+	})
+	if err != nil {
+		return params.WebhookDelivery{}, errors.Wrap(err, "updating webhook delivery")
+	}
+	return sqlToParamsWebhookDelivery(delivery), nil
+}
+
+// ListWebhookDeliveriesByStatus returns all deliveries with the given status, oldest first.
+func (s *sqlDatabase) ListWebhookDeliveriesByStatus(_ context.Context, status params.WebhookDeliveryStatus) ([]params.WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+	q := s.conn.Model(&WebhookDelivery{}).
+		Where("status = ?", status).
+		Order("created_at asc").
+		Find(&deliveries)
+	if q.Error != nil {
+		return nil, errors.Wrap(q.Error, "fetching webhook deliveries")
+	}
+
+	ret := make([]params.WebhookDelivery, len(deliveries))
+	for idx, delivery := range deliveries {
+		ret[idx] = sqlToParamsWebhookDelivery(delivery)
+	}
+	return ret, nil
+}
+
+func (s *sqlDatabase) DeleteWebhookDeliveriesOlderThan(_ context.Context, olderThan time.Time) error {
+	q := s.conn.
+		Where("status in ?", []params.WebhookDeliveryStatus{params.WebhookDeliveryCompleted, params.WebhookDeliveryFailed}).
+		Where("updated_at < ?", olderThan).
+		Delete(&WebhookDelivery{})
+	if q.Error != nil {
+		return errors.Wrap(q.Error, "deleting webhook deliveries")
+	}
+	return nil
+}
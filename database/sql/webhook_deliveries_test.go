@@ -0,0 +1,177 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//	Licensed under the Apache License, Version 2.0 (the "License"); you may
+//	not use this file except in compliance with the License. You may obtain
+//	a copy of the License at
+//
+//	     http://www.apache.org/licenses/LICENSE-2.0
+//
+//	Unless required by applicable law or agreed to in writing, software
+//	distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//	WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//	License for the specific language governing permissions and limitations
+//	under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
+)
+
+type WebhookDeliveriesTestSuite struct {
+	suite.Suite
+	Store dbCommon.Store
+	ctx   context.Context
+}
+
+func (s *WebhookDeliveriesTestSuite) SetupTest() {
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+	s.ctx = context.Background()
+}
+
+func (s *WebhookDeliveriesTestSuite) createDelivery(id string) params.WebhookDelivery {
+	delivery, err := s.Store.CreateWebhookDelivery(s.ctx, params.CreateWebhookDeliveryParams{
+		ID:             id,
+		HookTargetType: "repository",
+		Payload:        []byte(`{"action": "queued"}`),
+	})
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create delivery: %s", err))
+	}
+	return delivery
+}
+
+func (s *WebhookDeliveriesTestSuite) TestCreateWebhookDelivery() {
+	delivery := s.createDelivery("delivery-1")
+
+	s.Require().Equal("delivery-1", delivery.ID)
+	s.Require().Equal(params.WebhookDeliveryPending, delivery.Status)
+	s.Require().Equal([]byte(`{"action": "queued"}`), delivery.Payload)
+	s.Require().Equal(0, delivery.Attempts)
+}
+
+func (s *WebhookDeliveriesTestSuite) TestCreateWebhookDeliveryDuplicate() {
+	s.createDelivery("delivery-1")
+
+	_, err := s.Store.CreateWebhookDelivery(s.ctx, params.CreateWebhookDeliveryParams{
+		ID: "delivery-1",
+	})
+
+	s.Require().ErrorIs(err, runnerErrors.ErrDuplicateEntity)
+}
+
+func (s *WebhookDeliveriesTestSuite) TestCreateWebhookDeliveryConcurrent() {
+	errs := make(chan error, 10)
+	var wg sync.WaitGroup
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.Store.CreateWebhookDelivery(s.ctx, params.CreateWebhookDeliveryParams{
+				ID:      "delivery-1",
+				Payload: []byte(`{"action": "queued"}`),
+			})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		if err == nil {
+			created++
+			continue
+		}
+		s.Require().ErrorIs(err, runnerErrors.ErrDuplicateEntity)
+	}
+	s.Require().Equal(1, created)
+}
+
+func (s *WebhookDeliveriesTestSuite) TestCreateWebhookDeliveryMissingID() {
+	_, err := s.Store.CreateWebhookDelivery(s.ctx, params.CreateWebhookDeliveryParams{})
+
+	s.Require().Equal("missing delivery ID", err.Error())
+}
+
+func (s *WebhookDeliveriesTestSuite) TestUpdateWebhookDelivery() {
+	s.createDelivery("delivery-1")
+
+	status := params.WebhookDeliveryCompleted
+	attempts := 2
+	lastError := "transient error"
+	delivery, err := s.Store.UpdateWebhookDelivery(s.ctx, "delivery-1", params.UpdateWebhookDeliveryParams{
+		Status:    &status,
+		Attempts:  &attempts,
+		LastError: &lastError,
+	})
+
+	s.Require().Nil(err)
+	s.Require().Equal(status, delivery.Status)
+	s.Require().Equal(attempts, delivery.Attempts)
+	s.Require().Equal(lastError, delivery.LastError)
+}
+
+func (s *WebhookDeliveriesTestSuite) TestUpdateWebhookDeliveryNotFound() {
+	status := params.WebhookDeliveryCompleted
+	_, err := s.Store.UpdateWebhookDelivery(s.ctx, "missing", params.UpdateWebhookDeliveryParams{
+		Status: &status,
+	})
+
+	s.Require().ErrorIs(err, runnerErrors.ErrNotFound)
+}
+
+func (s *WebhookDeliveriesTestSuite) TestListWebhookDeliveriesByStatus() {
+	s.createDelivery("delivery-1")
+	s.createDelivery("delivery-2")
+	status := params.WebhookDeliveryFailed
+	_, err := s.Store.UpdateWebhookDelivery(s.ctx, "delivery-2", params.UpdateWebhookDeliveryParams{
+		Status: &status,
+	})
+	s.Require().Nil(err)
+
+	pending, err := s.Store.ListWebhookDeliveriesByStatus(s.ctx, params.WebhookDeliveryPending)
+
+	s.Require().Nil(err)
+	s.Require().Len(pending, 1)
+	s.Require().Equal("delivery-1", pending[0].ID)
+}
+
+func (s *WebhookDeliveriesTestSuite) TestDeleteWebhookDeliveriesOlderThan() {
+	s.createDelivery("delivery-1")
+	s.createDelivery("delivery-2")
+	status := params.WebhookDeliveryCompleted
+	_, err := s.Store.UpdateWebhookDelivery(s.ctx, "delivery-2", params.UpdateWebhookDeliveryParams{
+		Status: &status,
+	})
+	s.Require().Nil(err)
+
+	err = s.Store.DeleteWebhookDeliveriesOlderThan(s.ctx, time.Now().Add(1*time.Minute))
+	s.Require().Nil(err)
+
+	// Pending deliveries are never removed.
+	_, err = s.Store.GetWebhookDelivery(s.ctx, "delivery-1")
+	s.Require().Nil(err)
+	_, err = s.Store.GetWebhookDelivery(s.ctx, "delivery-2")
+	s.Require().ErrorIs(err, runnerErrors.ErrNotFound)
+}
+
+func TestWebhookDeliveriesTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(WebhookDeliveriesTestSuite))
+}
@@ -112,12 +112,15 @@ func (s *WatcherStoreTestSuite) TestJobWatcher() {
 		s.T().Fatal("expected payload not received")
 	}
 
+	// Jobs never move back to queued, so use a new one.
+	jobParams.ID = 2
 	jobParams.Status = "queued"
-	jobParams.LockedBy = entityID
 
 	updatedJob, err = s.store.CreateOrUpdateJob(s.ctx, jobParams)
 	s.Require().NoError(err)
-	// We don't care about the update event here.
+	err = s.store.LockJob(s.ctx, updatedJob.ID, entityID.String())
+	s.Require().NoError(err)
+	// We don't care about the create and lock events here.
 	consumeEvents(consumer)
 
 	err = s.store.BreakLockJobIsQueued(s.ctx, updatedJob.ID)
@@ -18,4 +18,11 @@ var (
 		Name:      "pool_manager_status",
 		Help:      "Status of the enterprise pool manager",
 	}, []string{"name", "id", "running"})
+
+	EnterpriseConflictingControllerRunners = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsEnterpriseSubsystem,
+		Name:      "conflicting_controller_runners",
+		Help:      "Number of runners other GARM controllers have on the enterprise",
+	}, []string{"name", "id", "controller_id"})
 )
@@ -25,4 +25,18 @@ var (
 		Name:      "errors_total",
 		Help:      "Total number of failed instance operation attempts",
 	}, []string{"operation", "provider"})
+
+	RunnerSeconds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsRunnerSubsystem,
+		Name:      "seconds_total",
+		Help:      "Total number of seconds runners were up",
+	}, []string{"pool_id", "pool_owner", "pool_type"})
+
+	RunnerCost = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsRunnerSubsystem,
+		Name:      "cost_total",
+		Help:      "Total cost of the time runners were up, using the hourly cost of their pool",
+	}, []string{"pool_id", "pool_owner", "pool_type"})
 )
@@ -28,12 +28,15 @@ func RegisterMetrics() error {
 		// organization metrics
 		OrganizationInfo,
 		OrganizationPoolManagerStatus,
+		OrganizationConflictingControllerRunners,
 		// enterprise metrics
 		EnterpriseInfo,
 		EnterprisePoolManagerStatus,
+		EnterpriseConflictingControllerRunners,
 		// repository metrics
 		RepositoryInfo,
 		RepositoryPoolManagerStatus,
+		RepositoryConflictingControllerRunners,
 		// provider metrics
 		ProviderInfo,
 		// pool metrics
@@ -51,11 +54,19 @@ func RegisterMetrics() error {
 		// runner instances
 		InstanceOperationCount,
 		InstanceOperationFailedCount,
+		RunnerSeconds,
+		RunnerCost,
+		// provider throttling
+		ProviderQueuedOperations,
+		ProviderRunningOperations,
+		ProviderOperationWaitSeconds,
 		// github
 		GithubOperationCount,
 		GithubOperationFailedCount,
 		// webhook metrics
 		WebhooksReceived,
+		WebhookQueueDepth,
+		WebhookDeliveriesProcessed,
 	)
 
 	for _, c := range collectors {
@@ -18,4 +18,11 @@ var (
 		Name:      "pool_manager_status",
 		Help:      "Status of the organization pool manager",
 	}, []string{"name", "id", "running"})
+
+	OrganizationConflictingControllerRunners = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsOrganizationSubsystem,
+		Name:      "conflicting_controller_runners",
+		Help:      "Number of runners other GARM controllers have on the organization",
+	}, []string{"name", "id", "controller_id"})
 )
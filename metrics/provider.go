@@ -10,3 +10,25 @@ var ProviderInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	Name:      "info",
 	Help:      "Info of the organization",
 }, []string{"name", "type", "description"})
+
+var ProviderQueuedOperations = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: metricsNamespace,
+	Subsystem: metricsProviderSubsystem,
+	Name:      "queued_operations",
+	Help:      "The number of operations waiting for the provider throttle",
+}, []string{"provider"})
+
+var ProviderRunningOperations = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: metricsNamespace,
+	Subsystem: metricsProviderSubsystem,
+	Name:      "running_operations",
+	Help:      "The number of provider operations in progress",
+}, []string{"provider"})
+
+var ProviderOperationWaitSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: metricsNamespace,
+	Subsystem: metricsProviderSubsystem,
+	Name:      "operation_wait_seconds",
+	Help:      "The time operations waited for the provider throttle",
+	Buckets:   []float64{0.01, 0.1, 0.5, 1, 5, 15, 30, 60, 120, 300},
+}, []string{"provider", "operation"})
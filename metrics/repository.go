@@ -18,4 +18,11 @@ var (
 		Name:      "pool_manager_status",
 		Help:      "Status of the enterprise pool manager",
 	}, []string{"name", "id", "running"})
+
+	RepositoryConflictingControllerRunners = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsRepositorySubsystem,
+		Name:      "conflicting_controller_runners",
+		Help:      "Number of runners other GARM controllers have on the repository",
+	}, []string{"name", "id", "controller_id"})
 )
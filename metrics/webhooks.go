@@ -8,3 +8,17 @@ var WebhooksReceived = prometheus.NewCounterVec(prometheus.CounterOpts{
 	Name:      "received",
 	Help:      "The total number of webhooks received",
 }, []string{"valid", "reason"})
+
+var WebhookQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: metricsNamespace,
+	Subsystem: metricsWebhookSubsystem,
+	Name:      "queue_depth",
+	Help:      "The number of webhook deliveries awaiting processing",
+})
+
+var WebhookDeliveriesProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Subsystem: metricsWebhookSubsystem,
+	Name:      "deliveries_processed",
+	Help:      "The total number of webhook delivery processing attempts",
+}, []string{"result"})
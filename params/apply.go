@@ -0,0 +1,683 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package params
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+)
+
+type (
+	ApplyActionType string
+	ApplyObjectKind string
+)
+
+const (
+	ApplyActionCreate ApplyActionType = "create"
+	ApplyActionUpdate ApplyActionType = "update"
+	ApplyActionDelete ApplyActionType = "delete"
+)
+
+const (
+	ApplyObjectKindEndpoint     ApplyObjectKind = "endpoint"
+	ApplyObjectKindCredentials  ApplyObjectKind = "credentials"
+	ApplyObjectKindRepository   ApplyObjectKind = "repository"
+	ApplyObjectKindOrganization ApplyObjectKind = "organization"
+	ApplyObjectKindEnterprise   ApplyObjectKind = "enterprise"
+	ApplyObjectKindPool         ApplyObjectKind = "pool"
+)
+
+// ApplyDocument is a declarative description of the GARM objects that should
+// exist. It is used both as input for apply and as output for export.
+//
+// Credentials are only referenced by name. Secrets (PATs, app keys) are never
+// part of the document and credentials must be created beforehand.
+type ApplyDocument struct {
+	Endpoints     []ApplyEndpoint     `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`
+	Credentials   []ApplyCredentials  `json:"credentials,omitempty" yaml:"credentials,omitempty"`
+	Repositories  []ApplyRepository   `json:"repositories,omitempty" yaml:"repositories,omitempty"`
+	Organizations []ApplyOrganization `json:"organizations,omitempty" yaml:"organizations,omitempty"`
+	Enterprises   []ApplyEnterprise   `json:"enterprises,omitempty" yaml:"enterprises,omitempty"`
+}
+
+func (a ApplyDocument) Validate() error {
+	seen := map[string]struct{}{}
+	checkDuplicate := func(kind ApplyObjectKind, name string) error {
+		key := fmt.Sprintf("%s/%s", kind, name)
+		if _, ok := seen[key]; ok {
+			return runnerErrors.NewBadRequestError("duplicate %s %s", kind, name)
+		}
+		seen[key] = struct{}{}
+		return nil
+	}
+
+	for _, ep := range a.Endpoints {
+		if ep.Name == "" {
+			return runnerErrors.NewBadRequestError("missing endpoint name")
+		}
+		if err := checkDuplicate(ApplyObjectKindEndpoint, ep.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, creds := range a.Credentials {
+		if creds.Name == "" {
+			return runnerErrors.NewBadRequestError("missing credentials name")
+		}
+		if err := checkDuplicate(ApplyObjectKindCredentials, creds.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, repo := range a.Repositories {
+		if repo.Owner == "" || repo.Name == "" {
+			return runnerErrors.NewBadRequestError("missing repository owner or name")
+		}
+		if err := repo.validate(); err != nil {
+			return errors.Wrapf(err, "repository %s/%s", repo.Owner, repo.Name)
+		}
+		if err := checkDuplicate(ApplyObjectKindRepository, repo.Owner+"/"+repo.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, org := range a.Organizations {
+		if org.Name == "" {
+			return runnerErrors.NewBadRequestError("missing organization name")
+		}
+		if err := org.validate(); err != nil {
+			return errors.Wrapf(err, "organization %s", org.Name)
+		}
+		if err := checkDuplicate(ApplyObjectKindOrganization, org.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, ent := range a.Enterprises {
+		if ent.Name == "" {
+			return runnerErrors.NewBadRequestError("missing enterprise name")
+		}
+		if err := ent.validate(); err != nil {
+			return errors.Wrapf(err, "enterprise %s", ent.Name)
+		}
+		if err := checkDuplicate(ApplyObjectKindEnterprise, ent.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type ApplyEndpoint struct {
+	Name          string `json:"name" yaml:"name"`
+	Description   string `json:"description,omitempty" yaml:"description,omitempty"`
+	APIBaseURL    string `json:"api_base_url,omitempty" yaml:"api_base_url,omitempty"`
+	UploadBaseURL string `json:"upload_base_url,omitempty" yaml:"upload_base_url,omitempty"`
+	BaseURL       string `json:"base_url,omitempty" yaml:"base_url,omitempty"`
+	// CACertBundle is the PEM encoded CA bundle used to access this endpoint.
+	CACertBundle string `json:"ca_cert_bundle,omitempty" yaml:"ca_cert_bundle,omitempty"`
+}
+
+// ApplyCredentials references existing GitHub credentials. Only the description
+// is converged.
+type ApplyCredentials struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Endpoint    string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+}
+
+// ApplyEntity holds the fields shared by repositories, organizations and enterprises.
+type ApplyEntity struct {
+	CredentialsName  string           `json:"credentials_name" yaml:"credentials_name"`
+	PoolBalancerType PoolBalancerType `json:"pool_balancer_type,omitempty" yaml:"pool_balancer_type,omitempty"`
+	// WebhookSecret is optional. A random secret is generated when creating
+	// an entity without one. It is never exported.
+	WebhookSecret string `json:"webhook_secret,omitempty" yaml:"webhook_secret,omitempty"`
+	// ObserveOnly makes the pool manager of the entity record the actions it
+	// would take, without creating or deleting runners.
+	ObserveOnly bool `json:"observe_only,omitempty" yaml:"observe_only,omitempty"`
+	// JobScheduling sets the order in which the queued jobs of the entity get
+	// runners. Leaving it out restores the default order.
+	JobScheduling *JobScheduling `json:"job_scheduling,omitempty" yaml:"job_scheduling,omitempty"`
+	Pools         []ApplyPool    `json:"pools,omitempty" yaml:"pools,omitempty"`
+}
+
+func (a ApplyEntity) validate() error {
+	if a.CredentialsName == "" {
+		return runnerErrors.NewBadRequestError("missing credentials name")
+	}
+	switch a.PoolBalancerType {
+	case PoolBalancerTypeRoundRobin, PoolBalancerTypePack, PoolBalancerTypeNone:
+	default:
+		return runnerErrors.NewBadRequestError("invalid pool balancer type %s", a.PoolBalancerType)
+	}
+	if err := ValidateJobScheduling(a.JobScheduling); err != nil {
+		return runnerErrors.NewBadRequestError("%s", err)
+	}
+
+	seen := map[string]struct{}{}
+	for _, pool := range a.Pools {
+		if err := pool.Validate(); err != nil {
+			return err
+		}
+		if pool.ID != "" {
+			if _, ok := seen[pool.ID]; ok {
+				return runnerErrors.NewBadRequestError("duplicate pool %s", pool.ID)
+			}
+			seen[pool.ID] = struct{}{}
+		}
+	}
+	return nil
+}
+
+type ApplyRepository struct {
+	Owner string `json:"owner" yaml:"owner"`
+	Name  string `json:"name" yaml:"name"`
+
+	ApplyEntity `yaml:",inline"`
+}
+
+type ApplyOrganization struct {
+	Name string `json:"name" yaml:"name"`
+
+	ApplyEntity `yaml:",inline"`
+}
+
+type ApplyEnterprise struct {
+	Name string `json:"name" yaml:"name"`
+
+	ApplyEntity `yaml:",inline"`
+}
+
+// ApplyPool describes a pool of an entity. Pools are matched against existing
+// pools by ID if set, or by provider and tags otherwise.
+type ApplyPool struct {
+	ID                     string              `json:"id,omitempty" yaml:"id,omitempty"`
+	ProviderName           string              `json:"provider_name" yaml:"provider_name"`
+	Image                  string              `json:"image" yaml:"image"`
+	Flavor                 string              `json:"flavor" yaml:"flavor"`
+	OSType                 commonParams.OSType `json:"os_type,omitempty" yaml:"os_type,omitempty"`
+	OSArch                 commonParams.OSArch `json:"os_arch,omitempty" yaml:"os_arch,omitempty"`
+	Tags                   []string            `json:"tags" yaml:"tags"`
+	MaxRunners             uint                `json:"max_runners" yaml:"max_runners"`
+	MinIdleRunners         uint                `json:"min_idle_runners" yaml:"min_idle_runners"`
+	RunnerPrefix           string              `json:"runner_prefix,omitempty" yaml:"runner_prefix,omitempty"`
+	RunnerBootstrapTimeout uint                `json:"runner_bootstrap_timeout,omitempty" yaml:"runner_bootstrap_timeout,omitempty"`
+	Enabled                bool                `json:"enabled" yaml:"enabled"`
+	ExtraSpecs             map[string]any      `json:"extra_specs,omitempty" yaml:"extra_specs,omitempty"`
+	GitHubRunnerGroup      string              `json:"github_runner_group,omitempty" yaml:"github_runner_group,omitempty"`
+	Priority               uint                `json:"priority,omitempty" yaml:"priority,omitempty"`
+	// TemplateID is the ID of the pool template the pool is derived from. The
+	// template of an existing pool cannot be changed.
+	TemplateID string `json:"template_id,omitempty" yaml:"template_id,omitempty"`
+	// TemplateOverrides lists the template fields that are set by the document.
+	// The other template fields are inherited from the template, and their
+	// values in the document are ignored.
+	TemplateOverrides []string `json:"template_overrides,omitempty" yaml:"template_overrides,omitempty"`
+	// RolloutMaxSurge and RolloutMaxUnavailable are not compared if left empty.
+	RolloutMaxSurge         *uint       `json:"rollout_max_surge,omitempty" yaml:"rollout_max_surge,omitempty"`
+	RolloutMaxUnavailable   *uint       `json:"rollout_max_unavailable,omitempty" yaml:"rollout_max_unavailable,omitempty"`
+	Reusable                bool        `json:"reusable,omitempty" yaml:"reusable,omitempty"`
+	RunnerMaxJobs           uint        `json:"runner_max_jobs,omitempty" yaml:"runner_max_jobs,omitempty"`
+	RunnerMaxLifetime       uint        `json:"runner_max_lifetime,omitempty" yaml:"runner_max_lifetime,omitempty"`
+	JobCompletedHook        string      `json:"job_completed_hook,omitempty" yaml:"job_completed_hook,omitempty"`
+	IncludedRepositories    []string    `json:"included_repositories,omitempty" yaml:"included_repositories,omitempty"`
+	ExcludedRepositories    []string    `json:"excluded_repositories,omitempty" yaml:"excluded_repositories,omitempty"`
+	Policy                  *PoolPolicy `json:"policy,omitempty" yaml:"policy,omitempty"`
+	HourlyCost              float64     `json:"hourly_cost,omitempty" yaml:"hourly_cost,omitempty"`
+	MaxRunnersPerRepository uint        `json:"max_runners_per_repository,omitempty" yaml:"max_runners_per_repository,omitempty"`
+}
+
+func (a ApplyPool) Validate() error {
+	if a.ProviderName == "" {
+		return runnerErrors.NewBadRequestError("missing pool provider name")
+	}
+	if a.Image == "" {
+		return runnerErrors.NewBadRequestError("missing pool image")
+	}
+	if a.Flavor == "" {
+		return runnerErrors.NewBadRequestError("missing pool flavor")
+	}
+	if len(a.Tags) == 0 {
+		return runnerErrors.NewBadRequestError("missing pool tags")
+	}
+	if a.MaxRunners == 0 {
+		return runnerErrors.NewBadRequestError("max_runners must be greater than 0")
+	}
+	if a.MinIdleRunners > a.MaxRunners {
+		return runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners")
+	}
+	if a.TemplateID == "" && len(a.TemplateOverrides) > 0 {
+		return runnerErrors.NewBadRequestError("template_overrides set on a pool without a template")
+	}
+	for _, field := range a.TemplateOverrides {
+		if !IsPoolTemplateField(field) {
+			return runnerErrors.NewBadRequestError("invalid template field %s", field)
+		}
+	}
+
+	if err := ValidateRunnerReuse(a.Reusable, a.RunnerMaxJobs, a.RunnerMaxLifetime); err != nil {
+		return runnerErrors.NewBadRequestError("%s", err)
+	}
+	if err := ValidateRepositoryPatterns(a.IncludedRepositories); err != nil {
+		return runnerErrors.NewBadRequestError("%s", err)
+	}
+	if err := ValidateRepositoryPatterns(a.ExcludedRepositories); err != nil {
+		return runnerErrors.NewBadRequestError("%s", err)
+	}
+	if err := ValidateIdleRunnersRepositories(a.MinIdleRunners, a.IncludedRepositories, a.ExcludedRepositories); err != nil {
+		return runnerErrors.NewBadRequestError("%s", err)
+	}
+	if err := ValidatePoolPolicy(a.Policy); err != nil {
+		return runnerErrors.NewBadRequestError("%s", err)
+	}
+	if err := ValidateIdleRunnersPolicy(a.MinIdleRunners, a.Policy); err != nil {
+		return runnerErrors.NewBadRequestError("%s", err)
+	}
+	if err := ValidateHourlyCost(a.HourlyCost); err != nil {
+		return runnerErrors.NewBadRequestError("%s", err)
+	}
+	return nil
+}
+
+// isInherited returns true if the template field is inherited from the template
+// of the pool, rather than set by the document.
+func (a ApplyPool) isInherited(field string) bool {
+	if a.TemplateID == "" || !IsPoolTemplateField(field) {
+		return false
+	}
+	for _, val := range a.TemplateOverrides {
+		if val == field {
+			return false
+		}
+	}
+	return true
+}
+
+// Key returns the value used to match this pool against existing pools
+// when no ID is set.
+func (a ApplyPool) Key() string {
+	return poolKey(a.ProviderName, a.Tags)
+}
+
+func (a ApplyPool) extraSpecs() (json.RawMessage, error) {
+	if len(a.ExtraSpecs) == 0 {
+		return nil, nil
+	}
+	ret, err := json.Marshal(a.ExtraSpecs)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling extra specs")
+	}
+	return ret, nil
+}
+
+// CreateParams returns the parameters needed to create the pool. For pools
+// derived from a template, the inherited fields are left empty, so they are
+// filled in from the template.
+func (a ApplyPool) CreateParams() (CreatePoolParams, error) {
+	extraSpecs, err := a.extraSpecs()
+	if err != nil {
+		return CreatePoolParams{}, err
+	}
+	ret := CreatePoolParams{
+		RunnerPrefix: RunnerPrefix{
+			Prefix: a.RunnerPrefix,
+		},
+		ProviderName:            a.ProviderName,
+		MaxRunners:              a.MaxRunners,
+		MinIdleRunners:          a.MinIdleRunners,
+		Image:                   a.Image,
+		Flavor:                  a.Flavor,
+		OSType:                  a.OSType,
+		OSArch:                  a.OSArch,
+		Tags:                    a.Tags,
+		Enabled:                 a.Enabled,
+		RunnerBootstrapTimeout:  a.RunnerBootstrapTimeout,
+		ExtraSpecs:              extraSpecs,
+		GitHubRunnerGroup:       a.GitHubRunnerGroup,
+		Priority:                a.Priority,
+		TemplateID:              a.TemplateID,
+		RolloutMaxSurge:         a.RolloutMaxSurge,
+		RolloutMaxUnavailable:   a.RolloutMaxUnavailable,
+		Reusable:                a.Reusable,
+		RunnerMaxJobs:           a.RunnerMaxJobs,
+		RunnerMaxLifetime:       a.RunnerMaxLifetime,
+		JobCompletedHook:        a.JobCompletedHook,
+		IncludedRepositories:    a.IncludedRepositories,
+		ExcludedRepositories:    a.ExcludedRepositories,
+		Policy:                  a.Policy,
+		HourlyCost:              a.HourlyCost,
+		MaxRunnersPerRepository: a.MaxRunnersPerRepository,
+	}
+	if a.TemplateID == "" {
+		return ret, nil
+	}
+
+	if a.isInherited(PoolTemplateFieldRunnerPrefix) {
+		ret.Prefix = ""
+	}
+	if a.isInherited(PoolTemplateFieldMaxRunners) {
+		ret.MaxRunners = 0
+	}
+	if a.isInherited(PoolTemplateFieldMinIdleRunners) {
+		ret.MinIdleRunners = 0
+	}
+	if a.isInherited(PoolTemplateFieldRunnerBootstrapTimeout) {
+		ret.RunnerBootstrapTimeout = 0
+	}
+	if a.isInherited(PoolTemplateFieldImage) {
+		ret.Image = ""
+	}
+	if a.isInherited(PoolTemplateFieldFlavor) {
+		ret.Flavor = ""
+	}
+	if a.isInherited(PoolTemplateFieldOSType) {
+		ret.OSType = ""
+	}
+	if a.isInherited(PoolTemplateFieldOSArch) {
+		ret.OSArch = ""
+	}
+	if a.isInherited(PoolTemplateFieldTags) {
+		ret.Tags = nil
+	}
+	if a.isInherited(PoolTemplateFieldExtraSpecs) {
+		ret.ExtraSpecs = nil
+	}
+	if a.isInherited(PoolTemplateFieldGitHubRunnerGroup) {
+		ret.GitHubRunnerGroup = ""
+	}
+	if a.isInherited(PoolTemplateFieldPriority) {
+		ret.Priority = 0
+	}
+	return ret, nil
+}
+
+// UpdateParams compares the desired pool with an existing one and returns the
+// parameters needed to converge it, along with the list of changed fields. Fields
+// left empty in the document (os type, os arch, bootstrap timeout, rollout limits)
+// and fields inherited from a template are not compared.
+func (a ApplyPool) UpdateParams(pool Pool) (UpdatePoolParams, []ApplyFieldChange, error) {
+	if a.ProviderName != pool.ProviderName {
+		return UpdatePoolParams{}, nil, runnerErrors.NewBadRequestError("pool %s: provider cannot be changed", pool.ID)
+	}
+	if a.TemplateID != pool.TemplateID {
+		return UpdatePoolParams{}, nil, runnerErrors.NewBadRequestError("pool %s: template cannot be changed", pool.ID)
+	}
+
+	var ret UpdatePoolParams
+	var changes []ApplyFieldChange
+	changed := func(field string, oldVal, newVal interface{}) bool {
+		if a.isInherited(field) {
+			return false
+		}
+		oldStr, newStr := fmt.Sprintf("%v", oldVal), fmt.Sprintf("%v", newVal)
+		if oldStr == newStr {
+			// A template field that becomes an override is set even if its
+			// value does not change, so it is recorded as an override.
+			return a.TemplateID != "" && IsPoolTemplateField(field) && !pool.IsTemplateOverride(field)
+		}
+		changes = append(changes, ApplyFieldChange{
+			Field: field,
+			Old:   oldStr,
+			New:   newStr,
+		})
+		return true
+	}
+
+	prefix := RunnerPrefix{Prefix: a.RunnerPrefix}
+	if changed(PoolTemplateFieldRunnerPrefix, pool.GetRunnerPrefix(), prefix.GetRunnerPrefix()) {
+		ret.Prefix = prefix.GetRunnerPrefix()
+	}
+	if changed(PoolTemplateFieldMaxRunners, pool.MaxRunners, a.MaxRunners) {
+		ret.MaxRunners = &a.MaxRunners
+	}
+	if changed(PoolTemplateFieldMinIdleRunners, pool.MinIdleRunners, a.MinIdleRunners) {
+		ret.MinIdleRunners = &a.MinIdleRunners
+	}
+	if a.RunnerBootstrapTimeout > 0 && changed(PoolTemplateFieldRunnerBootstrapTimeout, pool.RunnerBootstrapTimeout, a.RunnerBootstrapTimeout) {
+		ret.RunnerBootstrapTimeout = &a.RunnerBootstrapTimeout
+	}
+	if changed(PoolTemplateFieldImage, pool.Image, a.Image) {
+		ret.Image = a.Image
+	}
+	if changed(PoolTemplateFieldFlavor, pool.Flavor, a.Flavor) {
+		ret.Flavor = a.Flavor
+	}
+	if a.OSType != "" && changed(PoolTemplateFieldOSType, pool.OSType, a.OSType) {
+		ret.OSType = a.OSType
+	}
+	if a.OSArch != "" && changed(PoolTemplateFieldOSArch, pool.OSArch, a.OSArch) {
+		ret.OSArch = a.OSArch
+	}
+	if changed(PoolTemplateFieldTags, strings.Join(pool.tagNames(), ","), strings.Join(sortedCopy(a.Tags), ",")) {
+		ret.Tags = a.Tags
+	}
+	if changed("enabled", pool.Enabled, a.Enabled) {
+		ret.Enabled = &a.Enabled
+	}
+
+	extraSpecs, err := a.extraSpecs()
+	if err != nil {
+		return UpdatePoolParams{}, nil, err
+	}
+	currentSpecs, err := normalizeExtraSpecs(pool.ExtraSpecs)
+	if err != nil {
+		return UpdatePoolParams{}, nil, errors.Wrapf(err, "pool %s", pool.ID)
+	}
+	if changed(PoolTemplateFieldExtraSpecs, string(currentSpecs), string(extraSpecs)) {
+		ret.ExtraSpecs = json.RawMessage{}
+		if len(extraSpecs) > 0 {
+			ret.ExtraSpecs = extraSpecs
+		}
+	}
+	if changed(PoolTemplateFieldGitHubRunnerGroup, pool.GitHubRunnerGroup, a.GitHubRunnerGroup) {
+		ret.GitHubRunnerGroup = &a.GitHubRunnerGroup
+	}
+	if changed(PoolTemplateFieldPriority, pool.Priority, a.Priority) {
+		ret.Priority = &a.Priority
+	}
+
+	if a.TemplateID != "" {
+		overrides := []string{}
+		for _, field := range PoolTemplateFields {
+			if !a.isInherited(field) {
+				overrides = append(overrides, field)
+			} else if pool.IsTemplateOverride(field) {
+				ret.ResetTemplateOverrides = append(ret.ResetTemplateOverrides, field)
+			}
+		}
+		changed("template_overrides", strings.Join(pool.TemplateOverrides, ","), strings.Join(overrides, ","))
+	}
+
+	if a.RolloutMaxSurge != nil && changed("rollout_max_surge", pool.RolloutMaxSurge, *a.RolloutMaxSurge) {
+		ret.RolloutMaxSurge = a.RolloutMaxSurge
+	}
+	if a.RolloutMaxUnavailable != nil && changed("rollout_max_unavailable", pool.RolloutMaxUnavailable, *a.RolloutMaxUnavailable) {
+		ret.RolloutMaxUnavailable = a.RolloutMaxUnavailable
+	}
+	if changed("reusable", pool.Reusable, a.Reusable) {
+		ret.Reusable = &a.Reusable
+	}
+	if changed("runner_max_jobs", pool.RunnerMaxJobs, a.RunnerMaxJobs) {
+		ret.RunnerMaxJobs = &a.RunnerMaxJobs
+	}
+	if changed("runner_max_lifetime", pool.RunnerMaxLifetime, a.RunnerMaxLifetime) {
+		ret.RunnerMaxLifetime = &a.RunnerMaxLifetime
+	}
+	if changed("job_completed_hook", pool.JobCompletedHook, a.JobCompletedHook) {
+		ret.JobCompletedHook = &a.JobCompletedHook
+	}
+	// An empty list clears the repository filters of the pool.
+	if changed("included_repositories", strings.Join(pool.IncludedRepositories, ","), strings.Join(a.IncludedRepositories, ",")) {
+		ret.IncludedRepositories = append([]string{}, a.IncludedRepositories...)
+	}
+	if changed("excluded_repositories", strings.Join(pool.ExcludedRepositories, ","), strings.Join(a.ExcludedRepositories, ",")) {
+		ret.ExcludedRepositories = append([]string{}, a.ExcludedRepositories...)
+	}
+
+	currentPolicy, err := policyString(pool.Policy)
+	if err != nil {
+		return UpdatePoolParams{}, nil, errors.Wrapf(err, "pool %s", pool.ID)
+	}
+	policy, err := policyString(a.Policy)
+	if err != nil {
+		return UpdatePoolParams{}, nil, err
+	}
+	if changed("policy", currentPolicy, policy) {
+		// An empty policy removes the policy of the pool.
+		ret.Policy = &PoolPolicy{}
+		if a.Policy != nil {
+			ret.Policy = a.Policy
+		}
+	}
+	if changed("hourly_cost", pool.HourlyCost, a.HourlyCost) {
+		ret.HourlyCost = &a.HourlyCost
+	}
+	if changed("max_runners_per_repository", pool.MaxRunnersPerRepository, a.MaxRunnersPerRepository) {
+		ret.MaxRunnersPerRepository = &a.MaxRunnersPerRepository
+	}
+	return ret, changes, nil
+}
+
+// policyString encodes a pool policy so it can be compared with the one in a
+// declarative document. A policy without rules is the same as no policy.
+func policyString(policy *PoolPolicy) (string, error) {
+	if policy == nil || policy.IsEmpty() {
+		return "", nil
+	}
+	asJSON, err := json.Marshal(policy)
+	if err != nil {
+		return "", errors.Wrap(err, "marshaling policy")
+	}
+	return string(asJSON), nil
+}
+
+// ApplyPoolFromPool converts an existing pool to its declarative form.
+func ApplyPoolFromPool(pool Pool) (ApplyPool, error) {
+	var extraSpecs map[string]any
+	if len(pool.ExtraSpecs) > 0 {
+		if err := json.Unmarshal(pool.ExtraSpecs, &extraSpecs); err != nil {
+			return ApplyPool{}, errors.Wrapf(err, "unmarshaling extra specs of pool %s", pool.ID)
+		}
+	}
+	return ApplyPool{
+		ID:                      pool.ID,
+		ProviderName:            pool.ProviderName,
+		Image:                   pool.Image,
+		Flavor:                  pool.Flavor,
+		OSType:                  pool.OSType,
+		OSArch:                  pool.OSArch,
+		Tags:                    pool.tagNames(),
+		MaxRunners:              pool.MaxRunners,
+		MinIdleRunners:          pool.MinIdleRunners,
+		RunnerPrefix:            pool.Prefix,
+		RunnerBootstrapTimeout:  pool.RunnerBootstrapTimeout,
+		Enabled:                 pool.Enabled,
+		ExtraSpecs:              extraSpecs,
+		GitHubRunnerGroup:       pool.GitHubRunnerGroup,
+		Priority:                pool.Priority,
+		TemplateID:              pool.TemplateID,
+		TemplateOverrides:       pool.TemplateOverrides,
+		RolloutMaxSurge:         &pool.RolloutMaxSurge,
+		RolloutMaxUnavailable:   &pool.RolloutMaxUnavailable,
+		Reusable:                pool.Reusable,
+		RunnerMaxJobs:           pool.RunnerMaxJobs,
+		RunnerMaxLifetime:       pool.RunnerMaxLifetime,
+		JobCompletedHook:        pool.JobCompletedHook,
+		IncludedRepositories:    pool.IncludedRepositories,
+		ExcludedRepositories:    pool.ExcludedRepositories,
+		Policy:                  pool.Policy,
+		HourlyCost:              pool.HourlyCost,
+		MaxRunnersPerRepository: pool.MaxRunnersPerRepository,
+	}, nil
+}
+
+// PoolKey returns the value used to match a declarative pool against this pool.
+func (p Pool) PoolKey() string {
+	return poolKey(p.ProviderName, p.tagNames())
+}
+
+func (p Pool) tagNames() []string {
+	ret := make([]string, len(p.Tags))
+	for idx, tag := range p.Tags {
+		ret[idx] = tag.Name
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func poolKey(provider string, tags []string) string {
+	return fmt.Sprintf("%s:%s", provider, strings.Join(sortedCopy(tags), ","))
+}
+
+func sortedCopy(val []string) []string {
+	ret := make([]string, len(val))
+	copy(ret, val)
+	sort.Strings(ret)
+	return ret
+}
+
+// normalizeExtraSpecs re-encodes the extra specs so they can be compared with
+// the ones in a declarative document.
+func normalizeExtraSpecs(specs json.RawMessage) (json.RawMessage, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	var asMap map[string]any
+	if err := json.Unmarshal(specs, &asMap); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling extra specs")
+	}
+	if len(asMap) == 0 {
+		return nil, nil
+	}
+	ret, err := json.Marshal(asMap)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling extra specs")
+	}
+	return ret, nil
+}
+
+// ApplyFieldChange describes a single field that differs between the document
+// and the current state.
+type ApplyFieldChange struct {
+	Field string `json:"field,omitempty"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// ApplyAction is one step of the plan computed by apply.
+type ApplyAction struct {
+	Action ApplyActionType `json:"action,omitempty"`
+	Kind   ApplyObjectKind `json:"kind,omitempty"`
+	// Name identifies the object. For pools, this is the name of the entity
+	// followed by the pool ID or the pool provider and tags.
+	Name    string             `json:"name,omitempty"`
+	ID      string             `json:"id,omitempty"`
+	Changes []ApplyFieldChange `json:"changes,omitempty"`
+}
+
+// ApplyResult holds the plan computed by apply. If DryRun is false, the
+// actions have been carried out.
+type ApplyResult struct {
+	DryRun  bool          `json:"dry_run"`
+	Prune   bool          `json:"prune"`
+	Actions []ApplyAction `json:"actions,omitempty"`
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package params
+
+import (
+	"encoding/json"
+	"time"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+)
+
+// BackupFormatVersion is the version of the backup format. It must be bumped
+// whenever a change is made that older versions of GARM can not restore.
+const BackupFormatVersion = 1
+
+// Backup is a portable copy of the GARM database. All secrets are sealed
+// using the backup passphrase instead of the database passphrase.
+//
+// Runners, jobs and pending webhook deliveries are not part of the backup.
+type Backup struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Controller    BackupController     `json:"controller"`
+	Users         []BackupUser         `json:"users,omitempty"`
+	Endpoints     []BackupEndpoint     `json:"endpoints,omitempty"`
+	Credentials   []BackupCredentials  `json:"credentials,omitempty"`
+	Repositories  []BackupEntity       `json:"repositories,omitempty"`
+	Organizations []BackupEntity       `json:"organizations,omitempty"`
+	Enterprises   []BackupEntity       `json:"enterprises,omitempty"`
+	PoolTemplates []BackupPoolTemplate `json:"pool_templates,omitempty"`
+	Pools         []BackupPool         `json:"pools,omitempty"`
+}
+
+type BackupController struct {
+	ControllerID         string `json:"controller_id"`
+	CallbackURL          string `json:"callback_url,omitempty"`
+	MetadataURL          string `json:"metadata_url,omitempty"`
+	WebhookBaseURL       string `json:"webhook_base_url,omitempty"`
+	MinimumJobAgeBackoff uint   `json:"minimum_job_age_backoff,omitempty"`
+	ObserveOnly          bool   `json:"observe_only,omitempty"`
+
+	PartitionLabel        string   `json:"partition_label,omitempty"`
+	PartitionRepositories []string `json:"partition_repositories,omitempty"`
+}
+
+type BackupUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	FullName string `json:"full_name,omitempty"`
+	Email    string `json:"email"`
+	// Password is the bcrypt hash of the user password.
+	Password string `json:"password"`
+	IsAdmin  bool   `json:"is_admin"`
+	Enabled  bool   `json:"enabled"`
+}
+
+type BackupEndpoint struct {
+	Name          string `json:"name"`
+	Description   string `json:"description,omitempty"`
+	APIBaseURL    string `json:"api_base_url,omitempty"`
+	UploadBaseURL string `json:"upload_base_url,omitempty"`
+	BaseURL       string `json:"base_url,omitempty"`
+	CACertBundle  []byte `json:"ca_cert_bundle,omitempty"`
+}
+
+type BackupCredentials struct {
+	ID          uint           `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	AuthType    GithubAuthType `json:"auth_type"`
+	Endpoint    string         `json:"endpoint"`
+	UserID      string         `json:"user_id,omitempty"`
+	// Payload is sealed with the backup passphrase.
+	Payload []byte `json:"payload"`
+}
+
+// BackupEntity holds a repository, organization or enterprise. Owner is only
+// set for repositories.
+type BackupEntity struct {
+	ID               string           `json:"id"`
+	Owner            string           `json:"owner,omitempty"`
+	Name             string           `json:"name"`
+	CredentialsID    uint             `json:"credentials_id"`
+	Endpoint         string           `json:"endpoint"`
+	PoolBalancerType PoolBalancerType `json:"pool_balancer_type,omitempty"`
+	JobScheduling    *JobScheduling   `json:"job_scheduling,omitempty"`
+	ObserveOnly      bool             `json:"observe_only,omitempty"`
+	// WebhookSecret and PreviousWebhookSecret are sealed with the backup passphrase.
+	WebhookSecret                  []byte     `json:"webhook_secret"`
+	PreviousWebhookSecret          []byte     `json:"previous_webhook_secret,omitempty"`
+	PreviousWebhookSecretExpiresAt *time.Time `json:"previous_webhook_secret_expires_at,omitempty"`
+}
+
+type BackupPoolTemplate struct {
+	ID                     string              `json:"id"`
+	Name                   string              `json:"name"`
+	Description            string              `json:"description,omitempty"`
+	ProviderName           string              `json:"provider_name"`
+	RunnerPrefix           string              `json:"runner_prefix,omitempty"`
+	MaxRunners             uint                `json:"max_runners"`
+	MinIdleRunners         uint                `json:"min_idle_runners"`
+	RunnerBootstrapTimeout uint                `json:"runner_bootstrap_timeout"`
+	Image                  string              `json:"image"`
+	Flavor                 string              `json:"flavor"`
+	OSType                 commonParams.OSType `json:"os_type"`
+	OSArch                 commonParams.OSArch `json:"os_arch"`
+	Tags                   []string            `json:"tags"`
+	ExtraSpecs             json.RawMessage     `json:"extra_specs,omitempty"`
+	GitHubRunnerGroup      string              `json:"github_runner_group,omitempty"`
+	Priority               uint                `json:"priority,omitempty"`
+}
+
+type BackupPool struct {
+	ID                     string              `json:"id"`
+	RepoID                 string              `json:"repo_id,omitempty"`
+	OrgID                  string              `json:"org_id,omitempty"`
+	EnterpriseID           string              `json:"enterprise_id,omitempty"`
+	TemplateID             string              `json:"template_id,omitempty"`
+	TemplateOverrides      []string            `json:"template_overrides,omitempty"`
+	ProviderName           string              `json:"provider_name"`
+	RunnerPrefix           string              `json:"runner_prefix,omitempty"`
+	MaxRunners             uint                `json:"max_runners"`
+	MinIdleRunners         uint                `json:"min_idle_runners"`
+	RunnerBootstrapTimeout uint                `json:"runner_bootstrap_timeout"`
+	Image                  string              `json:"image"`
+	Flavor                 string              `json:"flavor"`
+	OSType                 commonParams.OSType `json:"os_type"`
+	OSArch                 commonParams.OSArch `json:"os_arch"`
+	Tags                   []string            `json:"tags"`
+	Enabled                bool                `json:"enabled"`
+	ExtraSpecs             json.RawMessage     `json:"extra_specs,omitempty"`
+	GitHubRunnerGroup      string              `json:"github_runner_group,omitempty"`
+	Priority               uint                `json:"priority,omitempty"`
+	IncludedRepositories   []string            `json:"included_repositories,omitempty"`
+	ExcludedRepositories   []string            `json:"excluded_repositories,omitempty"`
+	Policy                 *PoolPolicy         `json:"policy,omitempty"`
+	HourlyCost             float64             `json:"hourly_cost,omitempty"`
+	Reusable               bool                `json:"reusable,omitempty"`
+	RunnerMaxJobs          uint                `json:"runner_max_jobs,omitempty"`
+	RunnerMaxLifetime      uint                `json:"runner_max_lifetime,omitempty"`
+	JobCompletedHook       string              `json:"job_completed_hook,omitempty"`
+	Generation             uint                `json:"generation,omitempty"`
+	RolloutMaxSurge        uint                `json:"rollout_max_surge,omitempty"`
+	RolloutMaxUnavailable  uint                `json:"rollout_max_unavailable,omitempty"`
+
+	MaxRunnersPerRepository uint `json:"max_runners_per_repository,omitempty"`
+}
@@ -55,6 +55,8 @@ type WorkflowJob struct {
 		RunnerName      string   `json:"runner_name"`
 		RunnerGroupID   int64    `json:"runner_group_id"`
 		RunnerGroupName string   `json:"runner_group_name"`
+		HeadBranch      string   `json:"head_branch"`
+		WorkflowName    string   `json:"workflow_name"`
 	} `json:"workflow_job"`
 	Repository struct {
 		ID       int64  `json:"id"`
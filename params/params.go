@@ -23,6 +23,9 @@ import (
 	"encoding/pem"
 	"fmt"
 	"net/http"
+	"path"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/bradleyfalzon/ghinstallation/v2"
@@ -30,20 +33,26 @@ import (
 	"github.com/google/uuid"
 	"golang.org/x/oauth2"
 
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
 	commonParams "github.com/cloudbase/garm-provider-common/params"
 	"github.com/cloudbase/garm/util/appdefaults"
 )
 
 type (
-	GithubEntityType    string
-	EventType           string
-	EventLevel          string
-	ProviderType        string
-	JobStatus           string
-	RunnerStatus        string
-	WebhookEndpointType string
-	GithubAuthType      string
-	PoolBalancerType    string
+	GithubEntityType      string
+	EventType             string
+	EventLevel            string
+	ProviderType          string
+	JobStatus             string
+	RunnerStatus          string
+	WebhookEndpointType   string
+	GithubAuthType        string
+	PoolBalancerType      string
+	WebhookDeliveryStatus string
+	ProviderErrorClass    string
+	InstanceStage         string
+	ObservedActionType    string
+	JobSchedulingPolicy   string
 )
 
 const (
@@ -84,6 +93,66 @@ const (
 	JobStatusCompleted  JobStatus = "completed"
 )
 
+// jobStatusOrder is the order in which a job moves through its lifecycle.
+var jobStatusOrder = map[JobStatus]int{
+	JobStatusQueued:     0,
+	JobStatusInProgress: 1,
+	JobStatusCompleted:  2,
+}
+
+// CanTransitionTo returns true if a job in this status may move to the next
+// status. Jobs only ever move forward from queued to in_progress and completed.
+// GitHub does not guarantee the order of deliveries, so a status that would move
+// the job back (for example in_progress arriving after completed) is stale.
+// Moving to the same status is allowed, as the same event is sent to every
+// entity (repo, org, enterprise) that has a webhook configured.
+// Unknown statuses are always allowed.
+func (s JobStatus) CanTransitionTo(next JobStatus) bool {
+	current, ok := jobStatusOrder[s]
+	if !ok {
+		return true
+	}
+	nextOrder, ok := jobStatusOrder[next]
+	if !ok {
+		return true
+	}
+	return nextOrder >= current
+}
+
+const (
+	// WebhookDeliveryPending denotes a delivery that was persisted and awaits
+	// processing, or is awaiting a retry after a transient error.
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryCompleted denotes a delivery that was successfully processed.
+	WebhookDeliveryCompleted WebhookDeliveryStatus = "completed"
+	// WebhookDeliveryFailed denotes a delivery that could not be processed and
+	// will not be retried.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+const (
+	// ProviderErrorTransient errors are retried. Errors that were not classified
+	// by the provider are considered transient.
+	ProviderErrorTransient ProviderErrorClass = "transient"
+	// ProviderErrorQuotaExceeded errors are retried, as quota may be freed up.
+	ProviderErrorQuotaExceeded ProviderErrorClass = "quota_exceeded"
+	// ProviderErrorImageNotFound errors are not retried.
+	ProviderErrorImageNotFound ProviderErrorClass = "image_not_found"
+	// ProviderErrorInvalidConfig errors are not retried. They are returned when
+	// the flavor, extra specs or provider config are not valid.
+	ProviderErrorInvalidConfig ProviderErrorClass = "invalid_config"
+)
+
+// Retryable returns true if creating an instance that failed with this class
+// of error may be retried.
+func (c ProviderErrorClass) Retryable() bool {
+	switch c {
+	case ProviderErrorImageNotFound, ProviderErrorInvalidConfig:
+		return false
+	}
+	return true
+}
+
 const (
 	GithubEntityTypeRepository   GithubEntityType = "repository"
 	GithubEntityTypeOrganization GithubEntityType = "organization"
@@ -99,8 +168,69 @@ const (
 const (
 	StatusEvent     EventType = "status"
 	FetchTokenEvent EventType = "fetchToken"
+	// ReapEvent records why garm removed an instance on its own.
+	ReapEvent EventType = "reap"
+)
+
+// The stages an instance goes through, in order.
+const (
+	InstanceStageCreated         InstanceStage = "created"
+	InstanceStageProviderCreated InstanceStage = "provider_created"
+	InstanceStageFirstCallback   InstanceStage = "first_callback"
+	InstanceStageJITFetched      InstanceStage = "jit_fetched"
+	InstanceStageRegistered      InstanceStage = "registered"
+	InstanceStageJobAssigned     InstanceStage = "job_assigned"
+	InstanceStageTerminated      InstanceStage = "terminated"
+)
+
+// The actions a pool manager records instead of taking them, when running in
+// observe only mode.
+const (
+	// ObservedActionCreateRunner is recorded instead of adding a runner to a pool.
+	ObservedActionCreateRunner ObservedActionType = "create_runner"
+	// ObservedActionDeleteRunner is recorded instead of removing a runner.
+	ObservedActionDeleteRunner ObservedActionType = "delete_runner"
+	// ObservedActionDrainRunner is recorded instead of draining a runner.
+	ObservedActionDrainRunner ObservedActionType = "drain_runner"
+	// ObservedActionRetryRunner is recorded instead of retrying to create a
+	// runner that failed.
+	ObservedActionRetryRunner ObservedActionType = "retry_runner"
+	// ObservedActionCreateInstance is recorded instead of creating a pending
+	// instance in the provider.
+	ObservedActionCreateInstance ObservedActionType = "create_instance"
+	// ObservedActionDeleteInstance is recorded instead of removing a pending
+	// instance from the provider.
+	ObservedActionDeleteInstance ObservedActionType = "delete_instance"
+	// ObservedActionStartInstance is recorded instead of starting a stopped
+	// instance in the provider.
+	ObservedActionStartInstance ObservedActionType = "start_instance"
+	// ObservedActionRemoveGithubRunner is recorded instead of removing an
+	// orphaned runner from GitHub.
+	ObservedActionRemoveGithubRunner ObservedActionType = "remove_github_runner"
 )
 
+const (
+	// JobSchedulingFIFO handles queued jobs in the order they were received.
+	JobSchedulingFIFO JobSchedulingPolicy = "fifo"
+	// JobSchedulingRoundRobin shares runners equally between the repositories
+	// that have queued jobs.
+	JobSchedulingRoundRobin JobSchedulingPolicy = "roundrobin"
+	// JobSchedulingWeighted shares runners between the repositories that have
+	// queued jobs, in proportion to their weight.
+	JobSchedulingWeighted JobSchedulingPolicy = "weighted"
+)
+
+// InstanceStages lists the lifecycle stages of an instance, in order.
+var InstanceStages = []InstanceStage{
+	InstanceStageCreated,
+	InstanceStageProviderCreated,
+	InstanceStageFirstCallback,
+	InstanceStageJITFetched,
+	InstanceStageRegistered,
+	InstanceStageJobAssigned,
+	InstanceStageTerminated,
+}
+
 const (
 	EventInfo    EventLevel = "info"
 	EventWarning EventLevel = "warning"
@@ -186,6 +316,9 @@ type Instance struct {
 	// up.
 	StatusMessages []StatusMessage `json:"status_messages,omitempty"`
 
+	// CreatedAt is the timestamp of the creation of this runner.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+
 	// UpdatedAt is the timestamp of the last update to this runner.
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
 
@@ -196,6 +329,37 @@ type Instance struct {
 	// Job is the current job that is being serviced by this runner.
 	Job *Job `json:"job,omitempty"`
 
+	// Draining is set if the runner will be removed once it is no longer
+	// running a job.
+	Draining bool `json:"draining,omitempty"`
+
+	// PoolGeneration is the generation of the pool when this runner was created.
+	// Runners created with an older generation than the current one of the pool
+	// run an outdated image, flavor or extra specs.
+	PoolGeneration uint `json:"pool_generation,omitempty"`
+
+	// Adopted is set if the runner was adopted by GARM instead of created by it.
+	// The runner may have been created by another controller or autoscaler.
+	Adopted bool `json:"adopted,omitempty"`
+
+	// JobsCompleted is the number of jobs a reusable runner has completed.
+	JobsCompleted uint `json:"jobs_completed,omitempty"`
+
+	// ErrorClass is the class of the error the provider returned the last time
+	// it failed to create this instance.
+	ErrorClass ProviderErrorClass `json:"error_class,omitempty"`
+	// NextRetryAt is the time after which creating the instance is retried.
+	NextRetryAt *time.Time `json:"next_retry_at,omitempty"`
+
+	// Lifecycle records when the instance reached each stage of its life.
+	Lifecycle InstanceLifecycle `json:"lifecycle"`
+
+	// ConsoleOutputCapturedAt is the time the console output of the instance
+	// was captured after a failure.
+	ConsoleOutputCapturedAt *time.Time `json:"console_output_captured_at,omitempty"`
+	// ConsoleOutput is only returned by the console endpoint.
+	ConsoleOutput []byte `json:"-"`
+
 	// Do not serialize sensitive info.
 	CallbackURL      string            `json:"-"`
 	MetadataURL      string            `json:"-"`
@@ -205,6 +369,48 @@ type Instance struct {
 	JitConfiguration map[string]string `json:"-"`
 }
 
+// LastStage returns the latest stage the instance reached.
+func (i Instance) LastStage() InstanceStage {
+	stage := InstanceStageCreated
+	for _, entry := range i.Lifecycle.stages() {
+		if entry.at != nil {
+			stage = entry.stage
+		}
+	}
+	return stage
+}
+
+// Timeline returns the stages the instance reached and its events, in the
+// order in which they happened.
+func (i Instance) Timeline() InstanceTimeline {
+	entries := []InstanceTimelineEntry{
+		{Time: i.CreatedAt, Stage: InstanceStageCreated},
+	}
+	for _, entry := range i.Lifecycle.stages() {
+		if entry.at != nil {
+			entries = append(entries, InstanceTimelineEntry{Time: *entry.at, Stage: entry.stage})
+		}
+	}
+	for _, msg := range i.StatusMessages {
+		entries = append(entries, InstanceTimelineEntry{
+			Time:       msg.CreatedAt,
+			EventType:  msg.EventType,
+			EventLevel: msg.EventLevel,
+			Message:    msg.Message,
+		})
+	}
+	sort.SliceStable(entries, func(a, b int) bool {
+		return entries[a].Time.Before(entries[b].Time)
+	})
+	return InstanceTimeline{
+		InstanceName: i.Name,
+		Status:       i.Status,
+		RunnerStatus: i.RunnerStatus,
+		Stage:        i.LastStage(),
+		Entries:      entries,
+	}
+}
+
 func (i Instance) GetName() string {
 	return i.Name
 }
@@ -319,6 +525,261 @@ type Pool struct {
 	// When fetching matching pools for a set of tags, the result will be sorted in descending
 	// order of priority.
 	Priority uint `json:"priority,omitempty"`
+
+	// TemplateID is the ID of the pool template this pool was derived from, if any.
+	TemplateID string `json:"template_id,omitempty"`
+	// TemplateOverrides is the list of fields that were explicitly set on this pool
+	// and which are no longer kept in sync with the template.
+	TemplateOverrides []string `json:"template_overrides,omitempty"`
+
+	// Cordoned pools do not create new runners. Existing runners are kept.
+	Cordoned bool `json:"cordoned,omitempty"`
+	// RecycleRequestedAt is set while the runners that were created before this
+	// time are being replaced.
+	RecycleRequestedAt *time.Time `json:"recycle_requested_at,omitempty"`
+	// RecycleMaxUnavailable is the maximum number of runners that are replaced at
+	// the same time while the pool is being recycled.
+	RecycleMaxUnavailable uint `json:"recycle_max_unavailable,omitempty"`
+
+	// Generation is incremented every time the image, flavor or extra specs of
+	// the pool change.
+	Generation uint `json:"generation,omitempty"`
+	// OutdatedRunners is the number of runners created with a previous generation
+	// of the pool. It is only set when the pool is fetched with its instances.
+	OutdatedRunners uint `json:"outdated_runners,omitempty"`
+	// RunnerStages is the number of runners of the pool at each lifecycle
+	// stage. Runners stuck in an early stage usually point to a bootstrap problem.
+	RunnerStages map[InstanceStage]uint `json:"runner_stages,omitempty"`
+	// RolloutMaxSurge is the number of runners that may be created on top of the
+	// existing ones to replace outdated idle runners.
+	RolloutMaxSurge uint `json:"rollout_max_surge,omitempty"`
+	// RolloutMaxUnavailable is the number of outdated idle runners that may be
+	// removed before their replacement is ready. If both RolloutMaxSurge and
+	// RolloutMaxUnavailable are 0, outdated runners are not replaced automatically.
+	RolloutMaxUnavailable uint `json:"rollout_max_unavailable,omitempty"`
+
+	// Reusable pools keep their runners after a job completes. A runner is
+	// removed once it completed RunnerMaxJobs jobs or after RunnerMaxLifetime
+	// minutes, whichever comes first.
+	Reusable          bool `json:"reusable,omitempty"`
+	RunnerMaxJobs     uint `json:"runner_max_jobs,omitempty"`
+	RunnerMaxLifetime uint `json:"runner_max_lifetime,omitempty"`
+	// JobCompletedHook is a script reusable runners run after every job. It can
+	// be used to clean up the workspace.
+	JobCompletedHook string `json:"job_completed_hook,omitempty"`
+
+	// ProviderFailureClass is set when the provider failed to create a runner
+	// with an error that is not retryable, like a missing image. The pool does
+	// not create new runners until the failure is cleared.
+	ProviderFailureClass  ProviderErrorClass `json:"provider_failure_class,omitempty"`
+	ProviderFailureReason string             `json:"provider_failure_reason,omitempty"`
+	ProviderFailureAt     *time.Time         `json:"provider_failure_at,omitempty"`
+
+	// IncludedRepositories and ExcludedRepositories limit the repositories
+	// whose jobs the pool handles. Both are lists of owner/name glob patterns.
+	// Only meaningful for organization and enterprise pools.
+	IncludedRepositories []string `json:"included_repositories,omitempty"`
+	ExcludedRepositories []string `json:"excluded_repositories,omitempty"`
+
+	// Policy restricts the jobs the pool creates runners for.
+	Policy *PoolPolicy `json:"policy,omitempty"`
+
+	// HourlyCost is the cost of running a runner of this pool for one hour. It
+	// is used to attribute the cost of runners to the jobs that used them.
+	HourlyCost float64 `json:"hourly_cost,omitempty"`
+
+	// MaxRunnersPerRepository limits the runners of the pool a single repository
+	// may use at the same time. Only meaningful for organization and enterprise
+	// pools. 0 means no limit.
+	MaxRunnersPerRepository uint `json:"max_runners_per_repository,omitempty"`
+}
+
+// JobScheduling sets the order in which the queued jobs of an entity get runners.
+// Jobs with a higher priority always go first. Jobs with the same priority are
+// ordered by the scheduling policy.
+type JobScheduling struct {
+	Policy JobSchedulingPolicy `json:"policy,omitempty" yaml:"policy,omitempty"`
+	// RepositoryWeights maps owner/name to the share of runners a repository
+	// gets with the weighted policy. Repositories that are not listed have a
+	// weight of 1.
+	RepositoryWeights map[string]uint `json:"repository_weights,omitempty" yaml:"repository_weights,omitempty"`
+	// LabelPriorities maps job labels to priorities. The priority of a job is
+	// the highest priority of its labels, or 0.
+	LabelPriorities map[string]int `json:"label_priorities,omitempty" yaml:"label_priorities,omitempty"`
+}
+
+// IsEmpty returns true if the settings are the same as the defaults.
+func (j JobScheduling) IsEmpty() bool {
+	return (j.Policy == "" || j.Policy == JobSchedulingFIFO) &&
+		len(j.RepositoryWeights) == 0 && len(j.LabelPriorities) == 0
+}
+
+// JobPriority returns the priority of a job with the given labels.
+func (j JobScheduling) JobPriority(labels []string) int {
+	priority := 0
+	found := false
+	for label, value := range j.LabelPriorities {
+		if !containsFold(labels, label) {
+			continue
+		}
+		if !found || value > priority {
+			priority = value
+			found = true
+		}
+	}
+	return priority
+}
+
+// RepositoryWeight returns the weight of a repository with the weighted policy.
+func (j JobScheduling) RepositoryWeight(owner, name string) uint {
+	if j.Policy != JobSchedulingWeighted {
+		return 1
+	}
+	repo := fmt.Sprintf("%s/%s", owner, name)
+	for key, weight := range j.RepositoryWeights {
+		if strings.EqualFold(key, repo) && weight > 0 {
+			return weight
+		}
+	}
+	return 1
+}
+
+// PoolPolicy is a set of rules a queued job must satisfy before a pool creates
+// a runner for it. All the rules that are set must match.
+type PoolPolicy struct {
+	// Branches are glob patterns the head branch of the job must match.
+	Branches []string `json:"branches,omitempty" yaml:"branches,omitempty"`
+	// Workflows are glob patterns the workflow name must match.
+	Workflows []string `json:"workflows,omitempty" yaml:"workflows,omitempty"`
+	// WorkflowPaths are glob patterns the path of the workflow file must match,
+	// for example ".github/workflows/deploy-*.yml".
+	WorkflowPaths []string `json:"workflow_paths,omitempty" yaml:"workflow_paths,omitempty"`
+	// Events are the events that may trigger the workflow run, for example
+	// "push" or "workflow_dispatch".
+	Events []string `json:"events,omitempty" yaml:"events,omitempty"`
+	// Actors are the GitHub users that may trigger the workflow run.
+	Actors []string `json:"actors,omitempty" yaml:"actors,omitempty"`
+	// MaxRunAttempt is the maximum run attempt of the workflow run. 0 means
+	// re-runs are not limited.
+	MaxRunAttempt uint `json:"max_run_attempt,omitempty" yaml:"max_run_attempt,omitempty"`
+}
+
+// IsEmpty returns true if the policy has no rules.
+func (p PoolPolicy) IsEmpty() bool {
+	return len(p.Branches) == 0 && len(p.Workflows) == 0 && len(p.WorkflowPaths) == 0 &&
+		len(p.Events) == 0 && len(p.Actors) == 0 && p.MaxRunAttempt == 0
+}
+
+// NeedsWorkflowRun returns true if the policy has rules on fields that are
+// only available from the workflow run. Branch rules need the event of the
+// workflow run, see Evaluate().
+func (p PoolPolicy) NeedsWorkflowRun() bool {
+	return len(p.Branches) > 0 || len(p.WorkflowPaths) > 0 || len(p.Events) > 0
+}
+
+// Evaluate returns an error describing the first rule the job does not satisfy.
+// The head branch of pull request runs is the branch of the pull request, which
+// anyone can name after a protected branch in a fork. Pull request runs never
+// satisfy branch rules.
+func (p PoolPolicy) Evaluate(job Job) error {
+	if len(p.Branches) > 0 {
+		if job.Event == "" {
+			return fmt.Errorf("branch rules need the event of the workflow run")
+		}
+		if strings.EqualFold(job.Event, "pull_request") || strings.EqualFold(job.Event, "pull_request_target") {
+			return fmt.Errorf("branch rules do not allow %s runs", job.Event)
+		}
+		if !matchesAnyPattern(p.Branches, job.HeadBranch) {
+			return fmt.Errorf("branch %q is not allowed", job.HeadBranch)
+		}
+	}
+	if len(p.Workflows) > 0 && !matchesAnyPattern(p.Workflows, job.WorkflowName) {
+		return fmt.Errorf("workflow %q is not allowed", job.WorkflowName)
+	}
+	if len(p.WorkflowPaths) > 0 && !matchesAnyPattern(p.WorkflowPaths, job.WorkflowPath) {
+		return fmt.Errorf("workflow path %q is not allowed", job.WorkflowPath)
+	}
+	if len(p.Events) > 0 && !containsFold(p.Events, job.Event) {
+		return fmt.Errorf("event %q is not allowed", job.Event)
+	}
+	if len(p.Actors) > 0 && !containsFold(p.Actors, job.Actor) {
+		return fmt.Errorf("actor %q is not allowed", job.Actor)
+	}
+	if p.MaxRunAttempt > 0 && job.RunAttempt > int64(p.MaxRunAttempt) {
+		return fmt.Errorf("run attempt %d exceeds %d", job.RunAttempt, p.MaxRunAttempt)
+	}
+	return nil
+}
+
+// matchesAnyPattern returns true if the value matches one of the glob patterns.
+// An empty value never matches.
+func matchesAnyPattern(patterns []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, val := range values {
+		if strings.EqualFold(val, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// HandlesRepository returns true if the pool may create runners for jobs of
+// the repository. Excluded repositories take precedence over included ones.
+// If no repositories are included, all repositories that are not excluded
+// are handled.
+func (p Pool) HandlesRepository(owner, name string) bool {
+	fullName := strings.ToLower(owner + "/" + name)
+	for _, pattern := range p.ExcludedRepositories {
+		if matched, _ := path.Match(strings.ToLower(pattern), fullName); matched {
+			return false
+		}
+	}
+	if len(p.IncludedRepositories) == 0 {
+		return true
+	}
+	for _, pattern := range p.IncludedRepositories {
+		if matched, _ := path.Match(strings.ToLower(pattern), fullName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ProviderFailed returns true if the pool was flagged after the provider
+// returned an error that is not retryable.
+func (p Pool) ProviderFailed() bool {
+	return p.ProviderFailureClass != ""
+}
+
+// RunnerLifetimeExceeded returns true if a runner of a reusable pool lived
+// longer than the maximum lifetime of the pool.
+func (p Pool) RunnerLifetimeExceeded(instance Instance, now time.Time) bool {
+	if p.RunnerMaxLifetime == 0 {
+		return false
+	}
+	return now.Sub(instance.CreatedAt) >= time.Duration(p.RunnerMaxLifetime)*time.Minute
+}
+
+// IsTemplateOverride returns true if the field is not kept in sync with the
+// pool template.
+func (p Pool) IsTemplateOverride(field string) bool {
+	for _, val := range p.TemplateOverrides {
+		if val == field {
+			return true
+		}
+	}
+	return false
 }
 
 func (p Pool) GithubEntity() (GithubEntity, error) {
@@ -382,6 +843,188 @@ func (p *Pool) HasRequiredLabels(set []string) bool {
 // used by swagger client generated code
 type Pools []Pool
 
+// Fields of a pool that can be inherited from a pool template. The names match
+// the JSON field names of the pool.
+const (
+	PoolTemplateFieldRunnerPrefix           = "runner_prefix"
+	PoolTemplateFieldMaxRunners             = "max_runners"
+	PoolTemplateFieldMinIdleRunners         = "min_idle_runners"
+	PoolTemplateFieldRunnerBootstrapTimeout = "runner_bootstrap_timeout"
+	PoolTemplateFieldImage                  = "image"
+	PoolTemplateFieldFlavor                 = "flavor"
+	PoolTemplateFieldOSType                 = "os_type"
+	PoolTemplateFieldOSArch                 = "os_arch"
+	PoolTemplateFieldTags                   = "tags"
+	PoolTemplateFieldExtraSpecs             = "extra_specs"
+	PoolTemplateFieldGitHubRunnerGroup      = "github-runner-group"
+	PoolTemplateFieldPriority               = "priority"
+)
+
+// PoolTemplateFields is the list of pool fields that are inherited from a template.
+var PoolTemplateFields = []string{
+	PoolTemplateFieldRunnerPrefix,
+	PoolTemplateFieldMaxRunners,
+	PoolTemplateFieldMinIdleRunners,
+	PoolTemplateFieldRunnerBootstrapTimeout,
+	PoolTemplateFieldImage,
+	PoolTemplateFieldFlavor,
+	PoolTemplateFieldOSType,
+	PoolTemplateFieldOSArch,
+	PoolTemplateFieldTags,
+	PoolTemplateFieldExtraSpecs,
+	PoolTemplateFieldGitHubRunnerGroup,
+	PoolTemplateFieldPriority,
+}
+
+// IsPoolTemplateField returns true if the field can be inherited from a pool template.
+func IsPoolTemplateField(field string) bool {
+	for _, val := range PoolTemplateFields {
+		if val == field {
+			return true
+		}
+	}
+	return false
+}
+
+// PoolTemplate holds a set of pool settings that can be shared by pools
+// belonging to any repository, organization or enterprise. Pools derived
+// from a template inherit all the template fields they do not override.
+type PoolTemplate struct {
+	RunnerPrefix
+
+	ID                     string              `json:"id,omitempty"`
+	Name                   string              `json:"name,omitempty"`
+	Description            string              `json:"description,omitempty"`
+	ProviderName           string              `json:"provider_name,omitempty"`
+	MaxRunners             uint                `json:"max_runners,omitempty"`
+	MinIdleRunners         uint                `json:"min_idle_runners,omitempty"`
+	Image                  string              `json:"image,omitempty"`
+	Flavor                 string              `json:"flavor,omitempty"`
+	OSType                 commonParams.OSType `json:"os_type,omitempty"`
+	OSArch                 commonParams.OSArch `json:"os_arch,omitempty"`
+	Tags                   []Tag               `json:"tags,omitempty"`
+	RunnerBootstrapTimeout uint                `json:"runner_bootstrap_timeout,omitempty"`
+	ExtraSpecs             json.RawMessage     `json:"extra_specs,omitempty"`
+	GitHubRunnerGroup      string              `json:"github-runner-group,omitempty"`
+	Priority               uint                `json:"priority,omitempty"`
+	CreatedAt              time.Time           `json:"created_at,omitempty"`
+	UpdatedAt              time.Time           `json:"updated_at,omitempty"`
+}
+
+func (p PoolTemplate) tagNames() []string {
+	ret := make([]string, len(p.Tags))
+	for idx, val := range p.Tags {
+		ret[idx] = val.Name
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// PoolUpdateParams returns the update parameters needed to bring a pool derived from
+// this template in sync with it, along with a description of every field that changes.
+// Fields overridden by the pool are left untouched.
+func (p PoolTemplate) PoolUpdateParams(pool Pool) (UpdatePoolParams, []PoolTemplateChange, error) {
+	var ret UpdatePoolParams
+	var changes []PoolTemplateChange
+
+	changed := func(field string, oldVal, newVal interface{}) bool {
+		if pool.IsTemplateOverride(field) {
+			return false
+		}
+		oldStr, newStr := fmt.Sprintf("%v", oldVal), fmt.Sprintf("%v", newVal)
+		if oldStr == newStr {
+			return false
+		}
+		changes = append(changes, PoolTemplateChange{
+			PoolID: pool.ID,
+			Field:  field,
+			Old:    oldStr,
+			New:    newStr,
+		})
+		return true
+	}
+
+	if changed(PoolTemplateFieldRunnerPrefix, pool.GetRunnerPrefix(), p.GetRunnerPrefix()) {
+		ret.Prefix = p.GetRunnerPrefix()
+	}
+	if changed(PoolTemplateFieldMaxRunners, pool.MaxRunners, p.MaxRunners) {
+		ret.MaxRunners = &p.MaxRunners
+	}
+	if changed(PoolTemplateFieldMinIdleRunners, pool.MinIdleRunners, p.MinIdleRunners) {
+		ret.MinIdleRunners = &p.MinIdleRunners
+	}
+	if p.RunnerBootstrapTimeout > 0 && changed(PoolTemplateFieldRunnerBootstrapTimeout, pool.RunnerBootstrapTimeout, p.RunnerBootstrapTimeout) {
+		ret.RunnerBootstrapTimeout = &p.RunnerBootstrapTimeout
+	}
+	if changed(PoolTemplateFieldImage, pool.Image, p.Image) {
+		ret.Image = p.Image
+	}
+	if changed(PoolTemplateFieldFlavor, pool.Flavor, p.Flavor) {
+		ret.Flavor = p.Flavor
+	}
+	if changed(PoolTemplateFieldOSType, pool.OSType, p.OSType) {
+		ret.OSType = p.OSType
+	}
+	if changed(PoolTemplateFieldOSArch, pool.OSArch, p.OSArch) {
+		ret.OSArch = p.OSArch
+	}
+
+	if changed(PoolTemplateFieldTags, strings.Join(pool.tagNames(), ","), strings.Join(p.tagNames(), ",")) {
+		ret.Tags = p.tagNames()
+	}
+	if changed(PoolTemplateFieldExtraSpecs, string(pool.ExtraSpecs), string(p.ExtraSpecs)) {
+		ret.ExtraSpecs = json.RawMessage{}
+		if len(p.ExtraSpecs) > 0 {
+			ret.ExtraSpecs = p.ExtraSpecs
+		}
+	}
+	if changed(PoolTemplateFieldGitHubRunnerGroup, pool.GitHubRunnerGroup, p.GitHubRunnerGroup) {
+		ret.GitHubRunnerGroup = &p.GitHubRunnerGroup
+	}
+	if changed(PoolTemplateFieldPriority, pool.Priority, p.Priority) {
+		ret.Priority = &p.Priority
+	}
+
+	maxRunners, minIdleRunners := pool.MaxRunners, pool.MinIdleRunners
+	if ret.MaxRunners != nil {
+		maxRunners = *ret.MaxRunners
+	}
+	if ret.MinIdleRunners != nil {
+		minIdleRunners = *ret.MinIdleRunners
+	}
+	if minIdleRunners > maxRunners {
+		return UpdatePoolParams{}, nil, runnerErrors.NewBadRequestError("pool %s: min_idle_runners (%d) cannot be larger than max_runners (%d)", pool.ID, minIdleRunners, maxRunners)
+	}
+	if err := ValidateIdleRunnersPolicy(minIdleRunners, pool.Policy); err != nil {
+		return UpdatePoolParams{}, nil, runnerErrors.NewBadRequestError("pool %s: %s", pool.ID, err)
+	}
+	if err := ValidateIdleRunnersRepositories(minIdleRunners, pool.IncludedRepositories, pool.ExcludedRepositories); err != nil {
+		return UpdatePoolParams{}, nil, runnerErrors.NewBadRequestError("pool %s: %s", pool.ID, err)
+	}
+
+	return ret, changes, nil
+}
+
+// used by swagger client generated code
+type PoolTemplates []PoolTemplate
+
+// PoolTemplateChange describes a change that a pool template update
+// makes to one of the pools derived from it.
+type PoolTemplateChange struct {
+	PoolID string `json:"pool_id,omitempty"`
+	Field  string `json:"field,omitempty"`
+	Old    string `json:"old,omitempty"`
+	New    string `json:"new,omitempty"`
+}
+
+// PoolTemplateUpdateResult is returned when updating a pool template. When DryRun
+// is set, Template holds the would-be template and nothing was saved.
+type PoolTemplateUpdateResult struct {
+	Template PoolTemplate         `json:"template,omitempty"`
+	Changes  []PoolTemplateChange `json:"changes,omitempty"`
+	DryRun   bool                 `json:"dry_run,omitempty"`
+}
+
 type Repository struct {
 	ID    string `json:"id,omitempty"`
 	Owner string `json:"owner,omitempty"`
@@ -396,8 +1039,18 @@ type Repository struct {
 	PoolManagerStatus PoolManagerStatus `json:"pool_manager_status,omitempty"`
 	PoolBalancerType  PoolBalancerType  `json:"pool_balancing_type,omitempty"`
 	Endpoint          GithubEndpoint    `json:"endpoint,omitempty"`
+	// ObserveOnly is set if the pool manager of this entity only records the
+	// actions it would take, without calling providers or GitHub.
+	ObserveOnly bool `json:"observe_only,omitempty"`
+	// JobScheduling sets the order in which the queued jobs of this entity
+	// get runners.
+	JobScheduling *JobScheduling `json:"job_scheduling,omitempty"`
+	// PreviousWebhookSecretExpiresAt is set while a rotated webhook secret is still
+	// accepted alongside the current one.
+	PreviousWebhookSecretExpiresAt *time.Time `json:"previous_webhook_secret_expires_at,omitempty"`
 	// Do not serialize sensitive info.
-	WebhookSecret string `json:"-"`
+	WebhookSecret         string `json:"-"`
+	PreviousWebhookSecret string `json:"-"`
 }
 
 func (r Repository) GetEntity() (GithubEntity, error) {
@@ -410,8 +1063,13 @@ func (r Repository) GetEntity() (GithubEntity, error) {
 		Owner:            r.Owner,
 		Name:             r.Name,
 		PoolBalancerType: r.PoolBalancerType,
+		ObserveOnly:      r.ObserveOnly,
+		JobScheduling:    r.JobScheduling,
 		Credentials:      r.Credentials,
 		WebhookSecret:    r.WebhookSecret,
+
+		PreviousWebhookSecret:          r.PreviousWebhookSecret,
+		PreviousWebhookSecretExpiresAt: r.PreviousWebhookSecretExpiresAt,
 	}, nil
 }
 
@@ -450,8 +1108,18 @@ type Organization struct {
 	PoolManagerStatus PoolManagerStatus `json:"pool_manager_status,omitempty"`
 	PoolBalancerType  PoolBalancerType  `json:"pool_balancing_type,omitempty"`
 	Endpoint          GithubEndpoint    `json:"endpoint,omitempty"`
+	// ObserveOnly is set if the pool manager of this entity only records the
+	// actions it would take, without calling providers or GitHub.
+	ObserveOnly bool `json:"observe_only,omitempty"`
+	// JobScheduling sets the order in which the queued jobs of this entity
+	// get runners.
+	JobScheduling *JobScheduling `json:"job_scheduling,omitempty"`
+	// PreviousWebhookSecretExpiresAt is set while a rotated webhook secret is still
+	// accepted alongside the current one.
+	PreviousWebhookSecretExpiresAt *time.Time `json:"previous_webhook_secret_expires_at,omitempty"`
 	// Do not serialize sensitive info.
-	WebhookSecret string `json:"-"`
+	WebhookSecret         string `json:"-"`
+	PreviousWebhookSecret string `json:"-"`
 }
 
 func (o Organization) GetEntity() (GithubEntity, error) {
@@ -464,7 +1132,12 @@ func (o Organization) GetEntity() (GithubEntity, error) {
 		Owner:            o.Name,
 		WebhookSecret:    o.WebhookSecret,
 		PoolBalancerType: o.PoolBalancerType,
+		ObserveOnly:      o.ObserveOnly,
+		JobScheduling:    o.JobScheduling,
 		Credentials:      o.Credentials,
+
+		PreviousWebhookSecret:          o.PreviousWebhookSecret,
+		PreviousWebhookSecretExpiresAt: o.PreviousWebhookSecretExpiresAt,
 	}, nil
 }
 
@@ -499,8 +1172,18 @@ type Enterprise struct {
 	PoolManagerStatus PoolManagerStatus `json:"pool_manager_status,omitempty"`
 	PoolBalancerType  PoolBalancerType  `json:"pool_balancing_type,omitempty"`
 	Endpoint          GithubEndpoint    `json:"endpoint,omitempty"`
+	// ObserveOnly is set if the pool manager of this entity only records the
+	// actions it would take, without calling providers or GitHub.
+	ObserveOnly bool `json:"observe_only,omitempty"`
+	// JobScheduling sets the order in which the queued jobs of this entity
+	// get runners.
+	JobScheduling *JobScheduling `json:"job_scheduling,omitempty"`
+	// PreviousWebhookSecretExpiresAt is set while a rotated webhook secret is still
+	// accepted alongside the current one.
+	PreviousWebhookSecretExpiresAt *time.Time `json:"previous_webhook_secret_expires_at,omitempty"`
 	// Do not serialize sensitive info.
-	WebhookSecret string `json:"-"`
+	WebhookSecret         string `json:"-"`
+	PreviousWebhookSecret string `json:"-"`
 }
 
 func (e Enterprise) GetEntity() (GithubEntity, error) {
@@ -513,7 +1196,12 @@ func (e Enterprise) GetEntity() (GithubEntity, error) {
 		Owner:            e.Name,
 		WebhookSecret:    e.WebhookSecret,
 		PoolBalancerType: e.PoolBalancerType,
+		ObserveOnly:      e.ObserveOnly,
+		JobScheduling:    e.JobScheduling,
 		Credentials:      e.Credentials,
+
+		PreviousWebhookSecret:          e.PreviousWebhookSecret,
+		PreviousWebhookSecretExpiresAt: e.PreviousWebhookSecretExpiresAt,
 	}, nil
 }
 
@@ -596,6 +1284,17 @@ type ControllerInfo struct {
 	// runners to pick up the job before GARM attempts to allocate a new runner, thus avoiding
 	// the need to potentially scale down runners later.
 	MinimumJobAgeBackoff uint `json:"minimum_job_age_backoff,omitempty"`
+	// ObserveOnly is set if the pool managers of all entities only record the
+	// actions they would take, without calling providers or GitHub.
+	ObserveOnly bool `json:"observe_only,omitempty"`
+	// PartitionLabel is a label a job must request to be handled by this controller.
+	// It allows multiple controllers to manage the same entity, without competing
+	// for the same jobs. The label is added to all runners of this controller.
+	PartitionLabel string `json:"partition_label,omitempty"`
+	// PartitionRepositories limits the jobs this controller handles to the jobs of
+	// the given repositories (owner/name). This is useful when multiple controllers
+	// manage the same organization or enterprise.
+	PartitionRepositories []string `json:"partition_repositories,omitempty"`
 	// Version is the version of the GARM controller.
 	Version string `json:"version,omitempty"`
 }
@@ -718,6 +1417,20 @@ type Providers []Provider
 type PoolManagerStatus struct {
 	IsRunning     bool   `json:"running,omitempty"`
 	FailureReason string `json:"failure_reason,omitempty"`
+	// ConflictingControllers lists the other GARM controllers that have runners
+	// registered on the same entity.
+	ConflictingControllers []ControllerConflict `json:"conflicting_controllers,omitempty"`
+}
+
+// ControllerConflict describes the runners another GARM controller registered on
+// an entity this controller manages.
+type ControllerConflict struct {
+	ControllerID string `json:"controller_id"`
+	Runners      uint   `json:"runners"`
+	// OverlappingLabels are the labels of the other controller's runners that are
+	// also tags of pools of this controller. Jobs requesting these labels may be
+	// picked up by runners of either controller.
+	OverlappingLabels []string `json:"overlapping_labels,omitempty"`
 }
 
 type RunnerInfo struct {
@@ -767,6 +1480,20 @@ type Job struct {
 
 	Labels []string `json:"labels,omitempty"`
 
+	// HeadBranch, WorkflowName, RunAttempt and Actor describe the workflow run
+	// the job belongs to. They are evaluated by the policies of pools.
+	HeadBranch   string `json:"head_branch,omitempty"`
+	WorkflowName string `json:"workflow_name,omitempty"`
+	RunAttempt   int64  `json:"run_attempt,omitempty"`
+	Actor        string `json:"actor,omitempty"`
+	// Event and WorkflowPath are fetched from GitHub when a pool policy needs
+	// them, as they are not part of the workflow_job webhook.
+	Event        string `json:"event,omitempty"`
+	WorkflowPath string `json:"workflow_path,omitempty"`
+	// PolicyDenial is the reason the policies of the matching pools did not
+	// allow a runner to be created for the job.
+	PolicyDenial string `json:"policy_denial,omitempty"`
+
 	// The entity that received the hook.
 	//
 	// Webhooks may be configured on the repo, the org and/or the enterprise.
@@ -789,11 +1516,36 @@ type Job struct {
 // used by swagger client generated code
 type Jobs []Job
 
+// WebhookDelivery is a webhook received from GitHub, which was persisted
+// and is processed asynchronously.
+type WebhookDelivery struct {
+	// ID is the value of the X-GitHub-Delivery header. It is used to
+	// deduplicate deliveries.
+	ID             string                `json:"id,omitempty"`
+	HookTargetType string                `json:"hook_target_type,omitempty"`
+	Payload        []byte                `json:"payload,omitempty"`
+	Status         WebhookDeliveryStatus `json:"status,omitempty"`
+	// Attempts is the number of times we tried to process this delivery.
+	Attempts  int    `json:"attempts,omitempty"`
+	LastError string `json:"last_error,omitempty"`
+	// NextAttemptAt is the time after which the delivery may be processed.
+	NextAttemptAt time.Time `json:"next_attempt_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
 type InstallWebhookParams struct {
 	WebhookEndpointType WebhookEndpointType `json:"webhook_endpoint_type,omitempty"`
 	InsecureSSL         bool                `json:"insecure_ssl,omitempty"`
 }
 
+type RotateWebhookSecretParams struct {
+	// WebhookSecret is the new secret to set. If empty, a random secret
+	// will be generated.
+	WebhookSecret string `json:"webhook_secret,omitempty"`
+}
+
 type HookInfo struct {
 	ID          int64    `json:"id,omitempty"`
 	URL         string   `json:"url,omitempty"`
@@ -806,6 +1558,139 @@ type CertificateBundle struct {
 	RootCertificates map[string][]byte `json:"root_certificates,omitempty"`
 }
 
+// RunnerConfig holds the settings a runner needs to configure the GitHub
+// runner agent.
+type RunnerConfig struct {
+	// Ephemeral runners run a single job. Runners of reusable pools must be
+	// configured without the --ephemeral flag.
+	Ephemeral bool `json:"ephemeral"`
+	// JobCompletedHook is a script the runner agent must run after every job,
+	// usually by setting ACTIONS_RUNNER_HOOK_JOB_COMPLETED.
+	JobCompletedHook string `json:"job_completed_hook,omitempty"`
+}
+
+// InstanceLifecycle holds the time an instance reached each stage. Stages
+// that were not reached are nil.
+type InstanceLifecycle struct {
+	// ProviderCreatedAt is the time the provider created the instance.
+	ProviderCreatedAt *time.Time `json:"provider_created_at,omitempty"`
+	// FirstCallbackAt is the time the instance first called back to garm.
+	FirstCallbackAt *time.Time `json:"first_callback_at,omitempty"`
+	// JITFetchedAt is the time the instance fetched its JIT config or
+	// registration token.
+	JITFetchedAt *time.Time `json:"jit_fetched_at,omitempty"`
+	// RegisteredAt is the time the runner reported it registered in GitHub.
+	RegisteredAt *time.Time `json:"registered_at,omitempty"`
+	// JobAssignedAt is the time the runner first picked up a job.
+	JobAssignedAt *time.Time `json:"job_assigned_at,omitempty"`
+	// TerminatedAt is the time the runner finished its last job.
+	TerminatedAt *time.Time `json:"terminated_at,omitempty"`
+}
+
+type instanceStageTime struct {
+	stage InstanceStage
+	at    *time.Time
+}
+
+func (l InstanceLifecycle) stages() []instanceStageTime {
+	return []instanceStageTime{
+		{InstanceStageProviderCreated, l.ProviderCreatedAt},
+		{InstanceStageFirstCallback, l.FirstCallbackAt},
+		{InstanceStageJITFetched, l.JITFetchedAt},
+		{InstanceStageRegistered, l.RegisteredAt},
+		{InstanceStageJobAssigned, l.JobAssignedAt},
+		{InstanceStageTerminated, l.TerminatedAt},
+	}
+}
+
+// InstanceTimelineEntry is either a stage the instance reached or an event
+// recorded for it.
+type InstanceTimelineEntry struct {
+	Time       time.Time     `json:"time"`
+	Stage      InstanceStage `json:"stage,omitempty"`
+	EventType  EventType     `json:"event_type,omitempty"`
+	EventLevel EventLevel    `json:"event_level,omitempty"`
+	Message    string        `json:"message,omitempty"`
+}
+
+// SkippedRunner is a runner or instance that was not adopted.
+type SkippedRunner struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// AdoptRunnersResult holds the outcome of adopting the runners of a pool.
+type AdoptRunnersResult struct {
+	// Adopted holds the runners that were adopted, or that would be adopted
+	// in a dry run.
+	Adopted []Instance      `json:"adopted"`
+	Skipped []SkippedRunner `json:"skipped,omitempty"`
+}
+
+// InstanceTimeline is the lifecycle of an instance, from its creation in the
+// database to its termination.
+type InstanceTimeline struct {
+	InstanceName string                      `json:"instance_name"`
+	Status       commonParams.InstanceStatus `json:"status"`
+	RunnerStatus RunnerStatus                `json:"runner_status"`
+	// Stage is the latest stage the instance reached.
+	Stage InstanceStage `json:"stage"`
+	// Removed is set if the instance no longer exists. The timeline is the one
+	// the instance had when garm removed it.
+	Removed bool                    `json:"removed,omitempty"`
+	Entries []InstanceTimelineEntry `json:"entries"`
+}
+
+// InstanceReap records why garm removed an instance on its own. Reaps are not
+// removed along with the instance, so the reason can be looked up afterwards.
+type InstanceReap struct {
+	ID           uint   `json:"id"`
+	InstanceName string `json:"instance_name"`
+	PoolID       string `json:"pool_id"`
+	Reason       string `json:"reason"`
+	// Timeline is the timeline of the instance at the time it was reaped.
+	Timeline InstanceTimeline `json:"timeline"`
+	// ConsoleOutput is only returned by the console endpoint.
+	ConsoleOutput []byte    `json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// WithReaps adds the reaps of an instance to its timeline, in the order in which
+// they happened.
+func (t InstanceTimeline) WithReaps(reaps []InstanceReap) InstanceTimeline {
+	entries := make([]InstanceTimelineEntry, 0, len(t.Entries)+len(reaps))
+	entries = append(entries, t.Entries...)
+	for _, reap := range reaps {
+		entries = append(entries, reap.TimelineEntry())
+	}
+	sort.SliceStable(entries, func(a, b int) bool {
+		return entries[a].Time.Before(entries[b].Time)
+	})
+	t.Entries = entries
+	return t
+}
+
+// TimelineEntry returns the reap as an entry of the timeline of the instance.
+func (r InstanceReap) TimelineEntry() InstanceTimelineEntry {
+	return InstanceTimelineEntry{
+		Time:       r.CreatedAt,
+		EventType:  ReapEvent,
+		EventLevel: EventWarning,
+		Message:    r.Reason,
+	}
+}
+
+// InstanceConsoleOutput holds the console output of an instance.
+type InstanceConsoleOutput struct {
+	InstanceName string `json:"instance_name"`
+	Output       string `json:"output"`
+	// CapturedAt is the time the output was fetched from the provider.
+	CapturedAt time.Time `json:"captured_at"`
+	// Stored is set if the output was captured when the instance failed,
+	// rather than fetched from the provider for this request.
+	Stored bool `json:"stored"`
+}
+
 type UpdateSystemInfoParams struct {
 	OSName    string `json:"os_name,omitempty"`
 	OSVersion string `json:"os_version,omitempty"`
@@ -819,8 +1704,29 @@ type GithubEntity struct {
 	EntityType       GithubEntityType  `json:"entity_type,omitempty"`
 	Credentials      GithubCredentials `json:"credentials,omitempty"`
 	PoolBalancerType PoolBalancerType  `json:"pool_balancing_type,omitempty"`
+	ObserveOnly      bool              `json:"observe_only,omitempty"`
+	JobScheduling    *JobScheduling    `json:"job_scheduling,omitempty"`
+
+	WebhookSecret                  string     `json:"-"`
+	PreviousWebhookSecret          string     `json:"-"`
+	PreviousWebhookSecretExpiresAt *time.Time `json:"-"`
+}
 
-	WebhookSecret string `json:"-"`
+// WebhookSecrets returns the secrets that may be used to validate a webhook
+// payload for this entity. The previous secret is only returned while it is
+// still within its rotation grace period.
+func (g GithubEntity) WebhookSecrets() []string {
+	secrets := []string{}
+	if g.WebhookSecret != "" {
+		secrets = append(secrets, g.WebhookSecret)
+	}
+	if g.PreviousWebhookSecret == "" || g.PreviousWebhookSecretExpiresAt == nil {
+		return secrets
+	}
+	if time.Now().UTC().Before(g.PreviousWebhookSecretExpiresAt.UTC()) {
+		secrets = append(secrets, g.PreviousWebhookSecret)
+	}
+	return secrets
 }
 
 func (g GithubEntity) GetPoolBalancerType() PoolBalancerType {
@@ -830,6 +1736,19 @@ func (g GithubEntity) GetPoolBalancerType() PoolBalancerType {
 	return g.PoolBalancerType
 }
 
+// GetJobScheduling returns the job scheduling settings of the entity. Entities
+// without settings handle queued jobs in the order they were received.
+func (g GithubEntity) GetJobScheduling() JobScheduling {
+	if g.JobScheduling == nil {
+		return JobScheduling{Policy: JobSchedulingFIFO}
+	}
+	ret := *g.JobScheduling
+	if ret.Policy == "" {
+		ret.Policy = JobSchedulingFIFO
+	}
+	return ret
+}
+
 func (g GithubEntity) LabelScope() string {
 	switch g.EntityType {
 	case GithubEntityTypeRepository:
@@ -865,3 +1784,74 @@ type GithubEndpoint struct {
 
 	Credentials []GithubCredentials `json:"credentials,omitempty"`
 }
+
+// ObservedAction is an action a pool manager running in observe only mode
+// would have taken. Actions that repeat are recorded once, and counted.
+type ObservedAction struct {
+	ID         uint               `json:"id"`
+	EntityType GithubEntityType   `json:"entity_type"`
+	EntityID   string             `json:"entity_id"`
+	PoolID     string             `json:"pool_id,omitempty"`
+	Action     ObservedActionType `json:"action"`
+	// Target is the runner or job the action applies to.
+	Target string `json:"target,omitempty"`
+	// Reason is the reason the action would be taken, the last time it was
+	// observed.
+	Reason string `json:"reason"`
+	// Count is the number of times the action was observed.
+	Count       uint      `json:"count"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+// used by swagger client generated code
+type ObservedActions []ObservedAction
+
+// RunnerUsage is the time a runner was up for and its cost. A runner has one
+// usage for every job it ran and one for the time it was idle after its last
+// job, until it was removed.
+type RunnerUsage struct {
+	ID           uint             `json:"id"`
+	InstanceName string           `json:"instance_name"`
+	PoolID       string           `json:"pool_id"`
+	EntityType   GithubEntityType `json:"entity_type"`
+	EntityID     string           `json:"entity_id"`
+	EntityName   string           `json:"entity_name"`
+	// JobID is 0 for the time a runner was idle after its last job.
+	JobID           int64     `json:"job_id,omitempty"`
+	RepositoryOwner string    `json:"repository_owner,omitempty"`
+	RepositoryName  string    `json:"repository_name,omitempty"`
+	StartedAt       time.Time `json:"started_at"`
+	EndedAt         time.Time `json:"ended_at"`
+	Seconds         float64   `json:"seconds"`
+	Cost            float64   `json:"cost"`
+}
+
+// CostReport sums up the runner usage recorded since a point in time.
+type CostReport struct {
+	Since   time.Time `json:"since"`
+	Until   time.Time `json:"until"`
+	Jobs    uint      `json:"jobs"`
+	Seconds float64   `json:"seconds"`
+	Cost    float64   `json:"cost"`
+
+	Entities []CostReportEntry `json:"entities"`
+	Pools    []CostReportEntry `json:"pools"`
+	// Repositories only holds the usage that could be attributed to a
+	// repository. The time the runners of organization and enterprise pools
+	// were idle is only accounted to the entity and the pool.
+	Repositories []CostReportEntry `json:"repositories"`
+}
+
+// CostReportEntry is the runner usage of an entity, a pool or a repository.
+type CostReportEntry struct {
+	// ID is the ID of the entity or pool. It is empty for repositories that
+	// used runners of organization or enterprise pools.
+	ID string `json:"id,omitempty"`
+	// Type is the type of the entity.
+	Type    GithubEntityType `json:"type,omitempty"`
+	Name    string           `json:"name"`
+	Jobs    uint             `json:"jobs"`
+	Seconds float64          `json:"seconds"`
+	Cost    float64          `json:"cost"`
+}
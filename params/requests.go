@@ -19,8 +19,13 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"math"
 	"net/url"
+	"path"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
 	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
@@ -154,6 +159,97 @@ type UpdatePoolParams struct {
 	// The runner group must be created by someone with access to the enterprise.
 	GitHubRunnerGroup *string `json:"github-runner-group,omitempty"`
 	Priority          *uint   `json:"priority,omitempty"`
+	// ResetTemplateOverrides is a list of fields that should once again be kept
+	// in sync with the pool template. Only valid for pools derived from a template.
+	ResetTemplateOverrides []string `json:"reset_template_overrides,omitempty"`
+
+	// RolloutMaxSurge and RolloutMaxUnavailable limit the automatic replacement
+	// of outdated idle runners.
+	RolloutMaxSurge       *uint `json:"rollout_max_surge,omitempty"`
+	RolloutMaxUnavailable *uint `json:"rollout_max_unavailable,omitempty"`
+
+	Reusable          *bool   `json:"reusable,omitempty"`
+	RunnerMaxJobs     *uint   `json:"runner_max_jobs,omitempty"`
+	RunnerMaxLifetime *uint   `json:"runner_max_lifetime,omitempty"`
+	JobCompletedHook  *string `json:"job_completed_hook,omitempty"`
+
+	// ClearProviderFailure allows the pool to create runners again after the
+	// provider returned an error that is not retryable.
+	ClearProviderFailure *bool `json:"clear_provider_failure,omitempty"`
+
+	// IncludedRepositories and ExcludedRepositories replace the repository
+	// filters of the pool. An empty list removes the filter.
+	IncludedRepositories []string `json:"included_repositories"`
+	ExcludedRepositories []string `json:"excluded_repositories"`
+
+	// Policy replaces the policy of the pool. A policy without rules removes it.
+	Policy *PoolPolicy `json:"policy,omitempty"`
+
+	HourlyCost              *float64 `json:"hourly_cost,omitempty"`
+	MaxRunnersPerRepository *uint    `json:"max_runners_per_repository,omitempty"`
+
+	// Cordoned and Recycle are only set by the pool maintenance operations.
+	Cordoned *bool        `json:"-"`
+	Recycle  *PoolRecycle `json:"-"`
+	// ProviderFailure is set by the pool manager when the provider returns an
+	// error that is not retryable.
+	ProviderFailure *PoolProviderFailure `json:"-"`
+}
+
+// PoolProviderFailure holds the error that stopped a pool from creating runners.
+type PoolProviderFailure struct {
+	Class  ProviderErrorClass
+	Reason string
+	At     time.Time
+}
+
+// PoolRecycle holds the state of a pool recycle. A nil RequestedAt means the
+// pool is not being recycled.
+type PoolRecycle struct {
+	RequestedAt    *time.Time
+	MaxUnavailable uint
+}
+
+// TemplateFields returns the list of pool template fields set in these params.
+func (p UpdatePoolParams) TemplateFields() []string {
+	var ret []string
+	if p.Prefix != "" {
+		ret = append(ret, PoolTemplateFieldRunnerPrefix)
+	}
+	if p.MaxRunners != nil {
+		ret = append(ret, PoolTemplateFieldMaxRunners)
+	}
+	if p.MinIdleRunners != nil {
+		ret = append(ret, PoolTemplateFieldMinIdleRunners)
+	}
+	if p.RunnerBootstrapTimeout != nil {
+		ret = append(ret, PoolTemplateFieldRunnerBootstrapTimeout)
+	}
+	if p.Image != "" {
+		ret = append(ret, PoolTemplateFieldImage)
+	}
+	if p.Flavor != "" {
+		ret = append(ret, PoolTemplateFieldFlavor)
+	}
+	if p.OSType != "" {
+		ret = append(ret, PoolTemplateFieldOSType)
+	}
+	if p.OSArch != "" {
+		ret = append(ret, PoolTemplateFieldOSArch)
+	}
+	if len(p.Tags) > 0 {
+		ret = append(ret, PoolTemplateFieldTags)
+	}
+	if p.ExtraSpecs != nil {
+		ret = append(ret, PoolTemplateFieldExtraSpecs)
+	}
+	if p.GitHubRunnerGroup != nil {
+		ret = append(ret, PoolTemplateFieldGitHubRunnerGroup)
+	}
+	if p.Priority != nil {
+		ret = append(ret, PoolTemplateFieldPriority)
+	}
+	return ret
 }
 
 type CreateInstanceParams struct {
@@ -169,6 +265,7 @@ type CreateInstanceParams struct {
 	GitHubRunnerGroup string            `json:"github-runner-group,omitempty"`
 	CreateAttempt     int               `json:"-"`
 	AgentID           int64             `json:"-"`
+	Adopted           bool              `json:"-"`
 	AditionalLabels   []string          `json:"aditional_labels,omitempty"`
 	JitConfiguration  map[string]string `json:"jit_configuration,omitempty"`
 }
@@ -192,6 +289,205 @@ type CreatePoolParams struct {
 	// The runner group must be created by someone with access to the enterprise.
 	GitHubRunnerGroup string `json:"github-runner-group,omitempty"`
 	Priority          uint   `json:"priority,omitempty"`
+	// TemplateID is the ID of a pool template. Any field not set in these
+	// params is inherited from the template and kept in sync with it.
+	TemplateID string `json:"template_id,omitempty"`
+	// TemplateOverrides is populated by ApplyTemplate with the fields
+	// that were explicitly set and override the template.
+	TemplateOverrides []string `json:"-"`
+	// RolloutMaxSurge is the number of runners that may be created on top of
+	// the existing ones to replace outdated idle runners. Defaults to 1.
+	RolloutMaxSurge *uint `json:"rollout_max_surge,omitempty"`
+	// RolloutMaxUnavailable is the number of outdated idle runners that may be
+	// removed before their replacement is ready. Defaults to 0.
+	RolloutMaxUnavailable *uint `json:"rollout_max_unavailable,omitempty"`
+	// Reusable pools keep their runners after a job completes, until they
+	// completed RunnerMaxJobs jobs or lived for RunnerMaxLifetime minutes.
+	Reusable          bool `json:"reusable,omitempty"`
+	RunnerMaxJobs     uint `json:"runner_max_jobs,omitempty"`
+	RunnerMaxLifetime uint `json:"runner_max_lifetime,omitempty"`
+	// JobCompletedHook is a script reusable runners run after every job.
+	JobCompletedHook string `json:"job_completed_hook,omitempty"`
+	// IncludedRepositories and ExcludedRepositories are owner/name glob
+	// patterns that limit the repositories whose jobs the pool handles.
+	IncludedRepositories []string `json:"included_repositories,omitempty"`
+	ExcludedRepositories []string `json:"excluded_repositories,omitempty"`
+	// Policy restricts the jobs the pool creates runners for.
+	Policy *PoolPolicy `json:"policy,omitempty"`
+	// HourlyCost is the cost of running a runner of this pool for one hour.
+	HourlyCost float64 `json:"hourly_cost,omitempty"`
+	// MaxRunnersPerRepository limits the runners of the pool a single repository
+	// may use at the same time. 0 means no limit.
+	MaxRunnersPerRepository uint `json:"max_runners_per_repository,omitempty"`
+}
+
+// ValidatePoolPolicy checks the patterns of a pool policy.
+func ValidatePoolPolicy(policy *PoolPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	for _, patterns := range [][]string{policy.Branches, policy.Workflows, policy.WorkflowPaths} {
+		for _, pattern := range patterns {
+			if pattern == "" {
+				return fmt.Errorf("empty policy pattern")
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid policy pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateIdleRunnersPolicy checks that a pool with a policy does not keep idle
+// runners. Idle runners pick up any job of the entity, so the policy would not
+// apply to them.
+func ValidateIdleRunnersPolicy(minIdleRunners uint, policy *PoolPolicy) error {
+	if minIdleRunners > 0 && policy != nil && !policy.IsEmpty() {
+		return fmt.Errorf("min_idle_runners must be 0 for pools with a policy")
+	}
+	return nil
+}
+
+// ValidateJobScheduling checks the policy, weights and priorities of the job
+// scheduling settings of an entity.
+func ValidateJobScheduling(scheduling *JobScheduling) error {
+	if scheduling == nil {
+		return nil
+	}
+	switch scheduling.Policy {
+	case "", JobSchedulingFIFO, JobSchedulingRoundRobin, JobSchedulingWeighted:
+	default:
+		return fmt.Errorf("invalid job scheduling policy %q", scheduling.Policy)
+	}
+	for repo, weight := range scheduling.RepositoryWeights {
+		owner, name, found := strings.Cut(repo, "/")
+		if !found || owner == "" || name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("invalid repository %q: must be owner/name", repo)
+		}
+		if weight == 0 {
+			return fmt.Errorf("weight of repository %q must be larger than 0", repo)
+		}
+	}
+	for label := range scheduling.LabelPriorities {
+		if label == "" {
+			return fmt.Errorf("empty label in label priorities")
+		}
+	}
+	return nil
+}
+
+// ValidateHourlyCost checks that the hourly cost of a pool is a finite, non-negative number.
+func ValidateHourlyCost(cost float64) error {
+	if cost < 0 || math.IsNaN(cost) || math.IsInf(cost, 0) {
+		return fmt.Errorf("invalid hourly cost %v", cost)
+	}
+	return nil
+}
+
+// ValidateRepositoryPatterns checks that the repository filters of a pool are
+// valid owner/name glob patterns.
+func ValidateRepositoryPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		owner, name, found := strings.Cut(pattern, "/")
+		if !found || owner == "" || name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("invalid repository pattern %q: must be owner/name", pattern)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid repository pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// ValidateIdleRunnersRepositories checks that a pool with repository filters does
+// not keep idle runners. Idle runners pick up any job of the entity, so the
+// filters would not apply to them.
+func ValidateIdleRunnersRepositories(minIdleRunners uint, included, excluded []string) error {
+	if minIdleRunners > 0 && (len(included) > 0 || len(excluded) > 0) {
+		return fmt.Errorf("min_idle_runners must be 0 for pools with repository filters")
+	}
+	return nil
+}
+
+// ValidateRunnerReuse checks the reusable runner settings of a pool. Reusable
+// runners must be recycled after a number of jobs, after some time, or both.
+func ValidateRunnerReuse(reusable bool, maxJobs, maxLifetime uint) error {
+	if reusable && maxJobs == 0 && maxLifetime == 0 {
+		return fmt.Errorf("reusable pools need runner_max_jobs or runner_max_lifetime")
+	}
+	return nil
+}
+
+// RolloutLimits returns the rollout limits of the new pool, with defaults
+// applied.
+func (p CreatePoolParams) RolloutLimits() (maxSurge, maxUnavailable uint) {
+	maxSurge = 1
+	if p.RolloutMaxSurge != nil {
+		maxSurge = *p.RolloutMaxSurge
+	}
+	if p.RolloutMaxUnavailable != nil {
+		maxUnavailable = *p.RolloutMaxUnavailable
+	}
+	return maxSurge, maxUnavailable
+}
+
+// ApplyTemplate fills in every field not set in the create params from the
+// pool template. Fields that were set are recorded in TemplateOverrides. The
+// provider can not be overridden, as the pools derived from a template are kept
+// in sync with it and the provider of a pool can not be changed.
+func (p *CreatePoolParams) ApplyTemplate(tpl PoolTemplate) error {
+	if p.ProviderName != "" && p.ProviderName != tpl.ProviderName {
+		return fmt.Errorf("provider %s does not match the provider of pool template %s (%s)", p.ProviderName, tpl.Name, tpl.ProviderName)
+	}
+	p.ProviderName = tpl.ProviderName
+	p.TemplateID = tpl.ID
+	p.TemplateOverrides = nil
+
+	override := func(isSet bool, field string) bool {
+		if isSet {
+			p.TemplateOverrides = append(p.TemplateOverrides, field)
+		}
+		return isSet
+	}
+
+	if !override(p.Prefix != "", PoolTemplateFieldRunnerPrefix) {
+		p.Prefix = tpl.Prefix
+	}
+	if !override(p.MaxRunners != 0, PoolTemplateFieldMaxRunners) {
+		p.MaxRunners = tpl.MaxRunners
+	}
+	if !override(p.MinIdleRunners != 0, PoolTemplateFieldMinIdleRunners) {
+		p.MinIdleRunners = tpl.MinIdleRunners
+	}
+	if !override(p.RunnerBootstrapTimeout != 0, PoolTemplateFieldRunnerBootstrapTimeout) {
+		p.RunnerBootstrapTimeout = tpl.RunnerBootstrapTimeout
+	}
+	if !override(p.Image != "", PoolTemplateFieldImage) {
+		p.Image = tpl.Image
+	}
+	if !override(p.Flavor != "", PoolTemplateFieldFlavor) {
+		p.Flavor = tpl.Flavor
+	}
+	if !override(p.OSType != "", PoolTemplateFieldOSType) {
+		p.OSType = tpl.OSType
+	}
+	if !override(p.OSArch != "", PoolTemplateFieldOSArch) {
+		p.OSArch = tpl.OSArch
+	}
+	if !override(len(p.Tags) > 0, PoolTemplateFieldTags) {
+		p.Tags = tpl.tagNames()
+	}
+	if !override(len(p.ExtraSpecs) > 0, PoolTemplateFieldExtraSpecs) {
+		p.ExtraSpecs = tpl.ExtraSpecs
+	}
+	if !override(p.GitHubRunnerGroup != "", PoolTemplateFieldGitHubRunnerGroup) {
+		p.GitHubRunnerGroup = tpl.GitHubRunnerGroup
+	}
+	if !override(p.Priority != 0, PoolTemplateFieldPriority) {
+		p.Priority = tpl.Priority
+	}
+	return nil
 }
 
 func (p *CreatePoolParams) Validate() error {
@@ -207,6 +503,34 @@ func (p *CreatePoolParams) Validate() error {
 		return fmt.Errorf("max_runners cannot be 0")
 	}
 
+	if err := ValidateRunnerReuse(p.Reusable, p.RunnerMaxJobs, p.RunnerMaxLifetime); err != nil {
+		return err
+	}
+
+	if err := ValidateRepositoryPatterns(p.IncludedRepositories); err != nil {
+		return err
+	}
+
+	if err := ValidateRepositoryPatterns(p.ExcludedRepositories); err != nil {
+		return err
+	}
+
+	if err := ValidateIdleRunnersRepositories(p.MinIdleRunners, p.IncludedRepositories, p.ExcludedRepositories); err != nil {
+		return err
+	}
+
+	if err := ValidatePoolPolicy(p.Policy); err != nil {
+		return err
+	}
+
+	if err := ValidateIdleRunnersPolicy(p.MinIdleRunners, p.Policy); err != nil {
+		return err
+	}
+
+	if err := ValidateHourlyCost(p.HourlyCost); err != nil {
+		return err
+	}
+
 	if len(p.Tags) == 0 {
 		return fmt.Errorf("missing tags")
 	}
@@ -239,6 +563,21 @@ type UpdateInstanceParams struct {
 	CreateAttempt    int                         `json:"-"`
 	TokenFetched     *bool                       `json:"-"`
 	JitConfiguration map[string]string           `json:"-"`
+	Draining         *bool                       `json:"-"`
+	JobsCompleted    *uint                       `json:"-"`
+	ConsoleOutput    []byte                      `json:"-"`
+	// Retry is set when creating the instance failed. A zero value clears the
+	// retry state.
+	Retry *InstanceRetry `json:"-"`
+	// Stages records the lifecycle stages the instance reached. A stage is
+	// only recorded the first time it is reached.
+	Stages []InstanceStage `json:"-"`
+}
+
+// InstanceRetry holds the retry state of an instance that failed to be created.
+type InstanceRetry struct {
+	ErrorClass  ProviderErrorClass
+	NextRetryAt *time.Time
 }
 
 type UpdateUserParams struct {
@@ -267,6 +606,48 @@ type UpdateEntityParams struct {
 	CredentialsName  string           `json:"credentials_name,omitempty"`
 	WebhookSecret    string           `json:"webhook_secret,omitempty"`
 	PoolBalancerType PoolBalancerType `json:"pool_balancer_type,omitempty"`
+	// ObserveOnly makes the pool manager of the entity record the actions it
+	// would take, instead of taking them.
+	ObserveOnly *bool `json:"observe_only,omitempty"`
+	// JobScheduling replaces the job scheduling settings of the entity. Empty
+	// settings restore the default, first in first out, order.
+	JobScheduling *JobScheduling `json:"job_scheduling,omitempty"`
+
+	// PreviousWebhookSecretExpiresAt is only used internally when rotating the
+	// webhook secret. If set along with WebhookSecret, the current secret is kept
+	// as the previous secret and accepted until the given time.
+	PreviousWebhookSecretExpiresAt *time.Time `json:"-"`
+}
+
+// RecordObservedActionParams holds an action a pool manager in observe only
+// mode would take.
+type RecordObservedActionParams struct {
+	PoolID string
+	Action ObservedActionType
+	Target string
+	Reason string
+}
+
+// RecordInstanceReapParams holds the reason garm removes an instance for, and
+// the timeline and console output of the instance at that time.
+type RecordInstanceReapParams struct {
+	InstanceName  string
+	PoolID        string
+	Reason        string
+	Timeline      InstanceTimeline
+	ConsoleOutput []byte
+}
+
+// RecordRunnerUsageParams holds the usage of a runner up to EndedAt. The usage
+// starts where the previous usage of the runner ended, or when the runner was
+// created.
+type RecordRunnerUsageParams struct {
+	InstanceName string
+	EndedAt      time.Time
+	// JobID is set when the usage ends with the completion of a job.
+	JobID           int64
+	RepositoryOwner string
+	RepositoryName  string
 }
 
 type InstanceUpdateMessage struct {
@@ -507,6 +888,11 @@ type UpdateControllerParams struct {
 	CallbackURL          *string `json:"callback_url,omitempty"`
 	WebhookURL           *string `json:"webhook_url,omitempty"`
 	MinimumJobAgeBackoff *uint   `json:"minimum_job_age_backoff,omitempty"`
+	ObserveOnly          *bool   `json:"observe_only,omitempty"`
+	PartitionLabel       *string `json:"partition_label,omitempty"`
+	// PartitionRepositories replaces the repositories (owner/name) this controller
+	// handles jobs for. An empty list removes the limit.
+	PartitionRepositories []string `json:"partition_repositories"`
 }
 
 func (u UpdateControllerParams) Validate() error {
@@ -531,5 +917,245 @@ func (u UpdateControllerParams) Validate() error {
 		}
 	}
 
+	if u.PartitionLabel != nil && strings.HasPrefix(*u.PartitionLabel, "runner-") {
+		return runnerErrors.NewBadRequestError("partition_label may not use the reserved runner- prefix")
+	}
+
+	for _, repo := range u.PartitionRepositories {
+		if owner, name, ok := strings.Cut(repo, "/"); !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+			return runnerErrors.NewBadRequestError("invalid partition repository %q; expected owner/name", repo)
+		}
+	}
+
+	return nil
+}
+
+type CreateWebhookDeliveryParams struct {
+	ID             string
+	HookTargetType string
+	Payload        []byte
+}
+
+type UpdateWebhookDeliveryParams struct {
+	Status        *WebhookDeliveryStatus
+	Attempts      *int
+	LastError     *string
+	NextAttemptAt *time.Time
+}
+
+type CreatePoolTemplateParams struct {
+	RunnerPrefix
+
+	Name                   string              `json:"name,omitempty"`
+	Description            string              `json:"description,omitempty"`
+	ProviderName           string              `json:"provider_name,omitempty"`
+	MaxRunners             uint                `json:"max_runners,omitempty"`
+	MinIdleRunners         uint                `json:"min_idle_runners,omitempty"`
+	Image                  string              `json:"image,omitempty"`
+	Flavor                 string              `json:"flavor,omitempty"`
+	OSType                 commonParams.OSType `json:"os_type,omitempty"`
+	OSArch                 commonParams.OSArch `json:"os_arch,omitempty"`
+	Tags                   []string            `json:"tags,omitempty"`
+	RunnerBootstrapTimeout uint                `json:"runner_bootstrap_timeout,omitempty"`
+	ExtraSpecs             json.RawMessage     `json:"extra_specs,omitempty"`
+	GitHubRunnerGroup      string              `json:"github-runner-group,omitempty"`
+	Priority               uint                `json:"priority,omitempty"`
+}
+
+func (c CreatePoolTemplateParams) Validate() error {
+	if c.Name == "" {
+		return runnerErrors.NewBadRequestError("missing name")
+	}
+
+	if c.ProviderName == "" {
+		return runnerErrors.NewBadRequestError("missing provider")
+	}
+
+	if c.MaxRunners == 0 {
+		return runnerErrors.NewBadRequestError("max_runners cannot be 0")
+	}
+
+	if c.MinIdleRunners > c.MaxRunners {
+		return runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners")
+	}
+
+	if len(c.Tags) == 0 {
+		return runnerErrors.NewBadRequestError("missing tags")
+	}
+
+	if c.Flavor == "" {
+		return runnerErrors.NewBadRequestError("missing flavor")
+	}
+
+	if c.Image == "" {
+		return runnerErrors.NewBadRequestError("missing image")
+	}
+
+	return nil
+}
+
+// UpdatePoolTemplateParams holds the fields of a pool template that can be updated.
+// The provider of a template cannot be changed, as pools cannot change providers.
+type UpdatePoolTemplateParams struct {
+	RunnerPrefix
+
+	Name                   *string             `json:"name,omitempty"`
+	Description            *string             `json:"description,omitempty"`
+	MaxRunners             *uint               `json:"max_runners,omitempty"`
+	MinIdleRunners         *uint               `json:"min_idle_runners,omitempty"`
+	RunnerBootstrapTimeout *uint               `json:"runner_bootstrap_timeout,omitempty"`
+	Image                  string              `json:"image,omitempty"`
+	Flavor                 string              `json:"flavor,omitempty"`
+	OSType                 commonParams.OSType `json:"os_type,omitempty"`
+	OSArch                 commonParams.OSArch `json:"os_arch,omitempty"`
+	Tags                   []string            `json:"tags,omitempty"`
+	ExtraSpecs             json.RawMessage     `json:"extra_specs,omitempty"`
+	GitHubRunnerGroup      *string             `json:"github-runner-group,omitempty"`
+	Priority               *uint               `json:"priority,omitempty"`
+}
+
+func (u UpdatePoolTemplateParams) Validate() error {
+	if u.Name != nil && *u.Name == "" {
+		return runnerErrors.NewBadRequestError("name cannot be empty")
+	}
+
+	if u.RunnerBootstrapTimeout != nil && *u.RunnerBootstrapTimeout == 0 {
+		return runnerErrors.NewBadRequestError("runner_bootstrap_timeout cannot be 0")
+	}
+
+	return nil
+}
+
+// Apply returns a copy of the template with the updated fields set.
+func (u UpdatePoolTemplateParams) Apply(tpl PoolTemplate) (PoolTemplate, error) {
+	if u.Name != nil {
+		tpl.Name = *u.Name
+	}
+	if u.Description != nil {
+		tpl.Description = *u.Description
+	}
+	if u.Prefix != "" {
+		tpl.Prefix = u.Prefix
+	}
+	if u.MaxRunners != nil {
+		tpl.MaxRunners = *u.MaxRunners
+	}
+	if u.MinIdleRunners != nil {
+		tpl.MinIdleRunners = *u.MinIdleRunners
+	}
+	if u.RunnerBootstrapTimeout != nil {
+		tpl.RunnerBootstrapTimeout = *u.RunnerBootstrapTimeout
+	}
+	if u.Image != "" {
+		tpl.Image = u.Image
+	}
+	if u.Flavor != "" {
+		tpl.Flavor = u.Flavor
+	}
+	if u.OSType != "" {
+		tpl.OSType = u.OSType
+	}
+	if u.OSArch != "" {
+		tpl.OSArch = u.OSArch
+	}
+	if len(u.Tags) > 0 {
+		tpl.Tags = make([]Tag, len(u.Tags))
+		for idx, val := range u.Tags {
+			tpl.Tags[idx] = Tag{Name: val}
+		}
+	}
+	if u.ExtraSpecs != nil {
+		tpl.ExtraSpecs = u.ExtraSpecs
+	}
+	if u.GitHubRunnerGroup != nil {
+		tpl.GitHubRunnerGroup = *u.GitHubRunnerGroup
+	}
+	if u.Priority != nil {
+		tpl.Priority = *u.Priority
+	}
+
+	if tpl.MinIdleRunners > tpl.MaxRunners {
+		return PoolTemplate{}, runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners")
+	}
+	return tpl, nil
+}
+
+type CreateBackupParams struct {
+	// Passphrase is used to seal all secrets in the backup. It must be
+	// exactly 32 characters long.
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+func (c CreateBackupParams) Validate() error {
+	if len(c.Passphrase) != 32 {
+		return runnerErrors.NewBadRequestError("passphrase must be exactly 32 characters long")
+	}
+	return nil
+}
+
+type RestoreBackupParams struct {
+	// Passphrase is the passphrase used when the backup was created.
+	Passphrase string `json:"passphrase,omitempty"`
+	Backup     Backup `json:"backup"`
+}
+
+func (r RestoreBackupParams) Validate() error {
+	if len(r.Passphrase) != 32 {
+		return runnerErrors.NewBadRequestError("passphrase must be exactly 32 characters long")
+	}
+	if r.Backup.Version == 0 {
+		return runnerErrors.NewBadRequestError("missing backup version")
+	}
+	if r.Backup.Version > BackupFormatVersion {
+		return runnerErrors.NewBadRequestError("backup format version %d is not supported (latest supported version is %d)", r.Backup.Version, BackupFormatVersion)
+	}
+	return nil
+}
+
+type RecyclePoolParams struct {
+	// MaxUnavailable is the maximum number of runners that are replaced at the
+	// same time. Defaults to 1.
+	MaxUnavailable uint `json:"max_unavailable,omitempty"`
+}
+
+func (r *RecyclePoolParams) Validate() error {
+	if r.MaxUnavailable == 0 {
+		r.MaxUnavailable = 1
+	}
+	return nil
+}
+
+// AdoptRunnersParams holds the parameters used when adopting the runners of a
+// pool that GARM lost track of.
+type AdoptRunnersParams struct {
+	// DryRun only reports the runners that would be adopted.
+	DryRun bool `json:"dry_run,omitempty"`
+	// ControllerID is the ID of the controller that created the runners. It
+	// defaults to the ID of this controller. Set it when the runners were
+	// created by a controller whose database was lost.
+	ControllerID string `json:"controller_id,omitempty"`
+	// SourcePoolID is the pool ID the provider tagged the instances with. It
+	// defaults to the ID of the pool the runners are adopted into.
+	SourcePoolID string `json:"source_pool_id,omitempty"`
+	// Labels selects the runners to adopt by their GitHub labels instead of
+	// by controller ID. A runner must have all of the labels. Use this for
+	// runners that were not created by GARM.
+	Labels []string `json:"labels,omitempty"`
+}
+
+func (a AdoptRunnersParams) Validate() error {
+	if a.ControllerID != "" && len(a.Labels) > 0 {
+		return runnerErrors.NewBadRequestError("controller_id and labels are mutually exclusive")
+	}
+	if a.ControllerID != "" {
+		if _, err := uuid.Parse(a.ControllerID); err != nil {
+			return runnerErrors.NewBadRequestError("invalid controller_id: %s", a.ControllerID)
+		}
+	}
+	for _, label := range a.Labels {
+		if strings.TrimSpace(label) == "" {
+			return runnerErrors.NewBadRequestError("labels must not be empty")
+		}
+	}
 	return nil
 }
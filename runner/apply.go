@@ -0,0 +1,964 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm-provider-common/util"
+	"github.com/cloudbase/garm/auth"
+	"github.com/cloudbase/garm/params"
+)
+
+const redactedValue = "<redacted>"
+
+// applyStep is a single step of an apply plan. The action describes the step
+// and run carries it out.
+type applyStep struct {
+	action params.ApplyAction
+	run    func(ctx context.Context) error
+}
+
+// applyExistingEntity is the common view of an existing repository, organization
+// or enterprise used when computing the plan.
+type applyExistingEntity struct {
+	id               string
+	name             string
+	endpoint         string
+	credentialsName  string
+	poolBalancerType params.PoolBalancerType
+	webhookSecret    string
+	observeOnly      bool
+	jobScheduling    *params.JobScheduling
+	entity           params.GithubEntity
+}
+
+// applyDesiredEntity is the common view of an entity from the apply document.
+type applyDesiredEntity struct {
+	name string
+	spec params.ApplyEntity
+}
+
+// applyEntityOps holds the operations needed to converge one kind of entity.
+type applyEntityOps struct {
+	kind       params.ApplyObjectKind
+	create     func(ctx context.Context, desired applyDesiredEntity, webhookSecret string) (string, error)
+	update     func(ctx context.Context, id string, param params.UpdateEntityParams) error
+	delete     func(ctx context.Context, id string) error
+	createPool func(ctx context.Context, id string, param params.CreatePoolParams) error
+	updatePool func(ctx context.Context, id, poolID string, param params.UpdatePoolParams) error
+	deletePool func(ctx context.Context, id, poolID string) error
+}
+
+// Apply computes the changes needed to converge the current state to the one
+// described by the document and carries them out, unless dryRun is set. If prune
+// is set, repositories, organizations, enterprises and pools that are not part of
+// the document are removed. Endpoints and credentials are never removed.
+//
+// Steps are executed in order and are not rolled back if one of them fails.
+func (r *Runner) Apply(ctx context.Context, doc params.ApplyDocument, dryRun, prune bool) (params.ApplyResult, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.ApplyResult{}, runnerErrors.ErrUnauthorized
+	}
+
+	if err := doc.Validate(); err != nil {
+		return params.ApplyResult{}, errors.Wrap(err, "validating document")
+	}
+
+	steps, err := r.planApply(ctx, doc, prune)
+	if err != nil {
+		return params.ApplyResult{}, errors.Wrap(err, "computing plan")
+	}
+
+	ret := params.ApplyResult{
+		DryRun:  dryRun,
+		Prune:   prune,
+		Actions: make([]params.ApplyAction, 0, len(steps)),
+	}
+	for _, step := range steps {
+		ret.Actions = append(ret.Actions, step.action)
+	}
+	if dryRun {
+		return ret, nil
+	}
+
+	for _, step := range steps {
+		slog.InfoContext(
+			ctx, "applying change",
+			"action", step.action.Action,
+			"kind", step.action.Kind,
+			"name", step.action.Name)
+		if err := step.run(ctx); err != nil {
+			return params.ApplyResult{}, errors.Wrapf(err, "failed to %s %s %s", step.action.Action, step.action.Kind, step.action.Name)
+		}
+	}
+	return ret, nil
+}
+
+// Export returns the current state as an apply document. Webhook secrets and
+// credentials secrets are not included.
+func (r *Runner) Export(ctx context.Context) (params.ApplyDocument, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.ApplyDocument{}, runnerErrors.ErrUnauthorized
+	}
+
+	var doc params.ApplyDocument
+
+	endpoints, err := r.store.ListGithubEndpoints(ctx)
+	if err != nil {
+		return params.ApplyDocument{}, errors.Wrap(err, "listing endpoints")
+	}
+	for _, ep := range endpoints {
+		doc.Endpoints = append(doc.Endpoints, params.ApplyEndpoint{
+			Name:          ep.Name,
+			Description:   ep.Description,
+			APIBaseURL:    ep.APIBaseURL,
+			UploadBaseURL: ep.UploadBaseURL,
+			BaseURL:       ep.BaseURL,
+			CACertBundle:  string(ep.CACertBundle),
+		})
+	}
+
+	creds, err := r.store.ListGithubCredentials(ctx)
+	if err != nil {
+		return params.ApplyDocument{}, errors.Wrap(err, "listing credentials")
+	}
+	for _, cred := range creds {
+		doc.Credentials = append(doc.Credentials, params.ApplyCredentials{
+			Name:        cred.Name,
+			Description: cred.Description,
+			Endpoint:    cred.Endpoint.Name,
+		})
+	}
+
+	exportEntity := func(entity params.GithubEntity, credentialsName string, poolBalancerType params.PoolBalancerType, observeOnly bool, jobScheduling *params.JobScheduling) (params.ApplyEntity, error) {
+		pools, err := r.listApplyPools(ctx, entity)
+		if err != nil {
+			return params.ApplyEntity{}, errors.Wrap(err, "listing pools")
+		}
+		ret := params.ApplyEntity{
+			CredentialsName:  credentialsName,
+			PoolBalancerType: poolBalancerType,
+			ObserveOnly:      observeOnly,
+		}
+		if jobScheduling != nil && !jobScheduling.IsEmpty() {
+			ret.JobScheduling = jobScheduling
+		}
+		for _, pool := range pools {
+			applyPool, err := params.ApplyPoolFromPool(pool)
+			if err != nil {
+				return params.ApplyEntity{}, err
+			}
+			ret.Pools = append(ret.Pools, applyPool)
+		}
+		return ret, nil
+	}
+
+	repos, err := r.store.ListRepositories(ctx)
+	if err != nil {
+		return params.ApplyDocument{}, errors.Wrap(err, "listing repositories")
+	}
+	for _, repo := range repos {
+		entity, err := repo.GetEntity()
+		if err != nil {
+			return params.ApplyDocument{}, errors.Wrap(err, "getting entity")
+		}
+		spec, err := exportEntity(entity, repo.CredentialsName, repo.PoolBalancerType, repo.ObserveOnly, repo.JobScheduling)
+		if err != nil {
+			return params.ApplyDocument{}, errors.Wrapf(err, "exporting repository %s/%s", repo.Owner, repo.Name)
+		}
+		doc.Repositories = append(doc.Repositories, params.ApplyRepository{
+			Owner:       repo.Owner,
+			Name:        repo.Name,
+			ApplyEntity: spec,
+		})
+	}
+
+	orgs, err := r.store.ListOrganizations(ctx)
+	if err != nil {
+		return params.ApplyDocument{}, errors.Wrap(err, "listing organizations")
+	}
+	for _, org := range orgs {
+		entity, err := org.GetEntity()
+		if err != nil {
+			return params.ApplyDocument{}, errors.Wrap(err, "getting entity")
+		}
+		spec, err := exportEntity(entity, org.CredentialsName, org.PoolBalancerType, org.ObserveOnly, org.JobScheduling)
+		if err != nil {
+			return params.ApplyDocument{}, errors.Wrapf(err, "exporting organization %s", org.Name)
+		}
+		doc.Organizations = append(doc.Organizations, params.ApplyOrganization{
+			Name:        org.Name,
+			ApplyEntity: spec,
+		})
+	}
+
+	enterprises, err := r.store.ListEnterprises(ctx)
+	if err != nil {
+		return params.ApplyDocument{}, errors.Wrap(err, "listing enterprises")
+	}
+	for _, ent := range enterprises {
+		entity, err := ent.GetEntity()
+		if err != nil {
+			return params.ApplyDocument{}, errors.Wrap(err, "getting entity")
+		}
+		spec, err := exportEntity(entity, ent.CredentialsName, ent.PoolBalancerType, ent.ObserveOnly, ent.JobScheduling)
+		if err != nil {
+			return params.ApplyDocument{}, errors.Wrapf(err, "exporting enterprise %s", ent.Name)
+		}
+		doc.Enterprises = append(doc.Enterprises, params.ApplyEnterprise{
+			Name:        ent.Name,
+			ApplyEntity: spec,
+		})
+	}
+	return doc, nil
+}
+
+func (r *Runner) planApply(ctx context.Context, doc params.ApplyDocument, prune bool) ([]applyStep, error) {
+	var steps []applyStep
+
+	endpointSteps, knownEndpoints, err := r.planApplyEndpoints(ctx, doc.Endpoints)
+	if err != nil {
+		return nil, err
+	}
+	steps = append(steps, endpointSteps...)
+
+	credsSteps, credsByName, err := r.planApplyCredentials(ctx, doc.Credentials, knownEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	steps = append(steps, credsSteps...)
+
+	var deleteSteps []applyStep
+	entityPlans := []func() ([]applyStep, []applyStep, error){
+		func() ([]applyStep, []applyStep, error) {
+			return r.planApplyRepositories(ctx, doc.Repositories, credsByName, prune)
+		},
+		func() ([]applyStep, []applyStep, error) {
+			return r.planApplyOrganizations(ctx, doc.Organizations, credsByName, prune)
+		},
+		func() ([]applyStep, []applyStep, error) {
+			return r.planApplyEnterprises(ctx, doc.Enterprises, credsByName, prune)
+		},
+	}
+	for _, plan := range entityPlans {
+		entitySteps, entityDeleteSteps, err := plan()
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, entitySteps...)
+		deleteSteps = append(deleteSteps, entityDeleteSteps...)
+	}
+
+	// Deletes are done last, so pools and entities are only removed once
+	// everything that replaces them is in place.
+	return append(steps, deleteSteps...), nil
+}
+
+func (r *Runner) planApplyEndpoints(ctx context.Context, desired []params.ApplyEndpoint) ([]applyStep, map[string]struct{}, error) {
+	existing, err := r.store.ListGithubEndpoints(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "listing endpoints")
+	}
+
+	known := map[string]struct{}{}
+	byName := map[string]params.GithubEndpoint{}
+	for _, ep := range existing {
+		known[ep.Name] = struct{}{}
+		byName[ep.Name] = ep
+	}
+
+	var steps []applyStep
+	for _, ep := range desired {
+		known[ep.Name] = struct{}{}
+
+		current, ok := byName[ep.Name]
+		if !ok {
+			createParams := params.CreateGithubEndpointParams{
+				Name:          ep.Name,
+				Description:   ep.Description,
+				APIBaseURL:    ep.APIBaseURL,
+				UploadBaseURL: ep.UploadBaseURL,
+				BaseURL:       ep.BaseURL,
+				CACertBundle:  []byte(ep.CACertBundle),
+			}
+			if err := createParams.Validate(); err != nil {
+				return nil, nil, errors.Wrapf(err, "endpoint %s", ep.Name)
+			}
+			steps = append(steps, applyStep{
+				action: params.ApplyAction{
+					Action: params.ApplyActionCreate,
+					Kind:   params.ApplyObjectKindEndpoint,
+					Name:   ep.Name,
+				},
+				run: func(ctx context.Context) error {
+					_, err := r.CreateGithubEndpoint(ctx, createParams)
+					return err
+				},
+			})
+			continue
+		}
+
+		var updateParams params.UpdateGithubEndpointParams
+		var changes []params.ApplyFieldChange
+		changed := func(field, oldVal, newVal string) bool {
+			if oldVal == newVal {
+				return false
+			}
+			changes = append(changes, params.ApplyFieldChange{Field: field, Old: oldVal, New: newVal})
+			return true
+		}
+		if changed("description", current.Description, ep.Description) {
+			updateParams.Description = &ep.Description
+		}
+		// URLs are required by GARM and are only converged when set in the document.
+		if ep.APIBaseURL != "" && changed("api_base_url", current.APIBaseURL, ep.APIBaseURL) {
+			updateParams.APIBaseURL = &ep.APIBaseURL
+		}
+		if ep.UploadBaseURL != "" && changed("upload_base_url", current.UploadBaseURL, ep.UploadBaseURL) {
+			updateParams.UploadBaseURL = &ep.UploadBaseURL
+		}
+		if ep.BaseURL != "" && changed("base_url", current.BaseURL, ep.BaseURL) {
+			updateParams.BaseURL = &ep.BaseURL
+		}
+		if ep.CACertBundle != "" && string(current.CACertBundle) != ep.CACertBundle {
+			changes = append(changes, params.ApplyFieldChange{Field: "ca_cert_bundle"})
+			updateParams.CACertBundle = []byte(ep.CACertBundle)
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		if err := updateParams.Validate(); err != nil {
+			return nil, nil, errors.Wrapf(err, "endpoint %s", ep.Name)
+		}
+		steps = append(steps, applyStep{
+			action: params.ApplyAction{
+				Action:  params.ApplyActionUpdate,
+				Kind:    params.ApplyObjectKindEndpoint,
+				Name:    ep.Name,
+				Changes: changes,
+			},
+			run: func(ctx context.Context) error {
+				_, err := r.UpdateGithubEndpoint(ctx, ep.Name, updateParams)
+				return err
+			},
+		})
+	}
+	return steps, known, nil
+}
+
+func (r *Runner) planApplyCredentials(ctx context.Context, desired []params.ApplyCredentials, knownEndpoints map[string]struct{}) ([]applyStep, map[string]params.GithubCredentials, error) {
+	existing, err := r.store.ListGithubCredentials(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "listing credentials")
+	}
+
+	byName := map[string]params.GithubCredentials{}
+	for _, cred := range existing {
+		byName[cred.Name] = cred
+	}
+
+	var steps []applyStep
+	for _, cred := range desired {
+		current, ok := byName[cred.Name]
+		if !ok {
+			return nil, nil, runnerErrors.NewBadRequestError("credentials %s are not defined; credentials must be created before they can be referenced", cred.Name)
+		}
+		if cred.Endpoint != "" {
+			if _, ok := knownEndpoints[cred.Endpoint]; !ok {
+				return nil, nil, runnerErrors.NewBadRequestError("credentials %s: endpoint %s is not defined", cred.Name, cred.Endpoint)
+			}
+			if cred.Endpoint != current.Endpoint.Name {
+				return nil, nil, runnerErrors.NewBadRequestError("credentials %s belong to endpoint %s and cannot be moved to %s", cred.Name, current.Endpoint.Name, cred.Endpoint)
+			}
+		}
+		if cred.Description == current.Description {
+			continue
+		}
+		steps = append(steps, applyStep{
+			action: params.ApplyAction{
+				Action: params.ApplyActionUpdate,
+				Kind:   params.ApplyObjectKindCredentials,
+				Name:   cred.Name,
+				ID:     fmt.Sprintf("%d", current.ID),
+				Changes: []params.ApplyFieldChange{
+					{Field: "description", Old: current.Description, New: cred.Description},
+				},
+			},
+			run: func(ctx context.Context) error {
+				_, err := r.UpdateGithubCredentials(ctx, current.ID, params.UpdateGithubCredentialsParams{
+					Description: &cred.Description,
+				})
+				return err
+			},
+		})
+	}
+	return steps, byName, nil
+}
+
+func (r *Runner) planApplyRepositories(ctx context.Context, desired []params.ApplyRepository, credsByName map[string]params.GithubCredentials, prune bool) ([]applyStep, []applyStep, error) {
+	repos, err := r.store.ListRepositories(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "listing repositories")
+	}
+
+	var existing []applyExistingEntity
+	for _, repo := range repos {
+		entity, err := repo.GetEntity()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "getting entity")
+		}
+		existing = append(existing, applyExistingEntity{
+			id:               repo.ID,
+			name:             fmt.Sprintf("%s/%s", repo.Owner, repo.Name),
+			endpoint:         repo.Endpoint.Name,
+			credentialsName:  repo.CredentialsName,
+			poolBalancerType: repo.PoolBalancerType,
+			webhookSecret:    repo.WebhookSecret,
+			observeOnly:      repo.ObserveOnly,
+			jobScheduling:    repo.JobScheduling,
+			entity:           entity,
+		})
+	}
+
+	var wanted []applyDesiredEntity
+	for _, repo := range desired {
+		wanted = append(wanted, applyDesiredEntity{
+			name: fmt.Sprintf("%s/%s", repo.Owner, repo.Name),
+			spec: repo.ApplyEntity,
+		})
+	}
+
+	ops := applyEntityOps{
+		kind: params.ApplyObjectKindRepository,
+		create: func(ctx context.Context, desired applyDesiredEntity, webhookSecret string) (string, error) {
+			owner, name, _ := strings.Cut(desired.name, "/")
+			repo, err := r.CreateRepository(ctx, params.CreateRepoParams{
+				Owner:            owner,
+				Name:             name,
+				CredentialsName:  desired.spec.CredentialsName,
+				WebhookSecret:    webhookSecret,
+				PoolBalancerType: desired.spec.PoolBalancerType,
+			})
+			return repo.ID, err
+		},
+		update: func(ctx context.Context, id string, param params.UpdateEntityParams) error {
+			_, err := r.UpdateRepository(ctx, id, param)
+			return err
+		},
+		delete: func(ctx context.Context, id string) error {
+			return r.DeleteRepository(ctx, id, false)
+		},
+		createPool: func(ctx context.Context, id string, param params.CreatePoolParams) error {
+			_, err := r.CreateRepoPool(ctx, id, param)
+			return err
+		},
+		updatePool: func(ctx context.Context, id, poolID string, param params.UpdatePoolParams) error {
+			_, err := r.UpdateRepoPool(ctx, id, poolID, param)
+			return err
+		},
+		deletePool: r.DeleteRepoPool,
+	}
+	return r.planApplyEntities(ctx, ops, wanted, existing, credsByName, prune)
+}
+
+func (r *Runner) planApplyOrganizations(ctx context.Context, desired []params.ApplyOrganization, credsByName map[string]params.GithubCredentials, prune bool) ([]applyStep, []applyStep, error) {
+	orgs, err := r.store.ListOrganizations(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "listing organizations")
+	}
+
+	var existing []applyExistingEntity
+	for _, org := range orgs {
+		entity, err := org.GetEntity()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "getting entity")
+		}
+		existing = append(existing, applyExistingEntity{
+			id:               org.ID,
+			name:             org.Name,
+			endpoint:         org.Endpoint.Name,
+			credentialsName:  org.CredentialsName,
+			poolBalancerType: org.PoolBalancerType,
+			webhookSecret:    org.WebhookSecret,
+			observeOnly:      org.ObserveOnly,
+			jobScheduling:    org.JobScheduling,
+			entity:           entity,
+		})
+	}
+
+	var wanted []applyDesiredEntity
+	for _, org := range desired {
+		wanted = append(wanted, applyDesiredEntity{
+			name: org.Name,
+			spec: org.ApplyEntity,
+		})
+	}
+
+	ops := applyEntityOps{
+		kind: params.ApplyObjectKindOrganization,
+		create: func(ctx context.Context, desired applyDesiredEntity, webhookSecret string) (string, error) {
+			org, err := r.CreateOrganization(ctx, params.CreateOrgParams{
+				Name:             desired.name,
+				CredentialsName:  desired.spec.CredentialsName,
+				WebhookSecret:    webhookSecret,
+				PoolBalancerType: desired.spec.PoolBalancerType,
+			})
+			return org.ID, err
+		},
+		update: func(ctx context.Context, id string, param params.UpdateEntityParams) error {
+			_, err := r.UpdateOrganization(ctx, id, param)
+			return err
+		},
+		delete: func(ctx context.Context, id string) error {
+			return r.DeleteOrganization(ctx, id, false)
+		},
+		createPool: func(ctx context.Context, id string, param params.CreatePoolParams) error {
+			_, err := r.CreateOrgPool(ctx, id, param)
+			return err
+		},
+		updatePool: func(ctx context.Context, id, poolID string, param params.UpdatePoolParams) error {
+			_, err := r.UpdateOrgPool(ctx, id, poolID, param)
+			return err
+		},
+		deletePool: r.DeleteOrgPool,
+	}
+	return r.planApplyEntities(ctx, ops, wanted, existing, credsByName, prune)
+}
+
+func (r *Runner) planApplyEnterprises(ctx context.Context, desired []params.ApplyEnterprise, credsByName map[string]params.GithubCredentials, prune bool) ([]applyStep, []applyStep, error) {
+	enterprises, err := r.store.ListEnterprises(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "listing enterprises")
+	}
+
+	var existing []applyExistingEntity
+	for _, ent := range enterprises {
+		entity, err := ent.GetEntity()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "getting entity")
+		}
+		existing = append(existing, applyExistingEntity{
+			id:               ent.ID,
+			name:             ent.Name,
+			endpoint:         ent.Endpoint.Name,
+			credentialsName:  ent.CredentialsName,
+			poolBalancerType: ent.PoolBalancerType,
+			webhookSecret:    ent.WebhookSecret,
+			observeOnly:      ent.ObserveOnly,
+			jobScheduling:    ent.JobScheduling,
+			entity:           entity,
+		})
+	}
+
+	var wanted []applyDesiredEntity
+	for _, ent := range desired {
+		wanted = append(wanted, applyDesiredEntity{
+			name: ent.Name,
+			spec: ent.ApplyEntity,
+		})
+	}
+
+	ops := applyEntityOps{
+		kind: params.ApplyObjectKindEnterprise,
+		create: func(ctx context.Context, desired applyDesiredEntity, webhookSecret string) (string, error) {
+			ent, err := r.CreateEnterprise(ctx, params.CreateEnterpriseParams{
+				Name:             desired.name,
+				CredentialsName:  desired.spec.CredentialsName,
+				WebhookSecret:    webhookSecret,
+				PoolBalancerType: desired.spec.PoolBalancerType,
+			})
+			return ent.ID, err
+		},
+		update: func(ctx context.Context, id string, param params.UpdateEntityParams) error {
+			_, err := r.UpdateEnterprise(ctx, id, param)
+			return err
+		},
+		delete: r.DeleteEnterprise,
+		createPool: func(ctx context.Context, id string, param params.CreatePoolParams) error {
+			_, err := r.CreateEnterprisePool(ctx, id, param)
+			return err
+		},
+		updatePool: func(ctx context.Context, id, poolID string, param params.UpdatePoolParams) error {
+			_, err := r.UpdateEnterprisePool(ctx, id, poolID, param)
+			return err
+		},
+		deletePool: r.DeleteEnterprisePool,
+	}
+	return r.planApplyEntities(ctx, ops, wanted, existing, credsByName, prune)
+}
+
+// planApplyEntities computes the steps needed to converge one kind of entity and
+// their pools. Deletes are returned separately, so they can be run after all
+// other steps.
+func (r *Runner) planApplyEntities(ctx context.Context, ops applyEntityOps, desired []applyDesiredEntity, existing []applyExistingEntity, credsByName map[string]params.GithubCredentials, prune bool) ([]applyStep, []applyStep, error) {
+	var steps, deleteSteps []applyStep
+	matched := map[string]struct{}{}
+
+	for _, want := range desired {
+		creds, ok := credsByName[want.spec.CredentialsName]
+		if !ok {
+			return nil, nil, runnerErrors.NewBadRequestError("%s %s: credentials %s are not defined", ops.kind, want.name, want.spec.CredentialsName)
+		}
+
+		var current *applyExistingEntity
+		for idx := range existing {
+			if existing[idx].endpoint == creds.Endpoint.Name && strings.EqualFold(existing[idx].name, want.name) {
+				current = &existing[idx]
+				break
+			}
+		}
+
+		if current == nil {
+			// The ID of the new entity is only known once it is created. Pool
+			// steps read it when they run.
+			var entityID string
+			steps = append(steps, applyStep{
+				action: params.ApplyAction{
+					Action: params.ApplyActionCreate,
+					Kind:   ops.kind,
+					Name:   want.name,
+				},
+				run: func(ctx context.Context) error {
+					secret := want.spec.WebhookSecret
+					if secret == "" {
+						var err error
+						secret, err = util.GetRandomString(32)
+						if err != nil {
+							return errors.Wrap(err, "generating webhook secret")
+						}
+					}
+					id, err := ops.create(ctx, want, secret)
+					if err != nil {
+						return err
+					}
+					entityID = id
+					// Entities are created with the default mode and job
+					// scheduling. Those can only be set by updating the entity.
+					updateParams := params.UpdateEntityParams{
+						PoolBalancerType: want.spec.PoolBalancerType,
+					}
+					if want.spec.ObserveOnly {
+						observeOnly := true
+						updateParams.ObserveOnly = &observeOnly
+					}
+					if want.spec.JobScheduling != nil && !want.spec.JobScheduling.IsEmpty() {
+						updateParams.JobScheduling = want.spec.JobScheduling
+					}
+					if updateParams.ObserveOnly == nil && updateParams.JobScheduling == nil {
+						return nil
+					}
+					return ops.update(ctx, entityID, updateParams)
+				},
+			})
+			for _, pool := range want.spec.Pools {
+				if pool.ID != "" {
+					return nil, nil, runnerErrors.NewBadRequestError("%s %s: pool %s not found", ops.kind, want.name, pool.ID)
+				}
+				createParams, err := pool.CreateParams()
+				if err != nil {
+					return nil, nil, errors.Wrapf(err, "%s %s", ops.kind, want.name)
+				}
+				steps = append(steps, applyStep{
+					action: params.ApplyAction{
+						Action: params.ApplyActionCreate,
+						Kind:   params.ApplyObjectKindPool,
+						Name:   fmt.Sprintf("%s %s", want.name, pool.Key()),
+					},
+					run: func(ctx context.Context) error {
+						return ops.createPool(ctx, entityID, createParams)
+					},
+				})
+			}
+			continue
+		}
+
+		matched[current.id] = struct{}{}
+		entityStep, ok, err := r.planApplyEntityUpdate(ops, want, *current)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "%s %s", ops.kind, want.name)
+		}
+		if ok {
+			steps = append(steps, entityStep)
+		}
+
+		poolSteps, poolDeleteSteps, err := r.planApplyPools(ctx, ops, want, *current, prune)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "%s %s", ops.kind, want.name)
+		}
+		steps = append(steps, poolSteps...)
+		deleteSteps = append(deleteSteps, poolDeleteSteps...)
+	}
+
+	if !prune {
+		return steps, deleteSteps, nil
+	}
+
+	for _, current := range existing {
+		if _, ok := matched[current.id]; ok {
+			continue
+		}
+		pools, err := r.store.ListEntityPools(ctx, current.entity)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "listing pools of %s %s", ops.kind, current.name)
+		}
+		for _, pool := range pools {
+			deleteSteps = append(deleteSteps, applyStep{
+				action: params.ApplyAction{
+					Action: params.ApplyActionDelete,
+					Kind:   params.ApplyObjectKindPool,
+					Name:   fmt.Sprintf("%s %s", current.name, pool.PoolKey()),
+					ID:     pool.ID,
+				},
+				run: func(ctx context.Context) error {
+					return ops.deletePool(ctx, current.id, pool.ID)
+				},
+			})
+		}
+		deleteSteps = append(deleteSteps, applyStep{
+			action: params.ApplyAction{
+				Action: params.ApplyActionDelete,
+				Kind:   ops.kind,
+				Name:   current.name,
+				ID:     current.id,
+			},
+			run: func(ctx context.Context) error {
+				return ops.delete(ctx, current.id)
+			},
+		})
+	}
+	return steps, deleteSteps, nil
+}
+
+func (r *Runner) planApplyEntityUpdate(ops applyEntityOps, want applyDesiredEntity, current applyExistingEntity) (applyStep, bool, error) {
+	var changes []params.ApplyFieldChange
+	// The pool balancer type must always be set when updating an entity.
+	updateParams := params.UpdateEntityParams{
+		PoolBalancerType: current.poolBalancerType,
+	}
+
+	if want.spec.CredentialsName != current.credentialsName {
+		changes = append(changes, params.ApplyFieldChange{
+			Field: "credentials_name",
+			Old:   current.credentialsName,
+			New:   want.spec.CredentialsName,
+		})
+		updateParams.CredentialsName = want.spec.CredentialsName
+	}
+	if want.spec.PoolBalancerType != "" && want.spec.PoolBalancerType != current.poolBalancerType {
+		changes = append(changes, params.ApplyFieldChange{
+			Field: "pool_balancer_type",
+			Old:   string(current.poolBalancerType),
+			New:   string(want.spec.PoolBalancerType),
+		})
+		updateParams.PoolBalancerType = want.spec.PoolBalancerType
+	}
+	if want.spec.WebhookSecret != "" && want.spec.WebhookSecret != current.webhookSecret {
+		changes = append(changes, params.ApplyFieldChange{
+			Field: "webhook_secret",
+			Old:   redactedValue,
+			New:   redactedValue,
+		})
+		updateParams.WebhookSecret = want.spec.WebhookSecret
+	}
+	if want.spec.ObserveOnly != current.observeOnly {
+		changes = append(changes, params.ApplyFieldChange{
+			Field: "observe_only",
+			Old:   fmt.Sprintf("%v", current.observeOnly),
+			New:   fmt.Sprintf("%v", want.spec.ObserveOnly),
+		})
+		observeOnly := want.spec.ObserveOnly
+		updateParams.ObserveOnly = &observeOnly
+	}
+
+	currentScheduling, err := jobSchedulingString(current.jobScheduling)
+	if err != nil {
+		return applyStep{}, false, err
+	}
+	scheduling, err := jobSchedulingString(want.spec.JobScheduling)
+	if err != nil {
+		return applyStep{}, false, err
+	}
+	if scheduling != currentScheduling {
+		changes = append(changes, params.ApplyFieldChange{
+			Field: "job_scheduling",
+			Old:   currentScheduling,
+			New:   scheduling,
+		})
+		// Empty settings restore the default order.
+		updateParams.JobScheduling = &params.JobScheduling{}
+		if want.spec.JobScheduling != nil {
+			updateParams.JobScheduling = want.spec.JobScheduling
+		}
+	}
+	if len(changes) == 0 {
+		return applyStep{}, false, nil
+	}
+
+	return applyStep{
+		action: params.ApplyAction{
+			Action:  params.ApplyActionUpdate,
+			Kind:    ops.kind,
+			Name:    want.name,
+			ID:      current.id,
+			Changes: changes,
+		},
+		run: func(ctx context.Context) error {
+			return ops.update(ctx, current.id, updateParams)
+		},
+	}, true, nil
+}
+
+// jobSchedulingString encodes the job scheduling settings of an entity so they
+// can be compared with the ones in a declarative document. Settings that match
+// the defaults are the same as no settings.
+func jobSchedulingString(scheduling *params.JobScheduling) (string, error) {
+	if scheduling == nil || scheduling.IsEmpty() {
+		return "", nil
+	}
+	asJSON, err := json.Marshal(scheduling)
+	if err != nil {
+		return "", errors.Wrap(err, "marshaling job scheduling")
+	}
+	return string(asJSON), nil
+}
+
+// listApplyPools returns the pools of an entity, along with their extra specs.
+// Those are left out when listing the pools of an entity.
+func (r *Runner) listApplyPools(ctx context.Context, entity params.GithubEntity) ([]params.Pool, error) {
+	pools, err := r.store.ListEntityPools(ctx, entity)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing pools")
+	}
+	for idx, pool := range pools {
+		pools[idx], err = r.store.GetPoolByID(ctx, pool.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetching pool")
+		}
+	}
+	return pools, nil
+}
+
+// planApplyPools matches the pools in the document with the existing pools of an
+// entity. Pools with an ID are matched by ID. The rest are matched by provider and
+// tags, which must be unambiguous.
+func (r *Runner) planApplyPools(ctx context.Context, ops applyEntityOps, want applyDesiredEntity, current applyExistingEntity, prune bool) ([]applyStep, []applyStep, error) {
+	pools, err := r.listApplyPools(ctx, current.entity)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "listing pools")
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].ID < pools[j].ID })
+
+	byID := map[string]params.Pool{}
+	for _, pool := range pools {
+		byID[pool.ID] = pool
+	}
+
+	matched := map[string]struct{}{}
+	desiredPools := make([]*params.Pool, len(want.spec.Pools))
+	for idx, pool := range want.spec.Pools {
+		if pool.ID == "" {
+			continue
+		}
+		existing, ok := byID[pool.ID]
+		if !ok {
+			return nil, nil, runnerErrors.NewBadRequestError("pool %s not found", pool.ID)
+		}
+		matched[existing.ID] = struct{}{}
+		desiredPools[idx] = &existing
+	}
+
+	for idx, pool := range want.spec.Pools {
+		if pool.ID != "" {
+			continue
+		}
+		var candidates []params.Pool
+		for _, existing := range pools {
+			if _, ok := matched[existing.ID]; ok {
+				continue
+			}
+			if existing.PoolKey() == pool.Key() {
+				candidates = append(candidates, existing)
+			}
+		}
+		switch len(candidates) {
+		case 0:
+		case 1:
+			matched[candidates[0].ID] = struct{}{}
+			desiredPools[idx] = &candidates[0]
+		default:
+			return nil, nil, runnerErrors.NewBadRequestError("multiple pools match %s; set the pool ID", pool.Key())
+		}
+	}
+
+	var steps, deleteSteps []applyStep
+	for idx, pool := range want.spec.Pools {
+		existing := desiredPools[idx]
+		if existing == nil {
+			createParams, err := pool.CreateParams()
+			if err != nil {
+				return nil, nil, err
+			}
+			steps = append(steps, applyStep{
+				action: params.ApplyAction{
+					Action: params.ApplyActionCreate,
+					Kind:   params.ApplyObjectKindPool,
+					Name:   fmt.Sprintf("%s %s", want.name, pool.Key()),
+				},
+				run: func(ctx context.Context) error {
+					return ops.createPool(ctx, current.id, createParams)
+				},
+			})
+			continue
+		}
+
+		updateParams, changes, err := pool.UpdateParams(*existing)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		poolID := existing.ID
+		steps = append(steps, applyStep{
+			action: params.ApplyAction{
+				Action:  params.ApplyActionUpdate,
+				Kind:    params.ApplyObjectKindPool,
+				Name:    fmt.Sprintf("%s %s", want.name, existing.PoolKey()),
+				ID:      poolID,
+				Changes: changes,
+			},
+			run: func(ctx context.Context) error {
+				return ops.updatePool(ctx, current.id, poolID, updateParams)
+			},
+		})
+	}
+
+	if !prune {
+		return steps, nil, nil
+	}
+	for _, pool := range pools {
+		if _, ok := matched[pool.ID]; ok {
+			continue
+		}
+		poolID := pool.ID
+		deleteSteps = append(deleteSteps, applyStep{
+			action: params.ApplyAction{
+				Action: params.ApplyActionDelete,
+				Kind:   params.ApplyObjectKindPool,
+				Name:   fmt.Sprintf("%s %s", want.name, pool.PoolKey()),
+				ID:     poolID,
+			},
+			run: func(ctx context.Context) error {
+				return ops.deletePool(ctx, current.id, poolID)
+			},
+		})
+	}
+	return steps, deleteSteps, nil
+}
@@ -0,0 +1,470 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/auth"
+	"github.com/cloudbase/garm/database"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
+	runnerCommonMocks "github.com/cloudbase/garm/runner/common/mocks"
+	runnerMocks "github.com/cloudbase/garm/runner/mocks"
+)
+
+type ApplyTestSuite struct {
+	suite.Suite
+	Store    dbCommon.Store
+	Runner   *Runner
+	adminCtx context.Context
+	org      params.Organization
+	creds    params.GithubCredentials
+}
+
+func (s *ApplyTestSuite) SetupTest() {
+	adminCtx := auth.GetAdminContext(context.Background())
+	db, err := database.NewDatabase(adminCtx, garmTesting.GetTestSqliteDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+	s.adminCtx = garmTesting.ImpersonateAdminContext(adminCtx, db, s.T())
+
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(s.adminCtx, db, s.T())
+	s.creds = garmTesting.CreateTestGithubCredentials(s.adminCtx, "test-creds", db, s.T(), endpoint)
+	s.org, err = db.CreateOrganization(s.adminCtx, "test-org", s.creds.Name, "test-webhookSecret", params.PoolBalancerTypeRoundRobin)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create org: %s", err))
+	}
+
+	s.Runner = &Runner{
+		providers: map[string]common.Provider{
+			"test-provider": runnerCommonMocks.NewProvider(s.T()),
+		},
+		store: db,
+		ctx:   s.adminCtx,
+	}
+}
+
+func (s *ApplyTestSuite) orgDocument(pools ...params.ApplyPool) params.ApplyDocument {
+	return params.ApplyDocument{
+		Organizations: []params.ApplyOrganization{
+			{
+				Name: s.org.Name,
+				ApplyEntity: params.ApplyEntity{
+					CredentialsName:  s.creds.Name,
+					PoolBalancerType: params.PoolBalancerTypeRoundRobin,
+					Pools:            pools,
+				},
+			},
+		},
+	}
+}
+
+func (s *ApplyTestSuite) applyPool() params.ApplyPool {
+	return params.ApplyPool{
+		ProviderName: "test-provider",
+		Image:        "ubuntu:22.04",
+		Flavor:       "small",
+		OSType:       "linux",
+		OSArch:       "amd64",
+		Tags:         []string{"self-hosted", "linux"},
+		MaxRunners:   4,
+		Enabled:      true,
+	}
+}
+
+func (s *ApplyTestSuite) listOrgPools() []params.Pool {
+	entity, err := s.org.GetEntity()
+	s.Require().Nil(err)
+	pools, err := s.Store.ListEntityPools(s.adminCtx, entity)
+	s.Require().Nil(err)
+	return pools
+}
+
+func (s *ApplyTestSuite) TestApplyDryRun() {
+	result, err := s.Runner.Apply(s.adminCtx, s.orgDocument(s.applyPool()), true, false)
+
+	s.Require().Nil(err)
+	s.Require().True(result.DryRun)
+	s.Require().Len(result.Actions, 1)
+	s.Require().Equal(params.ApplyActionCreate, result.Actions[0].Action)
+	s.Require().Equal(params.ApplyObjectKindPool, result.Actions[0].Kind)
+	s.Require().Len(s.listOrgPools(), 0)
+}
+
+func (s *ApplyTestSuite) TestApplyCreatesAndUpdatesPools() {
+	_, err := s.Runner.Apply(s.adminCtx, s.orgDocument(s.applyPool()), false, false)
+	s.Require().Nil(err)
+
+	pools := s.listOrgPools()
+	s.Require().Len(pools, 1)
+	s.Require().Equal("ubuntu:22.04", pools[0].Image)
+
+	pool := s.applyPool()
+	pool.Image = "ubuntu:24.04"
+	result, err := s.Runner.Apply(s.adminCtx, s.orgDocument(pool), false, false)
+
+	s.Require().Nil(err)
+	s.Require().Len(result.Actions, 1)
+	s.Require().Equal(params.ApplyActionUpdate, result.Actions[0].Action)
+	s.Require().Equal(pools[0].ID, result.Actions[0].ID)
+	s.Require().Equal([]params.ApplyFieldChange{
+		{
+			Field: params.PoolTemplateFieldImage,
+			Old:   "ubuntu:22.04",
+			New:   "ubuntu:24.04",
+		},
+	}, result.Actions[0].Changes)
+
+	pools = s.listOrgPools()
+	s.Require().Len(pools, 1)
+	s.Require().Equal("ubuntu:24.04", pools[0].Image)
+}
+
+func (s *ApplyTestSuite) TestApplyPrune() {
+	extra := s.applyPool()
+	extra.Tags = []string{"windows"}
+	_, err := s.Runner.Apply(s.adminCtx, s.orgDocument(s.applyPool(), extra), false, false)
+	s.Require().Nil(err)
+	s.Require().Len(s.listOrgPools(), 2)
+
+	result, err := s.Runner.Apply(s.adminCtx, s.orgDocument(s.applyPool()), false, false)
+	s.Require().Nil(err)
+	s.Require().Len(result.Actions, 0)
+	s.Require().Len(s.listOrgPools(), 2)
+
+	result, err = s.Runner.Apply(s.adminCtx, s.orgDocument(s.applyPool()), false, true)
+	s.Require().Nil(err)
+	s.Require().Len(result.Actions, 1)
+	s.Require().Equal(params.ApplyActionDelete, result.Actions[0].Action)
+	s.Require().Len(s.listOrgPools(), 1)
+}
+
+func (s *ApplyTestSuite) TestApplyAmbiguousPool() {
+	_, err := s.Runner.Apply(s.adminCtx, s.orgDocument(s.applyPool(), s.applyPool()), false, false)
+	s.Require().Nil(err)
+
+	_, err = s.Runner.Apply(s.adminCtx, s.orgDocument(s.applyPool()), true, false)
+
+	var badRequest *runnerErrors.BadRequestError
+	s.Require().ErrorAs(err, &badRequest)
+}
+
+func (s *ApplyTestSuite) TestApplyUndefinedCredentials() {
+	doc := s.orgDocument()
+	doc.Organizations[0].CredentialsName = "missing-creds"
+
+	_, err := s.Runner.Apply(s.adminCtx, doc, true, false)
+
+	var badRequest *runnerErrors.BadRequestError
+	s.Require().ErrorAs(err, &badRequest)
+}
+
+func (s *ApplyTestSuite) TestApplyErrUnauthorized() {
+	_, err := s.Runner.Apply(context.Background(), params.ApplyDocument{}, true, false)
+
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
+func (s *ApplyTestSuite) TestExportRoundTrip() {
+	_, err := s.Runner.Apply(s.adminCtx, s.orgDocument(s.applyPool()), false, false)
+	s.Require().Nil(err)
+
+	doc, err := s.Runner.Export(s.adminCtx)
+	s.Require().Nil(err)
+	s.Require().Len(doc.Organizations, 1)
+	s.Require().Len(doc.Organizations[0].Pools, 1)
+	s.Require().Empty(doc.Organizations[0].WebhookSecret)
+
+	result, err := s.Runner.Apply(s.adminCtx, doc, true, true)
+	s.Require().Nil(err)
+	s.Require().Len(result.Actions, 0)
+}
+
+// fullApplyPool returns a pool that sets every field of the declarative format.
+func (s *ApplyTestSuite) fullApplyPool() params.ApplyPool {
+	maxSurge, maxUnavailable := uint(2), uint(1)
+	pool := s.applyPool()
+	pool.Tags = []string{"filtered", "self-hosted"}
+	pool.RunnerPrefix = "filtered"
+	pool.RunnerBootstrapTimeout = 30
+	pool.ExtraSpecs = map[string]any{"disk": "20G"}
+	pool.GitHubRunnerGroup = "test-group"
+	pool.Priority = 10
+	pool.RolloutMaxSurge = &maxSurge
+	pool.RolloutMaxUnavailable = &maxUnavailable
+	pool.Reusable = true
+	pool.RunnerMaxJobs = 5
+	pool.RunnerMaxLifetime = 120
+	pool.JobCompletedHook = "rm -rf ~/work"
+	pool.IncludedRepositories = []string{"test-org/*"}
+	pool.ExcludedRepositories = []string{"test-org/private"}
+	pool.Policy = &params.PoolPolicy{Branches: []string{"main"}}
+	pool.HourlyCost = 0.5
+	pool.MaxRunnersPerRepository = 2
+	return pool
+}
+
+func (s *ApplyTestSuite) TestExportRoundTripAllFields() {
+	tpl, err := s.Store.CreatePoolTemplate(s.adminCtx, params.CreatePoolTemplateParams{
+		Name:                   "linux-small",
+		ProviderName:           "test-provider",
+		MaxRunners:             4,
+		Image:                  "ubuntu:22.04",
+		Flavor:                 "small",
+		OSType:                 "linux",
+		OSArch:                 "amd64",
+		Tags:                   []string{"self-hosted", "templated"},
+		RunnerBootstrapTimeout: 20,
+	})
+	s.Require().Nil(err)
+	templated := s.applyPool()
+	templated.Tags = []string{"self-hosted", "templated"}
+	templated.Flavor = "large"
+	templated.RunnerBootstrapTimeout = 20
+	templated.TemplateID = tpl.ID
+	templated.TemplateOverrides = []string{params.PoolTemplateFieldFlavor}
+
+	_, err = s.Runner.Apply(s.adminCtx, s.orgDocument(s.fullApplyPool(), templated), false, false)
+	s.Require().Nil(err)
+
+	doc, err := s.Runner.Export(s.adminCtx)
+	s.Require().Nil(err)
+	s.Require().Len(doc.Organizations, 1)
+	pools := doc.Organizations[0].Pools
+	s.Require().Len(pools, 2)
+	byTemplate := map[string]params.ApplyPool{}
+	for _, pool := range pools {
+		byTemplate[pool.TemplateID] = pool
+	}
+
+	exported := byTemplate[""]
+	exported.ID = ""
+	s.Require().Equal(s.fullApplyPool(), exported)
+
+	exported = byTemplate[tpl.ID]
+	s.Require().Equal("large", exported.Flavor)
+	s.Require().Equal([]string{params.PoolTemplateFieldFlavor}, exported.TemplateOverrides)
+
+	result, err := s.Runner.Apply(s.adminCtx, doc, true, true)
+	s.Require().Nil(err)
+	s.Require().Len(result.Actions, 0)
+}
+
+func (s *ApplyTestSuite) TestApplyTemplateOverrides() {
+	tpl, err := s.Store.CreatePoolTemplate(s.adminCtx, params.CreatePoolTemplateParams{
+		Name:         "linux-small",
+		ProviderName: "test-provider",
+		MaxRunners:   4,
+		Image:        "ubuntu:22.04",
+		Flavor:       "small",
+		OSType:       "linux",
+		OSArch:       "amd64",
+		Tags:         []string{"self-hosted", "linux"},
+	})
+	s.Require().Nil(err)
+	pool := s.applyPool()
+	pool.Image = "ubuntu:24.04"
+	pool.TemplateID = tpl.ID
+	_, err = s.Runner.Apply(s.adminCtx, s.orgDocument(pool), false, false)
+	s.Require().Nil(err)
+
+	// The image is inherited from the template.
+	pools := s.listOrgPools()
+	s.Require().Len(pools, 1)
+	s.Require().Equal("ubuntu:22.04", pools[0].Image)
+	s.Require().Empty(pools[0].TemplateOverrides)
+
+	pool.TemplateOverrides = []string{params.PoolTemplateFieldImage}
+	result, err := s.Runner.Apply(s.adminCtx, s.orgDocument(pool), false, false)
+	s.Require().Nil(err)
+	s.Require().Len(result.Actions, 1)
+	s.Require().Equal([]params.ApplyFieldChange{
+		{
+			Field: params.PoolTemplateFieldImage,
+			Old:   "ubuntu:22.04",
+			New:   "ubuntu:24.04",
+		},
+		{
+			Field: "template_overrides",
+			New:   params.PoolTemplateFieldImage,
+		},
+	}, result.Actions[0].Changes)
+
+	pools = s.listOrgPools()
+	s.Require().Equal("ubuntu:24.04", pools[0].Image)
+	s.Require().Equal([]string{params.PoolTemplateFieldImage}, pools[0].TemplateOverrides)
+
+	pool.TemplateOverrides = nil
+	_, err = s.Runner.Apply(s.adminCtx, s.orgDocument(pool), false, false)
+	s.Require().Nil(err)
+
+	pools = s.listOrgPools()
+	s.Require().Equal("ubuntu:22.04", pools[0].Image)
+	s.Require().Empty(pools[0].TemplateOverrides)
+}
+
+func (s *ApplyTestSuite) TestApplyIdleRunnersWithRepositoryFilters() {
+	pool := s.applyPool()
+	pool.MinIdleRunners = 1
+	pool.IncludedRepositories = []string{"test-org/*"}
+
+	_, err := s.Runner.Apply(s.adminCtx, s.orgDocument(pool), true, false)
+
+	var badRequest *runnerErrors.BadRequestError
+	s.Require().ErrorAs(err, &badRequest)
+}
+
+func (s *ApplyTestSuite) TestApplyIdleRunnersOnPoolWithPolicy() {
+	pool := s.applyPool()
+	pool.Policy = &params.PoolPolicy{Branches: []string{"main"}}
+	_, err := s.Runner.Apply(s.adminCtx, s.orgDocument(pool), false, false)
+	s.Require().Nil(err)
+
+	pool.MinIdleRunners = 1
+	_, err = s.Runner.Apply(s.adminCtx, s.orgDocument(pool), false, false)
+
+	var badRequest *runnerErrors.BadRequestError
+	s.Require().ErrorAs(err, &badRequest)
+	s.Require().Equal(uint(0), s.listOrgPools()[0].MinIdleRunners)
+}
+
+// mockOrgPoolManagers sets up the pool managers needed to create and update
+// organizations.
+func (s *ApplyTestSuite) mockOrgPoolManagers() {
+	poolMgr := runnerCommonMocks.NewPoolManager(s.T())
+	poolMgr.On("Start").Return(nil).Maybe()
+	poolMgr.On("Status").Return(params.PoolManagerStatus{IsRunning: true}).Maybe()
+
+	poolMgrCtrl := runnerMocks.NewPoolManagerController(s.T())
+	poolMgrCtrl.On("CreateOrgPoolManager", mock.Anything, mock.AnythingOfType("params.Organization"), mock.Anything, mock.Anything).Return(poolMgr, nil).Maybe()
+	poolMgrCtrl.On("GetOrgPoolManager", mock.AnythingOfType("params.Organization")).Return(poolMgr, nil).Maybe()
+	s.Runner.poolManagerCtrl = poolMgrCtrl
+}
+
+func (s *ApplyTestSuite) TestApplyObserveOnly() {
+	s.mockOrgPoolManagers()
+	doc := s.orgDocument()
+	doc.Organizations[0].ObserveOnly = true
+
+	result, err := s.Runner.Apply(s.adminCtx, doc, false, false)
+	s.Require().Nil(err)
+	s.Require().Len(result.Actions, 1)
+	s.Require().Equal(params.ApplyActionUpdate, result.Actions[0].Action)
+	s.Require().Equal([]params.ApplyFieldChange{{Field: "observe_only", Old: "false", New: "true"}}, result.Actions[0].Changes)
+	org, err := s.Store.GetOrganizationByID(s.adminCtx, s.org.ID)
+	s.Require().Nil(err)
+	s.Require().True(org.ObserveOnly)
+
+	exported, err := s.Runner.Export(s.adminCtx)
+	s.Require().Nil(err)
+	s.Require().Len(exported.Organizations, 1)
+	s.Require().True(exported.Organizations[0].ObserveOnly)
+
+	result, err = s.Runner.Apply(s.adminCtx, exported, false, false)
+	s.Require().Nil(err)
+	s.Require().Len(result.Actions, 0)
+}
+
+func (s *ApplyTestSuite) TestApplyCreatesObserveOnlyEntity() {
+	s.mockOrgPoolManagers()
+	doc := s.orgDocument()
+	doc.Organizations[0].Name = "new-org"
+	doc.Organizations[0].ObserveOnly = true
+
+	_, err := s.Runner.Apply(s.adminCtx, doc, false, false)
+	s.Require().Nil(err)
+
+	org, err := s.Store.GetOrganization(s.adminCtx, "new-org", s.creds.Endpoint.Name)
+	s.Require().Nil(err)
+	s.Require().True(org.ObserveOnly)
+}
+
+func (s *ApplyTestSuite) weightedScheduling() *params.JobScheduling {
+	return &params.JobScheduling{
+		Policy:            params.JobSchedulingWeighted,
+		RepositoryWeights: map[string]uint{"test-org/app": 3},
+		LabelPriorities:   map[string]int{"urgent": 10},
+	}
+}
+
+func (s *ApplyTestSuite) TestApplyJobScheduling() {
+	s.mockOrgPoolManagers()
+	doc := s.orgDocument()
+	doc.Organizations[0].JobScheduling = s.weightedScheduling()
+
+	result, err := s.Runner.Apply(s.adminCtx, doc, false, false)
+	s.Require().Nil(err)
+	s.Require().Len(result.Actions, 1)
+	s.Require().Len(result.Actions[0].Changes, 1)
+	s.Require().Equal("job_scheduling", result.Actions[0].Changes[0].Field)
+	org, err := s.Store.GetOrganizationByID(s.adminCtx, s.org.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(s.weightedScheduling(), org.JobScheduling)
+
+	exported, err := s.Runner.Export(s.adminCtx)
+	s.Require().Nil(err)
+	s.Require().Len(exported.Organizations, 1)
+	s.Require().Equal(s.weightedScheduling(), exported.Organizations[0].JobScheduling)
+	result, err = s.Runner.Apply(s.adminCtx, exported, false, false)
+	s.Require().Nil(err)
+	s.Require().Len(result.Actions, 0)
+
+	// Leaving the settings out restores the default order.
+	result, err = s.Runner.Apply(s.adminCtx, s.orgDocument(), false, false)
+	s.Require().Nil(err)
+	s.Require().Len(result.Actions, 1)
+	org, err = s.Store.GetOrganizationByID(s.adminCtx, s.org.ID)
+	s.Require().Nil(err)
+	s.Require().True(org.JobScheduling == nil || org.JobScheduling.IsEmpty())
+}
+
+func (s *ApplyTestSuite) TestApplyCreatesEntityWithJobScheduling() {
+	s.mockOrgPoolManagers()
+	doc := s.orgDocument()
+	doc.Organizations[0].Name = "new-org"
+	doc.Organizations[0].JobScheduling = s.weightedScheduling()
+
+	_, err := s.Runner.Apply(s.adminCtx, doc, false, false)
+	s.Require().Nil(err)
+
+	org, err := s.Store.GetOrganization(s.adminCtx, "new-org", s.creds.Endpoint.Name)
+	s.Require().Nil(err)
+	s.Require().Equal(s.weightedScheduling(), org.JobScheduling)
+}
+
+func (s *ApplyTestSuite) TestApplyInvalidJobScheduling() {
+	doc := s.orgDocument()
+	doc.Organizations[0].JobScheduling = &params.JobScheduling{Policy: "random"}
+
+	_, err := s.Runner.Apply(s.adminCtx, doc, true, false)
+
+	var badRequest *runnerErrors.BadRequestError
+	s.Require().ErrorAs(err, &badRequest)
+}
+
+func TestApplyTestSuite(t *testing.T) {
+	suite.Run(t, new(ApplyTestSuite))
+}
@@ -0,0 +1,53 @@
+package runner
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/auth"
+	"github.com/cloudbase/garm/params"
+)
+
+// CreateBackup returns a copy of the database. Credentials and webhook secrets
+// are sealed using the passphrase in param.
+func (r *Runner) CreateBackup(ctx context.Context, param params.CreateBackupParams) (params.Backup, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.Backup{}, runnerErrors.ErrUnauthorized
+	}
+
+	if err := param.Validate(); err != nil {
+		return params.Backup{}, errors.Wrap(err, "validating params")
+	}
+
+	backup, err := r.store.CreateBackup(ctx, param.Passphrase)
+	if err != nil {
+		return params.Backup{}, errors.Wrap(err, "creating backup")
+	}
+	return backup, nil
+}
+
+// RestoreBackup restores a backup into a controller that has no credentials,
+// entities, pool templates or pools. GARM needs to be restarted afterwards, as
+// the controller ID is cached at startup.
+func (r *Runner) RestoreBackup(ctx context.Context, param params.RestoreBackupParams) error {
+	if !auth.IsAdmin(ctx) {
+		return runnerErrors.ErrUnauthorized
+	}
+
+	if err := param.Validate(); err != nil {
+		return errors.Wrap(err, "validating params")
+	}
+
+	if err := r.store.RestoreBackup(ctx, param.Backup, param.Passphrase); err != nil {
+		return errors.Wrap(err, "restoring backup")
+	}
+
+	slog.InfoContext(
+		ctx, "backup restored; restart GARM to load the restored controller",
+		"controller_id", param.Backup.Controller.ControllerID,
+		"backup_created_at", param.Backup.CreatedAt)
+	return nil
+}
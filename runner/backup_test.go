@@ -0,0 +1,109 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/auth"
+	"github.com/cloudbase/garm/database"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing"
+	"github.com/cloudbase/garm/params"
+)
+
+const testBackupPassphrase = "backup-passphrase-0123456789abcd"
+
+type BackupTestSuite struct {
+	suite.Suite
+	Store    dbCommon.Store
+	Runner   *Runner
+	adminCtx context.Context
+}
+
+func (s *BackupTestSuite) SetupTest() {
+	adminCtx := auth.GetAdminContext(context.Background())
+	db, err := database.NewDatabase(adminCtx, garmTesting.GetTestSqliteDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	if _, err := db.InitController(); err != nil {
+		s.FailNow(fmt.Sprintf("failed to init controller: %s", err))
+	}
+	s.Store = db
+	s.adminCtx = garmTesting.ImpersonateAdminContext(adminCtx, db, s.T())
+
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(s.adminCtx, db, s.T())
+	creds := garmTesting.CreateTestGithubCredentials(s.adminCtx, "test-creds", db, s.T(), endpoint)
+	_, err = db.CreateOrganization(s.adminCtx, "test-org", creds.Name, "test-webhookSecret", params.PoolBalancerTypeRoundRobin)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create org: %s", err))
+	}
+
+	s.Runner = &Runner{
+		store: db,
+		ctx:   s.adminCtx,
+	}
+}
+
+func (s *BackupTestSuite) TestCreateBackup() {
+	backup, err := s.Runner.CreateBackup(s.adminCtx, params.CreateBackupParams{Passphrase: testBackupPassphrase})
+
+	s.Require().Nil(err)
+	s.Require().Equal(params.BackupFormatVersion, backup.Version)
+	s.Require().Len(backup.Organizations, 1)
+}
+
+func (s *BackupTestSuite) TestCreateBackupInvalidPassphrase() {
+	_, err := s.Runner.CreateBackup(s.adminCtx, params.CreateBackupParams{Passphrase: "too-short"})
+
+	var badRequest *runnerErrors.BadRequestError
+	s.Require().ErrorAs(err, &badRequest)
+}
+
+func (s *BackupTestSuite) TestCreateBackupErrUnauthorized() {
+	_, err := s.Runner.CreateBackup(context.Background(), params.CreateBackupParams{Passphrase: testBackupPassphrase})
+
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
+func (s *BackupTestSuite) TestRestoreBackupUnsupportedVersion() {
+	backup, err := s.Runner.CreateBackup(s.adminCtx, params.CreateBackupParams{Passphrase: testBackupPassphrase})
+	s.Require().Nil(err)
+	backup.Version = params.BackupFormatVersion + 1
+
+	err = s.Runner.RestoreBackup(s.adminCtx, params.RestoreBackupParams{
+		Passphrase: testBackupPassphrase,
+		Backup:     backup,
+	})
+
+	var badRequest *runnerErrors.BadRequestError
+	s.Require().ErrorAs(err, &badRequest)
+}
+
+func (s *BackupTestSuite) TestRestoreBackupErrUnauthorized() {
+	err := s.Runner.RestoreBackup(context.Background(), params.RestoreBackupParams{})
+
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
+func TestBackupTestSuite(t *testing.T) {
+	suite.Run(t, new(BackupTestSuite))
+}
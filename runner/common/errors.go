@@ -0,0 +1,51 @@
+// Copyright 2022 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cloudbase/garm/params"
+)
+
+// ClassifiedProviderError is returned by providers that know why an operation
+// failed. The class decides whether the operation is retried.
+type ClassifiedProviderError struct {
+	Class  params.ProviderErrorClass
+	Reason string
+}
+
+func (e *ClassifiedProviderError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Class, e.Reason)
+}
+
+// NewClassifiedProviderError returns a new ClassifiedProviderError.
+func NewClassifiedProviderError(class params.ProviderErrorClass, msg string, a ...interface{}) error {
+	return &ClassifiedProviderError{
+		Class:  class,
+		Reason: fmt.Sprintf(msg, a...),
+	}
+}
+
+// ProviderErrorClassOf returns the class of a provider error. Errors that were
+// not classified by the provider are transient.
+func ProviderErrorClassOf(err error) params.ProviderErrorClass {
+	var classified *ClassifiedProviderError
+	if errors.As(err, &classified) {
+		return classified.Class
+	}
+	return params.ProviderErrorTransient
+}
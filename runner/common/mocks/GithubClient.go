@@ -115,6 +115,36 @@ func (_m *GithubClient) DeleteEntityHook(ctx context.Context, id int64) (*github
 	return r0, r1
 }
 
+// EditEntityHook provides a mock function with given fields: ctx, id, hook
+func (_m *GithubClient) EditEntityHook(ctx context.Context, id int64, hook *github.Hook) (*github.Hook, error) {
+	ret := _m.Called(ctx, id, hook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditEntityHook")
+	}
+
+	var r0 *github.Hook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *github.Hook) (*github.Hook, error)); ok {
+		return rf(ctx, id, hook)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *github.Hook) *github.Hook); ok {
+		r0 = rf(ctx, id, hook)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Hook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *github.Hook) error); ok {
+		r1 = rf(ctx, id, hook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEntityHook provides a mock function with given fields: ctx, id
 func (_m *GithubClient) GetEntityHook(ctx context.Context, id int64) (*github.Hook, error) {
 	ret := _m.Called(ctx, id)
@@ -145,6 +175,36 @@ func (_m *GithubClient) GetEntityHook(ctx context.Context, id int64) (*github.Ho
 	return r0, r1
 }
 
+// GetEntityHookDelivery provides a mock function with given fields: ctx, hookID, deliveryID
+func (_m *GithubClient) GetEntityHookDelivery(ctx context.Context, hookID int64, deliveryID int64) (*github.HookDelivery, error) {
+	ret := _m.Called(ctx, hookID, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEntityHookDelivery")
+	}
+
+	var r0 *github.HookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (*github.HookDelivery, error)); ok {
+		return rf(ctx, hookID, deliveryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *github.HookDelivery); ok {
+		r0 = rf(ctx, hookID, deliveryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.HookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, hookID, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEntityJITConfig provides a mock function with given fields: ctx, instance, pool, labels
 func (_m *GithubClient) GetEntityJITConfig(ctx context.Context, instance string, pool params.Pool, labels []string) (map[string]string, *github.Runner, error) {
 	ret := _m.Called(ctx, instance, pool, labels)
@@ -184,6 +244,75 @@ func (_m *GithubClient) GetEntityJITConfig(ctx context.Context, instance string,
 	return r0, r1, r2
 }
 
+// GetEntityRunner provides a mock function with given fields: ctx, runnerID
+func (_m *GithubClient) GetEntityRunner(ctx context.Context, runnerID int64) (*github.Runner, error) {
+	ret := _m.Called(ctx, runnerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEntityRunner")
+	}
+
+	var r0 *github.Runner
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*github.Runner, error)); ok {
+		return rf(ctx, runnerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *github.Runner); ok {
+		r0 = rf(ctx, runnerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Runner)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, runnerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkflowByID provides a mock function with given fields: ctx, owner, repo, workflowID
+func (_m *GithubClient) GetWorkflowByID(ctx context.Context, owner string, repo string, workflowID int64) (*github.Workflow, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, workflowID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowByID")
+	}
+
+	var r0 *github.Workflow
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) (*github.Workflow, *github.Response, error)); ok {
+		return rf(ctx, owner, repo, workflowID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) *github.Workflow); ok {
+		r0 = rf(ctx, owner, repo, workflowID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Workflow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) *github.Response); ok {
+		r1 = rf(ctx, owner, repo, workflowID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, int64) error); ok {
+		r2 = rf(ctx, owner, repo, workflowID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetWorkflowJobByID provides a mock function with given fields: ctx, owner, repo, jobID
 func (_m *GithubClient) GetWorkflowJobByID(ctx context.Context, owner string, repo string, jobID int64) (*github.WorkflowJob, *github.Response, error) {
 	ret := _m.Called(ctx, owner, repo, jobID)
@@ -223,6 +352,84 @@ func (_m *GithubClient) GetWorkflowJobByID(ctx context.Context, owner string, re
 	return r0, r1, r2
 }
 
+// GetWorkflowRunByID provides a mock function with given fields: ctx, owner, repo, runID
+func (_m *GithubClient) GetWorkflowRunByID(ctx context.Context, owner string, repo string, runID int64) (*github.WorkflowRun, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, runID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowRunByID")
+	}
+
+	var r0 *github.WorkflowRun
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) (*github.WorkflowRun, *github.Response, error)); ok {
+		return rf(ctx, owner, repo, runID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) *github.WorkflowRun); ok {
+		r0 = rf(ctx, owner, repo, runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.WorkflowRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) *github.Response); ok {
+		r1 = rf(ctx, owner, repo, runID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, int64) error); ok {
+		r2 = rf(ctx, owner, repo, runID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListEntityHookDeliveries provides a mock function with given fields: ctx, id, opts
+func (_m *GithubClient) ListEntityHookDeliveries(ctx context.Context, id int64, opts *github.ListCursorOptions) ([]*github.HookDelivery, *github.Response, error) {
+	ret := _m.Called(ctx, id, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEntityHookDeliveries")
+	}
+
+	var r0 []*github.HookDelivery
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *github.ListCursorOptions) ([]*github.HookDelivery, *github.Response, error)); ok {
+		return rf(ctx, id, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *github.ListCursorOptions) []*github.HookDelivery); ok {
+		r0 = rf(ctx, id, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.HookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *github.ListCursorOptions) *github.Response); ok {
+		r1 = rf(ctx, id, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64, *github.ListCursorOptions) error); ok {
+		r2 = rf(ctx, id, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListEntityHooks provides a mock function with given fields: ctx, opts
 func (_m *GithubClient) ListEntityHooks(ctx context.Context, opts *github.ListOptions) ([]*github.Hook, *github.Response, error) {
 	ret := _m.Called(ctx, opts)
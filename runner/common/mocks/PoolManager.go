@@ -14,6 +14,62 @@ type PoolManager struct {
 	mock.Mock
 }
 
+// AdoptRunners provides a mock function with given fields: ctx, poolID, param
+func (_m *PoolManager) AdoptRunners(ctx context.Context, poolID string, param params.AdoptRunnersParams) (params.AdoptRunnersResult, error) {
+	ret := _m.Called(ctx, poolID, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdoptRunners")
+	}
+
+	var r0 params.AdoptRunnersResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, params.AdoptRunnersParams) (params.AdoptRunnersResult, error)); ok {
+		return rf(ctx, poolID, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, params.AdoptRunnersParams) params.AdoptRunnersResult); ok {
+		r0 = rf(ctx, poolID, param)
+	} else {
+		r0 = ret.Get(0).(params.AdoptRunnersResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, params.AdoptRunnersParams) error); ok {
+		r1 = rf(ctx, poolID, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CordonPool provides a mock function with given fields: poolID
+func (_m *PoolManager) CordonPool(poolID string) (params.Pool, error) {
+	ret := _m.Called(poolID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CordonPool")
+	}
+
+	var r0 params.Pool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (params.Pool, error)); ok {
+		return rf(poolID)
+	}
+	if rf, ok := ret.Get(0).(func(string) params.Pool); ok {
+		r0 = rf(poolID)
+	} else {
+		r0 = ret.Get(0).(params.Pool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(poolID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteRunner provides a mock function with given fields: runner, forceRemove, bypassGHUnauthorizedError
 func (_m *PoolManager) DeleteRunner(runner params.Instance, forceRemove bool, bypassGHUnauthorizedError bool) error {
 	ret := _m.Called(runner, forceRemove, bypassGHUnauthorizedError)
@@ -32,6 +88,82 @@ func (_m *PoolManager) DeleteRunner(runner params.Instance, forceRemove bool, by
 	return r0
 }
 
+// DrainPool provides a mock function with given fields: poolID
+func (_m *PoolManager) DrainPool(poolID string) (params.Pool, error) {
+	ret := _m.Called(poolID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DrainPool")
+	}
+
+	var r0 params.Pool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (params.Pool, error)); ok {
+		return rf(poolID)
+	}
+	if rf, ok := ret.Get(0).(func(string) params.Pool); ok {
+		r0 = rf(poolID)
+	} else {
+		r0 = ret.Get(0).(params.Pool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(poolID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DrainRunner provides a mock function with given fields: runner
+func (_m *PoolManager) DrainRunner(runner params.Instance) error {
+	ret := _m.Called(runner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DrainRunner")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(params.Instance) error); ok {
+		r0 = rf(runner)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetConsoleOutput provides a mock function with given fields: ctx, runner
+func (_m *PoolManager) GetConsoleOutput(ctx context.Context, runner params.Instance) ([]byte, error) {
+	ret := _m.Called(ctx, runner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConsoleOutput")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.Instance) ([]byte, error)); ok {
+		return rf(ctx, runner)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.Instance) []byte); ok {
+		r0 = rf(ctx, runner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.Instance) error); ok {
+		r1 = rf(ctx, runner)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWebhookInfo provides a mock function with given fields: ctx
 func (_m *PoolManager) GetWebhookInfo(ctx context.Context) (params.HookInfo, error) {
 	ret := _m.Called(ctx)
@@ -152,6 +284,34 @@ func (_m *PoolManager) InstallWebhook(ctx context.Context, param params.InstallW
 	return r0, r1
 }
 
+// RecyclePool provides a mock function with given fields: poolID, param
+func (_m *PoolManager) RecyclePool(poolID string, param params.RecyclePoolParams) (params.Pool, error) {
+	ret := _m.Called(poolID, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecyclePool")
+	}
+
+	var r0 params.Pool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, params.RecyclePoolParams) (params.Pool, error)); ok {
+		return rf(poolID, param)
+	}
+	if rf, ok := ret.Get(0).(func(string, params.RecyclePoolParams) params.Pool); ok {
+		r0 = rf(poolID, param)
+	} else {
+		r0 = ret.Get(0).(params.Pool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, params.RecyclePoolParams) error); ok {
+		r1 = rf(poolID, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RootCABundle provides a mock function with given fields:
 func (_m *PoolManager) RootCABundle() (params.CertificateBundle, error) {
 	ret := _m.Called()
@@ -234,6 +394,34 @@ func (_m *PoolManager) Stop() error {
 	return r0
 }
 
+// UncordonPool provides a mock function with given fields: poolID
+func (_m *PoolManager) UncordonPool(poolID string) (params.Pool, error) {
+	ret := _m.Called(poolID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UncordonPool")
+	}
+
+	var r0 params.Pool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (params.Pool, error)); ok {
+		return rf(poolID)
+	}
+	if rf, ok := ret.Get(0).(func(string) params.Pool); ok {
+		r0 = rf(poolID)
+	} else {
+		r0 = ret.Get(0).(params.Pool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(poolID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UninstallWebhook provides a mock function with given fields: ctx
 func (_m *PoolManager) UninstallWebhook(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -252,6 +440,34 @@ func (_m *PoolManager) UninstallWebhook(ctx context.Context) error {
 	return r0
 }
 
+// UpdateWebhookSecret provides a mock function with given fields: ctx, secret
+func (_m *PoolManager) UpdateWebhookSecret(ctx context.Context, secret string) (params.HookInfo, error) {
+	ret := _m.Called(ctx, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhookSecret")
+	}
+
+	var r0 params.HookInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (params.HookInfo, error)); ok {
+		return rf(ctx, secret)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) params.HookInfo); ok {
+		r0 = rf(ctx, secret)
+	} else {
+		r0 = ret.Get(0).(params.HookInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Wait provides a mock function with given fields:
 func (_m *PoolManager) Wait() error {
 	ret := _m.Called()
@@ -288,6 +504,26 @@ func (_m *PoolManager) WebhookSecret() string {
 	return r0
 }
 
+// WebhookSecrets provides a mock function with given fields:
+func (_m *PoolManager) WebhookSecrets() []string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookSecrets")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
 // NewPoolManager creates a new instance of PoolManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPoolManager(t interface {
@@ -99,6 +99,36 @@ func (_m *Provider) DisableJITConfig() bool {
 	return r0
 }
 
+// GetConsoleOutput provides a mock function with given fields: ctx, instance
+func (_m *Provider) GetConsoleOutput(ctx context.Context, instance string, getConsoleOutputParams common.GetConsoleOutputParams) ([]byte, error) {
+	ret := _m.Called(ctx, instance)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConsoleOutput")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+		return rf(ctx, instance)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = rf(ctx, instance)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, instance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetInstance provides a mock function with given fields: ctx, instance
 func (_m *Provider) GetInstance(ctx context.Context, instance string, getInstanceParams common.GetInstanceParams) (garm_provider_commonparams.ProviderInstance, error) {
 	ret := _m.Called(ctx, instance)
@@ -52,6 +52,10 @@ type StartParams struct {
 	StartV011 StartV011Params
 }
 
+type GetConsoleOutputParams struct {
+	GetConsoleOutputV011 GetConsoleOutputV011Params
+}
+
 // Struct for the base provider parameters.
 type ProviderBaseParams struct {
 	PoolInfo       params.Pool
@@ -86,3 +90,7 @@ type StopV011Params struct {
 type StartV011Params struct {
 	ProviderBaseParams
 }
+
+type GetConsoleOutputV011Params struct {
+	ProviderBaseParams
+}
@@ -34,6 +34,13 @@ const (
 	// BackoffTimer is the time we wait before attempting to make another request
 	// to the github API.
 	BackoffTimer = 1 * time.Minute
+
+	// WebhookDeliveryReconcileInterval is the interval at which we check the webhook
+	// deliveries GitHub attempted to make to GARM, in order to replay any queued jobs
+	// GARM never received.
+	WebhookDeliveryReconcileInterval = 5 * time.Minute
+	// WebhookDeliveryLookback is how far back we look for failed webhook deliveries.
+	WebhookDeliveryLookback = 1 * time.Hour
 )
 
 //go:generate mockery --all
@@ -45,6 +52,9 @@ type PoolManager interface {
 	// GARM will have to create a webhook in GitHub which points to the GARM API server. To authenticate
 	// the webhook, a webhook secret is used. This function returns that secret.
 	WebhookSecret() string
+	// WebhookSecrets returns all webhook secrets currently accepted for this entity. This includes the
+	// current webhook secret and, while a secret rotation is in its grace period, the previous secret.
+	WebhookSecrets() []string
 	// GithubRunnerRegistrationToken returns a new registration token for a github runner. This is used
 	// for GHES installations that have not yet upgraded to a version >= 3.10. Starting with 3.10, we use
 	// just-in-time runners, which no longer require exposing a runner registration token.
@@ -60,6 +70,25 @@ type PoolManager interface {
 	// process. This can happen if the runner is already processing a job. At which point, you can simply cancel
 	// the job in github. Doing so will prompt GARM to reap the runner automatically.
 	DeleteRunner(runner params.Instance, forceRemove, bypassGHUnauthorizedError bool) error
+	// DrainRunner marks a runner to be removed once it is no longer running a job. Idle runners are
+	// removed right away.
+	DrainRunner(runner params.Instance) error
+	// GetConsoleOutput fetches the console output of a runner from the provider.
+	GetConsoleOutput(ctx context.Context, runner params.Instance) ([]byte, error)
+
+	// CordonPool stops the creation of new runners in a pool. Existing runners are kept.
+	CordonPool(poolID string) (params.Pool, error)
+	// UncordonPool allows a cordoned pool to create new runners again.
+	UncordonPool(poolID string) (params.Pool, error)
+	// DrainPool cordons a pool and drains all its runners.
+	DrainPool(poolID string) (params.Pool, error)
+	// RecyclePool replaces all existing runners of a pool with new ones, draining at most
+	// param.MaxUnavailable runners at a time. This is useful when the image behind the same
+	// image name was updated.
+	RecyclePool(poolID string, param params.RecyclePoolParams) (params.Pool, error)
+	// AdoptRunners creates database records for the runners of a pool that exist in the
+	// provider and are registered in GitHub, but that are unknown to GARM.
+	AdoptRunners(ctx context.Context, poolID string, param params.AdoptRunnersParams) (params.AdoptRunnersResult, error)
 
 	// InstallWebhook will create a webhook in github for the entity associated with this pool manager.
 	InstallWebhook(ctx context.Context, param params.InstallWebhookParams) (params.HookInfo, error)
@@ -67,6 +96,9 @@ type PoolManager interface {
 	GetWebhookInfo(ctx context.Context) (params.HookInfo, error)
 	// UninstallWebhook will remove the webhook installed in github for the entity associated with this pool manager.
 	UninstallWebhook(ctx context.Context) error
+	// UpdateWebhookSecret will set a new secret on the webhook GARM installed in github for the entity
+	// associated with this pool manager. The webhook is updated in place.
+	UpdateWebhookSecret(ctx context.Context, secret string) (params.HookInfo, error)
 
 	// RootCABundle will return a CA bundle that must be installed on all runners in order to properly validate
 	// x509 certificates used by various systems involved. This CA bundle is defined in the GARM config file and
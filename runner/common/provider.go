@@ -37,6 +37,9 @@ type Provider interface {
 	Stop(ctx context.Context, instance string, stopParams StopParams) error
 	// Start boots up an instance.
 	Start(ctx context.Context, instance string, startParams StartParams) error
+	// GetConsoleOutput returns the console output of an instance. This is an optional
+	// operation. Providers that do not support it return an error.
+	GetConsoleOutput(ctx context.Context, instance string, getConsoleOutputParams GetConsoleOutputParams) ([]byte, error)
 	// DisableJITConfig tells us if the provider explicitly disables JIT configuration and
 	// forces runner registration tokens to be used. This may happen if a provider has not yet
 	// been updated to support JIT configuration.
@@ -12,9 +12,15 @@ type GithubEntityOperations interface {
 	ListEntityHooks(ctx context.Context, opts *github.ListOptions) (ret []*github.Hook, response *github.Response, err error)
 	GetEntityHook(ctx context.Context, id int64) (ret *github.Hook, err error)
 	CreateEntityHook(ctx context.Context, hook *github.Hook) (ret *github.Hook, err error)
+	EditEntityHook(ctx context.Context, id int64, hook *github.Hook) (ret *github.Hook, err error)
 	DeleteEntityHook(ctx context.Context, id int64) (ret *github.Response, err error)
 	PingEntityHook(ctx context.Context, id int64) (ret *github.Response, err error)
+	ListEntityHookDeliveries(ctx context.Context, id int64, opts *github.ListCursorOptions) (ret []*github.HookDelivery, response *github.Response, err error)
+	GetEntityHookDelivery(ctx context.Context, hookID, deliveryID int64) (ret *github.HookDelivery, err error)
 	ListEntityRunners(ctx context.Context, opts *github.ListOptions) (*github.Runners, *github.Response, error)
+	// GetEntityRunner fetches a runner by its ID. The returned error wraps ErrNotFound
+	// if the runner is not registered.
+	GetEntityRunner(ctx context.Context, runnerID int64) (*github.Runner, error)
 	ListEntityRunnerApplicationDownloads(ctx context.Context) ([]*github.RunnerApplicationDownload, *github.Response, error)
 	RemoveEntityRunner(ctx context.Context, runnerID int64) (*github.Response, error)
 	CreateEntityRegistrationToken(ctx context.Context) (*github.RegistrationToken, *github.Response, error)
@@ -30,4 +36,8 @@ type GithubClient interface {
 
 	// GetWorkflowJobByID gets details about a single workflow job.
 	GetWorkflowJobByID(ctx context.Context, owner, repo string, jobID int64) (*github.WorkflowJob, *github.Response, error)
+	// GetWorkflowRunByID gets details about a single workflow run.
+	GetWorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (*github.WorkflowRun, *github.Response, error)
+	// GetWorkflowByID gets details about a single workflow.
+	GetWorkflowByID(ctx context.Context, owner, repo string, workflowID int64) (*github.Workflow, *github.Response, error)
 }
@@ -0,0 +1,26 @@
+package runner
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/auth"
+	"github.com/cloudbase/garm/params"
+)
+
+// GetCostReport returns the time runners were up and its cost, by entity, pool
+// and repository, for the usage that ended after since.
+func (r *Runner) GetCostReport(ctx context.Context, since time.Time) (params.CostReport, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.CostReport{}, runnerErrors.ErrUnauthorized
+	}
+
+	report, err := r.store.GetCostReport(ctx, since)
+	if err != nil {
+		return params.CostReport{}, errors.Wrap(err, "fetching cost report")
+	}
+	return report, nil
+}
@@ -169,6 +169,10 @@ func (r *Runner) UpdateEnterprise(ctx context.Context, enterpriseID string, para
 		return params.Enterprise{}, runnerErrors.NewBadRequestError("invalid pool balancer type: %s", param.PoolBalancerType)
 	}
 
+	if err := params.ValidateJobScheduling(param.JobScheduling); err != nil {
+		return params.Enterprise{}, runnerErrors.NewBadRequestError("%s", err)
+	}
+
 	enterprise, err := r.store.UpdateEnterprise(ctx, enterpriseID, param)
 	if err != nil {
 		return params.Enterprise{}, errors.Wrap(err, "updating enterprise")
@@ -188,7 +192,7 @@ func (r *Runner) CreateEnterprisePool(ctx context.Context, enterpriseID string,
 		return params.Pool{}, runnerErrors.ErrUnauthorized
 	}
 
-	createPoolParams, err := r.appendTagsToCreatePoolParams(param)
+	createPoolParams, err := r.appendTagsToCreatePoolParams(ctx, param)
 	if err != nil {
 		return params.Pool{}, fmt.Errorf("failed to append tags to create pool params: %w", err)
 	}
@@ -286,18 +290,8 @@ func (r *Runner) UpdateEnterprisePool(ctx context.Context, enterpriseID, poolID
 		return params.Pool{}, errors.Wrap(err, "fetching pool")
 	}
 
-	maxRunners := pool.MaxRunners
-	minIdleRunners := pool.MinIdleRunners
-
-	if param.MaxRunners != nil {
-		maxRunners = *param.MaxRunners
-	}
-	if param.MinIdleRunners != nil {
-		minIdleRunners = *param.MinIdleRunners
-	}
-
-	if minIdleRunners > maxRunners {
-		return params.Pool{}, runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners")
+	if err := validatePoolUpdate(pool, param); err != nil {
+		return params.Pool{}, err
 	}
 
 	newPool, err := r.store.UpdateEntityPool(ctx, entity, poolID, param)
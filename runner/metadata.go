@@ -127,6 +127,15 @@ func (r *Runner) GetJITConfigFile(ctx context.Context, file string) ([]byte, err
 		return nil, errors.Wrap(err, "decoding file contents")
 	}
 
+	if instance.Lifecycle.JITFetchedAt == nil {
+		updateParams := params.UpdateInstanceParams{
+			Stages: []params.InstanceStage{params.InstanceStageJITFetched},
+		}
+		if _, err := r.store.UpdateInstance(r.ctx, instance.Name, updateParams); err != nil {
+			return nil, errors.Wrap(err, "recording JIT config fetch")
+		}
+	}
+
 	return decoded, nil
 }
 
@@ -164,6 +173,7 @@ func (r *Runner) GetInstanceGithubRegistrationToken(ctx context.Context) (string
 	tokenFetched := true
 	updateParams := params.UpdateInstanceParams{
 		TokenFetched: &tokenFetched,
+		Stages:       []params.InstanceStage{params.InstanceStageJITFetched},
 	}
 
 	if _, err := r.store.UpdateInstance(r.ctx, instance.Name, updateParams); err != nil {
@@ -177,6 +187,29 @@ func (r *Runner) GetInstanceGithubRegistrationToken(ctx context.Context) (string
 	return token, nil
 }
 
+// GetRunnerConfig returns the settings the runner needs to configure the
+// GitHub runner agent.
+func (r *Runner) GetRunnerConfig(ctx context.Context) (params.RunnerConfig, error) {
+	instance, err := auth.InstanceParams(ctx)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			ctx, "failed to get instance params")
+		return params.RunnerConfig{}, runnerErrors.ErrUnauthorized
+	}
+
+	pool, err := r.store.GetPoolByID(r.ctx, instance.PoolID)
+	if err != nil {
+		return params.RunnerConfig{}, errors.Wrap(err, "fetching pool")
+	}
+
+	if !pool.Reusable {
+		return params.RunnerConfig{Ephemeral: true}, nil
+	}
+	return params.RunnerConfig{
+		JobCompletedHook: pool.JobCompletedHook,
+	}, nil
+}
+
 func (r *Runner) GetRootCertificateBundle(ctx context.Context) (params.CertificateBundle, error) {
 	instance, err := auth.InstanceParams(ctx)
 	if err != nil {
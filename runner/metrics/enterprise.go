@@ -13,6 +13,7 @@ func CollectEnterpriseMetric(ctx context.Context, r *runner.Runner) error {
 	// reset metrics
 	metrics.EnterpriseInfo.Reset()
 	metrics.EnterprisePoolManagerStatus.Reset()
+	metrics.EnterpriseConflictingControllerRunners.Reset()
 
 	enterprises, err := r.ListEnterprises(ctx)
 	if err != nil {
@@ -30,6 +31,14 @@ func CollectEnterpriseMetric(ctx context.Context, r *runner.Runner) error {
 			enterprise.ID,   // label: id
 			strconv.FormatBool(enterprise.PoolManagerStatus.IsRunning), // label: running
 		).Set(metrics.Bool2float64(enterprise.PoolManagerStatus.IsRunning))
+
+		for _, conflict := range enterprise.PoolManagerStatus.ConflictingControllers {
+			metrics.EnterpriseConflictingControllerRunners.WithLabelValues(
+				enterprise.Name,       // label: name
+				enterprise.ID,         // label: id
+				conflict.ControllerID, // label: controller_id
+			).Set(float64(conflict.Runners))
+		}
 	}
 	return nil
 }
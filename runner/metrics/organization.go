@@ -13,6 +13,7 @@ func CollectOrganizationMetric(ctx context.Context, r *runner.Runner) error {
 	// reset metrics
 	metrics.OrganizationInfo.Reset()
 	metrics.OrganizationPoolManagerStatus.Reset()
+	metrics.OrganizationConflictingControllerRunners.Reset()
 
 	organizations, err := r.ListOrganizations(ctx)
 	if err != nil {
@@ -30,6 +31,14 @@ func CollectOrganizationMetric(ctx context.Context, r *runner.Runner) error {
 			organization.ID,   // label: id
 			strconv.FormatBool(organization.PoolManagerStatus.IsRunning), // label: running
 		).Set(metrics.Bool2float64(organization.PoolManagerStatus.IsRunning))
+
+		for _, conflict := range organization.PoolManagerStatus.ConflictingControllers {
+			metrics.OrganizationConflictingControllerRunners.WithLabelValues(
+				organization.Name,     // label: name
+				organization.ID,       // label: id
+				conflict.ControllerID, // label: controller_id
+			).Set(float64(conflict.Runners))
+		}
 	}
 	return nil
 }
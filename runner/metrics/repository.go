@@ -12,6 +12,7 @@ func CollectRepositoryMetric(ctx context.Context, r *runner.Runner) error {
 	// reset metrics
 	metrics.EnterpriseInfo.Reset()
 	metrics.EnterprisePoolManagerStatus.Reset()
+	metrics.RepositoryConflictingControllerRunners.Reset()
 
 	repositories, err := r.ListRepositories(ctx)
 	if err != nil {
@@ -29,6 +30,14 @@ func CollectRepositoryMetric(ctx context.Context, r *runner.Runner) error {
 			repository.ID,   // label: id
 			strconv.FormatBool(repository.PoolManagerStatus.IsRunning), // label: running
 		).Set(metrics.Bool2float64(repository.PoolManagerStatus.IsRunning))
+
+		for _, conflict := range repository.PoolManagerStatus.ConflictingControllers {
+			metrics.RepositoryConflictingControllerRunners.WithLabelValues(
+				repository.Name,       // label: name
+				repository.ID,         // label: id
+				conflict.ControllerID, // label: controller_id
+			).Set(float64(conflict.Runners))
+		}
 	}
 	return nil
 }
@@ -0,0 +1,50 @@
+package runner
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/auth"
+	"github.com/cloudbase/garm/params"
+)
+
+func (r *Runner) listEntityObservedActions(ctx context.Context, entity params.GithubEntity) ([]params.ObservedAction, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, runnerErrors.ErrUnauthorized
+	}
+
+	actions, err := r.store.ListEntityObservedActions(ctx, entity)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching observed actions")
+	}
+	return actions, nil
+}
+
+// ListRepoObservedActions returns the actions the pool manager of a repository
+// would have taken while running in observe only mode.
+func (r *Runner) ListRepoObservedActions(ctx context.Context, repoID string) ([]params.ObservedAction, error) {
+	return r.listEntityObservedActions(ctx, params.GithubEntity{
+		ID:         repoID,
+		EntityType: params.GithubEntityTypeRepository,
+	})
+}
+
+// ListOrgObservedActions returns the actions the pool manager of an organization
+// would have taken while running in observe only mode.
+func (r *Runner) ListOrgObservedActions(ctx context.Context, orgID string) ([]params.ObservedAction, error) {
+	return r.listEntityObservedActions(ctx, params.GithubEntity{
+		ID:         orgID,
+		EntityType: params.GithubEntityTypeOrganization,
+	})
+}
+
+// ListEnterpriseObservedActions returns the actions the pool manager of an enterprise
+// would have taken while running in observe only mode.
+func (r *Runner) ListEnterpriseObservedActions(ctx context.Context, enterpriseID string) ([]params.ObservedAction, error) {
+	return r.listEntityObservedActions(ctx, params.GithubEntity{
+		ID:         enterpriseID,
+		EntityType: params.GithubEntityTypeEnterprise,
+	})
+}
@@ -198,6 +198,10 @@ func (r *Runner) UpdateOrganization(ctx context.Context, orgID string, param par
 		return params.Organization{}, runnerErrors.NewBadRequestError("invalid pool balancer type: %s", param.PoolBalancerType)
 	}
 
+	if err := params.ValidateJobScheduling(param.JobScheduling); err != nil {
+		return params.Organization{}, runnerErrors.NewBadRequestError("%s", err)
+	}
+
 	org, err := r.store.UpdateOrganization(ctx, orgID, param)
 	if err != nil {
 		return params.Organization{}, errors.Wrap(err, "updating org")
@@ -217,7 +221,7 @@ func (r *Runner) CreateOrgPool(ctx context.Context, orgID string, param params.C
 		return params.Pool{}, runnerErrors.ErrUnauthorized
 	}
 
-	createPoolParams, err := r.appendTagsToCreatePoolParams(param)
+	createPoolParams, err := r.appendTagsToCreatePoolParams(ctx, param)
 	if err != nil {
 		return params.Pool{}, errors.Wrap(err, "fetching pool params")
 	}
@@ -321,18 +325,8 @@ func (r *Runner) UpdateOrgPool(ctx context.Context, orgID, poolID string, param
 		return params.Pool{}, errors.Wrap(err, "fetching pool")
 	}
 
-	maxRunners := pool.MaxRunners
-	minIdleRunners := pool.MinIdleRunners
-
-	if param.MaxRunners != nil {
-		maxRunners = *param.MaxRunners
-	}
-	if param.MinIdleRunners != nil {
-		minIdleRunners = *param.MinIdleRunners
-	}
-
-	if minIdleRunners > maxRunners {
-		return params.Pool{}, runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners")
+	if err := validatePoolUpdate(pool, param); err != nil {
+		return params.Pool{}, err
 	}
 
 	newPool, err := r.store.UpdateEntityPool(ctx, entity, poolID, param)
@@ -418,6 +412,31 @@ func (r *Runner) UninstallOrgWebhook(ctx context.Context, orgID string) error {
 	return nil
 }
 
+func (r *Runner) RotateOrgWebhookSecret(ctx context.Context, orgID string, param params.RotateWebhookSecretParams) (params.HookInfo, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.HookInfo{}, runnerErrors.ErrUnauthorized
+	}
+
+	org, err := r.store.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return params.HookInfo{}, errors.Wrap(err, "fetching org")
+	}
+
+	poolManager, err := r.poolManagerCtrl.GetOrgPoolManager(org)
+	if err != nil {
+		return params.HookInfo{}, errors.Wrap(err, "fetching pool manager for org")
+	}
+
+	info, err := r.rotateWebhookSecret(ctx, poolManager, org.WebhookSecret, param, func(updateParams params.UpdateEntityParams) error {
+		_, err := r.store.UpdateOrganization(ctx, orgID, updateParams)
+		return err
+	})
+	if err != nil {
+		return params.HookInfo{}, errors.Wrap(err, "rotating webhook secret")
+	}
+	return info, nil
+}
+
 func (r *Runner) GetOrgWebhookInfo(ctx context.Context, orgID string) (params.HookInfo, error) {
 	if !auth.IsAdmin(ctx) {
 		return params.HookInfo{}, runnerErrors.ErrUnauthorized
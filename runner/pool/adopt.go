@@ -0,0 +1,263 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
+)
+
+// adoptionMatch is a provider instance and the GitHub runner running on it.
+type adoptionMatch struct {
+	instance commonParams.ProviderInstance
+	runner   *github.Runner
+}
+
+// runnerSelector selects the GitHub runners that may be adopted into a pool.
+type runnerSelector struct {
+	// controllerID is the ID of the controller that created the runners.
+	controllerID string
+	// poolID is the ID of the pool the runners were created for.
+	poolID string
+	// labels, if set, selects runners by label instead of by controller ID.
+	labels []string
+}
+
+// newRunnerSelector returns the selector described by the adopt parameters.
+// The controller and pool ID default to the ones of this controller and pool.
+func newRunnerSelector(param params.AdoptRunnersParams, controllerID, poolID string) runnerSelector {
+	selector := runnerSelector{
+		controllerID: controllerID,
+		poolID:       poolID,
+		labels:       param.Labels,
+	}
+	if param.ControllerID != "" {
+		selector.controllerID = param.ControllerID
+	}
+	if param.SourcePoolID != "" {
+		selector.poolID = param.SourcePoolID
+	}
+	return selector
+}
+
+// matches returns true if a runner with the given labels can be adopted.
+func (s runnerSelector) matches(runnerLabels []string) bool {
+	if len(s.labels) == 0 {
+		return isManagedRunner(runnerLabels, s.controllerID)
+	}
+	for _, label := range s.labels {
+		if !slices.ContainsFunc(runnerLabels, func(l string) bool {
+			return strings.EqualFold(l, label)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// inPool returns true if a matching runner belongs to the pool being adopted
+// into. Runners selected by label are not tagged with a pool ID, so they all do.
+func (s runnerSelector) inPool(runnerLabels []string) bool {
+	if len(s.labels) > 0 {
+		return true
+	}
+	return slices.Contains(runnerLabels, poolIDLabelprefix+s.poolID)
+}
+
+// adoptionPlan matches the instances a provider reports for a pool with the
+// GitHub runners picked by the selector. Runners are matched by name. Instances
+// without a runner and runners of the pool without an instance are skipped.
+func adoptionPlan(selector runnerSelector, instances []commonParams.ProviderInstance, runners []*github.Runner) ([]adoptionMatch, []params.SkippedRunner) {
+	runnersByName := map[string]*github.Runner{}
+	for _, runner := range runners {
+		if !selector.matches(labelsFromRunner(runner)) {
+			continue
+		}
+		runnersByName[runner.GetName()] = runner
+	}
+
+	var matches []adoptionMatch
+	var skipped []params.SkippedRunner
+	seen := map[string]bool{}
+	for _, instance := range instances {
+		seen[instance.Name] = true
+		runner, ok := runnersByName[instance.Name]
+		if !ok {
+			skipped = append(skipped, params.SkippedRunner{
+				Name:   instance.Name,
+				Reason: "instance has no runner registered in GitHub",
+			})
+			continue
+		}
+		matches = append(matches, adoptionMatch{instance: instance, runner: runner})
+	}
+
+	for name, runner := range runnersByName {
+		if seen[name] || !selector.inPool(labelsFromRunner(runner)) {
+			continue
+		}
+		skipped = append(skipped, params.SkippedRunner{
+			Name:   name,
+			Reason: "runner has no instance in the provider",
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].instance.Name < matches[j].instance.Name
+	})
+	sort.Slice(skipped, func(i, j int) bool {
+		return skipped[i].Name < skipped[j].Name
+	})
+	return matches, skipped
+}
+
+// adoptedRunnerStatus returns the runner status of an adopted runner, based
+// on what GitHub reports about it.
+func adoptedRunnerStatus(runner *github.Runner) params.RunnerStatus {
+	if runner.GetBusy() {
+		return params.RunnerActive
+	}
+	return params.RunnerIdle
+}
+
+// AdoptRunners creates database records for the instances of a pool that exist
+// in the provider and are registered in GitHub, but that GARM does not know about.
+// This happens if the database was lost, and would otherwise lead to the runners
+// being removed as orphans. The runners of another controller or autoscaler can be
+// adopted by passing its controller ID or a label selector.
+func (r *basePoolManager) AdoptRunners(ctx context.Context, poolID string, param params.AdoptRunnersParams) (params.AdoptRunnersResult, error) {
+	pool, err := r.store.GetEntityPool(ctx, r.entity, poolID)
+	if err != nil {
+		return params.AdoptRunnersResult{}, errors.Wrap(err, "fetching pool")
+	}
+
+	provider, ok := r.providers[pool.ProviderName]
+	if !ok {
+		return params.AdoptRunnersResult{}, fmt.Errorf("unknown provider %s for pool %s", pool.ProviderName, pool.ID)
+	}
+
+	listInstancesParams := common.ListInstancesParams{
+		ListInstancesV011: common.ListInstancesV011Params{
+			ProviderBaseParams: r.getProviderBaseParams(pool),
+		},
+	}
+	r.mux.Lock()
+	controllerInfo := r.controllerInfo
+	r.mux.Unlock()
+
+	selector := newRunnerSelector(param, controllerInfo.ControllerID.String(), pool.ID)
+	providerInstances, err := provider.ListInstances(ctx, selector.poolID, listInstancesParams)
+	if err != nil {
+		return params.AdoptRunnersResult{}, errors.Wrap(err, "listing provider instances")
+	}
+
+	ghRunners, err := r.GetGithubRunners()
+	if err != nil {
+		return params.AdoptRunnersResult{}, errors.Wrap(err, "fetching github runners")
+	}
+
+	matches, skipped := adoptionPlan(selector, providerInstances, ghRunners)
+	result := params.AdoptRunnersResult{
+		Adopted: []params.Instance{},
+		Skipped: skipped,
+	}
+	for _, match := range matches {
+		if _, err := r.store.GetInstanceByName(ctx, match.instance.Name); err == nil {
+			// Already managed by GARM.
+			continue
+		} else if !errors.Is(err, runnerErrors.ErrNotFound) {
+			return result, errors.Wrap(err, "fetching instance")
+		}
+
+		createParams := params.CreateInstanceParams{
+			Name:              match.instance.Name,
+			OSType:            match.instance.OSType,
+			OSArch:            match.instance.OSArch,
+			Status:            match.instance.Status,
+			RunnerStatus:      adoptedRunnerStatus(match.runner),
+			CallbackURL:       controllerInfo.CallbackURL,
+			MetadataURL:       controllerInfo.MetadataURL,
+			GitHubRunnerGroup: pool.GitHubRunnerGroup,
+			AgentID:           match.runner.GetID(),
+			Adopted:           true,
+		}
+		if createParams.OSType == "" {
+			createParams.OSType = pool.OSType
+		}
+		if createParams.OSArch == "" {
+			createParams.OSArch = pool.OSArch
+		}
+
+		if param.DryRun {
+			result.Adopted = append(result.Adopted, params.Instance{
+				Name:         createParams.Name,
+				ProviderID:   match.instance.ProviderID,
+				AgentID:      createParams.AgentID,
+				OSType:       createParams.OSType,
+				OSArch:       createParams.OSArch,
+				Status:       createParams.Status,
+				RunnerStatus: createParams.RunnerStatus,
+				PoolID:       pool.ID,
+				Adopted:      true,
+			})
+			continue
+		}
+
+		instance, err := r.adoptInstance(ctx, pool, match, createParams)
+		if err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				ctx, "failed to adopt runner",
+				"runner_name", match.instance.Name,
+				"pool_id", pool.ID)
+			result.Skipped = append(result.Skipped, params.SkippedRunner{
+				Name:   match.instance.Name,
+				Reason: err.Error(),
+			})
+			continue
+		}
+		result.Adopted = append(result.Adopted, instance)
+	}
+	return result, nil
+}
+
+func (r *basePoolManager) adoptInstance(ctx context.Context, pool params.Pool, match adoptionMatch, createParams params.CreateInstanceParams) (params.Instance, error) {
+	if !r.keyMux.TryLock(createParams.Name) {
+		return params.Instance{}, fmt.Errorf("runner %s is locked", createParams.Name)
+	}
+	defer r.keyMux.Unlock(createParams.Name, false)
+
+	if _, err := r.store.CreateInstance(ctx, pool.ID, createParams); err != nil {
+		return params.Instance{}, errors.Wrap(err, "creating instance")
+	}
+
+	// The runner is already registered, so it must not be handed a new token.
+	tokenFetched := true
+	updateParams := r.updateArgsFromProviderInstance(match.instance)
+	updateParams.TokenFetched = &tokenFetched
+	updateParams.Stages = []params.InstanceStage{params.InstanceStageProviderCreated, params.InstanceStageRegistered}
+	instance, err := r.store.UpdateInstance(ctx, createParams.Name, updateParams)
+	if err != nil {
+		return params.Instance{}, errors.Wrap(err, "updating instance")
+	}
+
+	slog.InfoContext(
+		ctx, "adopted runner",
+		"runner_name", instance.Name,
+		"pool_id", pool.ID)
+	if err := r.store.AddInstanceEvent(ctx, instance.Name, params.StatusEvent, params.EventInfo, "runner was adopted"); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			ctx, "failed to add instance event",
+			"runner_name", instance.Name)
+	}
+	return instance, nil
+}
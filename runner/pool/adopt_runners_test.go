@@ -0,0 +1,125 @@
+//go:build testing
+
+package pool
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
+	runnerCommonMocks "github.com/cloudbase/garm/runner/common/mocks"
+)
+
+// staleInstancesStore reports every instance as last updated an hour ago, so the
+// cleanup loops do not skip them as recently updated.
+type staleInstancesStore struct {
+	dbCommon.Store
+}
+
+func (s staleInstancesStore) ListEntityInstances(ctx context.Context, entity params.GithubEntity) ([]params.Instance, error) {
+	instances, err := s.Store.ListEntityInstances(ctx, entity)
+	for idx := range instances {
+		instances[idx].UpdatedAt = time.Now().Add(-time.Hour)
+	}
+	return instances, err
+}
+
+func (s *JobActionTestSuite) setupAdoption(oldControllerID, oldPoolID string) (params.Pool, *runnerCommonMocks.Provider, *runnerCommonMocks.GithubClient) {
+	pool, err := s.store.CreateEntityPool(s.ctx, s.poolMgr.entity, params.CreatePoolParams{
+		ProviderName: "test-provider",
+		MaxRunners:   4,
+		Image:        "test-image",
+		Flavor:       "test-flavor",
+		OSType:       "linux",
+		OSArch:       "amd64",
+		Tags:         []string{"self-hosted"},
+	})
+	s.Require().Nil(err)
+
+	provider := &runnerCommonMocks.Provider{}
+	provider.On("ListInstances", mock.Anything, oldPoolID, mock.Anything).Return([]commonParams.ProviderInstance{
+		{Name: "garm-old", ProviderID: "provider-old", Status: commonParams.InstanceRunning},
+	}, nil)
+	ghcli := &runnerCommonMocks.GithubClient{}
+	ghcli.On("ListEntityRunners", mock.Anything, mock.Anything).Return(&github.Runners{
+		Runners: []*github.Runner{
+			testRunner("garm-old", false, controllerLabelPrefix+oldControllerID, poolIDLabelprefix+oldPoolID),
+		},
+	}, &github.Response{}, nil)
+
+	s.poolMgr.providers = map[string]common.Provider{"test-provider": provider}
+	s.poolMgr.ghcli = ghcli
+	s.poolMgr.controllerInfo = params.ControllerInfo{ControllerID: uuid.New()}
+	return pool, provider, ghcli
+}
+
+func (s *JobActionTestSuite) TestAdoptRunnersOfLostController() {
+	oldControllerID := uuid.New().String()
+	pool, provider, ghcli := s.setupAdoption(oldControllerID, "old-pool")
+
+	result, err := s.poolMgr.AdoptRunners(s.ctx, pool.ID, params.AdoptRunnersParams{
+		ControllerID: oldControllerID,
+		SourcePoolID: "old-pool",
+	})
+	s.Require().Nil(err)
+	s.Require().Len(result.Adopted, 1)
+	s.Require().Empty(result.Skipped)
+	provider.AssertExpectations(s.T())
+	ghcli.AssertExpectations(s.T())
+
+	instance, err := s.store.GetInstanceByName(s.ctx, "garm-old")
+	s.Require().Nil(err)
+	s.Require().Equal(pool.ID, instance.PoolID)
+	s.Require().Equal("provider-old", instance.ProviderID)
+	s.Require().Equal(int64(len("garm-old")), instance.AgentID)
+	s.Require().Equal(params.RunnerIdle, instance.RunnerStatus)
+	s.Require().Equal(commonParams.InstanceRunning, instance.Status)
+}
+
+func (s *JobActionTestSuite) TestAdoptRunnersSkipsRunnersOfOtherControllers() {
+	pool, _, _ := s.setupAdoption(uuid.New().String(), "old-pool")
+
+	result, err := s.poolMgr.AdoptRunners(s.ctx, pool.ID, params.AdoptRunnersParams{
+		SourcePoolID: "old-pool",
+	})
+	s.Require().Nil(err)
+	s.Require().Empty(result.Adopted)
+	s.Require().Len(result.Skipped, 1)
+	s.Require().Equal("garm-old", result.Skipped[0].Name)
+
+	_, err = s.store.GetInstanceByName(s.ctx, "garm-old")
+	s.Require().NotNil(err)
+}
+
+func (s *JobActionTestSuite) TestAdoptedRunnersOfOtherControllersAreKept() {
+	oldControllerID := uuid.New().String()
+	pool, _, ghcli := s.setupAdoption(oldControllerID, "old-pool")
+
+	_, err := s.poolMgr.AdoptRunners(s.ctx, pool.ID, params.AdoptRunnersParams{
+		ControllerID: oldControllerID,
+		SourcePoolID: "old-pool",
+	})
+	s.Require().Nil(err)
+
+	instance, err := s.store.GetInstanceByName(s.ctx, "garm-old")
+	s.Require().Nil(err)
+	s.Require().True(instance.Adopted)
+
+	runners, _, err := ghcli.ListEntityRunners(s.ctx, nil)
+	s.Require().Nil(err)
+	s.poolMgr.store = staleInstancesStore{Store: s.store}
+	s.Require().Nil(s.poolMgr.cleanupOrphanedProviderRunners(runners.Runners))
+	s.Require().Nil(s.poolMgr.reapTimedOutRunners(runners.Runners))
+
+	// The runner still carries the controller ID label of the old controller.
+	instance, err = s.store.GetInstanceByName(s.ctx, "garm-old")
+	s.Require().Nil(err)
+	s.Require().Equal(commonParams.InstanceRunning, instance.Status)
+}
@@ -0,0 +1,89 @@
+package pool
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+)
+
+func testRunner(name string, busy bool, labels ...string) *github.Runner {
+	runner := &github.Runner{
+		ID:   github.Int64(int64(len(name))),
+		Name: github.String(name),
+		Busy: github.Bool(busy),
+	}
+	for _, label := range labels {
+		runner.Labels = append(runner.Labels, &github.RunnerLabels{Name: github.String(label)})
+	}
+	return runner
+}
+
+func TestAdoptionPlan(t *testing.T) {
+	controllerLabel := controllerLabelPrefix + "controller"
+	poolLabel := poolIDLabelprefix + "pool"
+	instances := []commonParams.ProviderInstance{
+		{Name: "garm-b", Status: commonParams.InstanceRunning},
+		{Name: "garm-a", Status: commonParams.InstanceRunning},
+		{Name: "garm-unregistered", Status: commonParams.InstanceRunning},
+		{Name: "garm-other-controller", Status: commonParams.InstanceRunning},
+	}
+	runners := []*github.Runner{
+		testRunner("garm-a", true, controllerLabel, poolLabel),
+		testRunner("garm-b", false, controllerLabel, poolLabel),
+		testRunner("garm-other-controller", false, controllerLabelPrefix+"other", poolLabel),
+		testRunner("garm-no-instance", false, controllerLabel, poolLabel),
+		testRunner("garm-other-pool", false, controllerLabel, poolIDLabelprefix+"other"),
+	}
+
+	selector := newRunnerSelector(params.AdoptRunnersParams{}, "controller", "pool")
+	matches, skipped := adoptionPlan(selector, instances, runners)
+	if len(matches) != 2 || matches[0].instance.Name != "garm-a" || matches[1].instance.Name != "garm-b" {
+		t.Fatalf("unexpected matches: %+v", matches)
+	}
+	if adoptedRunnerStatus(matches[0].runner) != params.RunnerActive || adoptedRunnerStatus(matches[1].runner) != params.RunnerIdle {
+		t.Fatalf("unexpected runner status")
+	}
+
+	expected := []string{"garm-no-instance", "garm-other-controller", "garm-unregistered"}
+	if len(skipped) != len(expected) {
+		t.Fatalf("expected %d skipped runners, got %+v", len(expected), skipped)
+	}
+	for idx, name := range expected {
+		if skipped[idx].Name != name {
+			t.Fatalf("expected %s to be skipped, got %+v", name, skipped)
+		}
+	}
+}
+
+func TestAdoptionPlanSelectors(t *testing.T) {
+	instances := []commonParams.ProviderInstance{
+		{Name: "old-controller", Status: commonParams.InstanceRunning},
+		{Name: "other-autoscaler", Status: commonParams.InstanceRunning},
+	}
+	runners := []*github.Runner{
+		testRunner("old-controller", false, controllerLabelPrefix+"old", poolIDLabelprefix+"old-pool"),
+		testRunner("other-autoscaler", false, "self-hosted", "Linux", "gpu"),
+		testRunner("other-autoscaler-cpu", false, "self-hosted", "Linux"),
+	}
+
+	selector := newRunnerSelector(params.AdoptRunnersParams{ControllerID: "old", SourcePoolID: "old-pool"}, "controller", "pool")
+	matches, skipped := adoptionPlan(selector, instances, runners)
+	if len(matches) != 1 || matches[0].instance.Name != "old-controller" {
+		t.Fatalf("unexpected matches: %+v", matches)
+	}
+	if len(skipped) != 1 || skipped[0].Name != "other-autoscaler" {
+		t.Fatalf("unexpected skipped runners: %+v", skipped)
+	}
+
+	selector = newRunnerSelector(params.AdoptRunnersParams{Labels: []string{"self-hosted", "GPU"}}, "controller", "pool")
+	matches, skipped = adoptionPlan(selector, instances, runners)
+	if len(matches) != 1 || matches[0].instance.Name != "other-autoscaler" {
+		t.Fatalf("unexpected matches: %+v", matches)
+	}
+	if len(skipped) != 1 || skipped[0].Name != "old-controller" {
+		t.Fatalf("unexpected skipped runners: %+v", skipped)
+	}
+}
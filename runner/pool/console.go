@@ -0,0 +1,126 @@
+package pool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
+)
+
+// maxConsoleOutputSize is the maximum size of the console output we store for
+// a failed instance. The end of the console output is usually the most useful
+// part, so we keep that.
+const maxConsoleOutputSize = 256 * 1024
+
+// reapedConsoleOutputLines is the number of console output lines logged for
+// runners that never came online.
+const reapedConsoleOutputLines = 50
+
+func truncateConsoleOutput(out []byte) []byte {
+	if len(out) <= maxConsoleOutputSize {
+		return out
+	}
+	return out[len(out)-maxConsoleOutputSize:]
+}
+
+// providerIdentifier returns the ID the provider knows the instance by.
+func providerIdentifier(instance params.Instance) string {
+	if instance.ProviderID != "" {
+		return instance.ProviderID
+	}
+	return instance.Name
+}
+
+func (r *basePoolManager) fetchConsoleOutput(ctx context.Context, pool params.Pool, identifier string) ([]byte, error) {
+	provider, ok := r.providers[pool.ProviderName]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %s for pool %s", pool.ProviderName, pool.ID)
+	}
+
+	consoleParams := common.GetConsoleOutputParams{
+		GetConsoleOutputV011: common.GetConsoleOutputV011Params{
+			ProviderBaseParams: r.getProviderBaseParams(pool),
+		},
+	}
+	out, err := provider.GetConsoleOutput(ctx, identifier, consoleParams)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching console output")
+	}
+	return truncateConsoleOutput(out), nil
+}
+
+// captureConsoleOutput saves the console output of an instance that failed, before
+// it is removed from the provider. Providers are not required to support fetching
+// the console output, so errors are only logged.
+func (r *basePoolManager) captureConsoleOutput(pool params.Pool, instanceName, identifier string) {
+	out, err := r.fetchConsoleOutput(r.ctx, pool, identifier)
+	if err != nil {
+		slog.With(slog.Any("error", err)).DebugContext(
+			r.ctx, "failed to capture console output",
+			"runner_name", instanceName)
+		return
+	}
+	if len(out) == 0 {
+		return
+	}
+	if _, err := r.store.UpdateInstance(r.ctx, instanceName, params.UpdateInstanceParams{ConsoleOutput: out}); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			r.ctx, "failed to save console output",
+			"runner_name", instanceName)
+	}
+}
+
+// reapedConsoleOutput fetches the console output of a runner that timed out. The
+// instance is removed from the database once it is deleted from the provider, so
+// the output is stored along with the reap instead of on the instance.
+func (r *basePoolManager) reapedConsoleOutput(pool params.Pool, instance params.Instance) []byte {
+	if instance.Status != commonParams.InstanceRunning {
+		return nil
+	}
+	out, err := r.fetchConsoleOutput(r.ctx, pool, providerIdentifier(instance))
+	if err != nil {
+		slog.With(slog.Any("error", err)).DebugContext(
+			r.ctx, "failed to capture console output",
+			"runner_name", instance.Name)
+		return nil
+	}
+	if len(out) > 0 {
+		slog.WarnContext(
+			r.ctx, "timed-out runner console output",
+			"runner_name", instance.Name,
+			"console_output", consoleOutputTail(out, reapedConsoleOutputLines))
+	}
+	return out
+}
+
+// consoleOutputTail returns the last lines of a console output.
+func consoleOutputTail(out []byte, lines int) string {
+	trimmed := bytes.TrimRight(out, "\n")
+	idx := len(trimmed)
+	for i := 0; i < lines && idx > 0; i++ {
+		idx = bytes.LastIndexByte(trimmed[:idx], '\n')
+		if idx < 0 {
+			return string(trimmed)
+		}
+	}
+	return string(trimmed[idx+1:])
+}
+
+// GetConsoleOutput fetches the console output of a runner from the provider.
+func (r *basePoolManager) GetConsoleOutput(ctx context.Context, runner params.Instance) ([]byte, error) {
+	pool, err := r.getEntityPool(runner.PoolID)
+	if err != nil {
+		return nil, err
+	}
+	if isBeingRemoved(runner) {
+		return nil, runnerErrors.NewBadRequestError("runner %s is being removed", runner.Name)
+	}
+	return r.fetchConsoleOutput(ctx, pool, providerIdentifier(runner))
+}
@@ -0,0 +1,89 @@
+//go:build testing
+
+package pool
+
+import (
+	"fmt"
+
+	"github.com/stretchr/testify/mock"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
+	runnerCommonMocks "github.com/cloudbase/garm/runner/common/mocks"
+)
+
+type testInstanceTokenGetter struct{}
+
+func (testInstanceTokenGetter) NewInstanceJWTToken(_ params.Instance, _ string, _ params.GithubEntityType, _ uint) (string, error) {
+	return "test-token", nil
+}
+
+func (s *JobActionTestSuite) setupConsoleCapture() (params.Pool, params.Instance, *runnerCommonMocks.Provider) {
+	pool, err := s.store.CreateEntityPool(s.ctx, s.poolMgr.entity, params.CreatePoolParams{
+		ProviderName: "test-provider",
+		MaxRunners:   4,
+		Image:        "test-image",
+		Flavor:       "test-flavor",
+		OSType:       "linux",
+		OSArch:       "amd64",
+		Tags:         []string{"self-hosted"},
+	})
+	s.Require().Nil(err)
+	instance, err := s.store.CreateInstance(s.ctx, pool.ID, params.CreateInstanceParams{
+		Name:   "garm-console",
+		OSType: "linux",
+		OSArch: "amd64",
+		Status: commonParams.InstanceRunning,
+	})
+	s.Require().Nil(err)
+
+	provider := &runnerCommonMocks.Provider{}
+	provider.On("GetConsoleOutput", mock.Anything, instance.Name, mock.Anything).Return([]byte("cloud-init failed\n"), nil)
+	provider.On("DeleteInstance", mock.Anything, instance.Name, mock.Anything).Return(nil)
+	s.poolMgr.providers = map[string]common.Provider{"test-provider": provider}
+	s.poolMgr.instanceTokenGetter = testInstanceTokenGetter{}
+	return pool, instance, provider
+}
+
+func (s *JobActionTestSuite) TestCreateFailureCapturesConsoleOutput() {
+	_, instance, provider := s.setupConsoleCapture()
+	provider.On("CreateInstance", mock.Anything, mock.Anything, mock.Anything).Return(
+		commonParams.ProviderInstance{}, fmt.Errorf("quota exceeded"))
+
+	err := s.poolMgr.addInstanceToProvider(instance)
+	s.Require().NotNil(err)
+	provider.AssertExpectations(s.T())
+
+	stored, err := s.store.GetInstanceByName(s.ctx, instance.Name)
+	s.Require().Nil(err)
+	s.Require().Equal("cloud-init failed\n", string(stored.ConsoleOutput))
+	s.Require().NotNil(stored.ConsoleOutputCapturedAt)
+}
+
+func (s *JobActionTestSuite) TestCreateErrorStatusCapturesConsoleOutput() {
+	_, instance, provider := s.setupConsoleCapture()
+	provider.On("CreateInstance", mock.Anything, mock.Anything, mock.Anything).Return(
+		commonParams.ProviderInstance{Name: instance.Name, Status: commonParams.InstanceError}, nil)
+
+	err := s.poolMgr.addInstanceToProvider(instance)
+	s.Require().Nil(err)
+	provider.AssertExpectations(s.T())
+
+	stored, err := s.store.GetInstanceByName(s.ctx, instance.Name)
+	s.Require().Nil(err)
+	s.Require().Equal(commonParams.InstanceError, stored.Status)
+	s.Require().Equal("cloud-init failed\n", string(stored.ConsoleOutput))
+}
+
+func (s *JobActionTestSuite) TestReapedConsoleOutputOutlivesInstance() {
+	pool, instance, _ := s.setupConsoleCapture()
+
+	s.poolMgr.recordReap(instance, "runner did not join GitHub", s.poolMgr.reapedConsoleOutput(pool, instance))
+	s.Require().Nil(s.store.DeleteInstance(s.ctx, pool.ID, instance.Name))
+
+	reaps, err := s.store.ListInstanceReaps(s.ctx, instance.Name)
+	s.Require().Nil(err)
+	s.Require().Len(reaps, 1)
+	s.Require().Equal("cloud-init failed\n", string(reaps[0].ConsoleOutput))
+}
@@ -0,0 +1,32 @@
+package pool
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTruncateConsoleOutput(t *testing.T) {
+	short := []byte("boot ok")
+	if out := truncateConsoleOutput(short); !bytes.Equal(out, short) {
+		t.Fatalf("expected short output to be kept, got %q", out)
+	}
+
+	long := append(bytes.Repeat([]byte("a"), maxConsoleOutputSize), []byte("the end")...)
+	out := truncateConsoleOutput(long)
+	if len(out) != maxConsoleOutputSize {
+		t.Fatalf("expected %d bytes, got %d", maxConsoleOutputSize, len(out))
+	}
+	if !bytes.HasSuffix(out, []byte("the end")) {
+		t.Fatalf("expected the end of the output to be kept")
+	}
+}
+
+func TestConsoleOutputTail(t *testing.T) {
+	out := []byte("one\ntwo\nthree\n")
+	if tail := consoleOutputTail(out, 2); tail != "two\nthree" {
+		t.Fatalf("expected the last two lines, got %q", tail)
+	}
+	if tail := consoleOutputTail(out, 10); tail != "one\ntwo\nthree" {
+		t.Fatalf("expected all lines, got %q", tail)
+	}
+}
@@ -0,0 +1,64 @@
+package pool
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm-provider-common/util"
+	"github.com/cloudbase/garm/metrics"
+	"github.com/cloudbase/garm/params"
+)
+
+// recordJobUsage attributes the time a runner was up until the job completed
+// to the job and its repository.
+func (r *basePoolManager) recordJobUsage(job params.Job) {
+	endedAt := job.CompletedAt
+	if endedAt.IsZero() {
+		endedAt = time.Now().UTC()
+	}
+	r.recordRunnerUsage(params.RecordRunnerUsageParams{
+		InstanceName:    job.RunnerName,
+		EndedAt:         endedAt,
+		JobID:           job.ID,
+		RepositoryOwner: job.RepositoryOwner,
+		RepositoryName:  job.RepositoryName,
+	})
+}
+
+// recordRemovedRunnerUsage records the time a runner was up since its last job,
+// before it is removed.
+func (r *basePoolManager) recordRemovedRunnerUsage(instance params.Instance) {
+	r.recordRunnerUsage(params.RecordRunnerUsageParams{
+		InstanceName: instance.Name,
+		EndedAt:      time.Now().UTC(),
+	})
+}
+
+func (r *basePoolManager) recordRunnerUsage(param params.RecordRunnerUsageParams) {
+	usage, err := r.store.RecordRunnerUsage(r.ctx, param)
+	if err != nil {
+		if errors.Is(err, runnerErrors.ErrDuplicateEntity) || errors.Is(err, runnerErrors.ErrNotFound) {
+			// Webhooks may be delivered more than once, and jobs may run on
+			// runners GARM does not manage.
+			return
+		}
+		slog.With(slog.Any("error", err)).ErrorContext(
+			r.ctx, "failed to record runner usage",
+			"runner_name", util.SanitizeLogEntry(param.InstanceName))
+		return
+	}
+
+	metrics.RunnerSeconds.WithLabelValues(
+		usage.PoolID,             // label: pool_id
+		usage.EntityName,         // label: pool_owner
+		string(usage.EntityType), // label: pool_type
+	).Add(usage.Seconds)
+	metrics.RunnerCost.WithLabelValues(
+		usage.PoolID,             // label: pool_id
+		usage.EntityName,         // label: pool_owner
+		string(usage.EntityType), // label: pool_type
+	).Add(usage.Cost)
+}
@@ -0,0 +1,209 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
+)
+
+const (
+	// maxDeliveryPages is the maximum number of delivery pages we fetch
+	// during one reconciliation run.
+	maxDeliveryPages = 10
+	// deliveriesPerPage is the number of deliveries we request per page.
+	deliveriesPerPage = 100
+)
+
+// hookTargetTypes maps entity types to the hook installation target type GitHub
+// sends along with webhooks.
+var hookTargetTypes = map[params.GithubEntityType]string{
+	params.GithubEntityTypeRepository:   "repository",
+	params.GithubEntityTypeOrganization: "organization",
+	params.GithubEntityTypeEnterprise:   "business",
+}
+
+// deliverySucceeded returns true if GARM acknowledged the delivery.
+func deliverySucceeded(delivery *github.HookDelivery) bool {
+	code := delivery.GetStatusCode()
+	return code >= 200 && code < 300
+}
+
+// listMissedQueuedDeliveries returns the workflow_job "queued" deliveries made to the
+// GARM webhook since the given time, which GARM never acknowledged. If a delivery
+// was redelivered successfully, it is not returned.
+func (r *basePoolManager) listMissedQueuedDeliveries(ctx context.Context, hookID int64, since time.Time) ([]*github.HookDelivery, error) {
+	opts := &github.ListCursorOptions{
+		PerPage: deliveriesPerPage,
+	}
+
+	var failed []*github.HookDelivery
+	succeeded := map[string]bool{}
+	for page := 0; page < maxDeliveryPages; page++ {
+		deliveries, ghResp, err := r.ghcli.ListEntityHookDeliveries(ctx, hookID, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing hook deliveries")
+		}
+
+		reachedCutoff := false
+		for _, delivery := range deliveries {
+			// Deliveries are returned newest first.
+			if delivery.GetDeliveredAt().Time.Before(since) {
+				reachedCutoff = true
+				break
+			}
+			if delivery.GetEvent() != "workflow_job" || delivery.GetAction() != "queued" {
+				continue
+			}
+			if deliverySucceeded(delivery) {
+				succeeded[delivery.GetGUID()] = true
+				continue
+			}
+			failed = append(failed, delivery)
+		}
+
+		if reachedCutoff || ghResp == nil || ghResp.Cursor == "" {
+			break
+		}
+		opts.Cursor = ghResp.Cursor
+	}
+
+	ret := []*github.HookDelivery{}
+	seen := map[string]bool{}
+	for _, delivery := range failed {
+		guid := delivery.GetGUID()
+		if succeeded[guid] || seen[guid] {
+			continue
+		}
+		seen[guid] = true
+		ret = append(ret, delivery)
+	}
+	return ret, nil
+}
+
+// workflowJobFromDelivery fetches the payload of a hook delivery and decodes it
+// as a workflow job. The raw payload is returned along with the job.
+func (r *basePoolManager) workflowJobFromDelivery(ctx context.Context, hookID int64, delivery *github.HookDelivery) (params.WorkflowJob, []byte, error) {
+	details, err := r.ghcli.GetEntityHookDelivery(ctx, hookID, delivery.GetID())
+	if err != nil {
+		return params.WorkflowJob{}, nil, errors.Wrap(err, "fetching hook delivery")
+	}
+
+	if details.Request == nil || details.Request.RawPayload == nil {
+		return params.WorkflowJob{}, nil, runnerErrors.NewBadRequestError("delivery %d has no payload", delivery.GetID())
+	}
+
+	payload := []byte(*details.Request.RawPayload)
+	var job params.WorkflowJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return params.WorkflowJob{}, nil, errors.Wrap(err, "decoding workflow job")
+	}
+	return job, payload, nil
+}
+
+// isJobStillQueued checks with GitHub that the job has not yet been picked up
+// by a runner or cancelled since the delivery was attempted.
+func (r *basePoolManager) isJobStillQueued(ctx context.Context, job params.WorkflowJob) (bool, error) {
+	ghJob, _, err := r.ghcli.GetWorkflowJobByID(ctx, job.Repository.Owner.Login, job.Repository.Name, job.WorkflowJob.ID)
+	if err != nil {
+		return false, errors.Wrap(err, "fetching workflow job")
+	}
+	return ghJob.GetStatus() == "queued", nil
+}
+
+// reconcileWebhookDeliveries looks at recent workflow_job deliveries made by GitHub to the
+// GARM webhook, and replays any queued jobs that GARM failed to receive. This can happen if
+// GARM was down or the network was unavailable when GitHub attempted the delivery. Jobs are
+// added to the webhook queue under the GUID of the delivery, which GitHub also sends as the
+// X-GitHub-Delivery header, so a delivery that reaches GARM more than once is handled once,
+// in order with the other events of the job.
+func (r *basePoolManager) reconcileWebhookDeliveries() error {
+	// Enterprise webhooks can't be managed or inspected through the API.
+	if r.entity.EntityType == params.GithubEntityTypeEnterprise {
+		return nil
+	}
+
+	hookInfo, err := r.GetWebhookInfo(r.ctx)
+	if err != nil {
+		var notFoundErr *runnerErrors.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			slog.DebugContext(r.ctx, "no webhook found; skipping delivery reconciliation")
+			return nil
+		}
+		return errors.Wrap(err, "fetching webhook info")
+	}
+
+	since := time.Now().UTC().Add(-common.WebhookDeliveryLookback)
+	deliveries, err := r.listMissedQueuedDeliveries(r.ctx, hookInfo.ID, since)
+	if err != nil {
+		return errors.Wrap(err, "listing missed deliveries")
+	}
+
+	targetType, ok := hookTargetTypes[r.entity.EntityType]
+	if !ok {
+		return fmt.Errorf("unknown entity type %s", r.entity.EntityType)
+	}
+
+	for _, delivery := range deliveries {
+		if delivery.GetGUID() == "" {
+			continue
+		}
+		job, payload, err := r.workflowJobFromDelivery(r.ctx, hookInfo.ID, delivery)
+		if err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to get workflow job from delivery",
+				"delivery_id", delivery.GetID())
+			continue
+		}
+
+		if _, err := r.store.GetJobByID(r.ctx, job.WorkflowJob.ID); err == nil {
+			// We already know about this job.
+			continue
+		} else if !errors.Is(err, runnerErrors.ErrNotFound) {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to get job",
+				"job_id", job.WorkflowJob.ID)
+			continue
+		}
+
+		queued, err := r.isJobStillQueued(r.ctx, job)
+		if err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to check job status",
+				"job_id", job.WorkflowJob.ID)
+			continue
+		}
+		if !queued {
+			continue
+		}
+
+		_, err = r.store.CreateWebhookDelivery(r.ctx, params.CreateWebhookDeliveryParams{
+			ID:             delivery.GetGUID(),
+			HookTargetType: targetType,
+			Payload:        payload,
+		})
+		if err != nil {
+			if errors.Is(err, runnerErrors.ErrDuplicateEntity) {
+				// The delivery reached us after all, or was already replayed.
+				continue
+			}
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to queue replayed workflow job",
+				"job_id", job.WorkflowJob.ID)
+			continue
+		}
+		slog.InfoContext(
+			r.ctx, "replaying missed workflow job",
+			"job_id", job.WorkflowJob.ID,
+			"delivery_id", delivery.GetID())
+	}
+	return nil
+}
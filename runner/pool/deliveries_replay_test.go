@@ -0,0 +1,110 @@
+//go:build testing
+
+package pool
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/cloudbase/garm/params"
+	runnerCommonMocks "github.com/cloudbase/garm/runner/common/mocks"
+)
+
+func (s *JobActionTestSuite) deliveryWithPayload(id, jobID int64) *github.HookDelivery {
+	job := s.workflowJob("queued")
+	job.WorkflowJob.ID = jobID
+	job.Repository.Name = s.repo.Name
+	job.Repository.Owner.Login = s.repo.Owner
+	asJSON, err := json.Marshal(job)
+	s.Require().Nil(err)
+	payload := json.RawMessage(asJSON)
+	return &github.HookDelivery{
+		ID:      github.Int64(id),
+		Request: &github.HookRequest{RawPayload: &payload},
+	}
+}
+
+func (s *JobActionTestSuite) TestReconcileWebhookDeliveriesQueuesMissedJobs() {
+	now := time.Now().UTC()
+	hookURL := "https://garm.example.com/webhooks/controller"
+	s.poolMgr.controllerInfo.ControllerWebhookURL = hookURL
+
+	ghcli := &runnerCommonMocks.GithubClient{}
+	ghcli.On("ListEntityHooks", mock.Anything, mock.Anything).Return([]*github.Hook{
+		{ID: github.Int64(7), Active: github.Bool(true), Config: map[string]interface{}{"url": hookURL}},
+	}, &github.Response{}, nil)
+
+	// Deliveries are returned newest first. The first page holds:
+	//   * a missed job we already know about
+	//   * a missed job that already went through the webhook queue
+	//   * a missed job that was picked up in the meantime
+	firstPage := []*github.HookDelivery{
+		newTestDelivery(15, "guid-known", "workflow_job", "queued", 502, now.Add(-1*time.Minute)),
+		newTestDelivery(14, "guid-handled", "workflow_job", "queued", 502, now.Add(-2*time.Minute)),
+		newTestDelivery(13, "guid-not-queued", "workflow_job", "queued", 502, now.Add(-3*time.Minute)),
+	}
+	// The second page holds a missed job that is still queued, and reaches the cutoff.
+	secondPage := []*github.HookDelivery{
+		newTestDelivery(12, "guid-missed", "workflow_job", "queued", 502, now.Add(-4*time.Minute)),
+		newTestDelivery(11, "guid-too-old", "workflow_job", "queued", 502, now.Add(-2*time.Hour)),
+	}
+	ghcli.On("ListEntityHookDeliveries", mock.Anything, int64(7), mock.MatchedBy(func(opts *github.ListCursorOptions) bool {
+		return opts.Cursor == ""
+	})).Return(firstPage, &github.Response{Cursor: "page-2"}, nil).Once()
+	ghcli.On("ListEntityHookDeliveries", mock.Anything, int64(7), mock.MatchedBy(func(opts *github.ListCursorOptions) bool {
+		return opts.Cursor == "page-2"
+	})).Return(secondPage, &github.Response{Cursor: "page-3"}, nil).Once()
+
+	jobIDs := map[int64]int64{15: 1, 14: 2, 13: 3, 12: 4}
+	for deliveryID, jobID := range jobIDs {
+		ghcli.On("GetEntityHookDelivery", mock.Anything, int64(7), deliveryID).Return(s.deliveryWithPayload(deliveryID, jobID), nil).Once()
+	}
+	for jobID, status := range map[int64]string{2: "queued", 3: "in_progress", 4: "queued"} {
+		ghcli.On("GetWorkflowJobByID", mock.Anything, s.repo.Owner, s.repo.Name, jobID).Return(
+			&github.WorkflowJob{ID: github.Int64(jobID), Status: github.String(status)}, &github.Response{}, nil).Once()
+	}
+	s.poolMgr.ghcli = ghcli
+
+	s.recordJob(params.JobStatusQueued, s.repo.ID, "")
+	_, err := s.store.CreateWebhookDelivery(s.ctx, params.CreateWebhookDeliveryParams{
+		ID:             "guid-handled",
+		HookTargetType: "repository",
+		Payload:        []byte("{}"),
+	})
+	s.Require().Nil(err)
+	completed := params.WebhookDeliveryCompleted
+	_, err = s.store.UpdateWebhookDelivery(s.ctx, "guid-handled", params.UpdateWebhookDeliveryParams{
+		Status: &completed,
+	})
+	s.Require().Nil(err)
+
+	s.Require().Nil(s.poolMgr.reconcileWebhookDeliveries())
+	ghcli.AssertExpectations(s.T())
+
+	// Only the missed job that is still queued was added to the webhook queue. It is
+	// not handled directly.
+	pending, err := s.store.ListWebhookDeliveriesByStatus(s.ctx, params.WebhookDeliveryPending)
+	s.Require().Nil(err)
+	s.Require().Len(pending, 1)
+	s.Require().Equal("guid-missed", pending[0].ID)
+	s.Require().Equal("repository", pending[0].HookTargetType)
+
+	var queued params.WorkflowJob
+	s.Require().Nil(json.Unmarshal(pending[0].Payload, &queued))
+	s.Require().Equal(int64(4), queued.WorkflowJob.ID)
+	_, err = s.store.GetJobByID(s.ctx, 4)
+	s.Require().NotNil(err)
+
+	// Running again does not queue the delivery twice.
+	ghcli.On("ListEntityHookDeliveries", mock.Anything, int64(7), mock.Anything).Return(secondPage, &github.Response{}, nil).Once()
+	ghcli.On("GetEntityHookDelivery", mock.Anything, int64(7), int64(12)).Return(s.deliveryWithPayload(12, 4), nil).Once()
+	ghcli.On("GetWorkflowJobByID", mock.Anything, s.repo.Owner, s.repo.Name, int64(4)).Return(
+		&github.WorkflowJob{ID: github.Int64(4), Status: github.String("queued")}, &github.Response{}, nil).Once()
+	s.Require().Nil(s.poolMgr.reconcileWebhookDeliveries())
+	pending, err = s.store.ListWebhookDeliveriesByStatus(s.ctx, params.WebhookDeliveryPending)
+	s.Require().Nil(err)
+	s.Require().Len(pending, 1)
+}
@@ -0,0 +1,86 @@
+package pool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/cloudbase/garm/runner/common/mocks"
+)
+
+func newTestDelivery(id int64, guid, event, action string, status int, deliveredAt time.Time) *github.HookDelivery {
+	return &github.HookDelivery{
+		ID:          github.Int64(id),
+		GUID:        github.String(guid),
+		Event:       github.String(event),
+		Action:      github.String(action),
+		StatusCode:  github.Int(status),
+		DeliveredAt: &github.Timestamp{Time: deliveredAt},
+	}
+}
+
+func TestListMissedQueuedDeliveries(t *testing.T) {
+	now := time.Now().UTC()
+	since := now.Add(-1 * time.Hour)
+
+	deliveries := []*github.HookDelivery{
+		// Redelivered successfully after the failure below.
+		newTestDelivery(6, "guid-1", "workflow_job", "queued", 200, now.Add(-1*time.Minute)),
+		newTestDelivery(5, "guid-1", "workflow_job", "queued", 502, now.Add(-2*time.Minute)),
+		// Missed.
+		newTestDelivery(4, "guid-2", "workflow_job", "queued", 502, now.Add(-3*time.Minute)),
+		// Not a queued job.
+		newTestDelivery(3, "guid-3", "workflow_job", "completed", 502, now.Add(-4*time.Minute)),
+		// Not a workflow job.
+		newTestDelivery(2, "guid-4", "ping", "", 502, now.Add(-5*time.Minute)),
+		// Older than the lookback window.
+		newTestDelivery(1, "guid-5", "workflow_job", "queued", 502, now.Add(-2*time.Hour)),
+	}
+
+	ghcli := mocks.NewGithubClient(t)
+	ghcli.On("ListEntityHookDeliveries", mock.Anything, int64(1), mock.Anything).Return(
+		deliveries, &github.Response{Cursor: "next"}, nil).Once()
+
+	r := &basePoolManager{ghcli: ghcli}
+	missed, err := r.listMissedQueuedDeliveries(context.Background(), 1, since)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(missed) != 1 {
+		t.Fatalf("expected 1 missed delivery, got %d", len(missed))
+	}
+	if missed[0].GetGUID() != "guid-2" {
+		t.Fatalf("expected guid-2, got %s", missed[0].GetGUID())
+	}
+}
+
+func TestListMissedQueuedDeliveriesFollowsCursor(t *testing.T) {
+	now := time.Now().UTC()
+	since := now.Add(-1 * time.Hour)
+
+	ghcli := mocks.NewGithubClient(t)
+	ghcli.On("ListEntityHookDeliveries", mock.Anything, int64(1), mock.MatchedBy(func(opts *github.ListCursorOptions) bool {
+		return opts.Cursor == ""
+	})).Return(
+		[]*github.HookDelivery{
+			newTestDelivery(2, "guid-2", "workflow_job", "queued", 502, now.Add(-1*time.Minute)),
+		}, &github.Response{Cursor: "page2"}, nil).Once()
+	ghcli.On("ListEntityHookDeliveries", mock.Anything, int64(1), mock.MatchedBy(func(opts *github.ListCursorOptions) bool {
+		return opts.Cursor == "page2"
+	})).Return(
+		[]*github.HookDelivery{
+			newTestDelivery(1, "guid-1", "workflow_job", "queued", 502, now.Add(-2*time.Minute)),
+		}, &github.Response{}, nil).Once()
+
+	r := &basePoolManager{ghcli: ghcli}
+	missed, err := r.listMissedQueuedDeliveries(context.Background(), 1, since)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(missed) != 2 {
+		t.Fatalf("expected 2 missed deliveries, got %d", len(missed))
+	}
+}
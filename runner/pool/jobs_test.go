@@ -0,0 +1,416 @@
+//go:build testing
+
+package pool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/database"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/database/watcher"
+	garmTesting "github.com/cloudbase/garm/internal/testing"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
+	runnerCommonMocks "github.com/cloudbase/garm/runner/common/mocks"
+)
+
+func init() {
+	watcher.SetWatcher(&garmTesting.MockWatcher{})
+}
+
+type JobActionTestSuite struct {
+	suite.Suite
+	store   dbCommon.Store
+	ctx     context.Context
+	repo    params.Repository
+	org     params.Organization
+	poolMgr *basePoolManager
+}
+
+func (s *JobActionTestSuite) SetupTest() {
+	ctx := context.Background()
+	store, err := database.NewDatabase(ctx, garmTesting.GetTestSqliteDBConfig(s.T()))
+	if err != nil {
+		s.T().Fatalf("failed to create db connection: %s", err)
+	}
+	adminCtx := garmTesting.ImpersonateAdminContext(ctx, store, s.T())
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(adminCtx, store, s.T())
+	creds := garmTesting.CreateTestGithubCredentials(adminCtx, "test-creds", store, s.T(), endpoint)
+
+	repo, err := store.CreateRepository(adminCtx, "test-owner", "test-repo", creds.Name, "secret", params.PoolBalancerTypeNone)
+	s.Require().Nil(err)
+	org, err := store.CreateOrganization(adminCtx, "test-owner", creds.Name, "secret", params.PoolBalancerTypeNone)
+	s.Require().Nil(err)
+	entity, err := repo.GetEntity()
+	s.Require().Nil(err)
+
+	s.store = store
+	s.ctx = adminCtx
+	s.repo = repo
+	s.org = org
+	s.poolMgr = &basePoolManager{
+		ctx:              adminCtx,
+		store:            store,
+		entity:           entity,
+		keyMux:           &keyMutex{},
+		managerIsRunning: true,
+	}
+}
+
+func (s *JobActionTestSuite) recordJob(status params.JobStatus, repoID, orgID string) {
+	job := params.Job{
+		ID:     1,
+		Action: string(status),
+		Status: string(status),
+		Labels: []string{"self-hosted"},
+	}
+	if repoID != "" {
+		asUUID := uuid.MustParse(repoID)
+		job.RepoID = &asUUID
+	}
+	if orgID != "" {
+		asUUID := uuid.MustParse(orgID)
+		job.OrgID = &asUUID
+	}
+	_, err := s.store.CreateOrUpdateJob(s.ctx, job)
+	s.Require().Nil(err)
+}
+
+func (s *JobActionTestSuite) workflowJob(action string) params.WorkflowJob {
+	job := params.WorkflowJob{
+		Action: action,
+	}
+	job.WorkflowJob.ID = 1
+	return job
+}
+
+func (s *JobActionTestSuite) TestUnknownJobIsHandled() {
+	ok, err := s.poolMgr.shouldHandleJobAction(s.workflowJob("queued"))
+	s.Require().Nil(err)
+	s.Require().True(ok)
+}
+
+func (s *JobActionTestSuite) TestForwardTransitionIsHandled() {
+	s.recordJob(params.JobStatusInProgress, s.repo.ID, "")
+
+	ok, err := s.poolMgr.shouldHandleJobAction(s.workflowJob("completed"))
+	s.Require().Nil(err)
+	s.Require().True(ok)
+}
+
+func (s *JobActionTestSuite) TestInProgressAfterCompletedIsIgnored() {
+	s.recordJob(params.JobStatusCompleted, s.repo.ID, "")
+
+	ok, err := s.poolMgr.shouldHandleJobAction(s.workflowJob("in_progress"))
+	s.Require().Nil(err)
+	s.Require().False(ok)
+}
+
+func (s *JobActionTestSuite) TestQueuedAfterInProgressIsIgnored() {
+	s.recordJob(params.JobStatusInProgress, s.repo.ID, "")
+
+	ok, err := s.poolMgr.shouldHandleJobAction(s.workflowJob("queued"))
+	s.Require().Nil(err)
+	s.Require().False(ok)
+}
+
+func (s *JobActionTestSuite) TestDuplicateForSameEntityIsIgnored() {
+	s.recordJob(params.JobStatusQueued, s.repo.ID, "")
+
+	ok, err := s.poolMgr.shouldHandleJobAction(s.workflowJob("queued"))
+	s.Require().Nil(err)
+	s.Require().False(ok)
+}
+
+func (s *JobActionTestSuite) TestSameEventFromAnotherEntityIsHandled() {
+	// The org received the queued event first. The repo still needs to record it.
+	s.recordJob(params.JobStatusQueued, "", s.org.ID)
+
+	ok, err := s.poolMgr.shouldHandleJobAction(s.workflowJob("queued"))
+	s.Require().Nil(err)
+	s.Require().True(ok)
+}
+
+func (s *JobActionTestSuite) createPoolWithRunner(minIdleRunners uint, runnerStatus params.RunnerStatus) params.Instance {
+	pool, err := s.store.CreateEntityPool(s.ctx, s.poolMgr.entity, params.CreatePoolParams{
+		ProviderName:   "test-provider",
+		MaxRunners:     4,
+		MinIdleRunners: minIdleRunners,
+		Image:          "test-image",
+		Flavor:         "test-flavor",
+		OSType:         "linux",
+		Tags:           []string{"self-hosted"},
+	})
+	s.Require().Nil(err)
+
+	instance, err := s.store.CreateInstance(s.ctx, pool.ID, params.CreateInstanceParams{
+		Name:            "test-runner",
+		Status:          commonParams.InstanceCreating,
+		RunnerStatus:    runnerStatus,
+		AditionalLabels: []string{fmt.Sprintf("%s%d", jobLabelPrefix, 1)},
+	})
+	s.Require().Nil(err)
+	return instance
+}
+
+func (s *JobActionTestSuite) cancelledJob() params.WorkflowJob {
+	job := s.workflowJob("completed")
+	job.WorkflowJob.Status = "completed"
+	job.WorkflowJob.Conclusion = "cancelled"
+	job.WorkflowJob.Labels = []string{"self-hosted"}
+	job.Repository.Name = s.repo.Name
+	job.Repository.Owner.Login = s.repo.Owner
+	return job
+}
+
+func (s *JobActionTestSuite) TestCancelledJobRemovesPendingRunner() {
+	instance := s.createPoolWithRunner(0, params.RunnerInstalling)
+	s.recordJob(params.JobStatusQueued, s.repo.ID, "")
+	s.Require().Nil(s.store.LockJob(s.ctx, 1, s.repo.ID))
+
+	err := s.poolMgr.HandleWorkflowJob(s.cancelledJob())
+	s.Require().Nil(err)
+
+	instance, err = s.store.GetInstanceByName(s.ctx, instance.Name)
+	s.Require().Nil(err)
+	s.Require().Equal(commonParams.InstancePendingDelete, instance.Status)
+
+	job, err := s.store.GetJobByID(s.ctx, 1)
+	s.Require().Nil(err)
+	s.Require().Equal(string(params.JobStatusCompleted), job.Status)
+}
+
+func (s *JobActionTestSuite) TestCancelledJobKeepsRunnerNeededForMinIdle() {
+	instance := s.createPoolWithRunner(1, params.RunnerPending)
+	s.recordJob(params.JobStatusQueued, s.repo.ID, "")
+	s.Require().Nil(s.store.LockJob(s.ctx, 1, s.repo.ID))
+
+	err := s.poolMgr.HandleWorkflowJob(s.cancelledJob())
+	s.Require().Nil(err)
+
+	instance, err = s.store.GetInstanceByName(s.ctx, instance.Name)
+	s.Require().Nil(err)
+	s.Require().Equal(commonParams.InstanceCreating, instance.Status)
+}
+
+func (s *JobActionTestSuite) TestCancelledJobNotLockedByUs() {
+	instance := s.createPoolWithRunner(0, params.RunnerPending)
+	s.recordJob(params.JobStatusQueued, s.repo.ID, "")
+
+	err := s.poolMgr.HandleWorkflowJob(s.cancelledJob())
+	s.Require().Nil(err)
+
+	instance, err = s.store.GetInstanceByName(s.ctx, instance.Name)
+	s.Require().Nil(err)
+	s.Require().Equal(commonParams.InstanceCreating, instance.Status)
+}
+
+func (s *JobActionTestSuite) TestCancelledJobKeepsIdleRunner() {
+	instance := s.createPoolWithRunner(0, params.RunnerIdle)
+	s.recordJob(params.JobStatusQueued, s.repo.ID, "")
+	s.Require().Nil(s.store.LockJob(s.ctx, 1, s.repo.ID))
+
+	err := s.poolMgr.HandleWorkflowJob(s.cancelledJob())
+	s.Require().Nil(err)
+
+	instance, err = s.store.GetInstanceByName(s.ctx, instance.Name)
+	s.Require().Nil(err)
+	s.Require().Equal(commonParams.InstanceCreating, instance.Status)
+}
+
+func (s *JobActionTestSuite) inProgressJob(jobID int64, runnerName string) params.WorkflowJob {
+	job := s.workflowJob("in_progress")
+	job.WorkflowJob.ID = jobID
+	job.WorkflowJob.Status = "in_progress"
+	job.WorkflowJob.RunnerName = runnerName
+	job.WorkflowJob.Labels = []string{"self-hosted"}
+	job.Repository.Name = s.repo.Name
+	job.Repository.Owner.Login = s.repo.Owner
+	return job
+}
+
+func (s *JobActionTestSuite) TestFailedEventIsNotRecorded() {
+	// The runner belongs to a pool of the org, so the repo pool manager fails to
+	// fetch its pool.
+	s.recordJob(params.JobStatusQueued, s.repo.ID, "")
+	orgEntity, err := s.org.GetEntity()
+	s.Require().Nil(err)
+	pool, err := s.store.CreateEntityPool(s.ctx, orgEntity, params.CreatePoolParams{
+		ProviderName: "test-provider",
+		MaxRunners:   4,
+		Image:        "test-image",
+		Flavor:       "test-flavor",
+		OSType:       "linux",
+		Tags:         []string{"self-hosted"},
+	})
+	s.Require().Nil(err)
+	_, err = s.store.CreateInstance(s.ctx, pool.ID, params.CreateInstanceParams{
+		Name:   "org-runner",
+		Status: commonParams.InstanceRunning,
+	})
+	s.Require().Nil(err)
+
+	job := s.inProgressJob(1, "org-runner")
+	err = s.poolMgr.HandleWorkflowJob(job)
+	s.Require().NotNil(err)
+
+	// A retry of the same delivery must not be dropped as a duplicate.
+	ok, err := s.poolMgr.shouldHandleJobAction(job)
+	s.Require().Nil(err)
+	s.Require().True(ok)
+}
+
+func (s *JobActionTestSuite) TestStaleInProgressBreaksLock() {
+	// The runner was created for job 1, but picked up job 2, which already completed.
+	instance := s.createPoolWithRunner(0, params.RunnerIdle)
+	s.recordJob(params.JobStatusQueued, s.repo.ID, "")
+	s.Require().Nil(s.store.LockJob(s.ctx, 1, s.repo.ID))
+	repoID := uuid.MustParse(s.repo.ID)
+	_, err := s.store.CreateOrUpdateJob(s.ctx, params.Job{
+		ID:     2,
+		Action: string(params.JobStatusCompleted),
+		Status: string(params.JobStatusCompleted),
+		Labels: []string{"self-hosted"},
+		RepoID: &repoID,
+	})
+	s.Require().Nil(err)
+
+	err = s.poolMgr.HandleWorkflowJob(s.inProgressJob(2, instance.Name))
+	s.Require().Nil(err)
+
+	job, err := s.store.GetJobByID(s.ctx, 1)
+	s.Require().Nil(err)
+	s.Require().Equal(uuid.Nil, job.LockedBy)
+}
+
+func (s *JobActionTestSuite) TestCompletedJobInObserveOnlyMode() {
+	provider := &runnerCommonMocks.Provider{}
+	s.poolMgr.providers = map[string]common.Provider{"test-provider": provider}
+	s.poolMgr.entity.ObserveOnly = true
+
+	instance := s.createPoolWithRunner(0, params.RunnerActive)
+	_, err := s.store.UpdateInstance(s.ctx, instance.Name, params.UpdateInstanceParams{Status: commonParams.InstanceRunning})
+	s.Require().Nil(err)
+	s.recordJob(params.JobStatusInProgress, s.repo.ID, "")
+
+	job := s.workflowJob("completed")
+	job.WorkflowJob.Status = "completed"
+	job.WorkflowJob.Conclusion = "success"
+	job.WorkflowJob.RunnerName = instance.Name
+	job.WorkflowJob.Labels = []string{"self-hosted"}
+	job.Repository.Name = s.repo.Name
+	job.Repository.Owner.Login = s.repo.Owner
+	s.Require().Nil(s.poolMgr.HandleWorkflowJob(job))
+
+	// A runner some other code path already marked for removal is not removed either.
+	pool, err := s.store.GetEntityPool(s.ctx, s.poolMgr.entity, instance.PoolID)
+	s.Require().Nil(err)
+	pending, err := s.store.CreateInstance(s.ctx, pool.ID, params.CreateInstanceParams{
+		Name:   "pending-runner",
+		Status: commonParams.InstancePendingDelete,
+	})
+	s.Require().Nil(err)
+
+	s.Require().Nil(s.poolMgr.deletePendingInstances())
+	s.Require().Nil(s.poolMgr.addPendingInstances())
+
+	instance, err = s.store.GetInstanceByName(s.ctx, instance.Name)
+	s.Require().Nil(err)
+	s.Require().Equal(commonParams.InstanceRunning, instance.Status)
+	pending, err = s.store.GetInstanceByName(s.ctx, pending.Name)
+	s.Require().Nil(err)
+	s.Require().Equal(commonParams.InstancePendingDelete, pending.Status)
+
+	actions, err := s.store.ListEntityObservedActions(s.ctx, s.poolMgr.entity)
+	s.Require().Nil(err)
+	recorded := map[params.ObservedActionType]string{}
+	for _, action := range actions {
+		recorded[action.Action] = action.Target
+	}
+	s.Require().Equal(instance.Name, recorded[params.ObservedActionDeleteRunner])
+	s.Require().Equal(pending.Name, recorded[params.ObservedActionDeleteInstance])
+	provider.AssertNotCalled(s.T(), "DeleteInstance")
+	s.Require().Empty(provider.Calls)
+}
+
+func (s *JobActionTestSuite) completeJobOnReusableRunner(ghRunner *github.Runner, ghErr error) params.Instance {
+	pool, err := s.store.CreateEntityPool(s.ctx, s.poolMgr.entity, params.CreatePoolParams{
+		ProviderName:  "test-provider",
+		MaxRunners:    4,
+		Image:         "test-image",
+		Flavor:        "test-flavor",
+		OSType:        "linux",
+		Tags:          []string{"self-hosted"},
+		Reusable:      true,
+		RunnerMaxJobs: 10,
+	})
+	s.Require().Nil(err)
+	instance, err := s.store.CreateInstance(s.ctx, pool.ID, params.CreateInstanceParams{
+		Name:         "test-runner",
+		Status:       commonParams.InstanceRunning,
+		RunnerStatus: params.RunnerActive,
+		AgentID:      42,
+	})
+	s.Require().Nil(err)
+	s.recordJob(params.JobStatusInProgress, s.repo.ID, "")
+
+	ghcli := &runnerCommonMocks.GithubClient{}
+	ghcli.On("GetEntityRunner", mock.Anything, int64(42)).Return(ghRunner, ghErr).Once()
+	s.poolMgr.ghcli = ghcli
+
+	job := s.workflowJob("completed")
+	job.WorkflowJob.Status = "completed"
+	job.WorkflowJob.Conclusion = "success"
+	job.WorkflowJob.RunnerName = instance.Name
+	job.WorkflowJob.Labels = []string{"self-hosted"}
+	job.Repository.Name = s.repo.Name
+	job.Repository.Owner.Login = s.repo.Owner
+	s.Require().Nil(s.poolMgr.HandleWorkflowJob(job))
+	ghcli.AssertExpectations(s.T())
+
+	instance, err = s.store.GetInstanceByName(s.ctx, instance.Name)
+	s.Require().Nil(err)
+	return instance
+}
+
+func (s *JobActionTestSuite) TestCompletedJobReusesRegisteredRunner() {
+	instance := s.completeJobOnReusableRunner(&github.Runner{
+		ID:     github.Int64(42),
+		Status: github.String("online"),
+	}, nil)
+
+	s.Require().Equal(params.RunnerIdle, instance.RunnerStatus)
+	s.Require().Equal(commonParams.InstanceRunning, instance.Status)
+	s.Require().Equal(uint(1), instance.JobsCompleted)
+}
+
+func (s *JobActionTestSuite) TestCompletedJobRemovesEphemeralRunner() {
+	// Ephemeral runners are removed from GitHub once their job is done.
+	instance := s.completeJobOnReusableRunner(nil, runnerErrors.ErrNotFound)
+
+	s.Require().Equal(params.RunnerTerminated, instance.RunnerStatus)
+	s.Require().Equal(commonParams.InstancePendingDelete, instance.Status)
+}
+
+func (s *JobActionTestSuite) TestCompletedJobRemovesOfflineRunner() {
+	instance := s.completeJobOnReusableRunner(&github.Runner{
+		ID:     github.Int64(42),
+		Status: github.String("offline"),
+	}, nil)
+
+	s.Require().Equal(params.RunnerTerminated, instance.RunnerStatus)
+	s.Require().Equal(commonParams.InstancePendingDelete, instance.Status)
+}
+
+func TestJobActionTestSuite(t *testing.T) {
+	suite.Run(t, new(JobActionTestSuite))
+}
@@ -0,0 +1,288 @@
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+)
+
+// isBeingRemoved returns true if the instance is already on its way out.
+func isBeingRemoved(instance params.Instance) bool {
+	switch instance.Status {
+	case commonParams.InstancePendingDelete, commonParams.InstancePendingForceDelete,
+		commonParams.InstanceDeleting:
+		return true
+	}
+	return false
+}
+
+// recycleCandidates returns the runners of a pool that should be drained next
+// while the pool is being recycled, and whether all runners created before the
+// recycle was requested are gone. At most RecycleMaxUnavailable of those runners
+// are draining or being removed at any time. Idle runners are drained first.
+func recycleCandidates(pool params.Pool, instances []params.Instance) ([]params.Instance, bool) {
+	if pool.RecycleRequestedAt == nil {
+		return nil, true
+	}
+
+	maxUnavailable := int(pool.RecycleMaxUnavailable)
+	if maxUnavailable == 0 {
+		maxUnavailable = 1
+	}
+
+	var stale int
+	var candidates []params.Instance
+	unavailable := 0
+	for _, instance := range instances {
+		if !instance.CreatedAt.Before(*pool.RecycleRequestedAt) {
+			continue
+		}
+		stale++
+		if instance.Draining || isBeingRemoved(instance) {
+			unavailable++
+			continue
+		}
+		candidates = append(candidates, instance)
+	}
+	if stale == 0 {
+		return nil, true
+	}
+
+	budget := maxUnavailable - unavailable
+	if budget <= 0 || len(candidates) == 0 {
+		return nil, false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].RunnerStatus != params.RunnerActive && candidates[j].RunnerStatus == params.RunnerActive
+	})
+	if budget > len(candidates) {
+		budget = len(candidates)
+	}
+	return candidates[:budget], false
+}
+
+func (r *basePoolManager) setInstanceDraining(instance params.Instance) error {
+	draining := true
+	if _, err := r.store.UpdateInstance(r.ctx, instance.Name, params.UpdateInstanceParams{Draining: &draining}); err != nil {
+		return errors.Wrap(err, "updating instance")
+	}
+	return nil
+}
+
+// recycleOnePool drains the next batch of runners of a pool that is being
+// recycled. The drained runners are replaced by ensureIdleRunnersForOnePool.
+func (r *basePoolManager) recycleOnePool(pool params.Pool, instances []params.Instance) error {
+	toDrain, done := recycleCandidates(pool, instances)
+	if done {
+		if pool.RecycleRequestedAt != nil {
+			slog.InfoContext(r.ctx, "pool recycle complete", "pool_id", pool.ID)
+			if _, err := r.store.UpdateEntityPool(r.ctx, r.entity, pool.ID, params.UpdatePoolParams{Recycle: &params.PoolRecycle{}}); err != nil {
+				return errors.Wrap(err, "updating pool")
+			}
+		}
+		return nil
+	}
+
+	for _, instance := range toDrain {
+		if r.observe(pool.ID, params.ObservedActionDrainRunner, instance.Name, "pool is being recycled") {
+			continue
+		}
+		slog.InfoContext(
+			r.ctx, "draining runner for pool recycle",
+			"runner_name", instance.Name,
+			"pool_id", pool.ID)
+		if err := r.setInstanceDraining(instance); err != nil {
+			return errors.Wrapf(err, "draining runner %s", instance.Name)
+		}
+	}
+	return nil
+}
+
+// drainOnePool removes the draining runners of a pool which are not running
+// a job. Runners that are running a job are removed once the job is done.
+func (r *basePoolManager) drainOnePool(pool params.Pool, instances []params.Instance) error {
+	for _, instance := range instances {
+		if !instance.Draining || isBeingRemoved(instance) || instance.RunnerStatus == params.RunnerActive {
+			continue
+		}
+		switch instance.Status {
+		case commonParams.InstanceRunning, commonParams.InstanceError:
+		default:
+			// Wait for the instance to come up before removing it.
+			continue
+		}
+
+		if r.observe(pool.ID, params.ObservedActionDeleteRunner, instance.Name, "runner was drained") {
+			continue
+		}
+		if !r.keyMux.TryLock(instance.Name) {
+			continue
+		}
+		slog.InfoContext(
+			r.ctx, "removing drained runner",
+			"runner_name", instance.Name,
+			"pool_id", pool.ID)
+		err := r.DeleteRunner(instance, false, false)
+		r.keyMux.Unlock(instance.Name, false)
+		if err != nil {
+			if errors.Is(err, runnerErrors.ErrBadRequest) {
+				// The runner picked up a job in the meantime. It will be removed
+				// once the job is done.
+				slog.DebugContext(
+					r.ctx, "drained runner is busy",
+					"runner_name", instance.Name)
+				continue
+			}
+			return errors.Wrapf(err, "removing runner %s", instance.Name)
+		}
+	}
+	return nil
+}
+
+// maintainPools recycles pools, replaces outdated and expired runners and
+// removes drained runners.
+func (r *basePoolManager) maintainPools() error {
+	if err := r.pruneObservedActions(); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			r.ctx, "failed to prune observed actions")
+	}
+	if err := r.pruneInstanceReaps(); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			r.ctx, "failed to prune instance reaps")
+	}
+
+	pools, err := r.store.ListEntityPools(r.ctx, r.entity)
+	if err != nil {
+		return fmt.Errorf("error listing pools: %w", err)
+	}
+
+	for _, pool := range pools {
+		instances, err := r.store.ListPoolInstances(r.ctx, pool.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list instances for pool %s: %w", pool.ID, err)
+		}
+		if err := r.recycleOnePool(pool, instances); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to recycle pool",
+				"pool_id", pool.ID)
+		}
+		if err := r.rolloutOnePool(pool, instances); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to replace outdated runners",
+				"pool_id", pool.ID)
+		}
+		if err := r.expireReusableRunners(pool, instances); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to expire reusable runners",
+				"pool_id", pool.ID)
+		}
+		// Pick up the runners drained above.
+		instances, err = r.store.ListPoolInstances(r.ctx, pool.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list instances for pool %s: %w", pool.ID, err)
+		}
+		if err := r.drainOnePool(pool, instances); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to drain runners",
+				"pool_id", pool.ID)
+		}
+	}
+	return nil
+}
+
+func (r *basePoolManager) getEntityPool(poolID string) (params.Pool, error) {
+	pool, err := r.store.GetEntityPool(r.ctx, r.entity, poolID)
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "fetching pool")
+	}
+	return pool, nil
+}
+
+func (r *basePoolManager) setPoolCordoned(poolID string, cordoned bool) (params.Pool, error) {
+	if _, err := r.getEntityPool(poolID); err != nil {
+		return params.Pool{}, err
+	}
+	pool, err := r.store.UpdateEntityPool(r.ctx, r.entity, poolID, params.UpdatePoolParams{Cordoned: &cordoned})
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "updating pool")
+	}
+	return pool, nil
+}
+
+// CordonPool stops the creation of new runners in a pool. Existing runners are
+// kept and will still pick up jobs.
+func (r *basePoolManager) CordonPool(poolID string) (params.Pool, error) {
+	return r.setPoolCordoned(poolID, true)
+}
+
+// UncordonPool allows a cordoned pool to create runners again.
+func (r *basePoolManager) UncordonPool(poolID string) (params.Pool, error) {
+	return r.setPoolCordoned(poolID, false)
+}
+
+// DrainRunner marks a runner to be removed once it is no longer running a job.
+// Idle runners are removed by the next maintenance run.
+func (r *basePoolManager) DrainRunner(runner params.Instance) error {
+	if _, err := r.getEntityPool(runner.PoolID); err != nil {
+		return err
+	}
+	if isBeingRemoved(runner) {
+		return runnerErrors.NewBadRequestError("runner %s is already being removed", runner.Name)
+	}
+	return r.setInstanceDraining(runner)
+}
+
+// DrainPool cordons a pool and drains all its runners.
+func (r *basePoolManager) DrainPool(poolID string) (params.Pool, error) {
+	if _, err := r.CordonPool(poolID); err != nil {
+		return params.Pool{}, errors.Wrap(err, "cordoning pool")
+	}
+
+	instances, err := r.store.ListPoolInstances(r.ctx, poolID)
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "listing instances")
+	}
+	for _, instance := range instances {
+		if instance.Draining || isBeingRemoved(instance) {
+			continue
+		}
+		if err := r.setInstanceDraining(instance); err != nil {
+			return params.Pool{}, errors.Wrapf(err, "draining runner %s", instance.Name)
+		}
+	}
+	return r.getEntityPool(poolID)
+}
+
+// RecyclePool replaces all runners of a pool that exist at the time of the
+// call, draining at most param.MaxUnavailable of them at a time. This is useful
+// when the image behind the same image name was updated, which does not start a
+// new pool generation.
+func (r *basePoolManager) RecyclePool(poolID string, param params.RecyclePoolParams) (params.Pool, error) {
+	pool, err := r.getEntityPool(poolID)
+	if err != nil {
+		return params.Pool{}, err
+	}
+	if pool.RecycleRequestedAt != nil {
+		return params.Pool{}, runnerErrors.NewConflictError("pool %s is already being recycled", poolID)
+	}
+
+	now := time.Now().UTC()
+	pool, err = r.store.UpdateEntityPool(r.ctx, r.entity, poolID, params.UpdatePoolParams{
+		Recycle: &params.PoolRecycle{
+			RequestedAt:    &now,
+			MaxUnavailable: param.MaxUnavailable,
+		},
+	})
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "updating pool")
+	}
+	return pool, nil
+}
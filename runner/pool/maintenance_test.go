@@ -0,0 +1,98 @@
+package pool
+
+import (
+	"testing"
+	"time"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+)
+
+func newTestInstance(name string, createdAt time.Time, runnerStatus params.RunnerStatus) params.Instance {
+	return params.Instance{
+		Name:         name,
+		CreatedAt:    createdAt,
+		Status:       commonParams.InstanceRunning,
+		RunnerStatus: runnerStatus,
+	}
+}
+
+func instanceNames(instances []params.Instance) []string {
+	ret := make([]string, len(instances))
+	for idx, instance := range instances {
+		ret[idx] = instance.Name
+	}
+	return ret
+}
+
+func TestRecycleCandidatesNotRecycling(t *testing.T) {
+	now := time.Now().UTC()
+	candidates, done := recycleCandidates(params.Pool{}, []params.Instance{
+		newTestInstance("old", now.Add(-time.Hour), params.RunnerIdle),
+	})
+	if !done || len(candidates) != 0 {
+		t.Fatalf("expected no candidates for a pool that is not recycling, got %v", instanceNames(candidates))
+	}
+}
+
+func TestRecycleCandidatesIdleFirst(t *testing.T) {
+	now := time.Now().UTC()
+	pool := params.Pool{
+		RecycleRequestedAt:    &now,
+		RecycleMaxUnavailable: 2,
+	}
+	instances := []params.Instance{
+		newTestInstance("active", now.Add(-3*time.Minute), params.RunnerActive),
+		newTestInstance("idle-1", now.Add(-2*time.Minute), params.RunnerIdle),
+		newTestInstance("idle-2", now.Add(-1*time.Minute), params.RunnerIdle),
+		// Created after the recycle was requested.
+		newTestInstance("replacement", now.Add(time.Minute), params.RunnerIdle),
+	}
+
+	candidates, done := recycleCandidates(pool, instances)
+	if done {
+		t.Fatalf("expected recycle to be in progress")
+	}
+	names := instanceNames(candidates)
+	if len(names) != 2 || names[0] != "idle-1" || names[1] != "idle-2" {
+		t.Fatalf("expected idle-1 and idle-2, got %v", names)
+	}
+}
+
+func TestRecycleCandidatesMaxUnavailable(t *testing.T) {
+	now := time.Now().UTC()
+	pool := params.Pool{
+		RecycleRequestedAt:    &now,
+		RecycleMaxUnavailable: 2,
+	}
+	draining := newTestInstance("draining", now.Add(-3*time.Minute), params.RunnerActive)
+	draining.Draining = true
+	deleting := newTestInstance("deleting", now.Add(-2*time.Minute), params.RunnerIdle)
+	deleting.Status = commonParams.InstancePendingDelete
+
+	candidates, done := recycleCandidates(pool, []params.Instance{
+		draining,
+		deleting,
+		newTestInstance("idle", now.Add(-1*time.Minute), params.RunnerIdle),
+	})
+	if done {
+		t.Fatalf("expected recycle to be in progress")
+	}
+	if len(candidates) != 0 {
+		t.Fatalf("expected no candidates while max unavailable is reached, got %v", instanceNames(candidates))
+	}
+}
+
+func TestRecycleCandidatesDone(t *testing.T) {
+	now := time.Now().UTC()
+	pool := params.Pool{
+		RecycleRequestedAt: &now,
+	}
+
+	candidates, done := recycleCandidates(pool, []params.Instance{
+		newTestInstance("replacement", now.Add(time.Minute), params.RunnerIdle),
+	})
+	if !done || len(candidates) != 0 {
+		t.Fatalf("expected recycle to be done, got %v", instanceNames(candidates))
+	}
+}
@@ -0,0 +1,89 @@
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudbase/garm/params"
+)
+
+// observedActionRetention is how long we keep actions that were not observed
+// again.
+const observedActionRetention = 7 * 24 * time.Hour
+
+// observing returns true if the pool manager should only record the actions it
+// would take, instead of calling the providers or GitHub. Observe only mode can
+// be enabled for the whole controller or for a single entity.
+func (r *basePoolManager) observing() bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return r.controllerInfo.ObserveOnly || r.entity.ObserveOnly
+}
+
+// observe records an action the pool manager would take, if it runs in observe
+// only mode. It returns true if the action was recorded, in which case the
+// caller must not take it.
+func (r *basePoolManager) observe(poolID string, action params.ObservedActionType, target, reason string) bool {
+	if !r.observing() {
+		return false
+	}
+
+	slog.InfoContext(
+		r.ctx, "observe only mode; skipping action",
+		"pool_id", poolID,
+		"action", action,
+		"target", target,
+		"reason", reason)
+	recordParams := params.RecordObservedActionParams{
+		PoolID: poolID,
+		Action: action,
+		Target: target,
+		Reason: reason,
+	}
+	if err := r.store.RecordObservedAction(r.ctx, r.entity, recordParams); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			r.ctx, "failed to record observed action",
+			"pool_id", poolID,
+			"action", action)
+	}
+	return true
+}
+
+// observeQueuedJob records the runner that would be created for a queued job,
+// in the first pool that could take it.
+func (r *basePoolManager) observeQueuedJob(job params.Job, pools poolCacheStore) {
+	target := fmt.Sprintf("job %d", job.ID)
+	for i := 0; i < pools.Len(); i++ {
+		pool, err := pools.Next()
+		if err != nil {
+			break
+		}
+		if !pool.HandlesRepository(job.RepositoryOwner, job.RepositoryName) || poolAllowsJob(pool, job) != nil {
+			continue
+		}
+		if err := r.canAddRunnerToPool(pool); err != nil {
+			slog.DebugContext(
+				r.ctx, "pool can not take job",
+				"pool_id", pool.ID,
+				"job_id", job.ID,
+				"reason", err)
+			continue
+		}
+		r.observe(pool.ID, params.ObservedActionCreateRunner, target, "job is queued")
+		return
+	}
+	r.observe("", params.ObservedActionCreateRunner, target, "job is queued, but no matching pool can take it")
+}
+
+// pruneObservedActions removes the actions that were not observed for a while,
+// so the list only shows what the pool manager would currently do.
+func (r *basePoolManager) pruneObservedActions() error {
+	olderThan := time.Now().UTC().Add(-observedActionRetention)
+	if err := r.store.DeleteEntityObservedActions(r.ctx, r.entity, olderThan); err != nil {
+		return errors.Wrap(err, "deleting observed actions")
+	}
+	return nil
+}
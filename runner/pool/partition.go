@@ -0,0 +1,150 @@
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+
+	"github.com/cloudbase/garm/params"
+)
+
+// jobInPartition returns true if the controller should handle the job. Jobs
+// must request the partition label, and belong to one of the partition
+// repositories, if set.
+func jobInPartition(info params.ControllerInfo, job params.WorkflowJob) bool {
+	if info.PartitionLabel != "" {
+		found := false
+		for _, label := range job.WorkflowJob.Labels {
+			if strings.EqualFold(label, info.PartitionLabel) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(info.PartitionRepositories) == 0 {
+		return true
+	}
+	fullName := fmt.Sprintf("%s/%s", job.Repository.Owner.Login, job.Repository.Name)
+	for _, repo := range info.PartitionRepositories {
+		if strings.EqualFold(repo, fullName) {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutLabel returns labels without the given label. The partition label is
+// added to all runners of the controller, so it is not a tag of any pool.
+func withoutLabel(labels []string, label string) []string {
+	if label == "" {
+		return labels
+	}
+	ret := make([]string, 0, len(labels))
+	for _, val := range labels {
+		if strings.EqualFold(val, label) {
+			continue
+		}
+		ret = append(ret, val)
+	}
+	return ret
+}
+
+// isInternalLabel returns true for the labels GARM adds to runners to keep track
+// of them.
+func isInternalLabel(label string) bool {
+	return strings.HasPrefix(label, controllerLabelPrefix) ||
+		strings.HasPrefix(label, poolIDLabelprefix) ||
+		strings.HasPrefix(label, jobLabelPrefix)
+}
+
+// controllerConflicts returns the other GARM controllers that registered runners
+// on the entity, along with the labels of those runners that are also tags of
+// our pools.
+func controllerConflicts(controllerID string, runners []*github.Runner, pools []params.Pool) []params.ControllerConflict {
+	poolTags := map[string]bool{}
+	for _, pool := range pools {
+		for _, tag := range pool.Tags {
+			poolTags[strings.ToLower(tag.Name)] = true
+		}
+	}
+
+	conflicts := map[string]*params.ControllerConflict{}
+	for _, runner := range runners {
+		labels := labelsFromRunner(runner)
+		runnerControllerID := controllerIDFromLabels(labels)
+		if runnerControllerID == "" || runnerControllerID == controllerID {
+			continue
+		}
+
+		conflict, ok := conflicts[runnerControllerID]
+		if !ok {
+			conflict = &params.ControllerConflict{ControllerID: runnerControllerID}
+			conflicts[runnerControllerID] = conflict
+		}
+		conflict.Runners++
+		for _, label := range labels {
+			if isInternalLabel(label) || !poolTags[strings.ToLower(label)] {
+				continue
+			}
+			if !slices.Contains(conflict.OverlappingLabels, label) {
+				conflict.OverlappingLabels = append(conflict.OverlappingLabels, label)
+			}
+		}
+	}
+
+	ret := make([]params.ControllerConflict, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		sort.Strings(conflict.OverlappingLabels)
+		ret = append(ret, *conflict)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ControllerID < ret[j].ControllerID
+	})
+	return ret
+}
+
+// partitionLabel returns the label jobs must request to be handled by this
+// controller, if any.
+func (r *basePoolManager) partitionLabel() string {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return r.controllerInfo.PartitionLabel
+}
+
+// detectControllerConflicts records the other GARM controllers that have runners
+// on the entity. Such controllers may compete with us for the same jobs.
+func (r *basePoolManager) detectControllerConflicts(runners []*github.Runner) error {
+	pools, err := r.store.ListEntityPools(r.ctx, r.entity)
+	if err != nil {
+		return fmt.Errorf("error listing pools: %w", err)
+	}
+
+	r.mux.Lock()
+	conflicts := controllerConflicts(r.controllerInfo.ControllerID.String(), runners, pools)
+	previous := map[string]bool{}
+	for _, conflict := range r.controllerConflicts {
+		previous[conflict.ControllerID] = true
+	}
+	r.controllerConflicts = conflicts
+	r.mux.Unlock()
+
+	for _, conflict := range conflicts {
+		if previous[conflict.ControllerID] {
+			continue
+		}
+		slog.WarnContext(
+			r.ctx, "another GARM controller has runners on this entity",
+			"controller_id", conflict.ControllerID,
+			"runners", conflict.Runners,
+			"overlapping_labels", strings.Join(conflict.OverlappingLabels, ","))
+	}
+	return nil
+}
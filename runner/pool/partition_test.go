@@ -0,0 +1,105 @@
+package pool
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+
+	"github.com/cloudbase/garm/params"
+)
+
+func testPartitionJob(owner, repo string, labels ...string) params.WorkflowJob {
+	var job params.WorkflowJob
+	job.Repository.Owner.Login = owner
+	job.Repository.Name = repo
+	job.WorkflowJob.Labels = labels
+	return job
+}
+
+func TestJobInPartition(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     params.ControllerInfo
+		job      params.WorkflowJob
+		expected bool
+	}{
+		{
+			name:     "no partition",
+			job:      testPartitionJob("org", "repo", "self-hosted"),
+			expected: true,
+		},
+		{
+			name:     "label matches",
+			info:     params.ControllerInfo{PartitionLabel: "garm-east"},
+			job:      testPartitionJob("org", "repo", "self-hosted", "GARM-East"),
+			expected: true,
+		},
+		{
+			name: "label missing",
+			info: params.ControllerInfo{PartitionLabel: "garm-east"},
+			job:  testPartitionJob("org", "repo", "self-hosted"),
+		},
+		{
+			name:     "repository matches",
+			info:     params.ControllerInfo{PartitionRepositories: []string{"org/other", "Org/Repo"}},
+			job:      testPartitionJob("org", "repo", "self-hosted"),
+			expected: true,
+		},
+		{
+			name: "repository missing",
+			info: params.ControllerInfo{PartitionRepositories: []string{"org/other"}},
+			job:  testPartitionJob("org", "repo", "self-hosted"),
+		},
+		{
+			name: "label matches but repository missing",
+			info: params.ControllerInfo{PartitionLabel: "garm-east", PartitionRepositories: []string{"org/other"}},
+			job:  testPartitionJob("org", "repo", "garm-east"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := jobInPartition(tc.info, tc.job); got != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestWithoutLabel(t *testing.T) {
+	labels := withoutLabel([]string{"self-hosted", "GARM-East", "linux"}, "garm-east")
+	if len(labels) != 2 || labels[0] != "self-hosted" || labels[1] != "linux" {
+		t.Fatalf("unexpected labels: %v", labels)
+	}
+	if labels := withoutLabel([]string{"self-hosted"}, ""); len(labels) != 1 {
+		t.Fatalf("unexpected labels: %v", labels)
+	}
+}
+
+func TestControllerConflicts(t *testing.T) {
+	pools := []params.Pool{
+		{Tags: []params.Tag{{Name: "self-hosted"}, {Name: "linux"}}},
+	}
+	runners := []*github.Runner{
+		testRunner("ours", false, controllerLabelPrefix+"ours", "linux"),
+		testRunner("unmanaged", false, "linux"),
+		testRunner("theirs-a", false, controllerLabelPrefix+"theirs", poolIDLabelprefix+"pool", "linux", "gpu"),
+		testRunner("theirs-b", true, controllerLabelPrefix+"theirs", "self-hosted", "linux"),
+		testRunner("another", false, controllerLabelPrefix+"another", "windows"),
+	}
+
+	conflicts := controllerConflicts("ours", runners, pools)
+	if len(conflicts) != 2 {
+		t.Fatalf("expected 2 conflicts, got %+v", conflicts)
+	}
+	if conflicts[0].ControllerID != "another" || conflicts[0].Runners != 1 || len(conflicts[0].OverlappingLabels) != 0 {
+		t.Fatalf("unexpected conflict: %+v", conflicts[0])
+	}
+	theirs := conflicts[1]
+	if theirs.ControllerID != "theirs" || theirs.Runners != 2 {
+		t.Fatalf("unexpected conflict: %+v", theirs)
+	}
+	if len(theirs.OverlappingLabels) != 2 || theirs.OverlappingLabels[0] != "linux" || theirs.OverlappingLabels[1] != "self-hosted" {
+		t.Fatalf("unexpected overlapping labels: %v", theirs.OverlappingLabels)
+	}
+}
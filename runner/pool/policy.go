@@ -0,0 +1,91 @@
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudbase/garm/params"
+)
+
+// poolAllowsJob returns an error if the policy of the pool does not allow a
+// runner to be created for the job.
+func poolAllowsJob(pool params.Pool, job params.Job) error {
+	if pool.Policy == nil {
+		return nil
+	}
+	return pool.Policy.Evaluate(job)
+}
+
+// policyDenial returns why none of the pools that handle the repository of the
+// job allow a runner to be created for it. It returns an empty string if at
+// least one of the pools allows the job.
+func policyDenial(pools []params.Pool, job params.Job) string {
+	var denials []string
+	for _, pool := range pools {
+		if !pool.HandlesRepository(job.RepositoryOwner, job.RepositoryName) {
+			continue
+		}
+		if err := poolAllowsJob(pool, job); err != nil {
+			denials = append(denials, fmt.Sprintf("pool %s: %s", pool.ID, err))
+			continue
+		}
+		return ""
+	}
+	return strings.Join(denials, "; ")
+}
+
+// fetchWorkflowRun adds the event and the workflow path of the workflow run to
+// the job, if the policy of any of the pools needs them. They are not part of
+// the workflow_job webhook.
+func (r *basePoolManager) fetchWorkflowRun(job *params.Job, pools []params.Pool) error {
+	if job.Event != "" || job.RunID == 0 {
+		return nil
+	}
+
+	needed := false
+	for _, pool := range pools {
+		if pool.Policy != nil && pool.Policy.NeedsWorkflowRun() {
+			needed = true
+			break
+		}
+	}
+	if !needed {
+		return nil
+	}
+
+	run, _, err := r.ghcli.GetWorkflowRunByID(r.ctx, job.RepositoryOwner, job.RepositoryName, job.RunID)
+	if err != nil {
+		return errors.Wrap(err, "fetching workflow run")
+	}
+	workflow, _, err := r.ghcli.GetWorkflowByID(r.ctx, job.RepositoryOwner, job.RepositoryName, run.GetWorkflowID())
+	if err != nil {
+		return errors.Wrap(err, "fetching workflow")
+	}
+	job.Event = run.GetEvent()
+	job.WorkflowPath = workflow.GetPath()
+	if err := r.store.SetJobWorkflowRun(r.ctx, job.ID, job.Event, job.WorkflowPath); err != nil {
+		return errors.Wrap(err, "recording workflow run")
+	}
+	return nil
+}
+
+// setPolicyDenial records why no runner was created for the job, if it changed.
+func (r *basePoolManager) setPolicyDenial(job params.Job, denial string) {
+	if job.PolicyDenial == denial {
+		return
+	}
+	if denial != "" {
+		slog.WarnContext(
+			r.ctx, "pool policies do not allow a runner for job",
+			"job_id", job.ID,
+			"reason", denial)
+	}
+	if err := r.store.SetJobPolicyDenial(r.ctx, job.ID, denial); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			r.ctx, "failed to record policy denial",
+			"job_id", job.ID)
+	}
+}
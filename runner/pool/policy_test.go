@@ -0,0 +1,112 @@
+package pool
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cloudbase/garm/params"
+)
+
+func TestPoolPolicyEvaluate(t *testing.T) {
+	policy := params.PoolPolicy{
+		Branches:      []string{"main", "release/*"},
+		Workflows:     []string{"Deploy*"},
+		WorkflowPaths: []string{".github/workflows/deploy-*.yml"},
+		Events:        []string{"push", "workflow_dispatch"},
+		Actors:        []string{"OctoCat"},
+		MaxRunAttempt: 2,
+	}
+	allowed := params.Job{
+		HeadBranch:   "release/1.0",
+		WorkflowName: "Deploy production",
+		WorkflowPath: ".github/workflows/deploy-prod.yml",
+		Event:        "push",
+		Actor:        "octocat",
+		RunAttempt:   2,
+	}
+	if err := policy.Evaluate(allowed); err != nil {
+		t.Fatalf("expected job to be allowed, got %s", err)
+	}
+
+	tests := map[string]func(job *params.Job){
+		"branch":        func(job *params.Job) { job.HeadBranch = "feature/x" },
+		"missing":       func(job *params.Job) { job.HeadBranch = "" },
+		"workflow":      func(job *params.Job) { job.WorkflowName = "Build" },
+		"workflow path": func(job *params.Job) { job.WorkflowPath = ".github/workflows/build.yml" },
+		"event":         func(job *params.Job) { job.Event = "pull_request_target" },
+		"actor":         func(job *params.Job) { job.Actor = "mallory" },
+		"run attempt":   func(job *params.Job) { job.RunAttempt = 3 },
+	}
+	for name, change := range tests {
+		t.Run(name, func(t *testing.T) {
+			job := allowed
+			change(&job)
+			if err := policy.Evaluate(job); err == nil {
+				t.Fatalf("expected job to be denied")
+			}
+		})
+	}
+}
+
+func TestPolicyDenial(t *testing.T) {
+	job := params.Job{RepositoryOwner: "org", RepositoryName: "repo", HeadBranch: "feature"}
+	mainOnly := &params.PoolPolicy{Branches: []string{"main"}}
+	pools := []params.Pool{
+		{ID: "main-only", Policy: mainOnly},
+		{ID: "other-repos", ExcludedRepositories: []string{"org/repo"}},
+	}
+
+	denial := policyDenial(pools, job)
+	if !strings.Contains(denial, "main-only") || strings.Contains(denial, "other-repos") {
+		t.Fatalf("unexpected denial: %q", denial)
+	}
+
+	pools = append(pools, params.Pool{ID: "no-policy"})
+	if denial := policyDenial(pools, job); denial != "" {
+		t.Fatalf("expected job to be allowed, got %q", denial)
+	}
+}
+
+func TestPoolPolicyBranchesDenyPullRequests(t *testing.T) {
+	policy := params.PoolPolicy{Branches: []string{"main"}}
+
+	tests := []struct {
+		event   string
+		allowed bool
+	}{
+		{"push", true},
+		{"workflow_dispatch", true},
+		// A pull request from a fork may use a branch called main.
+		{"pull_request", false},
+		{"pull_request_target", false},
+		// The workflow run was not fetched.
+		{"", false},
+	}
+	for _, tc := range tests {
+		job := params.Job{HeadBranch: "main", Event: tc.event}
+		err := policy.Evaluate(job)
+		if tc.allowed && err != nil {
+			t.Fatalf("expected %q job to be allowed, got %s", tc.event, err)
+		}
+		if !tc.allowed && err == nil {
+			t.Fatalf("expected %q job to be denied", tc.event)
+		}
+	}
+
+	if !policy.NeedsWorkflowRun() {
+		t.Fatalf("expected branch rules to need the workflow run")
+	}
+}
+
+func TestValidateIdleRunnersPolicy(t *testing.T) {
+	policy := &params.PoolPolicy{Branches: []string{"main"}}
+	if err := params.ValidateIdleRunnersPolicy(1, policy); err == nil {
+		t.Fatalf("expected idle runners to be rejected for pools with a policy")
+	}
+	if err := params.ValidateIdleRunnersPolicy(0, policy); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := params.ValidateIdleRunnersPolicy(1, &params.PoolPolicy{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
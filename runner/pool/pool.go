@@ -20,6 +20,7 @@ import (
 	"log/slog"
 	"math"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -124,6 +125,9 @@ type basePoolManager struct {
 
 	managerIsRunning   bool
 	managerErrorReason string
+	// controllerConflicts holds the other controllers that have runners
+	// on the entity.
+	controllerConflicts []params.ControllerConflict
 
 	mux    sync.Mutex
 	wg     *sync.WaitGroup
@@ -140,7 +144,7 @@ func (r *basePoolManager) getProviderBaseParams(pool params.Pool) common.Provide
 	}
 }
 
-func (r *basePoolManager) HandleWorkflowJob(job params.WorkflowJob) error {
+func (r *basePoolManager) HandleWorkflowJob(job params.WorkflowJob) (err error) {
 	if err := r.ValidateOwner(job); err != nil {
 		return errors.Wrap(err, "validating owner")
 	}
@@ -152,15 +156,30 @@ func (r *basePoolManager) HandleWorkflowJob(job params.WorkflowJob) error {
 		return nil
 	}
 
+	shouldHandle, err := r.shouldHandleJobAction(job)
+	if err != nil {
+		return errors.Wrap(err, "checking job status")
+	}
+	if !shouldHandle {
+		if job.Action == "in_progress" {
+			// The job moved past in_progress, but the runner that picked it up may still
+			// hold the lock on the job it was created for.
+			r.breakLockOfRunnerJob(job.WorkflowJob.RunnerName, job.WorkflowJob.ID)
+		}
+		return nil
+	}
+
 	var jobParams params.Job
-	var err error
 	var triggeredBy int64
 	defer func() {
-		// we're updating the job in the database, regardless of whether it was successful or not.
-		// or if it was meant for this pool or not. Github will send the same job data to all hierarchies
-		// that have been configured to work with garm. Updating the job at all levels should yield the same
-		// outcome in the db.
-		if jobParams.ID == 0 {
+		// we're updating the job in the database, regardless of whether it was meant for this pool
+		// or not. Github will send the same job data to all hierarchies that have been configured
+		// to work with garm. Stale and duplicate events were already filtered out by
+		// shouldHandleJobAction(), so updating the job at all levels yields the same outcome in
+		// the db, regardless of ordering.
+		// Events we failed to handle are not recorded. Recording them would make the retry of the
+		// same delivery look like a duplicate.
+		if err != nil || jobParams.ID == 0 {
 			return
 		}
 
@@ -173,7 +192,7 @@ func (r *basePoolManager) HandleWorkflowJob(job params.WorkflowJob) error {
 				return
 			}
 			// This job is new to us. Check if we have a pool that can handle it.
-			potentialPools, err := r.store.FindPoolsMatchingAllTags(r.ctx, r.entity.EntityType, r.entity.ID, jobParams.Labels)
+			potentialPools, err := r.store.FindPoolsMatchingAllTags(r.ctx, r.entity.EntityType, r.entity.ID, withoutLabel(jobParams.Labels, r.partitionLabel()))
 			if err != nil {
 				slog.With(slog.Any("error", err)).WarnContext(
 					r.ctx, "failed to find pools matching tags; not recording job",
@@ -186,6 +205,13 @@ func (r *basePoolManager) HandleWorkflowJob(job params.WorkflowJob) error {
 					"requested_tags", strings.Join(jobParams.Labels, ", "))
 				return
 			}
+			if !poolsHandleRepository(potentialPools, jobParams.RepositoryOwner, jobParams.RepositoryName) {
+				slog.WarnContext(
+					r.ctx, "no pools matching tags handle jobs of this repository; not recording job",
+					"requested_tags", strings.Join(jobParams.Labels, ", "),
+					"repository", jobParams.RepositoryOwner+"/"+jobParams.RepositoryName)
+				return
+			}
 		}
 
 		if _, jobErr := r.store.CreateOrUpdateJob(r.ctx, jobParams); jobErr != nil {
@@ -198,16 +224,21 @@ func (r *basePoolManager) HandleWorkflowJob(job params.WorkflowJob) error {
 			// transitioned to in_progress was created as a result of a different queued job. If that job is
 			// still queued and we don't remove the lock, it will linger until the lock timeout is reached.
 			// That may take a long time, so we break the lock here and allow it to be scheduled again.
-			if err := r.store.BreakLockJobIsQueued(r.ctx, triggeredBy); err != nil {
-				slog.With(slog.Any("error", err)).ErrorContext(
-					r.ctx, "failed to break lock for job",
-					"job_id", triggeredBy)
-			}
+			r.breakJobLock(triggeredBy)
 		}
 	}()
 
 	switch job.Action {
 	case "queued":
+		r.mux.Lock()
+		inPartition := jobInPartition(r.controllerInfo, job)
+		r.mux.Unlock()
+		if !inPartition {
+			slog.DebugContext(
+				r.ctx, "job is not in the partition of this controller; ignoring",
+				"job_id", job.WorkflowJob.ID)
+			return nil
+		}
 		// Record the job in the database. Queued jobs will be picked up by the consumeQueuedJobs() method
 		// when reconciling.
 		jobParams, err = r.paramsWorkflowJobToParamsJob(job)
@@ -228,13 +259,40 @@ func (r *basePoolManager) HandleWorkflowJob(job params.WorkflowJob) error {
 
 		// If job was not assigned to a runner, we can ignore it.
 		if jobParams.RunnerName == "" {
+			if jobParams.Conclusion == "cancelled" {
+				// The job was cancelled before a runner picked it up. Any runner we spun up
+				// in response to this job is no longer needed.
+				if err := r.removeRunnersForCancelledJob(jobParams.ID); err != nil {
+					slog.With(slog.Any("error", err)).ErrorContext(
+						r.ctx, "failed to remove runners for cancelled job",
+						"job_id", jobParams.ID)
+				}
+				return nil
+			}
 			slog.InfoContext(
 				r.ctx, "job never got assigned to a runner, ignoring")
 			return nil
 		}
 
+		r.recordJobUsage(jobParams)
+
+		// Runners of reusable pools go back to idle, unless they reached their limits.
+		reused, err := r.reuseRunner(jobParams.RunnerName)
+		if err != nil && !errors.Is(err, runnerErrors.ErrNotFound) {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to return runner to idle",
+				"runner_name", util.SanitizeLogEntry(jobParams.RunnerName))
+		}
+		if reused {
+			slog.DebugContext(
+				r.ctx, "returned reusable runner to idle",
+				"runner_name", util.SanitizeLogEntry(jobParams.RunnerName))
+			return nil
+		}
+
 		// update instance workload state.
-		if _, err := r.setInstanceRunnerStatus(jobParams.RunnerName, params.RunnerTerminated); err != nil {
+		instance, err := r.setInstanceRunnerStatus(jobParams.RunnerName, params.RunnerTerminated)
+		if err != nil {
 			if errors.Is(err, runnerErrors.ErrNotFound) {
 				return nil
 			}
@@ -243,6 +301,9 @@ func (r *basePoolManager) HandleWorkflowJob(job params.WorkflowJob) error {
 				"runner_name", util.SanitizeLogEntry(jobParams.RunnerName))
 			return errors.Wrap(err, "updating runner")
 		}
+		if r.observe(instance.PoolID, params.ObservedActionDeleteRunner, instance.Name, fmt.Sprintf("job %d completed", jobParams.ID)) {
+			return nil
+		}
 		slog.DebugContext(
 			r.ctx, "marking instance as pending_delete",
 			"runner_name", util.SanitizeLogEntry(jobParams.RunnerName))
@@ -298,6 +359,189 @@ func (r *basePoolManager) HandleWorkflowJob(job params.WorkflowJob) error {
 	return nil
 }
 
+func (r *basePoolManager) breakJobLock(jobID int64) {
+	if err := r.store.BreakLockJobIsQueued(r.ctx, jobID); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			r.ctx, "failed to break lock for job",
+			"job_id", jobID)
+	}
+}
+
+// breakLockOfRunnerJob breaks the lock on the queued job the runner was created for,
+// if the runner picked up a different job.
+func (r *basePoolManager) breakLockOfRunnerJob(runnerName string, jobID int64) {
+	if runnerName == "" {
+		return
+	}
+	instance, err := r.store.GetInstanceByName(r.ctx, runnerName)
+	if err != nil {
+		if !errors.Is(err, runnerErrors.ErrNotFound) {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to get runner",
+				"runner_name", util.SanitizeLogEntry(runnerName))
+		}
+		return
+	}
+	if triggeredBy := jobIDFromLabels(instance.AditionalLabels); triggeredBy != 0 && triggeredBy != jobID {
+		r.breakJobLock(triggeredBy)
+	}
+}
+
+// jobRecordedForEntity returns true if the job was already recorded as having been
+// received by the entity this pool manager is responsible for.
+func (r *basePoolManager) jobRecordedForEntity(job params.Job) bool {
+	var entityID *uuid.UUID
+	switch r.entity.EntityType {
+	case params.GithubEntityTypeRepository:
+		entityID = job.RepoID
+	case params.GithubEntityTypeOrganization:
+		entityID = job.OrgID
+	case params.GithubEntityTypeEnterprise:
+		entityID = job.EnterpriseID
+	}
+	return entityID != nil && entityID.String() == r.entity.ID
+}
+
+// shouldHandleJobAction checks the webhook against the job we have recorded in the
+// database. GitHub may deliver the same event more than once, and does not guarantee
+// the order of deliveries. A completed event may arrive before the in_progress event
+// of the same job. Events that would move a job back to a previous status are stale
+// and are ignored, as are events this entity has already handled. The same event
+// received by another entity (a repo and its org) is still handled, as each
+// entity needs to record the job.
+func (r *basePoolManager) shouldHandleJobAction(job params.WorkflowJob) (bool, error) {
+	dbJob, err := r.store.GetJobByID(r.ctx, job.WorkflowJob.ID)
+	if err != nil {
+		if errors.Is(err, runnerErrors.ErrNotFound) {
+			return true, nil
+		}
+		return false, errors.Wrap(err, "fetching job")
+	}
+
+	current := params.JobStatus(dbJob.Status)
+	next := params.JobStatus(job.Action)
+	if !current.CanTransitionTo(next) {
+		slog.InfoContext(
+			r.ctx, "ignoring stale workflow job event",
+			"job_id", job.WorkflowJob.ID, "current_status", current, "action", job.Action)
+		return false, nil
+	}
+
+	if current == next && r.jobRecordedForEntity(dbJob) {
+		slog.DebugContext(
+			r.ctx, "ignoring duplicate workflow job event",
+			"job_id", job.WorkflowJob.ID, "action", job.Action)
+		return false, nil
+	}
+	return true, nil
+}
+
+// instanceNeededForMinIdle returns true if the instance is needed to maintain the minimum
+// number of idle runners in its pool.
+func (r *basePoolManager) instanceNeededForMinIdle(instance params.Instance) (bool, error) {
+	pool, err := r.store.GetEntityPool(r.ctx, r.entity, instance.PoolID)
+	if err != nil {
+		return false, errors.Wrap(err, "fetching pool")
+	}
+	if pool.MinIdleRunners == 0 {
+		return false, nil
+	}
+
+	existingInstances, err := r.store.ListPoolInstances(r.ctx, pool.ID)
+	if err != nil {
+		return false, errors.Wrap(err, "listing pool instances")
+	}
+
+	var idleOrPending uint
+	for _, inst := range existingInstances {
+		if inst.ID == instance.ID {
+			continue
+		}
+		if inst.RunnerStatus != params.RunnerActive && inst.RunnerStatus != params.RunnerTerminated {
+			idleOrPending++
+		}
+	}
+	return idleOrPending < pool.MinIdleRunners, nil
+}
+
+// removeRunnersForCancelledJob tears down runners that were created in response to a job
+// which was cancelled before any runner picked it up. Only runners that are still pending
+// or installing are removed, and only if they are not needed to maintain the minimum
+// number of idle runners in their pool.
+func (r *basePoolManager) removeRunnersForCancelledJob(jobID int64) error {
+	job, err := r.store.GetJobByID(r.ctx, jobID)
+	if err != nil {
+		if errors.Is(err, runnerErrors.ErrNotFound) {
+			return nil
+		}
+		return errors.Wrap(err, "fetching job")
+	}
+
+	if job.LockedBy.String() != r.ID() {
+		// We did not create a runner for this job.
+		return nil
+	}
+
+	instances, err := r.store.ListEntityInstances(r.ctx, r.entity)
+	if err != nil {
+		return errors.Wrap(err, "listing instances")
+	}
+
+	for _, instance := range instances {
+		if jobIDFromLabels(instance.AditionalLabels) != jobID {
+			continue
+		}
+
+		if instance.RunnerStatus != params.RunnerPending && instance.RunnerStatus != params.RunnerInstalling {
+			continue
+		}
+
+		switch instance.Status {
+		case commonParams.InstancePendingDelete, commonParams.InstancePendingForceDelete, commonParams.InstanceDeleting:
+			continue
+		}
+
+		needed, err := r.instanceNeededForMinIdle(instance)
+		if err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to check if runner is needed",
+				"runner_name", instance.Name)
+			continue
+		}
+		if needed {
+			slog.InfoContext(
+				r.ctx, "keeping runner of cancelled job as idle runner",
+				"runner_name", instance.Name,
+				"job_id", jobID)
+			continue
+		}
+
+		if r.observe(instance.PoolID, params.ObservedActionDeleteRunner, instance.Name, fmt.Sprintf("job %d was cancelled", jobID)) {
+			continue
+		}
+
+		if !r.keyMux.TryLock(instance.Name) {
+			slog.DebugContext(
+				r.ctx, "failed to acquire lock for instance",
+				"runner_name", instance.Name)
+			continue
+		}
+
+		slog.InfoContext(
+			r.ctx, "removing runner created for cancelled job",
+			"runner_name", instance.Name,
+			"job_id", jobID)
+		err = r.DeleteRunner(instance, false, false)
+		r.keyMux.Unlock(instance.Name, false)
+		if err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to remove runner",
+				"runner_name", instance.Name)
+		}
+	}
+	return nil
+}
+
 func jobIDFromLabels(labels []string) int64 {
 	for _, lbl := range labels {
 		if strings.HasPrefix(lbl, jobLabelPrefix) {
@@ -383,6 +627,30 @@ func (r *basePoolManager) updateTools() error {
 	return err
 }
 
+// managedRunnersByName returns the GitHub runners managed by this controller, by name.
+// Adopted instances may have been created by another controller or autoscaler, so
+// their runners are included regardless of the controller ID label.
+func (r *basePoolManager) managedRunnersByName(runners []*github.Runner, instances []params.Instance) map[string]*github.Runner {
+	adopted := map[string]bool{}
+	for _, instance := range instances {
+		if instance.Adopted {
+			adopted[instance.Name] = true
+		}
+	}
+
+	runnersByName := map[string]*github.Runner{}
+	for _, run := range runners {
+		if !adopted[run.GetName()] && !isManagedRunner(labelsFromRunner(run), r.controllerInfo.ControllerID.String()) {
+			slog.DebugContext(
+				r.ctx, "runner is not managed by a pool we manage",
+				"runner_name", run.GetName())
+			continue
+		}
+		runnersByName[run.GetName()] = run
+	}
+	return runnersByName
+}
+
 // cleanupOrphanedProviderRunners compares runners in github with local runners and removes
 // any local runners that are not present in Github. Runners that are "idle" in our
 // provider, but do not exist in github, will be removed. This can happen if the
@@ -396,16 +664,7 @@ func (r *basePoolManager) cleanupOrphanedProviderRunners(runners []*github.Runne
 		return errors.Wrap(err, "fetching instances from db")
 	}
 
-	runnerNames := map[string]bool{}
-	for _, run := range runners {
-		if !isManagedRunner(labelsFromRunner(run), r.controllerInfo.ControllerID.String()) {
-			slog.DebugContext(
-				r.ctx, "runner is not managed by a pool we manage",
-				"runner_name", run.GetName())
-			continue
-		}
-		runnerNames[*run.Name] = true
-	}
+	runnersByName := r.managedRunnersByName(runners, dbInstances)
 
 	for _, instance := range dbInstances {
 		lockAcquired := r.keyMux.TryLock(instance.Name)
@@ -450,7 +709,12 @@ func (r *basePoolManager) cleanupOrphanedProviderRunners(runners []*github.Runne
 			continue
 		}
 
-		if ok := runnerNames[instance.Name]; !ok {
+		if _, ok := runnersByName[instance.Name]; !ok {
+			reason := fmt.Sprintf("runner is not registered in GitHub (last stage: %s)", instance.LastStage())
+			if r.observe(instance.PoolID, params.ObservedActionDeleteRunner, instance.Name, reason) {
+				continue
+			}
+			r.recordReap(instance, reason, nil)
 			// Set pending_delete on DB field. Allow consolidate() to remove it.
 			if _, err := r.setInstanceStatus(instance.Name, commonParams.InstancePendingDelete, nil); err != nil {
 				slog.With(slog.Any("error", err)).ErrorContext(
@@ -472,16 +736,7 @@ func (r *basePoolManager) reapTimedOutRunners(runners []*github.Runner) error {
 		return errors.Wrap(err, "fetching instances from db")
 	}
 
-	runnersByName := map[string]*github.Runner{}
-	for _, run := range runners {
-		if !isManagedRunner(labelsFromRunner(run), r.controllerInfo.ControllerID.String()) {
-			slog.DebugContext(
-				r.ctx, "runner is not managed by a pool we manage",
-				"runner_name", run.GetName())
-			continue
-		}
-		runnersByName[*run.Name] = run
-	}
+	runnersByName := r.managedRunnersByName(runners, dbInstances)
 
 	for _, instance := range dbInstances {
 		slog.DebugContext(
@@ -510,9 +765,11 @@ func (r *basePoolManager) reapTimedOutRunners(runners []*github.Runner) error {
 		//     never started on the instance.
 		//   * A JIT config was created, but the runner never joined github.
 		if runner, ok := runnersByName[instance.Name]; !ok || runner.GetStatus() == "offline" {
-			slog.InfoContext(
-				r.ctx, "reaping timed-out/failed runner",
-				"runner_name", instance.Name)
+			reason := reapReason(instance, runner, pool.RunnerTimeout())
+			if r.observe(pool.ID, params.ObservedActionDeleteRunner, instance.Name, reason) {
+				continue
+			}
+			r.recordReap(instance, reason, r.reapedConsoleOutput(pool, instance))
 			if err := r.DeleteRunner(instance, false, false); err != nil {
 				slog.With(slog.Any("error", err)).ErrorContext(
 					r.ctx, "failed to update runner status",
@@ -552,6 +809,9 @@ func (r *basePoolManager) cleanupOrphanedGithubRunners(runners []*github.Runner)
 			}
 			// We no longer have a DB entry for this instance, and the runner appears offline in github.
 			// Previous forceful removal may have failed?
+			if r.observe("", params.ObservedActionRemoveGithubRunner, runner.GetName(), "offline runner has no database entry") {
+				continue
+			}
 			slog.InfoContext(
 				r.ctx, "Runner has no database entry in garm, removing from github",
 				"runner_name", runner.GetName())
@@ -637,6 +897,9 @@ func (r *basePoolManager) cleanupOrphanedGithubRunners(runners []*github.Runner)
 			if !ok {
 				// The runner instance is no longer on the provider, and it appears offline in github.
 				// It should be safe to force remove it.
+				if r.observe(pool.ID, params.ObservedActionRemoveGithubRunner, dbInstance.Name, "offline runner is no longer on the provider") {
+					return nil
+				}
 				slog.InfoContext(
 					r.ctx, "Runner instance is no longer on the provider, removing from github",
 					"runner_name", dbInstance.Name)
@@ -655,6 +918,7 @@ func (r *basePoolManager) cleanupOrphanedGithubRunners(runners []*github.Runner)
 				slog.InfoContext(
 					r.ctx, "Removing from database",
 					"runner_name", dbInstance.Name)
+				r.recordRemovedRunnerUsage(dbInstance)
 				if err := r.store.DeleteInstance(ctx, dbInstance.PoolID, dbInstance.Name); err != nil {
 					return errors.Wrap(err, "removing runner from database")
 				}
@@ -672,6 +936,9 @@ func (r *basePoolManager) cleanupOrphanedGithubRunners(runners []*github.Runner)
 				return nil
 			}
 
+			if r.observe(pool.ID, params.ObservedActionStartInstance, dbInstance.Name, "runner is offline and the instance is stopped") {
+				return nil
+			}
 			slog.InfoContext(
 				r.ctx, "instance was found in stopped state; starting",
 				"runner_name", dbInstance.Name)
@@ -716,6 +983,12 @@ func (r *basePoolManager) setInstanceRunnerStatus(runnerName string, status para
 	updateParams := params.UpdateInstanceParams{
 		RunnerStatus: status,
 	}
+	switch status {
+	case params.RunnerActive:
+		updateParams.Stages = []params.InstanceStage{params.InstanceStageJobAssigned}
+	case params.RunnerTerminated:
+		updateParams.Stages = []params.InstanceStage{params.InstanceStageTerminated}
+	}
 	instance, err := r.store.UpdateInstance(r.ctx, runnerName, updateParams)
 	if err != nil {
 		return params.Instance{}, errors.Wrap(err, "updating runner state")
@@ -753,7 +1026,9 @@ func (r *basePoolManager) AddRunner(ctx context.Context, poolID string, aditiona
 	jitConfig := make(map[string]string)
 	var runner *github.Runner
 
-	if !provider.DisableJITConfig() {
+	// JIT runners can only run a single job, so reusable runners register
+	// using a registration token.
+	if !provider.DisableJITConfig() && !pool.Reusable {
 		// Attempt to create JIT config
 		jitConfig, runner, err = r.ghcli.GetEntityJITConfig(ctx, name, pool, labels)
 		if err != nil {
@@ -813,8 +1088,9 @@ func (r *basePoolManager) Status() params.PoolManagerStatus {
 	r.mux.Lock()
 	defer r.mux.Unlock()
 	return params.PoolManagerStatus{
-		IsRunning:     r.managerIsRunning,
-		FailureReason: r.managerErrorReason,
+		IsRunning:              r.managerIsRunning,
+		FailureReason:          r.managerErrorReason,
+		ConflictingControllers: r.controllerConflicts,
 	}
 }
 
@@ -844,6 +1120,9 @@ func (r *basePoolManager) getLabelsForInstance(pool params.Pool) []string {
 	}
 	labels = append(labels, r.controllerLabel())
 	labels = append(labels, r.poolLabel(pool.ID))
+	if partitionLabel := r.partitionLabel(); partitionLabel != "" {
+		labels = append(labels, partitionLabel)
+	}
 	return labels
 }
 
@@ -897,6 +1176,7 @@ func (r *basePoolManager) addInstanceToProvider(instance params.Instance) error
 
 	defer func() {
 		if instanceIDToDelete != "" {
+			r.captureConsoleOutput(pool, instance.Name, instanceIDToDelete)
 			deleteInstanceParams := common.DeleteInstanceParams{
 				DeleteInstanceV011: common.DeleteInstanceV011Params{
 					ProviderBaseParams: r.getProviderBaseParams(pool),
@@ -931,6 +1211,11 @@ func (r *basePoolManager) addInstanceToProvider(instance params.Instance) error
 	}
 
 	updateInstanceArgs := r.updateArgsFromProviderInstance(providerInstance)
+	if providerInstance.Status == commonParams.InstanceError {
+		updateInstanceArgs.Retry = createRetry(instance, params.ProviderErrorTransient, time.Now().UTC())
+	} else {
+		updateInstanceArgs.Stages = []params.InstanceStage{params.InstanceStageProviderCreated}
+	}
 	if _, err := r.store.UpdateInstance(r.ctx, instance.Name, updateInstanceArgs); err != nil {
 		return errors.Wrap(err, "updating instance")
 	}
@@ -972,6 +1257,10 @@ func (r *basePoolManager) paramsWorkflowJobToParamsJob(job params.WorkflowJob) (
 		RepositoryName:  job.Repository.Name,
 		RepositoryOwner: job.Repository.Owner.Login,
 		Labels:          job.WorkflowJob.Labels,
+		HeadBranch:      job.WorkflowJob.HeadBranch,
+		WorkflowName:    job.WorkflowJob.WorkflowName,
+		RunAttempt:      job.WorkflowJob.RunAttempt,
+		Actor:           job.Sender.Login,
 	}
 
 	switch r.entity.EntityType {
@@ -1029,7 +1318,7 @@ func (r *basePoolManager) scaleDownOnePool(ctx context.Context, pool params.Pool
 		// consideration for scale-down. The 5 minute grace period prevents a situation where a
 		// "queued" workflow triggers the creation of a new idle runner, and this routine reaps
 		// an idle runner before they have a chance to pick up a job.
-		if inst.RunnerStatus == params.RunnerIdle && inst.Status == commonParams.InstanceRunning && time.Since(inst.UpdatedAt).Minutes() > 2 {
+		if inst.RunnerStatus == params.RunnerIdle && inst.Status == commonParams.InstanceRunning && !inst.Draining && time.Since(inst.UpdatedAt).Minutes() > 2 {
 			idleWorkers = append(idleWorkers, inst)
 		}
 	}
@@ -1038,6 +1327,11 @@ func (r *basePoolManager) scaleDownOnePool(ctx context.Context, pool params.Pool
 		return nil
 	}
 
+	// Remove runners created with a previous generation of the pool first.
+	sort.SliceStable(idleWorkers, func(i, j int) bool {
+		return idleWorkers[i].PoolGeneration < idleWorkers[j].PoolGeneration
+	})
+
 	surplus := float64(len(idleWorkers) - int(pool.MinIdleRunners))
 
 	if surplus <= 0 {
@@ -1051,6 +1345,13 @@ func (r *basePoolManager) scaleDownOnePool(ctx context.Context, pool params.Pool
 		return fmt.Errorf("invalid number of instances to scale down: %v, check your scaleDownFactor: %v", numScaleDown, scaleDownFactor)
 	}
 
+	if r.observing() {
+		for _, instanceToDelete := range idleWorkers[:numScaleDown] {
+			r.observe(pool.ID, params.ObservedActionDeleteRunner, instanceToDelete.Name, "idle runner is not needed to satisfy min idle runners")
+		}
+		return nil
+	}
+
 	g, _ := errgroup.WithContext(ctx)
 
 	for _, instanceToDelete := range idleWorkers[:numScaleDown] {
@@ -1092,7 +1393,7 @@ func (r *basePoolManager) scaleDownOnePool(ctx context.Context, pool params.Pool
 		}
 
 		for _, job := range queued {
-			if time.Since(job.CreatedAt).Minutes() > 10 && pool.HasRequiredLabels(job.Labels) {
+			if time.Since(job.CreatedAt).Minutes() > 10 && pool.HasRequiredLabels(withoutLabel(job.Labels, r.partitionLabel())) && pool.HandlesRepository(job.RepositoryOwner, job.RepositoryName) && poolAllowsJob(pool, job) == nil {
 				if err := r.store.DeleteJob(ctx, job.ID); err != nil && !errors.Is(err, runnerErrors.ErrNotFound) {
 					slog.With(slog.Any("error", err)).ErrorContext(
 						ctx, "failed to delete job",
@@ -1108,11 +1409,20 @@ func (r *basePoolManager) scaleDownOnePool(ctx context.Context, pool params.Pool
 	return nil
 }
 
-func (r *basePoolManager) addRunnerToPool(pool params.Pool, aditionalLabels []string) error {
+// canAddRunnerToPool returns an error if a new runner can not be added to the pool.
+func (r *basePoolManager) canAddRunnerToPool(pool params.Pool) error {
 	if !pool.Enabled {
 		return fmt.Errorf("pool %s is disabled", pool.ID)
 	}
 
+	if pool.Cordoned {
+		return fmt.Errorf("pool %s is cordoned", pool.ID)
+	}
+
+	if pool.ProviderFailed() {
+		return fmt.Errorf("pool %s was flagged after a provider failure: %s", pool.ID, pool.ProviderFailureReason)
+	}
+
 	poolInstanceCount, err := r.store.PoolInstanceCount(r.ctx, pool.ID)
 	if err != nil {
 		return fmt.Errorf("failed to list pool instances: %w", err)
@@ -1121,6 +1431,13 @@ func (r *basePoolManager) addRunnerToPool(pool params.Pool, aditionalLabels []st
 	if poolInstanceCount >= int64(pool.MaxRunners) {
 		return fmt.Errorf("max workers (%d) reached for pool %s", pool.MaxRunners, pool.ID)
 	}
+	return nil
+}
+
+func (r *basePoolManager) addRunnerToPool(pool params.Pool, aditionalLabels []string) error {
+	if err := r.canAddRunnerToPool(pool); err != nil {
+		return err
+	}
 
 	if err := r.AddRunner(r.ctx, pool.ID, aditionalLabels); err != nil {
 		return fmt.Errorf("failed to add new instance for pool %s: %s", pool.ID, err)
@@ -1129,7 +1446,7 @@ func (r *basePoolManager) addRunnerToPool(pool params.Pool, aditionalLabels []st
 }
 
 func (r *basePoolManager) ensureIdleRunnersForOnePool(pool params.Pool) error {
-	if !pool.Enabled || pool.MinIdleRunners == 0 {
+	if !pool.Enabled || pool.Cordoned || pool.ProviderFailed() || pool.MinIdleRunners == 0 {
 		return nil
 	}
 
@@ -1148,6 +1465,10 @@ func (r *basePoolManager) ensureIdleRunnersForOnePool(pool params.Pool) error {
 
 	idleOrPendingWorkers := []params.Instance{}
 	for _, inst := range existingInstances {
+		// Draining runners are about to be removed and need to be replaced.
+		if inst.Draining {
+			continue
+		}
 		if inst.RunnerStatus != params.RunnerActive && inst.RunnerStatus != params.RunnerTerminated {
 			idleOrPendingWorkers = append(idleOrPendingWorkers, inst)
 		}
@@ -1166,6 +1487,10 @@ func (r *basePoolManager) ensureIdleRunnersForOnePool(pool params.Pool) error {
 		}
 	}
 
+	if required > 0 && r.observe(pool.ID, params.ObservedActionCreateRunner, "", fmt.Sprintf("pool needs %d more idle runners", required)) {
+		return nil
+	}
+
 	for i := 0; i < required; i++ {
 		slog.InfoContext(
 			r.ctx, "adding new idle worker to pool",
@@ -1178,7 +1503,7 @@ func (r *basePoolManager) ensureIdleRunnersForOnePool(pool params.Pool) error {
 }
 
 func (r *basePoolManager) retryFailedInstancesForOnePool(ctx context.Context, pool params.Pool) error {
-	if !pool.Enabled {
+	if !pool.Enabled || pool.ProviderFailed() {
 		return nil
 	}
 	slog.DebugContext(
@@ -1190,14 +1515,16 @@ func (r *basePoolManager) retryFailedInstancesForOnePool(ctx context.Context, po
 		return fmt.Errorf("failed to list instances for pool %s: %w", pool.ID, err)
 	}
 
+	now := time.Now().UTC()
 	g, errCtx := errgroup.WithContext(ctx)
 	for _, instance := range existingInstances {
 		instance := instance
 
-		if instance.Status != commonParams.InstanceError {
+		if !canRetryCreate(instance, now) {
 			continue
 		}
-		if instance.CreateAttempt >= maxCreateAttempts {
+
+		if r.observe(pool.ID, params.ObservedActionRetryRunner, instance.Name, fmt.Sprintf("runner failed to be created (attempt %d)", instance.CreateAttempt)) {
 			continue
 		}
 
@@ -1217,10 +1544,9 @@ func (r *basePoolManager) retryFailedInstancesForOnePool(ctx context.Context, po
 			slog.DebugContext(
 				ctx, "attempting to clean up any previous instance",
 				"runner_name", instance.Name)
-			// nolint:golangci-lint,godox
 			// NOTE(gabriel-samfira): this is done in parallel. If there are many failed instances
-			// this has the potential to create many API requests to the target provider.
-			// TODO(gabriel-samfira): implement request throttling.
+			// this has the potential to create many API requests to the target provider. Operators
+			// can limit those requests using the throttle settings of the provider.
 			if err := r.deleteInstanceFromProvider(errCtx, instance); err != nil {
 				slog.With(slog.Any("error", err)).ErrorContext(
 					ctx, "failed to delete instance from provider",
@@ -1247,6 +1573,7 @@ func (r *basePoolManager) retryFailedInstancesForOnePool(ctx context.Context, po
 				TokenFetched:  &tokenFetched,
 				Status:        commonParams.InstancePendingCreate,
 				RunnerStatus:  params.RunnerPending,
+				Retry:         &params.InstanceRetry{},
 			}
 			slog.DebugContext(
 				ctx, "queueing previously failed instance for retry",
@@ -1379,6 +1706,10 @@ func (r *basePoolManager) deletePendingInstances() error {
 			continue
 		}
 
+		if r.observe(instance.PoolID, params.ObservedActionDeleteInstance, instance.Name, fmt.Sprintf("instance is in %s status", instance.Status)) {
+			continue
+		}
+
 		slog.InfoContext(
 			r.ctx, "removing instance from pool",
 			"runner_name", instance.Name,
@@ -1437,6 +1768,7 @@ func (r *basePoolManager) deletePendingInstances() error {
 			slog.InfoContext(
 				r.ctx, "removing instance from database",
 				"runner_name", instance.Name)
+			r.recordRemovedRunnerUsage(instance)
 			if deleteErr := r.store.DeleteInstance(r.ctx, instance.PoolID, instance.Name); deleteErr != nil {
 				return fmt.Errorf("failed to delete instance from database: %w", deleteErr)
 			}
@@ -1464,6 +1796,10 @@ func (r *basePoolManager) addPendingInstances() error {
 			continue
 		}
 
+		if r.observe(instance.PoolID, params.ObservedActionCreateInstance, instance.Name, "instance is in pending_create status") {
+			continue
+		}
+
 		slog.DebugContext(
 			r.ctx, "attempting to acquire lock for instance",
 			"runner_name", instance.Name,
@@ -1498,8 +1834,7 @@ func (r *basePoolManager) addPendingInstances() error {
 				slog.With(slog.Any("error", err)).ErrorContext(
 					r.ctx, "failed to add instance to provider",
 					"runner_name", instance.Name)
-				errAsBytes := []byte(err.Error())
-				if _, statusErr := r.setInstanceStatus(instance.Name, commonParams.InstanceError, errAsBytes); statusErr != nil {
+				if statusErr := r.handleCreateFailure(instance, err); statusErr != nil {
 					slog.With(slog.Any("error", statusErr)).ErrorContext(
 						r.ctx, "failed to update runner status",
 						"runner_name", instance.Name)
@@ -1538,6 +1873,11 @@ func (r *basePoolManager) runnerCleanup() (err error) {
 		return fmt.Errorf("failed to fetch github runners: %w", err)
 	}
 
+	if err := r.detectControllerConflicts(runners); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			r.ctx, "failed to detect other controllers")
+	}
+
 	if err := r.reapTimedOutRunners(runners); err != nil {
 		return fmt.Errorf("failed to reap timed out runners: %w", err)
 	}
@@ -1588,8 +1928,10 @@ func (r *basePoolManager) Start() error {
 		go r.startLoopForFunction(r.addPendingInstances, common.PoolConsilitationInterval, "consolidate[add_pending]", false)
 		go r.startLoopForFunction(r.ensureMinIdleRunners, common.PoolConsilitationInterval, "consolidate[ensure_min_idle]", false)
 		go r.startLoopForFunction(r.retryFailedInstances, common.PoolConsilitationInterval, "consolidate[retry_failed]", false)
+		go r.startLoopForFunction(r.maintainPools, common.PoolConsilitationInterval, "consolidate[maintenance]", false)
 		go r.startLoopForFunction(r.updateTools, common.PoolToolUpdateInterval, "update_tools", true)
 		go r.startLoopForFunction(r.consumeQueuedJobs, common.PoolConsilitationInterval, "job_queue_consumer", false)
+		go r.startLoopForFunction(r.reconcileWebhookDeliveries, common.WebhookDeliveryReconcileInterval, "webhook_delivery_reconciler", false)
 	}()
 	return nil
 }
@@ -1603,6 +1945,10 @@ func (r *basePoolManager) WebhookSecret() string {
 	return r.entity.WebhookSecret
 }
 
+func (r *basePoolManager) WebhookSecrets() []string {
+	return r.entity.WebhookSecrets()
+}
+
 func (r *basePoolManager) ID() string {
 	return r.entity.ID
 }
@@ -1703,9 +2049,21 @@ func (r *basePoolManager) consumeQueuedJobs() error {
 		return errors.Wrap(err, "listing queued jobs")
 	}
 
+	scheduling := r.entity.GetJobScheduling()
+	active := map[string]uint{}
+	if scheduling.Policy != params.JobSchedulingFIFO {
+		active, err = r.activeRepositoryJobs(queued)
+		if err != nil {
+			return errors.Wrap(err, "counting active jobs")
+		}
+	}
+	queued = scheduleQueuedJobs(queued, active, scheduling)
+	repoRunners := newRepositoryRunners(r, queued)
+
 	poolsCache := poolsForTags{
 		poolCacheType: r.entity.GetPoolBalancerType(),
 	}
+	partitionLabel := r.partitionLabel()
 
 	slog.DebugContext(
 		r.ctx, "found queued jobs",
@@ -1754,15 +2112,16 @@ func (r *basePoolManager) consumeQueuedJobs() error {
 			continue
 		}
 
-		poolRR, ok := poolsCache.Get(job.Labels)
+		poolLabels := withoutLabel(job.Labels, partitionLabel)
+		poolRR, ok := poolsCache.Get(poolLabels)
 		if !ok {
-			potentialPools, err := r.store.FindPoolsMatchingAllTags(r.ctx, r.entity.EntityType, r.entity.ID, job.Labels)
+			potentialPools, err := r.store.FindPoolsMatchingAllTags(r.ctx, r.entity.EntityType, r.entity.ID, poolLabels)
 			if err != nil {
 				slog.With(slog.Any("error", err)).ErrorContext(
 					r.ctx, "error finding pools matching labels")
 				continue
 			}
-			poolRR = poolsCache.Add(job.Labels, potentialPools)
+			poolRR = poolsCache.Add(poolLabels, potentialPools)
 		}
 
 		if poolRR.Len() == 0 {
@@ -1770,6 +2129,31 @@ func (r *basePoolManager) consumeQueuedJobs() error {
 			continue
 		}
 
+		if !poolsHandleRepository(poolRR.Pools(), job.RepositoryOwner, job.RepositoryName) {
+			slog.DebugContext(
+				r.ctx, "no pool with matching labels handles jobs of this repository",
+				"requested_labels", strings.Join(job.Labels, ","),
+				"repository", job.RepositoryOwner+"/"+job.RepositoryName)
+			continue
+		}
+
+		if err := r.fetchWorkflowRun(&job, poolRR.Pools()); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to fetch workflow run of job",
+				"job_id", job.ID)
+			continue
+		}
+
+		if denial := policyDenial(poolRR.Pools(), job); denial != "" {
+			r.setPolicyDenial(job, denial)
+			continue
+		}
+
+		if r.observing() {
+			r.observeQueuedJob(job, poolRR)
+			continue
+		}
+
 		runnerCreated := false
 		if err := r.store.LockJob(r.ctx, job.ID, r.ID()); err != nil {
 			slog.With(slog.Any("error", err)).ErrorContext(
@@ -1790,6 +2174,14 @@ func (r *basePoolManager) consumeQueuedJobs() error {
 				break
 			}
 
+			if !pool.HandlesRepository(job.RepositoryOwner, job.RepositoryName) || poolAllowsJob(pool, job) != nil {
+				continue
+			}
+
+			if r.repositoryLimitReached(pool, job, repoRunners) {
+				continue
+			}
+
 			slog.InfoContext(
 				r.ctx, "attempting to create a runner in pool",
 				"pool_id", pool.ID,
@@ -1804,6 +2196,8 @@ func (r *basePoolManager) consumeQueuedJobs() error {
 				"pool_id", pool.ID,
 				"job_id", job.ID)
 			runnerCreated = true
+			repoRunners.add(pool.ID, repositoryKey(job.RepositoryOwner, job.RepositoryName))
+			r.setPolicyDenial(job, "")
 			break
 		}
 
@@ -1919,6 +2313,36 @@ func (r *basePoolManager) InstallWebhook(ctx context.Context, param params.Insta
 	return r.InstallHook(ctx, req)
 }
 
+func (r *basePoolManager) UpdateWebhookSecret(ctx context.Context, secret string) (params.HookInfo, error) {
+	hookInfo, err := r.GetWebhookInfo(ctx)
+	if err != nil {
+		return params.HookInfo{}, errors.Wrap(err, "fetching webhook info")
+	}
+
+	trimmedController := strings.TrimRight(r.controllerInfo.ControllerWebhookURL, "/")
+	if !strings.EqualFold(strings.TrimRight(hookInfo.URL, "/"), trimmedController) {
+		return params.HookInfo{}, runnerErrors.NewBadRequestError("base hook found (%s) and must be updated manually", hookInfo.URL)
+	}
+
+	insecureSSL := "0"
+	if hookInfo.InsecureSSL {
+		insecureSSL = "1"
+	}
+	req := &github.Hook{
+		Config: map[string]interface{}{
+			"url":          hookInfo.URL,
+			"content_type": "json",
+			"insecure_ssl": insecureSSL,
+			"secret":       secret,
+		},
+	}
+	hook, err := r.ghcli.EditEntityHook(ctx, hookInfo.ID, req)
+	if err != nil {
+		return params.HookInfo{}, errors.Wrap(err, "updating entity hook")
+	}
+	return hookToParamsHookInfo(hook), nil
+}
+
 func (r *basePoolManager) ValidateOwner(job params.WorkflowJob) error {
 	switch r.entity.EntityType {
 	case params.GithubEntityTypeRepository:
@@ -0,0 +1,99 @@
+package pool
+
+import (
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
+)
+
+const (
+	// createRetryBackoff is the time we wait before retrying to create an
+	// instance for the first time. It doubles with every attempt.
+	createRetryBackoff = 30 * time.Second
+	// maxCreateRetryBackoff is the longest we wait between two attempts to
+	// create an instance.
+	maxCreateRetryBackoff = 10 * time.Minute
+)
+
+// createRetryDelay returns the time to wait before the next attempt to create
+// an instance that already failed attempt times. jitter is a number in [0, 1)
+// which spreads the retries of instances that failed at the same time.
+func createRetryDelay(attempt int, jitter float64) time.Duration {
+	delay := createRetryBackoff
+	for i := 1; i < attempt && delay < maxCreateRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxCreateRetryBackoff {
+		delay = maxCreateRetryBackoff
+	}
+	return delay/2 + time.Duration(float64(delay/2)*jitter)
+}
+
+// createRetry returns the retry state of an instance that failed to be created
+// with an error of the given class.
+func createRetry(instance params.Instance, class params.ProviderErrorClass, now time.Time) *params.InstanceRetry {
+	retry := &params.InstanceRetry{ErrorClass: class}
+	if class.Retryable() && instance.CreateAttempt < maxCreateAttempts {
+		// nolint:gosec
+		nextRetryAt := now.Add(createRetryDelay(instance.CreateAttempt, rand.Float64()))
+		retry.NextRetryAt = &nextRetryAt
+	}
+	return retry
+}
+
+// canRetryCreate returns true if an instance that failed to be created is due
+// for another attempt.
+func canRetryCreate(instance params.Instance, now time.Time) bool {
+	if instance.Status != commonParams.InstanceError {
+		return false
+	}
+	if instance.CreateAttempt >= maxCreateAttempts {
+		return false
+	}
+	if instance.ErrorClass != "" && !instance.ErrorClass.Retryable() {
+		return false
+	}
+	return instance.NextRetryAt == nil || !instance.NextRetryAt.After(now)
+}
+
+// handleCreateFailure records the failure to create an instance and schedules
+// the next attempt. Errors that will not go away by retrying also flag the pool,
+// so no more runners are created in it until an operator looks at it.
+func (r *basePoolManager) handleCreateFailure(instance params.Instance, createErr error) error {
+	class := common.ProviderErrorClassOf(createErr)
+	now := time.Now().UTC()
+	updateParams := params.UpdateInstanceParams{
+		Status:        commonParams.InstanceError,
+		ProviderFault: []byte(createErr.Error()),
+		Retry:         createRetry(instance, class, now),
+	}
+	if _, err := r.store.UpdateInstance(r.ctx, instance.Name, updateParams); err != nil {
+		return errors.Wrap(err, "updating instance")
+	}
+
+	if class.Retryable() {
+		return nil
+	}
+
+	slog.With(slog.Any("error", createErr)).ErrorContext(
+		r.ctx, "provider failure is not retryable; flagging pool",
+		"pool_id", instance.PoolID,
+		"error_class", class)
+	failureParams := params.UpdatePoolParams{
+		ProviderFailure: &params.PoolProviderFailure{
+			Class:  class,
+			Reason: createErr.Error(),
+			At:     now,
+		},
+	}
+	if _, err := r.store.UpdateEntityPool(r.ctx, r.entity, instance.PoolID, failureParams); err != nil {
+		return errors.Wrap(err, "flagging pool")
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package pool
+
+import (
+	"testing"
+	"time"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+)
+
+func TestCreateRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		jitter   float64
+		expected time.Duration
+	}{
+		{1, 0, 15 * time.Second},
+		{1, 0.5, 22500 * time.Millisecond},
+		{2, 0, 30 * time.Second},
+		{3, 0, time.Minute},
+		{6, 0, 5 * time.Minute},
+		{10, 0.99, 5*time.Minute + 297*time.Second},
+	}
+	for _, tc := range tests {
+		if got := createRetryDelay(tc.attempt, tc.jitter); got != tc.expected {
+			t.Fatalf("attempt %d with jitter %v: expected %s, got %s", tc.attempt, tc.jitter, tc.expected, got)
+		}
+	}
+}
+
+func TestCreateRetry(t *testing.T) {
+	now := time.Now().UTC()
+	instance := params.Instance{CreateAttempt: 1}
+
+	retry := createRetry(instance, params.ProviderErrorQuotaExceeded, now)
+	if retry.ErrorClass != params.ProviderErrorQuotaExceeded || retry.NextRetryAt == nil {
+		t.Fatalf("expected quota errors to be retried, got %+v", retry)
+	}
+	if retry.NextRetryAt.Before(now.Add(15*time.Second)) || retry.NextRetryAt.After(now.Add(30*time.Second)) {
+		t.Fatalf("unexpected next retry time %s", retry.NextRetryAt)
+	}
+
+	if retry := createRetry(instance, params.ProviderErrorImageNotFound, now); retry.NextRetryAt != nil {
+		t.Fatalf("expected missing images not to be retried")
+	}
+
+	instance.CreateAttempt = maxCreateAttempts
+	if retry := createRetry(instance, params.ProviderErrorTransient, now); retry.NextRetryAt != nil {
+		t.Fatalf("expected no retry after the last attempt")
+	}
+}
+
+func TestCanRetryCreate(t *testing.T) {
+	now := time.Now().UTC()
+	past := now.Add(-time.Second)
+	future := now.Add(time.Minute)
+	failed := params.Instance{Status: commonParams.InstanceError, CreateAttempt: 1}
+
+	due := failed
+	due.NextRetryAt = &past
+	waiting := failed
+	waiting.NextRetryAt = &future
+	notRetryable := failed
+	notRetryable.ErrorClass = params.ProviderErrorInvalidConfig
+	exhausted := failed
+	exhausted.CreateAttempt = maxCreateAttempts
+	running := params.Instance{Status: commonParams.InstanceRunning}
+
+	tests := []struct {
+		name     string
+		instance params.Instance
+		expected bool
+	}{
+		{"no retry time", failed, true},
+		{"due", due, true},
+		{"waiting", waiting, false},
+		{"not retryable", notRetryable, false},
+		{"attempts exhausted", exhausted, false},
+		{"running", running, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := canRetryCreate(tc.instance, now); got != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
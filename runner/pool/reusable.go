@@ -0,0 +1,123 @@
+package pool
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/params"
+)
+
+// runnerCanBeReused returns true if a runner that just completed a job can go
+// back to idle. The instance must already count the completed job.
+func runnerCanBeReused(pool params.Pool, instance params.Instance, now time.Time) bool {
+	if !pool.Reusable || instance.Draining || isOutdated(pool, instance) {
+		return false
+	}
+	if pool.RunnerMaxJobs > 0 && instance.JobsCompleted >= pool.RunnerMaxJobs {
+		return false
+	}
+	return !pool.RunnerLifetimeExceeded(instance, now)
+}
+
+// reuseRunner sets a runner of a reusable pool back to idle after it completed
+// a job. It returns false if the runner must be removed instead.
+func (r *basePoolManager) reuseRunner(runnerName string) (bool, error) {
+	instance, err := r.store.GetInstanceByName(r.ctx, runnerName)
+	if err != nil {
+		return false, errors.Wrap(err, "fetching instance")
+	}
+	pool, err := r.store.GetEntityPool(r.ctx, r.entity, instance.PoolID)
+	if err != nil {
+		if errors.Is(err, runnerErrors.ErrNotFound) {
+			// Not one of our runners.
+			return false, nil
+		}
+		return false, errors.Wrap(err, "fetching pool")
+	}
+	if !pool.Reusable {
+		return false, nil
+	}
+
+	jobsCompleted := instance.JobsCompleted + 1
+	instance.JobsCompleted = jobsCompleted
+	if !runnerCanBeReused(pool, instance, time.Now().UTC()) {
+		slog.InfoContext(
+			r.ctx, "reusable runner reached its limits",
+			"runner_name", instance.Name,
+			"jobs_completed", jobsCompleted,
+			"pool_id", pool.ID)
+		return false, nil
+	}
+
+	registered, err := r.runnerIsRegistered(instance)
+	if err != nil {
+		return false, errors.Wrap(err, "checking runner registration")
+	}
+	if !registered {
+		slog.WarnContext(
+			r.ctx, "reusable runner is no longer registered in GitHub; make sure the runner is not configured as ephemeral",
+			"runner_name", instance.Name,
+			"pool_id", pool.ID)
+		return false, nil
+	}
+
+	updateParams := params.UpdateInstanceParams{
+		RunnerStatus:  params.RunnerIdle,
+		JobsCompleted: &jobsCompleted,
+	}
+	if _, err := r.store.UpdateInstance(r.ctx, instance.Name, updateParams); err != nil {
+		return false, errors.Wrap(err, "updating instance")
+	}
+	return true, nil
+}
+
+// runnerIsRegistered returns true if the runner of an instance is still registered
+// in GitHub and online. GitHub removes runners configured with --ephemeral once they
+// complete a job, so a runner that is gone can not be handed another job.
+func (r *basePoolManager) runnerIsRegistered(instance params.Instance) (bool, error) {
+	if instance.AgentID == 0 {
+		// We don't know the ID of the runner, so we can't check it.
+		return false, nil
+	}
+	runner, err := r.ghcli.GetEntityRunner(r.ctx, instance.AgentID)
+	if err != nil {
+		if errors.Is(err, runnerErrors.ErrNotFound) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "fetching runner")
+	}
+	return runner.GetStatus() != "offline", nil
+}
+
+// expireReusableRunners drains the runners of a reusable pool that outlived
+// the maximum lifetime of the pool. Runners that are running a job are removed
+// when the job completes.
+func (r *basePoolManager) expireReusableRunners(pool params.Pool, instances []params.Instance) error {
+	if !pool.Reusable || pool.RunnerMaxLifetime == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	for _, instance := range instances {
+		if instance.Draining || isBeingRemoved(instance) || instance.RunnerStatus != params.RunnerIdle {
+			continue
+		}
+		if !pool.RunnerLifetimeExceeded(instance, now) {
+			continue
+		}
+		if r.observe(pool.ID, params.ObservedActionDrainRunner, instance.Name, "runner reached its max lifetime") {
+			continue
+		}
+		slog.InfoContext(
+			r.ctx, "draining runner that reached its max lifetime",
+			"runner_name", instance.Name,
+			"pool_id", pool.ID)
+		if err := r.setInstanceDraining(instance); err != nil {
+			return errors.Wrapf(err, "draining runner %s", instance.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package pool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudbase/garm/params"
+)
+
+func TestRunnerCanBeReused(t *testing.T) {
+	now := time.Now().UTC()
+	reusablePool := params.Pool{Reusable: true, RunnerMaxJobs: 3, RunnerMaxLifetime: 60}
+	instance := newTestInstance("runner", now.Add(-time.Minute), params.RunnerActive)
+	instance.JobsCompleted = 1
+
+	draining := instance
+	draining.Draining = true
+	maxJobs := instance
+	maxJobs.JobsCompleted = 3
+	expired := instance
+	expired.CreatedAt = now.Add(-2 * time.Hour)
+	outdated := instance
+	outdatedPool := reusablePool
+	outdatedPool.Generation = 1
+
+	tests := []struct {
+		name     string
+		pool     params.Pool
+		instance params.Instance
+		expected bool
+	}{
+		{"ephemeral pool", params.Pool{}, instance, false},
+		{"reusable", reusablePool, instance, true},
+		{"draining", reusablePool, draining, false},
+		{"max jobs reached", reusablePool, maxJobs, false},
+		{"max lifetime reached", reusablePool, expired, false},
+		{"outdated", outdatedPool, outdated, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := runnerCanBeReused(tc.pool, tc.instance, now); got != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+)
+
+// isOutdated returns true if the instance was created with a previous
+// generation of its pool.
+func isOutdated(pool params.Pool, instance params.Instance) bool {
+	return instance.PoolGeneration < pool.Generation
+}
+
+// rolloutPlan returns the outdated idle runners of a pool that can be drained
+// now, and the number of runners that can be created on top of the existing
+// ones to replace the remaining outdated idle runners.
+//
+// Outdated idle runners are drained while fewer than RolloutMaxUnavailable
+// outdated runners are draining, or when the pool has more idle runners than
+// it needs. Otherwise, up to RolloutMaxSurge replacements are created first,
+// even if that takes the pool over its max runners.
+func rolloutPlan(pool params.Pool, instances []params.Instance) ([]params.Instance, int) {
+	if pool.RolloutMaxSurge == 0 && pool.RolloutMaxUnavailable == 0 {
+		return nil, 0
+	}
+
+	var outdatedIdle []params.Instance
+	var unavailable, pending, idle int
+	for _, instance := range instances {
+		outdated := isOutdated(pool, instance)
+		if instance.Draining || isBeingRemoved(instance) {
+			if outdated {
+				unavailable++
+			}
+			continue
+		}
+
+		switch {
+		case instance.RunnerStatus == params.RunnerIdle && instance.Status == commonParams.InstanceRunning:
+			idle++
+			if outdated {
+				outdatedIdle = append(outdatedIdle, instance)
+			}
+		case !outdated && instance.RunnerStatus != params.RunnerActive && instance.RunnerStatus != params.RunnerTerminated:
+			// Replacements that are still coming up.
+			pending++
+		}
+	}
+	if len(outdatedIdle) == 0 {
+		return nil, 0
+	}
+
+	budget := int(pool.RolloutMaxUnavailable) - unavailable
+	if budget < 0 {
+		budget = 0
+	}
+	// Idle runners above the minimum are not needed. Outdated ones can be removed
+	// without waiting for a replacement.
+	if surplus := idle - int(pool.MinIdleRunners); surplus > 0 {
+		budget += surplus
+	}
+	if budget > len(outdatedIdle) {
+		budget = len(outdatedIdle)
+	}
+
+	sort.SliceStable(outdatedIdle, func(i, j int) bool {
+		return outdatedIdle[i].CreatedAt.Before(outdatedIdle[j].CreatedAt)
+	})
+
+	toCreate := int(pool.RolloutMaxSurge) - pending
+	if remaining := len(outdatedIdle) - budget; toCreate > remaining {
+		toCreate = remaining
+	}
+	if capacity := int(pool.MaxRunners+pool.RolloutMaxSurge) - len(instances); toCreate > capacity {
+		toCreate = capacity
+	}
+	if toCreate < 0 {
+		toCreate = 0
+	}
+	return outdatedIdle[:budget], toCreate
+}
+
+// rolloutOnePool replaces the idle runners of a pool that were created with a
+// previous image, flavor or extra specs. Runners that are running a job are
+// ephemeral and will not pick up another job on the old settings.
+func (r *basePoolManager) rolloutOnePool(pool params.Pool, instances []params.Instance) error {
+	if !pool.Enabled || pool.Cordoned || pool.ProviderFailed() || pool.RecycleRequestedAt != nil {
+		// Replacements can't be created, or the pool recycle is already
+		// replacing all runners.
+		return nil
+	}
+
+	toDrain, toCreate := rolloutPlan(pool, instances)
+	if r.observing() {
+		for _, instance := range toDrain {
+			r.observe(pool.ID, params.ObservedActionDrainRunner, instance.Name, fmt.Sprintf("runner is outdated (generation %d of %d)", instance.PoolGeneration, pool.Generation))
+		}
+		if toCreate > 0 {
+			r.observe(pool.ID, params.ObservedActionCreateRunner, "", fmt.Sprintf("pool needs %d runners to replace outdated runners", toCreate))
+		}
+		return nil
+	}
+
+	for _, instance := range toDrain {
+		slog.InfoContext(
+			r.ctx, "draining outdated runner",
+			"runner_name", instance.Name,
+			"runner_generation", instance.PoolGeneration,
+			"pool_generation", pool.Generation,
+			"pool_id", pool.ID)
+		if err := r.setInstanceDraining(instance); err != nil {
+			return errors.Wrapf(err, "draining runner %s", instance.Name)
+		}
+	}
+
+	for i := 0; i < toCreate; i++ {
+		slog.InfoContext(
+			r.ctx, "adding runner to replace outdated runners",
+			"pool_generation", pool.Generation,
+			"pool_id", pool.ID)
+		if err := r.AddRunner(r.ctx, pool.ID, nil); err != nil {
+			return errors.Wrap(err, "adding runner")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package pool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudbase/garm/params"
+)
+
+func newRolloutTestInstance(name string, generation uint, age time.Duration, runnerStatus params.RunnerStatus) params.Instance {
+	instance := newTestInstance(name, time.Now().UTC().Add(-age), runnerStatus)
+	instance.PoolGeneration = generation
+	return instance
+}
+
+func TestRolloutPlanDisabled(t *testing.T) {
+	pool := params.Pool{Generation: 1, MaxRunners: 5}
+	toDrain, toCreate := rolloutPlan(pool, []params.Instance{
+		newRolloutTestInstance("old", 0, time.Hour, params.RunnerIdle),
+	})
+	if len(toDrain) != 0 || toCreate != 0 {
+		t.Fatalf("expected no rollout, got drain %v and create %d", instanceNames(toDrain), toCreate)
+	}
+}
+
+func TestRolloutPlanSurgeFirst(t *testing.T) {
+	pool := params.Pool{Generation: 1, MaxRunners: 2, MinIdleRunners: 2, RolloutMaxSurge: 1}
+	toDrain, toCreate := rolloutPlan(pool, []params.Instance{
+		newRolloutTestInstance("old-1", 0, 2*time.Hour, params.RunnerIdle),
+		newRolloutTestInstance("old-2", 0, time.Hour, params.RunnerIdle),
+	})
+	if len(toDrain) != 0 || toCreate != 1 {
+		t.Fatalf("expected one surge runner, got drain %v and create %d", instanceNames(toDrain), toCreate)
+	}
+
+	// The surge runner is still coming up.
+	toDrain, toCreate = rolloutPlan(pool, []params.Instance{
+		newRolloutTestInstance("old-1", 0, 2*time.Hour, params.RunnerIdle),
+		newRolloutTestInstance("old-2", 0, time.Hour, params.RunnerIdle),
+		newRolloutTestInstance("new-1", 1, time.Minute, params.RunnerInstalling),
+	})
+	if len(toDrain) != 0 || toCreate != 0 {
+		t.Fatalf("expected to wait for the surge runner, got drain %v and create %d", instanceNames(toDrain), toCreate)
+	}
+
+	// The surge runner is idle, so the oldest outdated runner can go. The pool
+	// is at max runners plus surge, so the next replacement waits for it.
+	toDrain, toCreate = rolloutPlan(pool, []params.Instance{
+		newRolloutTestInstance("old-2", 0, time.Hour, params.RunnerIdle),
+		newRolloutTestInstance("old-1", 0, 2*time.Hour, params.RunnerIdle),
+		newRolloutTestInstance("new-1", 1, time.Minute, params.RunnerIdle),
+	})
+	names := instanceNames(toDrain)
+	if len(names) != 1 || names[0] != "old-1" || toCreate != 0 {
+		t.Fatalf("expected to drain old-1 only, got drain %v and create %d", names, toCreate)
+	}
+}
+
+func TestRolloutPlanMaxUnavailable(t *testing.T) {
+	pool := params.Pool{Generation: 2, MaxRunners: 3, MinIdleRunners: 3, RolloutMaxUnavailable: 2}
+	draining := newRolloutTestInstance("draining", 1, 3*time.Hour, params.RunnerIdle)
+	draining.Draining = true
+	toDrain, toCreate := rolloutPlan(pool, []params.Instance{
+		draining,
+		newRolloutTestInstance("old-1", 1, 2*time.Hour, params.RunnerIdle),
+		newRolloutTestInstance("old-2", 0, time.Hour, params.RunnerIdle),
+	})
+	names := instanceNames(toDrain)
+	if len(names) != 1 || names[0] != "old-1" || toCreate != 0 {
+		t.Fatalf("expected to drain old-1 only, got drain %v and create %d", names, toCreate)
+	}
+}
+
+func TestRolloutPlanSkipsActiveRunners(t *testing.T) {
+	pool := params.Pool{Generation: 1, MaxRunners: 5, MinIdleRunners: 1, RolloutMaxSurge: 1, RolloutMaxUnavailable: 1}
+	toDrain, toCreate := rolloutPlan(pool, []params.Instance{
+		newRolloutTestInstance("busy", 0, time.Hour, params.RunnerActive),
+		newRolloutTestInstance("current", 1, time.Minute, params.RunnerIdle),
+	})
+	if len(toDrain) != 0 || toCreate != 0 {
+		t.Fatalf("expected busy runners to be left alone, got drain %v and create %d", instanceNames(toDrain), toCreate)
+	}
+}
@@ -0,0 +1,196 @@
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+)
+
+// repositoryKey returns the key used to group the jobs and runners of a repository.
+func repositoryKey(owner, name string) string {
+	return strings.ToLower(fmt.Sprintf("%s/%s", owner, name))
+}
+
+// scheduleQueuedJobs returns the queued jobs in the order in which they should get
+// runners. Jobs with a higher priority go first. With the round robin and weighted
+// policies, jobs of the same priority are interleaved so that every repository gets
+// its share of runners, taking into account the jobs of each repository that
+// already have a runner. Locked jobs are not considered and are moved to the end.
+func scheduleQueuedJobs(jobs []params.Job, active map[string]uint, scheduling params.JobScheduling) []params.Job {
+	ret := make([]params.Job, 0, len(jobs))
+	locked := []params.Job{}
+	for _, job := range jobs {
+		if job.LockedBy != uuid.Nil {
+			locked = append(locked, job)
+			continue
+		}
+		ret = append(ret, job)
+	}
+
+	priorities := make(map[int64]int, len(ret))
+	for _, job := range ret {
+		priorities[job.ID] = scheduling.JobPriority(job.Labels)
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return priorities[ret[i].ID] > priorities[ret[j].ID]
+	})
+
+	if scheduling.Policy == params.JobSchedulingRoundRobin || scheduling.Policy == params.JobSchedulingWeighted {
+		usage := make(map[string]uint, len(active))
+		for repo, count := range active {
+			usage[repo] = count
+		}
+		for start := 0; start < len(ret); {
+			end := start
+			for end < len(ret) && priorities[ret[end].ID] == priorities[ret[start].ID] {
+				end++
+			}
+			shareJobs(ret[start:end], usage, scheduling)
+			start = end
+		}
+	}
+
+	return append(ret, locked...)
+}
+
+// shareJobs orders jobs of the same priority in place. The next job always belongs
+// to the repository with the fewest runners relative to its weight. Ties go to the
+// repository that queued its job first.
+func shareJobs(jobs []params.Job, usage map[string]uint, scheduling params.JobScheduling) {
+	queues := map[string][]params.Job{}
+	weights := map[string]uint{}
+	repos := []string{}
+	for _, job := range jobs {
+		repo := repositoryKey(job.RepositoryOwner, job.RepositoryName)
+		if _, ok := queues[repo]; !ok {
+			repos = append(repos, repo)
+			weights[repo] = scheduling.RepositoryWeight(job.RepositoryOwner, job.RepositoryName)
+		}
+		queues[repo] = append(queues[repo], job)
+	}
+
+	for idx := range jobs {
+		next := ""
+		for _, repo := range repos {
+			if len(queues[repo]) == 0 {
+				continue
+			}
+			// usage[repo] / weights[repo] < usage[next] / weights[next]
+			if next == "" || usage[repo]*weights[next] < usage[next]*weights[repo] {
+				next = repo
+			}
+		}
+		jobs[idx] = queues[next][0]
+		queues[next] = queues[next][1:]
+		usage[next]++
+	}
+}
+
+// activeRepositoryJobs counts, for every repository, the jobs of the entity that
+// are running or waiting for a runner we created for them.
+func (r *basePoolManager) activeRepositoryJobs(queued []params.Job) (map[string]uint, error) {
+	inProgress, err := r.store.ListEntityJobsByStatus(r.ctx, r.entity.EntityType, r.entity.ID, params.JobStatusInProgress)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing in progress jobs")
+	}
+
+	active := map[string]uint{}
+	for _, job := range inProgress {
+		active[repositoryKey(job.RepositoryOwner, job.RepositoryName)]++
+	}
+	for _, job := range queued {
+		if job.LockedBy.String() == r.ID() {
+			active[repositoryKey(job.RepositoryOwner, job.RepositoryName)]++
+		}
+	}
+	return active, nil
+}
+
+// repositoryRunners counts the runners of a pool used by each repository. A runner
+// is used by the repository of the job it runs, or by the repository of the queued
+// job it was created for.
+type repositoryRunners struct {
+	r        *basePoolManager
+	jobRepos map[int64]string
+	counts   map[string]map[string]uint
+}
+
+func newRepositoryRunners(r *basePoolManager, queued []params.Job) *repositoryRunners {
+	jobRepos := make(map[int64]string, len(queued))
+	for _, job := range queued {
+		jobRepos[job.ID] = repositoryKey(job.RepositoryOwner, job.RepositoryName)
+	}
+	return &repositoryRunners{
+		r:        r,
+		jobRepos: jobRepos,
+		counts:   map[string]map[string]uint{},
+	}
+}
+
+func (c *repositoryRunners) count(poolID, repo string) (uint, error) {
+	if counts, ok := c.counts[poolID]; ok {
+		return counts[repo], nil
+	}
+
+	instances, err := c.r.store.ListPoolInstances(c.r.ctx, poolID)
+	if err != nil {
+		return 0, errors.Wrap(err, "listing pool instances")
+	}
+
+	counts := map[string]uint{}
+	for _, instance := range instances {
+		switch instance.Status {
+		case commonParams.InstancePendingDelete, commonParams.InstancePendingForceDelete, commonParams.InstanceDeleting:
+			continue
+		}
+		if instance.RunnerStatus == params.RunnerTerminated {
+			continue
+		}
+		if instance.Job != nil {
+			counts[repositoryKey(instance.Job.RepositoryOwner, instance.Job.RepositoryName)]++
+			continue
+		}
+		if jobRepo, ok := c.jobRepos[jobIDFromLabels(instance.AditionalLabels)]; ok {
+			counts[jobRepo]++
+		}
+	}
+	c.counts[poolID] = counts
+	return counts[repo], nil
+}
+
+func (c *repositoryRunners) add(poolID, repo string) {
+	if counts, ok := c.counts[poolID]; ok {
+		counts[repo]++
+	}
+}
+
+// repositoryLimitReached returns true if the repository of the job already uses
+// the maximum number of runners of the pool.
+func (r *basePoolManager) repositoryLimitReached(pool params.Pool, job params.Job, runners *repositoryRunners) bool {
+	if pool.MaxRunnersPerRepository == 0 || r.entity.EntityType == params.GithubEntityTypeRepository {
+		return false
+	}
+	count, err := runners.count(pool.ID, repositoryKey(job.RepositoryOwner, job.RepositoryName))
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			r.ctx, "failed to count the runners of the repository",
+			"pool_id", pool.ID)
+		return true
+	}
+	if count >= pool.MaxRunnersPerRepository {
+		slog.DebugContext(
+			r.ctx, "repository reached the maximum runners of the pool",
+			"pool_id", pool.ID,
+			"repository", job.RepositoryOwner+"/"+job.RepositoryName,
+			"max_runners_per_repository", pool.MaxRunnersPerRepository)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package pool
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/cloudbase/garm/params"
+)
+
+func schedulingTestJobs(repos ...string) []params.Job {
+	jobs := make([]params.Job, 0, len(repos))
+	for idx, repo := range repos {
+		jobs = append(jobs, params.Job{
+			ID:              int64(idx + 1),
+			RepositoryOwner: "org",
+			RepositoryName:  repo,
+			Labels:          []string{"linux"},
+		})
+	}
+	return jobs
+}
+
+func scheduledRepos(jobs []params.Job) string {
+	ret := ""
+	for _, job := range jobs {
+		ret += job.RepositoryName
+	}
+	return ret
+}
+
+func TestScheduleQueuedJobs(t *testing.T) {
+	jobs := schedulingTestJobs("a", "a", "a", "a", "b", "c", "b")
+
+	tests := []struct {
+		name       string
+		scheduling params.JobScheduling
+		active     map[string]uint
+		expected   string
+	}{
+		{
+			name:       "fifo",
+			scheduling: params.JobScheduling{Policy: params.JobSchedulingFIFO},
+			expected:   "aaaabcb",
+		},
+		{
+			name:       "round robin",
+			scheduling: params.JobScheduling{Policy: params.JobSchedulingRoundRobin},
+			expected:   "abcabaa",
+		},
+		{
+			name:       "round robin with active jobs",
+			scheduling: params.JobScheduling{Policy: params.JobSchedulingRoundRobin},
+			active:     map[string]uint{"org/a": 2},
+			expected:   "bcbaaaa",
+		},
+		{
+			name: "weighted",
+			scheduling: params.JobScheduling{
+				Policy:            params.JobSchedulingWeighted,
+				RepositoryWeights: map[string]uint{"Org/A": 3},
+			},
+			expected: "abcaaab",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := scheduledRepos(scheduleQueuedJobs(jobs, tc.active, tc.scheduling))
+			if got != tc.expected {
+				t.Fatalf("expected order %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestScheduleQueuedJobsPriorities(t *testing.T) {
+	jobs := schedulingTestJobs("a", "a", "b", "c")
+	jobs[1].Labels = append(jobs[1].Labels, "Urgent")
+	jobs[2].LockedBy = uuid.New()
+
+	scheduling := params.JobScheduling{
+		Policy:          params.JobSchedulingRoundRobin,
+		LabelPriorities: map[string]int{"urgent": 10},
+	}
+	scheduled := scheduleQueuedJobs(jobs, nil, scheduling)
+	ids := ""
+	for _, job := range scheduled {
+		ids += fmt.Sprintf("%d", job.ID)
+	}
+	// The urgent job goes first and counts towards the share of its repository.
+	// The locked job goes last.
+	if ids != "2413" {
+		t.Fatalf("expected order 2413, got %s", ids)
+	}
+}
@@ -24,6 +24,10 @@ func (s *stubGithubClient) CreateEntityHook(_ context.Context, _ *github.Hook) (
 	return nil, s.err
 }
 
+func (s *stubGithubClient) EditEntityHook(_ context.Context, _ int64, _ *github.Hook) (*github.Hook, error) {
+	return nil, s.err
+}
+
 func (s *stubGithubClient) DeleteEntityHook(_ context.Context, _ int64) (*github.Response, error) {
 	return nil, s.err
 }
@@ -32,10 +36,22 @@ func (s *stubGithubClient) PingEntityHook(_ context.Context, _ int64) (*github.R
 	return nil, s.err
 }
 
+func (s *stubGithubClient) ListEntityHookDeliveries(_ context.Context, _ int64, _ *github.ListCursorOptions) ([]*github.HookDelivery, *github.Response, error) {
+	return nil, nil, s.err
+}
+
+func (s *stubGithubClient) GetEntityHookDelivery(_ context.Context, _, _ int64) (*github.HookDelivery, error) {
+	return nil, s.err
+}
+
 func (s *stubGithubClient) ListEntityRunners(_ context.Context, _ *github.ListOptions) (*github.Runners, *github.Response, error) {
 	return nil, nil, s.err
 }
 
+func (s *stubGithubClient) GetEntityRunner(_ context.Context, _ int64) (*github.Runner, error) {
+	return nil, s.err
+}
+
 func (s *stubGithubClient) ListEntityRunnerApplicationDownloads(_ context.Context) ([]*github.RunnerApplicationDownload, *github.Response, error) {
 	return nil, nil, s.err
 }
@@ -55,3 +71,11 @@ func (s *stubGithubClient) GetEntityJITConfig(_ context.Context, _ string, _ par
 func (s *stubGithubClient) GetWorkflowJobByID(_ context.Context, _, _ string, _ int64) (*github.WorkflowJob, *github.Response, error) {
 	return nil, nil, s.err
 }
+
+func (s *stubGithubClient) GetWorkflowRunByID(_ context.Context, _, _ string, _ int64) (*github.WorkflowRun, *github.Response, error) {
+	return nil, nil, s.err
+}
+
+func (s *stubGithubClient) GetWorkflowByID(_ context.Context, _, _ string, _ int64) (*github.Workflow, *github.Response, error) {
+	return nil, nil, s.err
+}
@@ -0,0 +1,62 @@
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/pkg/errors"
+
+	"github.com/cloudbase/garm/params"
+)
+
+// instanceReapRetention is how long we keep the reason an instance was reaped for.
+const instanceReapRetention = 7 * 24 * time.Hour
+
+// reapReason describes why a runner that did not finish bootstrapping within
+// timeout minutes is removed. ghRunner is nil if the runner is not registered
+// in GitHub.
+func reapReason(instance params.Instance, ghRunner *github.Runner, timeout uint) string {
+	if ghRunner == nil {
+		return fmt.Sprintf(
+			"runner did not join GitHub within %d minutes (last stage: %s)",
+			timeout, instance.LastStage())
+	}
+	return fmt.Sprintf(
+		"runner is offline in GitHub and was not updated for %d minutes (last stage: %s)",
+		timeout, instance.LastStage())
+}
+
+// recordReap records why garm is about to remove an instance on its own, so
+// operators can tell why the runner went away. The reap is stored apart from
+// the instance, along with its timeline and console output, so it outlives
+// the instance.
+func (r *basePoolManager) recordReap(instance params.Instance, reason string, consoleOutput []byte) {
+	slog.InfoContext(
+		r.ctx, "reaping runner",
+		"runner_name", instance.Name,
+		"pool_id", instance.PoolID,
+		"reason", reason)
+	reapParams := params.RecordInstanceReapParams{
+		InstanceName:  instance.Name,
+		PoolID:        instance.PoolID,
+		Reason:        reason,
+		Timeline:      instance.Timeline(),
+		ConsoleOutput: consoleOutput,
+	}
+	if _, err := r.store.RecordInstanceReap(r.ctx, reapParams); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			r.ctx, "failed to record reap reason",
+			"runner_name", instance.Name)
+	}
+}
+
+// pruneInstanceReaps removes the reaps of instances that were removed a while ago.
+func (r *basePoolManager) pruneInstanceReaps() error {
+	olderThan := time.Now().UTC().Add(-instanceReapRetention)
+	if err := r.store.DeleteInstanceReapsOlderThan(r.ctx, olderThan); err != nil {
+		return errors.Wrap(err, "deleting instance reaps")
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package pool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+
+	"github.com/cloudbase/garm/params"
+)
+
+func TestReapReason(t *testing.T) {
+	now := time.Now().UTC()
+	instance := params.Instance{Lifecycle: params.InstanceLifecycle{FirstCallbackAt: &now}}
+
+	expected := "runner did not join GitHub within 20 minutes (last stage: first_callback)"
+	if got := reapReason(instance, nil, 20); got != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+
+	expected = "runner is offline in GitHub and was not updated for 20 minutes (last stage: first_callback)"
+	if got := reapReason(instance, &github.Runner{Status: github.String("offline")}, 20); got != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+}
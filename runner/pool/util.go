@@ -19,6 +19,7 @@ type poolCacheStore interface {
 	Next() (params.Pool, error)
 	Reset()
 	Len() int
+	Pools() []params.Pool
 }
 
 type poolRoundRobin struct {
@@ -43,6 +44,21 @@ func (p *poolRoundRobin) Reset() {
 	atomic.StoreUint32(&p.next, 0)
 }
 
+func (p *poolRoundRobin) Pools() []params.Pool {
+	return p.pools
+}
+
+// poolsHandleRepository returns true if any of the pools may create runners
+// for jobs of the repository.
+func poolsHandleRepository(pools []params.Pool, owner, name string) bool {
+	for _, pool := range pools {
+		if pool.HandlesRepository(owner, name) {
+			return true
+		}
+	}
+	return false
+}
+
 type poolsForTags struct {
 	pools         sync.Map
 	poolCacheType params.PoolBalancerType
@@ -1,6 +1,7 @@
 package pool
 
 import (
+	"strings"
 	"sync"
 	"testing"
 
@@ -226,3 +227,53 @@ func TestPoolsForTagsBalancerTypePack(t *testing.T) {
 		t.Fatalf("expected 0, got %d", poolCache.next)
 	}
 }
+
+func TestPoolsHandleRepository(t *testing.T) {
+	p := &poolRoundRobin{
+		pools: []params.Pool{
+			{
+				ID:                   "1",
+				IncludedRepositories: []string{"org/trusted-*"},
+			},
+			{
+				ID:                   "2",
+				ExcludedRepositories: []string{"org/trusted-*", "*/fork-*"},
+			},
+		},
+	}
+
+	tests := map[string]bool{
+		"org/trusted-app": true,
+		"Org/Trusted-App": true,
+		"org/app":         true,
+		"org/fork-app":    false,
+	}
+	for repo, expected := range tests {
+		owner, name, _ := strings.Cut(repo, "/")
+		if got := poolsHandleRepository(p.Pools(), owner, name); got != expected {
+			t.Fatalf("expected %v for %s, got %v", expected, repo, got)
+		}
+	}
+
+	if p.pools[0].HandlesRepository("org", "app") {
+		t.Fatalf("expected pool 1 to only handle included repositories")
+	}
+	if p.pools[1].HandlesRepository("org", "trusted-app") {
+		t.Fatalf("expected pool 2 to skip excluded repositories")
+	}
+}
+
+func TestValidateIdleRunnersRepositories(t *testing.T) {
+	if err := params.ValidateIdleRunnersRepositories(1, []string{"org/*"}, nil); err == nil {
+		t.Fatalf("expected idle runners to be rejected for pools with included repositories")
+	}
+	if err := params.ValidateIdleRunnersRepositories(1, nil, []string{"org/fork-*"}); err == nil {
+		t.Fatalf("expected idle runners to be rejected for pools with excluded repositories")
+	}
+	if err := params.ValidateIdleRunnersRepositories(0, []string{"org/*"}, []string{"org/fork-*"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := params.ValidateIdleRunnersRepositories(1, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,151 @@
+package runner
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/auth"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/util/appdefaults"
+)
+
+func (r *Runner) CreatePoolTemplate(ctx context.Context, param params.CreatePoolTemplateParams) (params.PoolTemplate, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.PoolTemplate{}, runnerErrors.ErrUnauthorized
+	}
+
+	if err := param.Validate(); err != nil {
+		return params.PoolTemplate{}, errors.Wrap(err, "failed to validate pool template params")
+	}
+
+	if !IsSupportedOSType(param.OSType) {
+		return params.PoolTemplate{}, runnerErrors.NewBadRequestError("invalid OS type %s", param.OSType)
+	}
+
+	if !IsSupportedArch(param.OSArch) {
+		return params.PoolTemplate{}, runnerErrors.NewBadRequestError("invalid OS architecture %s", param.OSArch)
+	}
+
+	if _, ok := r.providers[param.ProviderName]; !ok {
+		return params.PoolTemplate{}, runnerErrors.NewBadRequestError("no such provider %s", param.ProviderName)
+	}
+
+	if param.RunnerBootstrapTimeout == 0 {
+		param.RunnerBootstrapTimeout = appdefaults.DefaultRunnerBootstrapTimeout
+	}
+
+	tpl, err := r.store.CreatePoolTemplate(ctx, param)
+	if err != nil {
+		return params.PoolTemplate{}, errors.Wrap(err, "failed to create pool template")
+	}
+	return tpl, nil
+}
+
+func (r *Runner) GetPoolTemplate(ctx context.Context, templateID string) (params.PoolTemplate, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.PoolTemplate{}, runnerErrors.ErrUnauthorized
+	}
+
+	tpl, err := r.store.GetPoolTemplate(ctx, templateID)
+	if err != nil {
+		return params.PoolTemplate{}, errors.Wrap(err, "failed to get pool template")
+	}
+	return tpl, nil
+}
+
+func (r *Runner) ListPoolTemplates(ctx context.Context) ([]params.PoolTemplate, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, runnerErrors.ErrUnauthorized
+	}
+
+	templates, err := r.store.ListPoolTemplates(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pool templates")
+	}
+	return templates, nil
+}
+
+func (r *Runner) ListPoolTemplatePools(ctx context.Context, templateID string) ([]params.Pool, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, runnerErrors.ErrUnauthorized
+	}
+
+	pools, err := r.store.ListPoolTemplatePools(ctx, templateID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pool template pools")
+	}
+	return pools, nil
+}
+
+func (r *Runner) DeletePoolTemplate(ctx context.Context, templateID string) error {
+	if !auth.IsAdmin(ctx) {
+		return runnerErrors.ErrUnauthorized
+	}
+
+	if err := r.store.DeletePoolTemplate(ctx, templateID); err != nil {
+		return errors.Wrap(err, "failed to delete pool template")
+	}
+	return nil
+}
+
+// UpdatePoolTemplate updates a pool template and propagates the changes to all pools
+// derived from it. When dryRun is set, nothing is saved and the returned result only
+// describes the changes that would be made.
+func (r *Runner) UpdatePoolTemplate(ctx context.Context, templateID string, param params.UpdatePoolTemplateParams, dryRun bool) (params.PoolTemplateUpdateResult, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.PoolTemplateUpdateResult{}, runnerErrors.ErrUnauthorized
+	}
+
+	if err := param.Validate(); err != nil {
+		return params.PoolTemplateUpdateResult{}, errors.Wrap(err, "failed to validate pool template params")
+	}
+
+	if param.OSType != "" && !IsSupportedOSType(param.OSType) {
+		return params.PoolTemplateUpdateResult{}, runnerErrors.NewBadRequestError("invalid OS type %s", param.OSType)
+	}
+
+	if param.OSArch != "" && !IsSupportedArch(param.OSArch) {
+		return params.PoolTemplateUpdateResult{}, runnerErrors.NewBadRequestError("invalid OS architecture %s", param.OSArch)
+	}
+
+	if !dryRun {
+		tpl, changes, err := r.store.UpdatePoolTemplate(ctx, templateID, param)
+		if err != nil {
+			return params.PoolTemplateUpdateResult{}, errors.Wrap(err, "failed to update pool template")
+		}
+		return params.PoolTemplateUpdateResult{
+			Template: tpl,
+			Changes:  changes,
+		}, nil
+	}
+
+	tpl, err := r.store.GetPoolTemplate(ctx, templateID)
+	if err != nil {
+		return params.PoolTemplateUpdateResult{}, errors.Wrap(err, "failed to get pool template")
+	}
+
+	tpl, err = param.Apply(tpl)
+	if err != nil {
+		return params.PoolTemplateUpdateResult{}, errors.Wrap(err, "failed to apply pool template changes")
+	}
+
+	pools, err := r.store.ListPoolTemplatePools(ctx, templateID)
+	if err != nil {
+		return params.PoolTemplateUpdateResult{}, errors.Wrap(err, "failed to list pool template pools")
+	}
+
+	ret := params.PoolTemplateUpdateResult{
+		Template: tpl,
+		DryRun:   true,
+	}
+	for _, pool := range pools {
+		_, changes, err := tpl.PoolUpdateParams(pool)
+		if err != nil {
+			return params.PoolTemplateUpdateResult{}, errors.Wrap(err, "failed to compute pool changes")
+		}
+		ret.Changes = append(ret.Changes, changes...)
+	}
+	return ret, nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/auth"
+	"github.com/cloudbase/garm/database"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
+	runnerCommonMocks "github.com/cloudbase/garm/runner/common/mocks"
+)
+
+type PoolTemplateTestSuite struct {
+	suite.Suite
+	Store    dbCommon.Store
+	Runner   *Runner
+	adminCtx context.Context
+	org      params.Organization
+}
+
+func (s *PoolTemplateTestSuite) SetupTest() {
+	adminCtx := auth.GetAdminContext(context.Background())
+	db, err := database.NewDatabase(adminCtx, garmTesting.GetTestSqliteDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+	s.adminCtx = garmTesting.ImpersonateAdminContext(adminCtx, db, s.T())
+
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(s.adminCtx, db, s.T())
+	creds := garmTesting.CreateTestGithubCredentials(s.adminCtx, "test-creds", db, s.T(), endpoint)
+	s.org, err = db.CreateOrganization(s.adminCtx, "test-org", creds.Name, "test-webhookSecret", params.PoolBalancerTypeRoundRobin)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create org: %s", err))
+	}
+
+	s.Runner = &Runner{
+		providers: map[string]common.Provider{
+			"test-provider": runnerCommonMocks.NewProvider(s.T()),
+		},
+		store: db,
+		ctx:   s.adminCtx,
+	}
+}
+
+func (s *PoolTemplateTestSuite) createTemplate() params.PoolTemplate {
+	tpl, err := s.Runner.CreatePoolTemplate(s.adminCtx, params.CreatePoolTemplateParams{
+		Name:         "linux-small",
+		ProviderName: "test-provider",
+		MaxRunners:   4,
+		Image:        "ubuntu:22.04",
+		Flavor:       "small",
+		OSType:       "linux",
+		OSArch:       "amd64",
+		Tags:         []string{"self-hosted"},
+	})
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create pool template: %s", err))
+	}
+	return tpl
+}
+
+func (s *PoolTemplateTestSuite) TestCreatePoolTemplateUnknownProvider() {
+	_, err := s.Runner.CreatePoolTemplate(s.adminCtx, params.CreatePoolTemplateParams{
+		Name:         "linux-small",
+		ProviderName: "missing-provider",
+		MaxRunners:   4,
+		Image:        "ubuntu:22.04",
+		Flavor:       "small",
+		OSType:       "linux",
+		OSArch:       "amd64",
+		Tags:         []string{"self-hosted"},
+	})
+
+	var badRequest *runnerErrors.BadRequestError
+	s.Require().ErrorAs(err, &badRequest)
+}
+
+func (s *PoolTemplateTestSuite) TestCreatePoolTemplateErrUnauthorized() {
+	_, err := s.Runner.CreatePoolTemplate(context.Background(), params.CreatePoolTemplateParams{})
+
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
+func (s *PoolTemplateTestSuite) TestCreateOrgPoolFromTemplate() {
+	tpl := s.createTemplate()
+
+	pool, err := s.Runner.CreateOrgPool(s.adminCtx, s.org.ID, params.CreatePoolParams{
+		TemplateID: tpl.ID,
+		Flavor:     "large",
+	})
+
+	s.Require().Nil(err)
+	s.Require().Equal(tpl.ID, pool.TemplateID)
+	s.Require().Equal("test-provider", pool.ProviderName)
+	s.Require().Equal("ubuntu:22.04", pool.Image)
+	s.Require().Equal("large", pool.Flavor)
+	s.Require().Equal([]string{params.PoolTemplateFieldFlavor}, pool.TemplateOverrides)
+}
+
+func (s *PoolTemplateTestSuite) TestUpdatePoolTemplateDryRun() {
+	tpl := s.createTemplate()
+	pool, err := s.Runner.CreateOrgPool(s.adminCtx, s.org.ID, params.CreatePoolParams{
+		TemplateID: tpl.ID,
+	})
+	s.Require().Nil(err)
+
+	result, err := s.Runner.UpdatePoolTemplate(s.adminCtx, tpl.ID, params.UpdatePoolTemplateParams{
+		Image: "ubuntu:24.04",
+	}, true)
+
+	s.Require().Nil(err)
+	s.Require().True(result.DryRun)
+	s.Require().Equal("ubuntu:24.04", result.Template.Image)
+	s.Require().Equal([]params.PoolTemplateChange{
+		{
+			PoolID: pool.ID,
+			Field:  params.PoolTemplateFieldImage,
+			Old:    "ubuntu:22.04",
+			New:    "ubuntu:24.04",
+		},
+	}, result.Changes)
+
+	tpl, err = s.Store.GetPoolTemplate(s.adminCtx, tpl.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("ubuntu:22.04", tpl.Image)
+	pool, err = s.Store.GetPoolByID(s.adminCtx, pool.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("ubuntu:22.04", pool.Image)
+}
+
+func (s *PoolTemplateTestSuite) TestUpdatePoolTemplate() {
+	tpl := s.createTemplate()
+	pool, err := s.Runner.CreateOrgPool(s.adminCtx, s.org.ID, params.CreatePoolParams{
+		TemplateID: tpl.ID,
+	})
+	s.Require().Nil(err)
+
+	result, err := s.Runner.UpdatePoolTemplate(s.adminCtx, tpl.ID, params.UpdatePoolTemplateParams{
+		Image: "ubuntu:24.04",
+	}, false)
+
+	s.Require().Nil(err)
+	s.Require().False(result.DryRun)
+	s.Require().Len(result.Changes, 1)
+	pool, err = s.Store.GetPoolByID(s.adminCtx, pool.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("ubuntu:24.04", pool.Image)
+}
+
+func TestPoolTemplateTestSuite(t *testing.T) {
+	suite.Run(t, new(PoolTemplateTestSuite))
+}
@@ -22,6 +22,7 @@ import (
 	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
 	"github.com/cloudbase/garm/auth"
 	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
 )
 
 func (r *Runner) ListAllPools(ctx context.Context) ([]params.Pool, error) {
@@ -81,6 +82,25 @@ func (r *Runner) UpdatePoolByID(ctx context.Context, poolID string, param params
 		return params.Pool{}, errors.Wrap(err, "fetching pool")
 	}
 
+	if err := validatePoolUpdate(pool, param); err != nil {
+		return params.Pool{}, err
+	}
+
+	entity, err := pool.GithubEntity()
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "getting entity")
+	}
+
+	newPool, err := r.store.UpdateEntityPool(ctx, entity, poolID, param)
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "updating pool")
+	}
+	return newPool, nil
+}
+
+// validatePoolUpdate checks the update params against the current settings of
+// the pool they are applied to.
+func validatePoolUpdate(pool params.Pool, param params.UpdatePoolParams) error {
 	maxRunners := pool.MaxRunners
 	minIdleRunners := pool.MinIdleRunners
 
@@ -92,23 +112,182 @@ func (r *Runner) UpdatePoolByID(ctx context.Context, poolID string, param params
 	}
 
 	if param.RunnerBootstrapTimeout != nil && *param.RunnerBootstrapTimeout == 0 {
-		return params.Pool{}, runnerErrors.NewBadRequestError("runner_bootstrap_timeout cannot be 0")
+		return runnerErrors.NewBadRequestError("runner_bootstrap_timeout cannot be 0")
 	}
 
 	if minIdleRunners > maxRunners {
-		return params.Pool{}, runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners")
+		return runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners")
 	}
 
-	entity, err := pool.GithubEntity()
+	reusable := pool.Reusable
+	runnerMaxJobs := pool.RunnerMaxJobs
+	runnerMaxLifetime := pool.RunnerMaxLifetime
+	if param.Reusable != nil {
+		reusable = *param.Reusable
+	}
+	if param.RunnerMaxJobs != nil {
+		runnerMaxJobs = *param.RunnerMaxJobs
+	}
+	if param.RunnerMaxLifetime != nil {
+		runnerMaxLifetime = *param.RunnerMaxLifetime
+	}
+	if err := params.ValidateRunnerReuse(reusable, runnerMaxJobs, runnerMaxLifetime); err != nil {
+		return runnerErrors.NewBadRequestError("%s", err)
+	}
+
+	if err := params.ValidateRepositoryPatterns(param.IncludedRepositories); err != nil {
+		return runnerErrors.NewBadRequestError("%s", err)
+	}
+	if err := params.ValidateRepositoryPatterns(param.ExcludedRepositories); err != nil {
+		return runnerErrors.NewBadRequestError("%s", err)
+	}
+	included := pool.IncludedRepositories
+	if param.IncludedRepositories != nil {
+		included = param.IncludedRepositories
+	}
+	excluded := pool.ExcludedRepositories
+	if param.ExcludedRepositories != nil {
+		excluded = param.ExcludedRepositories
+	}
+	if err := params.ValidateIdleRunnersRepositories(minIdleRunners, included, excluded); err != nil {
+		return runnerErrors.NewBadRequestError("%s", err)
+	}
+	if err := params.ValidatePoolPolicy(param.Policy); err != nil {
+		return runnerErrors.NewBadRequestError("%s", err)
+	}
+	policy := pool.Policy
+	if param.Policy != nil {
+		policy = param.Policy
+	}
+	if err := params.ValidateIdleRunnersPolicy(minIdleRunners, policy); err != nil {
+		return runnerErrors.NewBadRequestError("%s", err)
+	}
+
+	if param.HourlyCost != nil {
+		if err := params.ValidateHourlyCost(*param.HourlyCost); err != nil {
+			return runnerErrors.NewBadRequestError("%s", err)
+		}
+	}
+	return nil
+}
+
+// getPoolAndManager returns a pool along with the pool manager of the entity
+// the pool belongs to.
+func (r *Runner) getPoolAndManager(ctx context.Context, poolID string) (params.Pool, common.PoolManager, error) {
+	pool, err := r.store.GetPoolByID(ctx, poolID)
 	if err != nil {
-		return params.Pool{}, errors.Wrap(err, "getting entity")
+		return params.Pool{}, nil, errors.Wrap(err, "fetching pool")
 	}
 
-	newPool, err := r.store.UpdateEntityPool(ctx, entity, poolID, param)
+	poolMgr, err := r.getPoolManagerFromPool(ctx, pool)
 	if err != nil {
-		return params.Pool{}, errors.Wrap(err, "updating pool")
+		return params.Pool{}, nil, errors.Wrap(err, "fetching pool manager")
 	}
-	return newPool, nil
+	return pool, poolMgr, nil
+}
+
+// CordonPool stops the creation of new runners in a pool. Existing runners are kept.
+func (r *Runner) CordonPool(ctx context.Context, poolID string) (params.Pool, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.Pool{}, runnerErrors.ErrUnauthorized
+	}
+
+	_, poolMgr, err := r.getPoolAndManager(ctx, poolID)
+	if err != nil {
+		return params.Pool{}, err
+	}
+
+	pool, err := poolMgr.CordonPool(poolID)
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "cordoning pool")
+	}
+	return pool, nil
+}
+
+// UncordonPool allows a cordoned pool to create new runners again.
+func (r *Runner) UncordonPool(ctx context.Context, poolID string) (params.Pool, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.Pool{}, runnerErrors.ErrUnauthorized
+	}
+
+	_, poolMgr, err := r.getPoolAndManager(ctx, poolID)
+	if err != nil {
+		return params.Pool{}, err
+	}
+
+	pool, err := poolMgr.UncordonPool(poolID)
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "uncordoning pool")
+	}
+	return pool, nil
+}
+
+// DrainPool cordons a pool and drains all its runners. Runners are removed once
+// they are no longer running a job.
+func (r *Runner) DrainPool(ctx context.Context, poolID string) (params.Pool, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.Pool{}, runnerErrors.ErrUnauthorized
+	}
+
+	_, poolMgr, err := r.getPoolAndManager(ctx, poolID)
+	if err != nil {
+		return params.Pool{}, err
+	}
+
+	pool, err := poolMgr.DrainPool(poolID)
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "draining pool")
+	}
+	return pool, nil
+}
+
+// RecyclePool replaces all existing runners of a pool, draining at most
+// param.MaxUnavailable runners at a time.
+func (r *Runner) RecyclePool(ctx context.Context, poolID string, param params.RecyclePoolParams) (params.Pool, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.Pool{}, runnerErrors.ErrUnauthorized
+	}
+
+	if err := param.Validate(); err != nil {
+		return params.Pool{}, errors.Wrap(err, "validating params")
+	}
+
+	pool, poolMgr, err := r.getPoolAndManager(ctx, poolID)
+	if err != nil {
+		return params.Pool{}, err
+	}
+	if param.MaxUnavailable > pool.MaxRunners && pool.MaxRunners > 0 {
+		return params.Pool{}, runnerErrors.NewBadRequestError("max_unavailable cannot be larger than max_runners")
+	}
+
+	pool, err = poolMgr.RecyclePool(poolID, param)
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "recycling pool")
+	}
+	return pool, nil
+}
+
+// AdoptPoolRunners creates database records for the runners of a pool that exist
+// in the provider and are registered in GitHub, but that GARM does not know about.
+func (r *Runner) AdoptPoolRunners(ctx context.Context, poolID string, param params.AdoptRunnersParams) (params.AdoptRunnersResult, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.AdoptRunnersResult{}, runnerErrors.ErrUnauthorized
+	}
+
+	if err := param.Validate(); err != nil {
+		return params.AdoptRunnersResult{}, errors.Wrap(err, "validating params")
+	}
+
+	_, poolMgr, err := r.getPoolAndManager(ctx, poolID)
+	if err != nil {
+		return params.AdoptRunnersResult{}, err
+	}
+
+	result, err := poolMgr.AdoptRunners(ctx, poolID, param)
+	if err != nil {
+		return params.AdoptRunnersResult{}, errors.Wrap(err, "adopting runners")
+	}
+	return result, nil
 }
 
 func (r *Runner) ListAllJobs(ctx context.Context) ([]params.Job, error) {
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
@@ -29,6 +31,8 @@ import (
 	garmTesting "github.com/cloudbase/garm/internal/testing"
 	"github.com/cloudbase/garm/params"
 	"github.com/cloudbase/garm/runner/common"
+	runnerCommonMocks "github.com/cloudbase/garm/runner/common/mocks"
+	runnerMocks "github.com/cloudbase/garm/runner/mocks"
 )
 
 type PoolTestFixtures struct {
@@ -39,6 +43,8 @@ type PoolTestFixtures struct {
 	Credentials          map[string]config.Github
 	CreateInstanceParams params.CreateInstanceParams
 	UpdatePoolParams     params.UpdatePoolParams
+	PoolMgrMock          *runnerCommonMocks.PoolManager
+	PoolMgrCtrlMock      *runnerMocks.PoolManagerController
 }
 
 type PoolTestSuite struct {
@@ -121,14 +127,17 @@ func (s *PoolTestSuite) SetupTest() {
 			Name:   "test-instance-name",
 			OSType: "linux",
 		},
+		PoolMgrMock:     runnerCommonMocks.NewPoolManager(s.T()),
+		PoolMgrCtrlMock: runnerMocks.NewPoolManagerController(s.T()),
 	}
 	s.Fixtures = fixtures
 
 	// setup test runner
 	runner := &Runner{
-		providers: fixtures.Providers,
-		store:     fixtures.Store,
-		ctx:       fixtures.AdminContext,
+		providers:       fixtures.Providers,
+		store:           fixtures.Store,
+		ctx:             fixtures.AdminContext,
+		poolManagerCtrl: fixtures.PoolMgrCtrlMock,
 	}
 	s.Runner = runner
 }
@@ -209,6 +218,21 @@ func (s *PoolTestSuite) TestUpdatePoolByID() {
 	s.Require().Equal(s.Fixtures.UpdatePoolParams.Flavor, pool.Flavor)
 }
 
+func (s *PoolTestSuite) TestUpdatePoolByIDReusable() {
+	reusable := true
+	s.Fixtures.UpdatePoolParams.Reusable = &reusable
+
+	_, err := s.Runner.UpdatePoolByID(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID, s.Fixtures.UpdatePoolParams)
+	s.Require().Equal(runnerErrors.NewBadRequestError("reusable pools need runner_max_jobs or runner_max_lifetime"), err)
+
+	var maxJobs uint = 10
+	s.Fixtures.UpdatePoolParams.RunnerMaxJobs = &maxJobs
+	pool, err := s.Runner.UpdatePoolByID(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID, s.Fixtures.UpdatePoolParams)
+	s.Require().Nil(err)
+	s.Require().True(pool.Reusable)
+	s.Require().Equal(maxJobs, pool.RunnerMaxJobs)
+}
+
 func (s *PoolTestSuite) TestUpdatePoolByIDErrUnauthorized() {
 	_, err := s.Runner.UpdatePoolByID(context.Background(), "dummy-pool-id", s.Fixtures.UpdatePoolParams)
 
@@ -246,6 +270,177 @@ func (s *PoolTestSuite) TestTestUpdatePoolByIDMinIdleGreaterThanMax() {
 	s.Require().Equal(runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners"), err)
 }
 
+func (s *PoolTestSuite) TestUpdatePoolByIDRepositoryFiltersWithIdleRunners() {
+	s.Fixtures.UpdatePoolParams.IncludedRepositories = []string{"test-org/trusted-*"}
+
+	_, err := s.Runner.UpdatePoolByID(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID, s.Fixtures.UpdatePoolParams)
+	s.Require().Equal(runnerErrors.NewBadRequestError("min_idle_runners must be 0 for pools with repository filters"), err)
+
+	var minIdleRunners uint
+	s.Fixtures.UpdatePoolParams.MinIdleRunners = &minIdleRunners
+	pool, err := s.Runner.UpdatePoolByID(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID, s.Fixtures.UpdatePoolParams)
+	s.Require().Nil(err)
+	s.Require().Equal([]string{"test-org/trusted-*"}, pool.IncludedRepositories)
+
+	// The pool keeps its filters, so idle runners are still rejected.
+	minIdleRunners = 1
+	_, err = s.Runner.UpdatePoolByID(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID, params.UpdatePoolParams{MinIdleRunners: &minIdleRunners})
+	s.Require().Equal(runnerErrors.NewBadRequestError("min_idle_runners must be 0 for pools with repository filters"), err)
+}
+
+func (s *PoolTestSuite) TestCordonPool() {
+	pool := s.Fixtures.Pools[0]
+	pool.Cordoned = true
+	s.Fixtures.PoolMgrCtrlMock.On("GetOrgPoolManager", mock.AnythingOfType("params.Organization")).Return(s.Fixtures.PoolMgrMock, nil)
+	s.Fixtures.PoolMgrMock.On("CordonPool", pool.ID).Return(pool, nil)
+
+	cordoned, err := s.Runner.CordonPool(s.Fixtures.AdminContext, pool.ID)
+
+	s.Require().Nil(err)
+	s.Require().True(cordoned.Cordoned)
+	s.Fixtures.PoolMgrMock.AssertExpectations(s.T())
+}
+
+func (s *PoolTestSuite) TestCordonPoolErrUnauthorized() {
+	_, err := s.Runner.CordonPool(context.Background(), s.Fixtures.Pools[0].ID)
+
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
+func (s *PoolTestSuite) TestUncordonPool() {
+	pool := s.Fixtures.Pools[0]
+	s.Fixtures.PoolMgrCtrlMock.On("GetOrgPoolManager", mock.AnythingOfType("params.Organization")).Return(s.Fixtures.PoolMgrMock, nil)
+	s.Fixtures.PoolMgrMock.On("UncordonPool", pool.ID).Return(pool, nil)
+
+	uncordoned, err := s.Runner.UncordonPool(s.Fixtures.AdminContext, pool.ID)
+
+	s.Require().Nil(err)
+	s.Require().False(uncordoned.Cordoned)
+	s.Fixtures.PoolMgrMock.AssertExpectations(s.T())
+}
+
+func (s *PoolTestSuite) TestDrainPool() {
+	pool := s.Fixtures.Pools[0]
+	s.Fixtures.PoolMgrCtrlMock.On("GetOrgPoolManager", mock.AnythingOfType("params.Organization")).Return(s.Fixtures.PoolMgrMock, nil)
+	s.Fixtures.PoolMgrMock.On("DrainPool", pool.ID).Return(pool, nil)
+
+	_, err := s.Runner.DrainPool(s.Fixtures.AdminContext, pool.ID)
+
+	s.Require().Nil(err)
+	s.Fixtures.PoolMgrMock.AssertExpectations(s.T())
+}
+
+func (s *PoolTestSuite) TestDrainPoolNotFound() {
+	err := s.Fixtures.Store.DeletePoolByID(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID)
+	s.Require().Nil(err)
+
+	_, err = s.Runner.DrainPool(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID)
+
+	s.Require().ErrorIs(err, runnerErrors.ErrNotFound)
+}
+
+func (s *PoolTestSuite) TestRecyclePoolDefaultsMaxUnavailable() {
+	pool := s.Fixtures.Pools[0]
+	s.Fixtures.PoolMgrCtrlMock.On("GetOrgPoolManager", mock.AnythingOfType("params.Organization")).Return(s.Fixtures.PoolMgrMock, nil)
+	s.Fixtures.PoolMgrMock.On("RecyclePool", pool.ID, params.RecyclePoolParams{MaxUnavailable: 1}).Return(pool, nil)
+
+	_, err := s.Runner.RecyclePool(s.Fixtures.AdminContext, pool.ID, params.RecyclePoolParams{})
+
+	s.Require().Nil(err)
+	s.Fixtures.PoolMgrMock.AssertExpectations(s.T())
+}
+
+func (s *PoolTestSuite) TestRecyclePoolMaxUnavailableTooLarge() {
+	s.Fixtures.PoolMgrCtrlMock.On("GetOrgPoolManager", mock.AnythingOfType("params.Organization")).Return(s.Fixtures.PoolMgrMock, nil)
+
+	_, err := s.Runner.RecyclePool(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID, params.RecyclePoolParams{MaxUnavailable: 5})
+
+	s.Require().Equal(runnerErrors.NewBadRequestError("max_unavailable cannot be larger than max_runners"), err)
+}
+
+func (s *PoolTestSuite) TestDrainRunner() {
+	instance, err := s.Fixtures.Store.CreateInstance(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID, s.Fixtures.CreateInstanceParams)
+	s.Require().Nil(err)
+	s.Fixtures.PoolMgrCtrlMock.On("GetOrgPoolManager", mock.AnythingOfType("params.Organization")).Return(s.Fixtures.PoolMgrMock, nil)
+	s.Fixtures.PoolMgrMock.On("DrainRunner", mock.MatchedBy(func(runner params.Instance) bool {
+		return runner.Name == instance.Name
+	})).Return(nil)
+
+	err = s.Runner.DrainRunner(s.Fixtures.AdminContext, instance.Name)
+
+	s.Require().Nil(err)
+	s.Fixtures.PoolMgrMock.AssertExpectations(s.T())
+}
+
+func (s *PoolTestSuite) TestDrainRunnerErrUnauthorized() {
+	err := s.Runner.DrainRunner(context.Background(), "dummy-runner")
+
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
+func (s *PoolTestSuite) TestGetInstanceTimelineAfterReap() {
+	instance, err := s.Fixtures.Store.CreateInstance(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID, s.Fixtures.CreateInstanceParams)
+	s.Require().Nil(err)
+	_, err = s.Fixtures.Store.RecordInstanceReap(s.Fixtures.AdminContext, params.RecordInstanceReapParams{
+		InstanceName: instance.Name,
+		PoolID:       instance.PoolID,
+		Reason:       "runner did not join GitHub within 20 minutes (last stage: created)",
+		Timeline:     instance.Timeline(),
+	})
+	s.Require().Nil(err)
+
+	timeline, err := s.Runner.GetInstanceTimeline(s.Fixtures.AdminContext, instance.Name)
+	s.Require().Nil(err)
+	s.Require().False(timeline.Removed)
+	s.Require().Len(timeline.Entries, 2)
+	s.Require().Equal(params.ReapEvent, timeline.Entries[1].EventType)
+
+	err = s.Fixtures.Store.DeleteInstance(s.Fixtures.AdminContext, instance.PoolID, instance.Name)
+	s.Require().Nil(err)
+
+	timeline, err = s.Runner.GetInstanceTimeline(s.Fixtures.AdminContext, instance.Name)
+	s.Require().Nil(err)
+	s.Require().True(timeline.Removed)
+	s.Require().Equal(instance.Name, timeline.InstanceName)
+	s.Require().Len(timeline.Entries, 2)
+	s.Require().Equal(params.InstanceStageCreated, timeline.Entries[0].Stage)
+	s.Require().Equal(params.ReapEvent, timeline.Entries[1].EventType)
+	s.Require().Contains(timeline.Entries[1].Message, "did not join GitHub")
+}
+
+func (s *PoolTestSuite) TestGetInstanceConsoleOutputAfterReap() {
+	instance, err := s.Fixtures.Store.CreateInstance(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID, s.Fixtures.CreateInstanceParams)
+	s.Require().Nil(err)
+	_, err = s.Fixtures.Store.RecordInstanceReap(s.Fixtures.AdminContext, params.RecordInstanceReapParams{
+		InstanceName:  instance.Name,
+		PoolID:        instance.PoolID,
+		Reason:        "runner did not join GitHub within 20 minutes (last stage: created)",
+		Timeline:      instance.Timeline(),
+		ConsoleOutput: []byte("cloud-init failed"),
+	})
+	s.Require().Nil(err)
+	err = s.Fixtures.Store.DeleteInstance(s.Fixtures.AdminContext, instance.PoolID, instance.Name)
+	s.Require().Nil(err)
+
+	out, err := s.Runner.GetInstanceConsoleOutput(s.Fixtures.AdminContext, instance.Name)
+	s.Require().Nil(err)
+	s.Require().True(out.Stored)
+	s.Require().Equal(instance.Name, out.InstanceName)
+	s.Require().Equal("cloud-init failed", out.Output)
+}
+
+func (s *PoolTestSuite) TestGetInstanceConsoleOutputNotFound() {
+	_, err := s.Runner.GetInstanceConsoleOutput(s.Fixtures.AdminContext, "dummy-runner")
+
+	s.Require().True(errors.Is(err, runnerErrors.ErrNotFound))
+}
+
+func (s *PoolTestSuite) TestGetInstanceTimelineNotFound() {
+	_, err := s.Runner.GetInstanceTimeline(s.Fixtures.AdminContext, "dummy-runner")
+
+	s.Require().True(errors.Is(err, runnerErrors.ErrNotFound))
+}
+
 func TestPoolTestSuite(t *testing.T) {
 	suite.Run(t, new(PoolTestSuite))
 }
@@ -1,11 +1,50 @@
 package common
 
 import (
+	"errors"
+	"os/exec"
+
 	garmErrors "github.com/cloudbase/garm-provider-common/errors"
+	commonExecution "github.com/cloudbase/garm-provider-common/execution/common"
 	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
 	"github.com/cloudbase/garm/runner/providers/util"
 )
 
+// GetConsoleOutputCommand is an optional command that prints the console output
+// of an instance to stdout. Providers that don't implement it exit with an error.
+const GetConsoleOutputCommand commonExecution.ExecutionCommand = "GetConsoleOutput"
+
+// Exit codes external providers use to classify the reason CreateInstance
+// failed. Any other exit code is treated as a transient error.
+const (
+	ExitCodeQuotaExceeded int = 40
+	ExitCodeImageNotFound int = 41
+	ExitCodeInvalidConfig int = 42
+	ExitCodeTransient     int = 43
+)
+
+var exitCodeErrorClasses = map[int]params.ProviderErrorClass{
+	ExitCodeQuotaExceeded: params.ProviderErrorQuotaExceeded,
+	ExitCodeImageNotFound: params.ProviderErrorImageNotFound,
+	ExitCodeInvalidConfig: params.ProviderErrorInvalidConfig,
+	ExitCodeTransient:     params.ProviderErrorTransient,
+}
+
+// NewExecError returns the error for a failed provider command. If the exit
+// code of the provider classifies the failure, a common.ClassifiedProviderError
+// is returned.
+func NewExecError(execPath string, err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if class, ok := exitCodeErrorClasses[exitErr.ExitCode()]; ok {
+			return common.NewClassifiedProviderError(class, "provider binary %s returned error: %s", execPath, err)
+		}
+	}
+	return garmErrors.NewProviderError("provider binary %s returned error: %s", execPath, err)
+}
+
 func ValidateResult(inst commonParams.ProviderInstance) error {
 	if inst.ProviderID == "" {
 		return garmErrors.NewProviderError("missing provider ID")
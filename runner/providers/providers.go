@@ -24,6 +24,7 @@ import (
 	"github.com/cloudbase/garm/params"
 	"github.com/cloudbase/garm/runner/common"
 	"github.com/cloudbase/garm/runner/providers/external"
+	"github.com/cloudbase/garm/runner/providers/throttle"
 )
 
 // LoadProvidersFromConfig loads all providers from the config and populates
@@ -41,7 +42,7 @@ func LoadProvidersFromConfig(ctx context.Context, cfg config.Config, controllerI
 			if err != nil {
 				return nil, errors.Wrap(err, "creating provider")
 			}
-			providers[providerCfg.Name] = provider
+			providers[providerCfg.Name] = throttle.NewProvider(provider, providerCfg.Name, providerCfg.Throttle)
 		default:
 			return nil, errors.Errorf("unknown provider type %s", providerCfg.ProviderType)
 		}
@@ -0,0 +1,212 @@
+package throttle
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenBucket is a rate limiter that allows bursts of up to burst operations.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst uint, now time.Time) *tokenBucket {
+	if burst == 0 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+}
+
+// take consumes a token if one is available. Otherwise it returns the time
+// until the next token is available.
+func (b *tokenBucket) take(now time.Time) (bool, time.Duration) {
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	if wait <= 0 {
+		wait = time.Millisecond
+	}
+	return false, wait
+}
+
+type waiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// limiter bounds the number of concurrent operations and the rate at which
+// they start. Operations are queued per key and the queues are served in
+// turn, so a busy key does not starve the others.
+type limiter struct {
+	mux sync.Mutex
+
+	maxConcurrent int
+	bucket        *tokenBucket
+	now           func() time.Time
+	// onChange is called with the number of running and queued operations
+	// whenever they change.
+	onChange func(running, queued int)
+
+	running int
+	queued  int
+	queues  map[string][]*waiter
+	// keys holds the keys with queued operations, in the order they are served.
+	keys  []string
+	timer *time.Timer
+}
+
+func newLimiter(maxConcurrent uint, rate float64, burst uint, now func() time.Time, onChange func(running, queued int)) *limiter {
+	l := &limiter{
+		maxConcurrent: int(maxConcurrent),
+		now:           now,
+		onChange:      onChange,
+		queues:        map[string][]*waiter{},
+	}
+	if rate > 0 {
+		l.bucket = newTokenBucket(rate, burst, now())
+	}
+	return l
+}
+
+// hasCapacityLocked returns whether one more operation may start now. If the
+// rate limit is reached, a dispatch is scheduled for when the next token is
+// available.
+func (l *limiter) hasCapacityLocked() bool {
+	if l.maxConcurrent > 0 && l.running >= l.maxConcurrent {
+		return false
+	}
+	if l.bucket == nil {
+		return true
+	}
+	ok, wait := l.bucket.take(l.now())
+	if !ok && l.timer == nil {
+		l.timer = time.AfterFunc(wait, func() {
+			l.mux.Lock()
+			defer l.mux.Unlock()
+			l.timer = nil
+			l.dispatchLocked()
+			l.notifyLocked()
+		})
+	}
+	return ok
+}
+
+func (l *limiter) notifyLocked() {
+	if l.onChange != nil {
+		l.onChange(l.running, l.queued)
+	}
+}
+
+// dispatchLocked starts queued operations while there is capacity, taking one
+// operation from each key in turn.
+func (l *limiter) dispatchLocked() {
+	for len(l.keys) > 0 && l.hasCapacityLocked() {
+		key := l.keys[0]
+		queue := l.queues[key]
+		w := queue[0]
+		if len(queue) == 1 {
+			delete(l.queues, key)
+			l.keys = l.keys[1:]
+		} else {
+			l.queues[key] = queue[1:]
+			// Move the key to the back of the line.
+			l.keys = append(l.keys[1:], key)
+		}
+		l.queued--
+		l.running++
+		w.granted = true
+		close(w.ready)
+	}
+}
+
+func (l *limiter) removeLocked(key string, w *waiter) {
+	queue := l.queues[key]
+	for idx, queued := range queue {
+		if queued != w {
+			continue
+		}
+		queue = append(queue[:idx], queue[idx+1:]...)
+		l.queued--
+		break
+	}
+	if len(queue) > 0 {
+		l.queues[key] = queue
+		return
+	}
+	delete(l.queues, key)
+	for idx, k := range l.keys {
+		if k == key {
+			l.keys = append(l.keys[:idx], l.keys[idx+1:]...)
+			break
+		}
+	}
+}
+
+// acquire waits until an operation for key may start. Every successful call
+// must be followed by a call to release.
+func (l *limiter) acquire(ctx context.Context, key string) error {
+	l.mux.Lock()
+	if len(l.keys) == 0 && l.hasCapacityLocked() {
+		l.running++
+		l.notifyLocked()
+		l.mux.Unlock()
+		return nil
+	}
+
+	w := &waiter{ready: make(chan struct{})}
+	if _, ok := l.queues[key]; !ok {
+		l.keys = append(l.keys, key)
+	}
+	l.queues[key] = append(l.queues[key], w)
+	l.queued++
+	l.dispatchLocked()
+	l.notifyLocked()
+	l.mux.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		l.mux.Lock()
+		defer l.mux.Unlock()
+		if w.granted {
+			// We were admitted while the context was canceled.
+			l.running--
+			l.dispatchLocked()
+		} else {
+			l.removeLocked(key, w)
+		}
+		l.notifyLocked()
+		return ctx.Err()
+	}
+}
+
+func (l *limiter) release() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.running--
+	l.dispatchLocked()
+	l.notifyLocked()
+}
@@ -0,0 +1,107 @@
+package throttle
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(2, 2, now)
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := bucket.take(now); !ok {
+			t.Fatalf("expected burst token %d to be available", i)
+		}
+	}
+	ok, wait := bucket.take(now)
+	if ok {
+		t.Fatalf("expected the bucket to be empty")
+	}
+	if wait != 500*time.Millisecond {
+		t.Fatalf("expected to wait 500ms, got %s", wait)
+	}
+	if ok, _ := bucket.take(now.Add(wait)); !ok {
+		t.Fatalf("expected a token after waiting")
+	}
+}
+
+func TestLimiterMaxConcurrent(t *testing.T) {
+	l := newLimiter(1, 0, 0, time.Now, nil)
+	if err := l.acquire(context.Background(), "a"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := l.acquire(ctx, "a"); err == nil {
+		t.Fatalf("expected the second operation to wait")
+	}
+	if l.queued != 0 {
+		t.Fatalf("expected the canceled operation to leave the queue, got %d queued", l.queued)
+	}
+
+	l.release()
+	if err := l.acquire(context.Background(), "a"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestLimiterFairness(t *testing.T) {
+	l := newLimiter(1, 0, 0, time.Now, nil)
+	if err := l.acquire(context.Background(), "busy"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	order := make(chan string, 4)
+	queued := func(key string) int {
+		l.mux.Lock()
+		defer l.mux.Unlock()
+		return len(l.queues[key])
+	}
+	queue := func(key string) {
+		before := queued(key)
+		go func() {
+			if err := l.acquire(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			order <- key
+		}()
+		for queued(key) == before {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	queue("busy")
+	queue("busy")
+	queue("busy")
+	queue("quiet")
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		l.release()
+		got = append(got, <-order)
+	}
+	expected := []string{"busy", "quiet", "busy", "busy"}
+	for idx := range expected {
+		if got[idx] != expected[idx] {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestLimiterRate(t *testing.T) {
+	l := newLimiter(0, 20, 1, time.Now, nil)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.acquire(context.Background(), "a"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		l.release()
+	}
+	// The first operation uses the burst, the next two wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("expected operations to be rate limited, took %s", elapsed)
+	}
+}
@@ -0,0 +1,128 @@
+package throttle
+
+import (
+	"context"
+	"time"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/config"
+	"github.com/cloudbase/garm/metrics"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
+)
+
+var _ common.Provider = (*provider)(nil)
+
+// NewProvider wraps a provider so that all operations are subject to the rate
+// and concurrency limits in cfg. If no limits are set, the provider is
+// returned as is.
+func NewProvider(prov common.Provider, name string, cfg config.ProviderThrottle) common.Provider {
+	if cfg.MaxConcurrentOperations == 0 && cfg.OperationsPerSecond == 0 {
+		return prov
+	}
+	onChange := func(running, queued int) {
+		metrics.ProviderRunningOperations.WithLabelValues(name).Set(float64(running))
+		metrics.ProviderQueuedOperations.WithLabelValues(name).Set(float64(queued))
+	}
+	return &provider{
+		name:    name,
+		limiter: newLimiter(cfg.MaxConcurrentOperations, cfg.OperationsPerSecond, cfg.Burst, time.Now, onChange),
+		wrapped: prov,
+	}
+}
+
+type provider struct {
+	name    string
+	limiter *limiter
+	wrapped common.Provider
+}
+
+// poolOwner returns the ID of the entity that owns a pool. Operations are
+// shared fairly between pool owners.
+func poolOwner(pool params.Pool) string {
+	entity, err := pool.GithubEntity()
+	if err != nil {
+		return pool.ID
+	}
+	return entity.ID
+}
+
+// run waits for the throttle and runs f.
+func (p *provider) run(ctx context.Context, operation, owner string, f func() error) error {
+	start := time.Now()
+	err := p.limiter.acquire(ctx, owner)
+	metrics.ProviderOperationWaitSeconds.WithLabelValues(p.name, operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		return err
+	}
+	defer p.limiter.release()
+	return f()
+}
+
+func (p *provider) CreateInstance(ctx context.Context, bootstrapParams commonParams.BootstrapInstance, createInstanceParams common.CreateInstanceParams) (commonParams.ProviderInstance, error) {
+	var ret commonParams.ProviderInstance
+	err := p.run(ctx, "CreateInstance", poolOwner(createInstanceParams.CreateInstanceV011.PoolInfo), func() (err error) {
+		ret, err = p.wrapped.CreateInstance(ctx, bootstrapParams, createInstanceParams)
+		return err
+	})
+	return ret, err
+}
+
+func (p *provider) DeleteInstance(ctx context.Context, instance string, deleteInstanceParams common.DeleteInstanceParams) error {
+	return p.run(ctx, "DeleteInstance", poolOwner(deleteInstanceParams.DeleteInstanceV011.PoolInfo), func() error {
+		return p.wrapped.DeleteInstance(ctx, instance, deleteInstanceParams)
+	})
+}
+
+func (p *provider) GetInstance(ctx context.Context, instance string, getInstanceParams common.GetInstanceParams) (commonParams.ProviderInstance, error) {
+	var ret commonParams.ProviderInstance
+	err := p.run(ctx, "GetInstance", poolOwner(getInstanceParams.GetInstanceV011.PoolInfo), func() (err error) {
+		ret, err = p.wrapped.GetInstance(ctx, instance, getInstanceParams)
+		return err
+	})
+	return ret, err
+}
+
+func (p *provider) ListInstances(ctx context.Context, poolID string, listInstancesParams common.ListInstancesParams) ([]commonParams.ProviderInstance, error) {
+	var ret []commonParams.ProviderInstance
+	err := p.run(ctx, "ListInstances", poolOwner(listInstancesParams.ListInstancesV011.PoolInfo), func() (err error) {
+		ret, err = p.wrapped.ListInstances(ctx, poolID, listInstancesParams)
+		return err
+	})
+	return ret, err
+}
+
+func (p *provider) RemoveAllInstances(ctx context.Context, removeAllInstancesParams common.RemoveAllInstancesParams) error {
+	return p.run(ctx, "RemoveAllInstances", poolOwner(removeAllInstancesParams.RemoveAllInstancesV011.PoolInfo), func() error {
+		return p.wrapped.RemoveAllInstances(ctx, removeAllInstancesParams)
+	})
+}
+
+func (p *provider) Stop(ctx context.Context, instance string, stopParams common.StopParams) error {
+	return p.run(ctx, "Stop", poolOwner(stopParams.StopV011.PoolInfo), func() error {
+		return p.wrapped.Stop(ctx, instance, stopParams)
+	})
+}
+
+func (p *provider) Start(ctx context.Context, instance string, startParams common.StartParams) error {
+	return p.run(ctx, "Start", poolOwner(startParams.StartV011.PoolInfo), func() error {
+		return p.wrapped.Start(ctx, instance, startParams)
+	})
+}
+
+func (p *provider) GetConsoleOutput(ctx context.Context, instance string, getConsoleOutputParams common.GetConsoleOutputParams) ([]byte, error) {
+	var ret []byte
+	err := p.run(ctx, "GetConsoleOutput", poolOwner(getConsoleOutputParams.GetConsoleOutputV011.PoolInfo), func() (err error) {
+		ret, err = p.wrapped.GetConsoleOutput(ctx, instance, getConsoleOutputParams)
+		return err
+	})
+	return ret, err
+}
+
+func (p *provider) DisableJITConfig() bool {
+	return p.wrapped.DisableJITConfig()
+}
+
+func (p *provider) AsParams() params.Provider {
+	return p.wrapped.AsParams()
+}
@@ -83,7 +83,7 @@ func (e *external) CreateInstance(ctx context.Context, bootstrapParams commonPar
 			"CreateInstance", // label: operation
 			e.cfg.Name,       // label: provider
 		).Inc()
-		return commonParams.ProviderInstance{}, garmErrors.NewProviderError("provider binary %s returned error: %s", e.execPath, err)
+		return commonParams.ProviderInstance{}, commonExternal.NewExecError(e.execPath, err)
 	}
 
 	var param commonParams.ProviderInstance
@@ -307,6 +307,11 @@ func (e *external) Start(ctx context.Context, instance string, _ common.StartPar
 	return nil
 }
 
+// GetConsoleOutput is not supported by the v0.1.0 interface.
+func (e *external) GetConsoleOutput(_ context.Context, _ string, _ common.GetConsoleOutputParams) ([]byte, error) {
+	return nil, garmErrors.NewBadRequestError("provider %s does not support fetching the console output (interface version %s)", e.cfg.Name, common.Version010)
+}
+
 func (e *external) AsParams() params.Provider {
 	return params.Provider{
 		Name:         e.cfg.Name,
@@ -90,7 +90,7 @@ func (e *external) CreateInstance(ctx context.Context, bootstrapParams commonPar
 			"CreateInstance", // label: operation
 			e.cfg.Name,       // label: provider
 		).Inc()
-		return commonParams.ProviderInstance{}, garmErrors.NewProviderError("provider binary %s returned error: %s", e.execPath, err)
+		return commonParams.ProviderInstance{}, commonExternal.NewExecError(e.execPath, err)
 	}
 
 	var param commonParams.ProviderInstance
@@ -367,6 +367,45 @@ func (e *external) Start(ctx context.Context, instance string, startParams commo
 	return nil
 }
 
+// GetConsoleOutput returns the console output of an instance.
+func (e *external) GetConsoleOutput(ctx context.Context, instance string, getConsoleOutputParams common.GetConsoleOutputParams) ([]byte, error) {
+	extraspecs := getConsoleOutputParams.GetConsoleOutputV011.PoolInfo.ExtraSpecs
+	extraspecsValue, err := json.Marshal(extraspecs)
+	if err != nil {
+		return nil, errors.Wrap(err, "serializing extraspecs")
+	}
+	// Encode the extraspecs as base64 to avoid issues with special characters.
+	base64EncodedExtraSpecs := base64.StdEncoding.EncodeToString(extraspecsValue)
+	asEnv := []string{
+		fmt.Sprintf("GARM_COMMAND=%s", commonExternal.GetConsoleOutputCommand),
+		fmt.Sprintf("GARM_CONTROLLER_ID=%s", e.controllerID),
+		fmt.Sprintf("GARM_INSTANCE_ID=%s", instance),
+		fmt.Sprintf("GARM_PROVIDER_CONFIG_FILE=%s", e.cfg.External.ConfigFile),
+		fmt.Sprintf("GARM_POOL_ID=%s", getConsoleOutputParams.GetConsoleOutputV011.PoolInfo.ID),
+		fmt.Sprintf("GARM_POOL_EXTRASPECS=%s", base64EncodedExtraSpecs),
+	}
+	asEnv = append(asEnv, e.environmentVariables...)
+
+	metrics.InstanceOperationCount.WithLabelValues(
+		"GetConsoleOutput", // label: operation
+		e.cfg.Name,         // label: provider
+	).Inc()
+
+	out, err := garmExec.Exec(ctx, e.execPath, nil, asEnv)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == commonExecution.ExitCodeNotFound {
+			return nil, garmErrors.NewNotFoundError("instance %s not found in provider", instance)
+		}
+		metrics.InstanceOperationFailedCount.WithLabelValues(
+			"GetConsoleOutput", // label: operation
+			e.cfg.Name,         // label: provider
+		).Inc()
+		return nil, garmErrors.NewProviderError("provider binary %s returned error: %s", e.execPath, err)
+	}
+	return out, nil
+}
+
 func (e *external) AsParams() params.Provider {
 	return params.Provider{
 		Name:         e.cfg.Name,
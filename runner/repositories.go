@@ -197,6 +197,10 @@ func (r *Runner) UpdateRepository(ctx context.Context, repoID string, param para
 		return params.Repository{}, runnerErrors.NewBadRequestError("invalid pool balancer type: %s", param.PoolBalancerType)
 	}
 
+	if err := params.ValidateJobScheduling(param.JobScheduling); err != nil {
+		return params.Repository{}, runnerErrors.NewBadRequestError("%s", err)
+	}
+
 	slog.InfoContext(ctx, "updating repository", "repo_id", repoID, "param", param)
 	repo, err := r.store.UpdateRepository(ctx, repoID, param)
 	if err != nil {
@@ -217,7 +221,7 @@ func (r *Runner) CreateRepoPool(ctx context.Context, repoID string, param params
 		return params.Pool{}, runnerErrors.ErrUnauthorized
 	}
 
-	createPoolParams, err := r.appendTagsToCreatePoolParams(param)
+	createPoolParams, err := r.appendTagsToCreatePoolParams(ctx, param)
 	if err != nil {
 		return params.Pool{}, errors.Wrap(err, "appending tags to create pool params")
 	}
@@ -328,18 +332,8 @@ func (r *Runner) UpdateRepoPool(ctx context.Context, repoID, poolID string, para
 		return params.Pool{}, errors.Wrap(err, "fetching pool")
 	}
 
-	maxRunners := pool.MaxRunners
-	minIdleRunners := pool.MinIdleRunners
-
-	if param.MaxRunners != nil {
-		maxRunners = *param.MaxRunners
-	}
-	if param.MinIdleRunners != nil {
-		minIdleRunners = *param.MinIdleRunners
-	}
-
-	if minIdleRunners > maxRunners {
-		return params.Pool{}, runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners")
+	if err := validatePoolUpdate(pool, param); err != nil {
+		return params.Pool{}, err
 	}
 
 	newPool, err := r.store.UpdateEntityPool(ctx, entity, poolID, param)
@@ -423,6 +417,31 @@ func (r *Runner) UninstallRepoWebhook(ctx context.Context, repoID string) error
 	return nil
 }
 
+func (r *Runner) RotateRepoWebhookSecret(ctx context.Context, repoID string, param params.RotateWebhookSecretParams) (params.HookInfo, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.HookInfo{}, runnerErrors.ErrUnauthorized
+	}
+
+	repo, err := r.store.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		return params.HookInfo{}, errors.Wrap(err, "fetching repo")
+	}
+
+	poolManager, err := r.poolManagerCtrl.GetRepoPoolManager(repo)
+	if err != nil {
+		return params.HookInfo{}, errors.Wrap(err, "fetching pool manager for repo")
+	}
+
+	info, err := r.rotateWebhookSecret(ctx, poolManager, repo.WebhookSecret, param, func(updateParams params.UpdateEntityParams) error {
+		_, err := r.store.UpdateRepository(ctx, repoID, updateParams)
+		return err
+	})
+	if err != nil {
+		return params.HookInfo{}, errors.Wrap(err, "rotating webhook secret")
+	}
+	return info, nil
+}
+
 func (r *Runner) GetRepoWebhookInfo(ctx context.Context, repoID string) (params.HookInfo, error) {
 	if !auth.IsAdmin(ctx) {
 		return params.HookInfo{}, runnerErrors.ErrUnauthorized
@@ -16,8 +16,12 @@ package runner
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
@@ -635,6 +639,131 @@ func (s *RepoTestSuite) TestFindRepoPoolManagerFetchPoolMgrFailed() {
 	s.Require().Regexp("fetching pool manager for repo", err.Error())
 }
 
+func (s *RepoTestSuite) TestRotateRepoWebhookSecret() {
+	repo := s.Fixtures.StoreRepos["test-repo-1"]
+	rotateParams := params.RotateWebhookSecretParams{
+		WebhookSecret: "rotated-webhook-secret",
+	}
+	hookInfo := params.HookInfo{
+		ID:          1,
+		URL:         "https://garm.example.com/webhooks",
+		Active:      true,
+		InsecureSSL: true,
+	}
+	s.Fixtures.PoolMgrCtrlMock.On("GetRepoPoolManager", mock.AnythingOfType("params.Repository")).Return(s.Fixtures.PoolMgrMock, nil)
+	s.Fixtures.PoolMgrMock.On("GetWebhookInfo", s.Fixtures.AdminContext).Return(hookInfo, nil)
+	s.Fixtures.PoolMgrMock.On("UpdateWebhookSecret", s.Fixtures.AdminContext, rotateParams.WebhookSecret).Return(hookInfo, nil)
+
+	info, err := s.Runner.RotateRepoWebhookSecret(s.Fixtures.AdminContext, repo.ID, rotateParams)
+
+	s.Fixtures.PoolMgrCtrlMock.AssertExpectations(s.T())
+	s.Fixtures.PoolMgrMock.AssertExpectations(s.T())
+	s.Require().Nil(err)
+	s.Require().Equal(hookInfo, info)
+
+	updated, err := s.Fixtures.Store.GetRepositoryByID(s.Fixtures.AdminContext, repo.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(rotateParams.WebhookSecret, updated.WebhookSecret)
+	s.Require().Equal(repo.WebhookSecret, updated.PreviousWebhookSecret)
+	s.Require().NotNil(updated.PreviousWebhookSecretExpiresAt)
+}
+
+func (s *RepoTestSuite) TestRotateRepoWebhookSecretKeepsSecretOnFailure() {
+	repo := s.Fixtures.StoreRepos["test-repo-1"]
+	rotateParams := params.RotateWebhookSecretParams{
+		WebhookSecret: "rotated-webhook-secret",
+	}
+	s.Fixtures.PoolMgrCtrlMock.On("GetRepoPoolManager", mock.AnythingOfType("params.Repository")).Return(s.Fixtures.PoolMgrMock, nil)
+	s.Fixtures.PoolMgrMock.On("GetWebhookInfo", s.Fixtures.AdminContext).Return(params.HookInfo{ID: 1}, nil)
+	s.Fixtures.PoolMgrMock.On("UpdateWebhookSecret", s.Fixtures.AdminContext, rotateParams.WebhookSecret).Return(params.HookInfo{}, s.Fixtures.ErrMock)
+
+	_, err := s.Runner.RotateRepoWebhookSecret(s.Fixtures.AdminContext, repo.ID, rotateParams)
+
+	s.Fixtures.PoolMgrCtrlMock.AssertExpectations(s.T())
+	s.Fixtures.PoolMgrMock.AssertExpectations(s.T())
+	s.Require().NotNil(err)
+
+	// GitHub still uses the old secret.
+	unchanged, err := s.Fixtures.Store.GetRepositoryByID(s.Fixtures.AdminContext, repo.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(repo.WebhookSecret, unchanged.WebhookSecret)
+	s.Require().Equal("", unchanged.PreviousWebhookSecret)
+}
+
+func (s *RepoTestSuite) TestRotateRepoWebhookSecretKeepsPreviousSecretOnFailure() {
+	repo := s.Fixtures.StoreRepos["test-repo-1"]
+	// An earlier rotation is still within its grace period.
+	expiresAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	_, err := s.Fixtures.Store.UpdateRepository(s.Fixtures.AdminContext, repo.ID, params.UpdateEntityParams{
+		WebhookSecret:                  "current-webhook-secret",
+		PreviousWebhookSecretExpiresAt: &expiresAt,
+	})
+	s.Require().Nil(err)
+
+	rotateParams := params.RotateWebhookSecretParams{
+		WebhookSecret: "rotated-webhook-secret",
+	}
+	s.Fixtures.PoolMgrCtrlMock.On("GetRepoPoolManager", mock.AnythingOfType("params.Repository")).Return(s.Fixtures.PoolMgrMock, nil)
+	s.Fixtures.PoolMgrMock.On("GetWebhookInfo", s.Fixtures.AdminContext).Return(params.HookInfo{ID: 1}, nil)
+	s.Fixtures.PoolMgrMock.On("UpdateWebhookSecret", s.Fixtures.AdminContext, rotateParams.WebhookSecret).Return(params.HookInfo{}, s.Fixtures.ErrMock)
+
+	_, err = s.Runner.RotateRepoWebhookSecret(s.Fixtures.AdminContext, repo.ID, rotateParams)
+
+	s.Fixtures.PoolMgrCtrlMock.AssertExpectations(s.T())
+	s.Fixtures.PoolMgrMock.AssertExpectations(s.T())
+	s.Require().NotNil(err)
+
+	unchanged, err := s.Fixtures.Store.GetRepositoryByID(s.Fixtures.AdminContext, repo.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("current-webhook-secret", unchanged.WebhookSecret)
+	s.Require().Equal(repo.WebhookSecret, unchanged.PreviousWebhookSecret)
+	s.Require().NotNil(unchanged.PreviousWebhookSecretExpiresAt)
+	s.Require().True(expiresAt.Equal(*unchanged.PreviousWebhookSecretExpiresAt))
+}
+
+func (s *RepoTestSuite) TestRotateRepoWebhookSecretErrUnauthorized() {
+	_, err := s.Runner.RotateRepoWebhookSecret(context.Background(), "dummy-repo-id", params.RotateWebhookSecretParams{})
+
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
+func (s *RepoTestSuite) TestRotateRepoWebhookSecretNoWebhook() {
+	repo := s.Fixtures.StoreRepos["test-repo-1"]
+	s.Fixtures.PoolMgrCtrlMock.On("GetRepoPoolManager", mock.AnythingOfType("params.Repository")).Return(s.Fixtures.PoolMgrMock, nil)
+	s.Fixtures.PoolMgrMock.On("GetWebhookInfo", s.Fixtures.AdminContext).Return(params.HookInfo{}, runnerErrors.NewNotFoundError("hook not found"))
+
+	_, err := s.Runner.RotateRepoWebhookSecret(s.Fixtures.AdminContext, repo.ID, params.RotateWebhookSecretParams{})
+
+	s.Fixtures.PoolMgrCtrlMock.AssertExpectations(s.T())
+	s.Fixtures.PoolMgrMock.AssertExpectations(s.T())
+	var notFoundErr *runnerErrors.NotFoundError
+	s.Require().True(errors.As(err, &notFoundErr))
+
+	// The secret must not change if we can't update the webhook.
+	unchanged, err := s.Fixtures.Store.GetRepositoryByID(s.Fixtures.AdminContext, repo.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(repo.WebhookSecret, unchanged.WebhookSecret)
+	s.Require().Equal("", unchanged.PreviousWebhookSecret)
+}
+
+func (s *RepoTestSuite) TestValidateHookBodyWithPreviousSecret() {
+	body := []byte(`{"action": "queued"}`)
+	mac := hmac.New(sha256.New, []byte("previous-secret"))
+	_, err := mac.Write(body)
+	s.Require().Nil(err)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	err = s.Runner.validateHookBodyWithSecrets(signature, []string{"current-secret", "previous-secret"}, body)
+	s.Require().Nil(err)
+
+	err = s.Runner.validateHookBodyWithSecrets(signature, []string{"current-secret"}, body)
+	var unauthorizedErr *runnerErrors.UnauthorizedError
+	s.Require().True(errors.As(err, &unauthorizedErr))
+
+	err = s.Runner.validateHookBodyWithSecrets(signature, []string{}, body)
+	s.Require().NotNil(err)
+}
+
 func TestRepoTestSuite(t *testing.T) {
 	t.Parallel()
 	suite.Run(t, new(RepoTestSuite))
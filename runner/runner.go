@@ -30,6 +30,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/juju/clock"
 	"github.com/juju/retry"
 	"github.com/pkg/errors"
@@ -78,6 +79,7 @@ func NewRunner(ctx context.Context, cfg config.Config, db dbCommon.Store) (*Runn
 		poolManagerCtrl: poolManagerCtrl,
 		providers:       providers,
 	}
+	runner.webhookQueue = newWebhookQueue(ctx, db, runner.processWebhookDelivery)
 
 	if err := runner.loadReposOrgsAndEnterprises(); err != nil {
 		return nil, errors.Wrap(err, "loading pool managers")
@@ -245,6 +247,8 @@ type Runner struct {
 	poolManagerCtrl PoolManagerController
 
 	providers map[string]common.Provider
+
+	webhookQueue *webhookQueue
 }
 
 // UpdateController will update the controller settings.
@@ -420,6 +424,10 @@ func (r *Runner) Start() error {
 	if err := r.waitForErrorGroupOrTimeout(g); err != nil {
 		return fmt.Errorf("failed to start pool managers: %w", err)
 	}
+
+	if r.webhookQueue != nil {
+		r.webhookQueue.Start()
+	}
 	return nil
 }
 
@@ -461,6 +469,12 @@ func (r *Runner) Stop() error {
 		return errors.Wrap(err, "fetch enterprise pool managers")
 	}
 
+	// Stop processing webhook deliveries before stopping the pool managers.
+	// Pending deliveries are picked up again on start.
+	if r.webhookQueue != nil {
+		r.webhookQueue.Stop()
+	}
+
 	g, _ := errgroup.WithContext(r.ctx)
 
 	for _, repo := range repos {
@@ -557,6 +571,24 @@ func (r *Runner) Wait() error {
 	return nil
 }
 
+// validateHookBodyWithSecrets validates the webhook body against each of the
+// supplied secrets, in order. The body is considered valid if any of the secrets
+// match the signature.
+func (r *Runner) validateHookBodyWithSecrets(signature string, secrets []string, body []byte) error {
+	if len(secrets) == 0 {
+		return runnerErrors.NewMissingSecretError("missing secret to validate webhook signature")
+	}
+
+	var err error
+	for _, secret := range secrets {
+		err = r.validateHookBody(signature, secret, body)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
 func (r *Runner) validateHookBody(signature, secret string, body []byte) error {
 	if secret == "" {
 		return runnerErrors.NewMissingSecretError("missing secret to validate webhook signature")
@@ -625,19 +657,21 @@ func (r *Runner) findEndpointForJob(job params.WorkflowJob) (params.GithubEndpoi
 	return params.GithubEndpoint{}, runnerErrors.NewNotFoundError("no endpoint found for job")
 }
 
-func (r *Runner) DispatchWorkflowJob(hookTargetType, signature string, jobData []byte) error {
+// resolveWorkflowJob decodes the workflow job and finds the pool manager responsible
+// for the entity that received the webhook.
+func (r *Runner) resolveWorkflowJob(hookTargetType string, jobData []byte) (params.WorkflowJob, common.PoolManager, error) {
 	if len(jobData) == 0 {
-		return runnerErrors.NewBadRequestError("missing job data")
+		return params.WorkflowJob{}, nil, runnerErrors.NewBadRequestError("missing job data")
 	}
 
 	var job params.WorkflowJob
 	if err := json.Unmarshal(jobData, &job); err != nil {
-		return errors.Wrapf(runnerErrors.ErrBadRequest, "invalid job data: %s", err)
+		return params.WorkflowJob{}, nil, errors.Wrapf(runnerErrors.ErrBadRequest, "invalid job data: %s", err)
 	}
 
 	endpoint, err := r.findEndpointForJob(job)
 	if err != nil {
-		return errors.Wrap(err, "finding endpoint for job")
+		return params.WorkflowJob{}, nil, errors.Wrap(err, "finding endpoint for job")
 	}
 
 	var poolManager common.PoolManager
@@ -660,30 +694,139 @@ func (r *Runner) DispatchWorkflowJob(hookTargetType, signature string, jobData [
 			"enterprise", util.SanitizeLogEntry(job.Enterprise.Slug))
 		poolManager, err = r.findEnterprisePoolManager(job.Enterprise.Slug, endpoint.Name)
 	default:
-		return runnerErrors.NewBadRequestError("cannot handle hook target type %s", hookTargetType)
+		return params.WorkflowJob{}, nil, runnerErrors.NewBadRequestError("cannot handle hook target type %s", hookTargetType)
 	}
 
 	if err != nil {
 		// We don't have a repository or organization configured that
 		// can handle this workflow job.
-		return errors.Wrap(err, "fetching poolManager")
+		return params.WorkflowJob{}, nil, errors.Wrap(err, "fetching poolManager")
+	}
+	return job, poolManager, nil
+}
+
+// validateWorkflowJob resolves the pool manager for the workflow job and validates
+// the webhook signature against the secrets of the entity.
+func (r *Runner) validateWorkflowJob(hookTargetType, signature string, jobData []byte) (params.WorkflowJob, common.PoolManager, error) {
+	job, poolManager, err := r.resolveWorkflowJob(hookTargetType, jobData)
+	if err != nil {
+		return params.WorkflowJob{}, nil, err
 	}
 
 	// We found a pool. Validate the webhook job. If a secret is configured,
-	// we make sure that the source of this workflow job is valid.
-	secret := poolManager.WebhookSecret()
-	if err := r.validateHookBody(signature, secret, jobData); err != nil {
-		return errors.Wrap(err, "validating webhook data")
+	// we make sure that the source of this workflow job is valid. While a
+	// webhook secret rotation is in progress, the previous secret is also
+	// accepted.
+	if err := r.validateHookBodyWithSecrets(signature, poolManager.WebhookSecrets(), jobData); err != nil {
+		return params.WorkflowJob{}, nil, errors.Wrap(err, "validating webhook data")
+	}
+	return job, poolManager, nil
+}
+
+// EnqueueWorkflowJob validates the webhook and persists it as a delivery, to be processed
+// asynchronously by the webhook queue workers. Deliveries are deduplicated using the
+// delivery ID GitHub sends in the X-GitHub-Delivery header. A delivery we have already
+// seen is silently accepted.
+func (r *Runner) EnqueueWorkflowJob(deliveryID, hookTargetType, signature string, jobData []byte) error {
+	if _, _, err := r.validateWorkflowJob(hookTargetType, signature, jobData); err != nil {
+		return err
+	}
+
+	if deliveryID == "" {
+		// Without a delivery ID we have no way to deduplicate. Generate one
+		// so the delivery can still be queued.
+		deliveryID = uuid.New().String()
+	}
+
+	_, err := r.store.CreateWebhookDelivery(r.ctx, params.CreateWebhookDeliveryParams{
+		ID:             deliveryID,
+		HookTargetType: hookTargetType,
+		Payload:        jobData,
+	})
+	if err != nil {
+		if errors.Is(err, runnerErrors.ErrDuplicateEntity) {
+			slog.DebugContext(r.ctx, "ignoring duplicate webhook delivery", "delivery_id", util.SanitizeLogEntry(deliveryID))
+			return nil
+		}
+		return errors.Wrap(err, "persisting webhook delivery")
+	}
+
+	if r.webhookQueue != nil {
+		r.webhookQueue.Notify()
+	}
+	return nil
+}
+
+// processWebhookDelivery handles a persisted webhook delivery. The signature was validated
+// when the delivery was received, so it is not checked again.
+func (r *Runner) processWebhookDelivery(delivery params.WebhookDelivery) error {
+	job, poolManager, err := r.resolveWorkflowJob(delivery.HookTargetType, delivery.Payload)
+	if err != nil {
+		return err
 	}
 
 	if err := poolManager.HandleWorkflowJob(job); err != nil {
 		return errors.Wrap(err, "handling workflow job")
 	}
-
 	return nil
 }
 
-func (r *Runner) appendTagsToCreatePoolParams(param params.CreatePoolParams) (params.CreatePoolParams, error) {
+// rotateWebhookSecret generates a new webhook secret for the entity handled by the
+// supplied pool manager, updates the GARM webhook in GitHub in place to use it and
+// saves it using the updateEntity function. The old secret is kept as the previous
+// secret of the entity and is accepted until the configured grace period expires.
+// The entity is only changed once GitHub uses the new secret, so a failed webhook
+// update leaves the secrets of the entity untouched.
+func (r *Runner) rotateWebhookSecret(ctx context.Context, poolManager common.PoolManager, currentSecret string, param params.RotateWebhookSecretParams, updateEntity func(params.UpdateEntityParams) error) (params.HookInfo, error) {
+	// Only hooks installed by GARM can be rotated. Make sure we have one before
+	// changing the secret.
+	if _, err := poolManager.GetWebhookInfo(ctx); err != nil {
+		return params.HookInfo{}, errors.Wrap(err, "fetching webhook info")
+	}
+
+	secret := param.WebhookSecret
+	if secret == "" {
+		var err error
+		secret, err = util.GetRandomString(32)
+		if err != nil {
+			return params.HookInfo{}, errors.Wrap(err, "generating webhook secret")
+		}
+	}
+
+	info, err := poolManager.UpdateWebhookSecret(ctx, secret)
+	if err != nil {
+		return params.HookInfo{}, errors.Wrap(err, "updating webhook")
+	}
+
+	// Deliveries already signed with the old secret are still accepted until
+	// the grace period expires.
+	expiresAt := time.Now().UTC().Add(r.config.Default.GetWebhookSecretGracePeriod())
+	updateParams := params.UpdateEntityParams{
+		WebhookSecret:                  secret,
+		PreviousWebhookSecretExpiresAt: &expiresAt,
+	}
+	if err := updateEntity(updateParams); err != nil {
+		// We could not save the new secret. Switch GitHub back to the one we have.
+		if _, restoreErr := poolManager.UpdateWebhookSecret(ctx, currentSecret); restoreErr != nil {
+			slog.With(slog.Any("error", restoreErr)).ErrorContext(
+				ctx, "failed to restore webhook secret")
+		}
+		return params.HookInfo{}, errors.Wrap(err, "updating webhook secret")
+	}
+	return info, nil
+}
+
+func (r *Runner) appendTagsToCreatePoolParams(ctx context.Context, param params.CreatePoolParams) (params.CreatePoolParams, error) {
+	if param.TemplateID != "" {
+		tpl, err := r.store.GetPoolTemplate(ctx, param.TemplateID)
+		if err != nil {
+			return params.CreatePoolParams{}, errors.Wrap(err, "fetching pool template")
+		}
+		if err := param.ApplyTemplate(tpl); err != nil {
+			return params.CreatePoolParams{}, runnerErrors.NewBadRequestError("%s", err)
+		}
+	}
+
 	if err := param.Validate(); err != nil {
 		return params.CreatePoolParams{}, fmt.Errorf("failed to validate params (%q): %w", err, runnerErrors.ErrBadRequest)
 		// errors.Wrapf(runnerErrors.ErrBadRequest, "validating params: %s", err)
@@ -741,6 +884,11 @@ func (r *Runner) AddInstanceStatusMessage(ctx context.Context, param params.Inst
 
 	updateParams := params.UpdateInstanceParams{
 		RunnerStatus: param.Status,
+		Stages:       []params.InstanceStage{params.InstanceStageFirstCallback},
+	}
+	if param.Status == params.RunnerIdle {
+		// The runner reports idle once it registered in GitHub.
+		updateParams.Stages = append(updateParams.Stages, params.InstanceStageRegistered)
 	}
 
 	if param.AgentID != nil {
@@ -787,7 +935,10 @@ func (r *Runner) getPoolManagerFromInstance(ctx context.Context, instance params
 	if err != nil {
 		return nil, errors.Wrap(err, "fetching pool")
 	}
+	return r.getPoolManagerFromPool(ctx, pool)
+}
 
+func (r *Runner) getPoolManagerFromPool(ctx context.Context, pool params.Pool) (common.PoolManager, error) {
 	var poolMgr common.PoolManager
 
 	switch {
@@ -859,3 +1010,128 @@ func (r *Runner) DeleteRunner(ctx context.Context, instanceName string, forceDel
 	}
 	return nil
 }
+
+// DrainRunner marks a runner to be removed once it is no longer running a job.
+// Idle runners are removed right away.
+func (r *Runner) DrainRunner(ctx context.Context, instanceName string) error {
+	if !auth.IsAdmin(ctx) {
+		return runnerErrors.ErrUnauthorized
+	}
+
+	instance, err := r.store.GetInstanceByName(ctx, instanceName)
+	if err != nil {
+		return errors.Wrap(err, "fetching instance")
+	}
+
+	poolMgr, err := r.getPoolManagerFromInstance(ctx, instance)
+	if err != nil {
+		return errors.Wrap(err, "fetching pool manager for instance")
+	}
+
+	if err := poolMgr.DrainRunner(instance); err != nil {
+		return errors.Wrap(err, "draining runner")
+	}
+	return nil
+}
+
+// GetInstanceTimeline returns the lifecycle stages and events of an instance.
+func (r *Runner) GetInstanceTimeline(ctx context.Context, instanceName string) (params.InstanceTimeline, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.InstanceTimeline{}, runnerErrors.ErrUnauthorized
+	}
+
+	reaps, err := r.store.ListInstanceReaps(ctx, instanceName)
+	if err != nil {
+		return params.InstanceTimeline{}, errors.Wrap(err, "fetching instance reaps")
+	}
+
+	instance, err := r.store.GetInstanceByName(ctx, instanceName)
+	if err != nil {
+		if !errors.Is(err, runnerErrors.ErrNotFound) || len(reaps) == 0 {
+			return params.InstanceTimeline{}, errors.Wrap(err, "fetching instance")
+		}
+		// The instance was removed. Serve the timeline it had when it was reaped.
+		timeline := reaps[len(reaps)-1].Timeline
+		timeline.Removed = true
+		return timeline.WithReaps(reaps), nil
+	}
+	return instance.Timeline().WithReaps(reaps), nil
+}
+
+// GetInstanceConsoleOutput fetches the console output of an instance from its
+// provider. If the provider cannot return it, or the instance was removed, the
+// console output captured when the instance failed or was reaped is returned
+// instead.
+func (r *Runner) GetInstanceConsoleOutput(ctx context.Context, instanceName string) (params.InstanceConsoleOutput, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.InstanceConsoleOutput{}, runnerErrors.ErrUnauthorized
+	}
+
+	reaps, err := r.store.ListInstanceReaps(ctx, instanceName)
+	if err != nil {
+		return params.InstanceConsoleOutput{}, errors.Wrap(err, "fetching instance reaps")
+	}
+
+	instance, err := r.store.GetInstanceByName(ctx, instanceName)
+	if err != nil {
+		if !errors.Is(err, runnerErrors.ErrNotFound) {
+			return params.InstanceConsoleOutput{}, errors.Wrap(err, "fetching instance")
+		}
+		stored, ok := storedConsoleOutput(params.Instance{Name: instanceName}, reaps)
+		if !ok {
+			return params.InstanceConsoleOutput{}, errors.Wrap(err, "fetching instance")
+		}
+		return stored, nil
+	}
+
+	poolMgr, err := r.getPoolManagerFromInstance(ctx, instance)
+	if err != nil {
+		return params.InstanceConsoleOutput{}, errors.Wrap(err, "fetching pool manager for instance")
+	}
+
+	out, err := poolMgr.GetConsoleOutput(ctx, instance)
+	if err == nil {
+		return params.InstanceConsoleOutput{
+			InstanceName: instance.Name,
+			Output:       strings.ToValidUTF8(string(out), "\uFFFD"),
+			CapturedAt:   time.Now().UTC(),
+		}, nil
+	}
+	stored, ok := storedConsoleOutput(instance, reaps)
+	if !ok {
+		return params.InstanceConsoleOutput{}, errors.Wrap(err, "fetching console output")
+	}
+	slog.With(slog.Any("error", err)).DebugContext(
+		ctx, "returning stored console output",
+		"runner_name", instance.Name)
+	return stored, nil
+}
+
+// storedConsoleOutput returns the most recent console output garm saved for an
+// instance, either when the instance failed or when it was reaped.
+func storedConsoleOutput(instance params.Instance, reaps []params.InstanceReap) (params.InstanceConsoleOutput, bool) {
+	var ret params.InstanceConsoleOutput
+	var found bool
+	if len(instance.ConsoleOutput) > 0 && instance.ConsoleOutputCapturedAt != nil {
+		ret = params.InstanceConsoleOutput{
+			InstanceName: instance.Name,
+			Output:       strings.ToValidUTF8(string(instance.ConsoleOutput), "\uFFFD"),
+			CapturedAt:   *instance.ConsoleOutputCapturedAt,
+			Stored:       true,
+		}
+		found = true
+	}
+	for _, reap := range reaps {
+		if len(reap.ConsoleOutput) == 0 || (found && reap.CreatedAt.Before(ret.CapturedAt)) {
+			continue
+		}
+		ret = params.InstanceConsoleOutput{
+			InstanceName: instance.Name,
+			Output:       strings.ToValidUTF8(string(reap.ConsoleOutput), "\uFFFD"),
+			CapturedAt:   reap.CreatedAt,
+			Stored:       true,
+		}
+		found = true
+	}
+	return ret, found
+}
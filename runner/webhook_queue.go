@@ -0,0 +1,266 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/metrics"
+	"github.com/cloudbase/garm/params"
+)
+
+const (
+	// webhookQueueWorkers is the number of workers processing webhook deliveries.
+	webhookQueueWorkers = 4
+	// webhookQueuePollInterval is the interval at which we look for pending deliveries,
+	// in case we missed a notification, or a delivery is due for a retry.
+	webhookQueuePollInterval = 5 * time.Second
+	// webhookDeliveryMaxAttempts is the maximum number of times we try to process
+	// a delivery before marking it as failed.
+	webhookDeliveryMaxAttempts = 5
+	// webhookDeliveryRetryBackoff is the base backoff between attempts. It is doubled
+	// after each failed attempt.
+	webhookDeliveryRetryBackoff = 5 * time.Second
+	// webhookDeliveryRetention is how long processed deliveries are kept. This is also
+	// the window in which duplicate deliveries are detected.
+	webhookDeliveryRetention = 24 * time.Hour
+	// webhookDeliveryCleanupInterval is the interval at which old deliveries are removed.
+	webhookDeliveryCleanupInterval = 1 * time.Hour
+)
+
+// isTransientDeliveryError returns false for errors that will not go away by
+// retrying the delivery.
+func isTransientDeliveryError(err error) bool {
+	var badRequestErr *runnerErrors.BadRequestError
+	var notFoundErr *runnerErrors.NotFoundError
+	switch {
+	case errors.As(err, &badRequestErr), errors.As(err, &notFoundErr):
+		return false
+	}
+	return true
+}
+
+// deliveryJobID returns the ID of the workflow job a delivery is about, or 0 if
+// the payload can not be decoded.
+func deliveryJobID(delivery params.WebhookDelivery) int64 {
+	var payload struct {
+		WorkflowJob struct {
+			ID int64 `json:"id"`
+		} `json:"workflow_job"`
+	}
+	if err := json.Unmarshal(delivery.Payload, &payload); err != nil {
+		return 0
+	}
+	return payload.WorkflowJob.ID
+}
+
+func newWebhookQueue(ctx context.Context, store dbCommon.Store, process func(params.WebhookDelivery) error) *webhookQueue {
+	return &webhookQueue{
+		ctx:      ctx,
+		store:    store,
+		process:  process,
+		workers:  webhookQueueWorkers,
+		inFlight: map[string]struct{}{},
+		work:     make(chan params.WebhookDelivery),
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// webhookQueue processes webhook deliveries persisted in the database. Deliveries
+// survive a restart of GARM, as pending deliveries are picked up again on start.
+type webhookQueue struct {
+	ctx     context.Context
+	store   dbCommon.Store
+	process func(params.WebhookDelivery) error
+	workers int
+
+	mux      sync.Mutex
+	inFlight map[string]struct{}
+	running  bool
+
+	work   chan params.WebhookDelivery
+	notify chan struct{}
+	quit   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func (q *webhookQueue) Start() {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if q.running {
+		return
+	}
+	q.running = true
+	q.quit = make(chan struct{})
+
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.worker()
+	}
+	q.wg.Add(1)
+	go q.loop()
+}
+
+func (q *webhookQueue) Stop() {
+	q.mux.Lock()
+	if !q.running {
+		q.mux.Unlock()
+		return
+	}
+	q.running = false
+	close(q.quit)
+	q.mux.Unlock()
+
+	q.wg.Wait()
+}
+
+// Notify wakes up the queue to process newly added deliveries.
+func (q *webhookQueue) Notify() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (q *webhookQueue) loop() {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(webhookQueuePollInterval)
+	defer ticker.Stop()
+	cleanupTicker := time.NewTicker(webhookDeliveryCleanupInterval)
+	defer cleanupTicker.Stop()
+
+	q.dispatchPending()
+	for {
+		select {
+		case <-q.notify:
+			q.dispatchPending()
+		case <-ticker.C:
+			q.dispatchPending()
+		case <-cleanupTicker.C:
+			olderThan := time.Now().UTC().Add(-webhookDeliveryRetention)
+			if err := q.store.DeleteWebhookDeliveriesOlderThan(q.ctx, olderThan); err != nil {
+				slog.With(slog.Any("error", err)).ErrorContext(q.ctx, "failed to delete old webhook deliveries")
+			}
+		case <-q.quit:
+			return
+		case <-q.ctx.Done():
+			return
+		}
+	}
+}
+
+// dispatchPending sends all pending deliveries that are due to the workers.
+// Deliveries of the same job are processed one at a time, in the order in which
+// they were received. A delivery that is being processed, or that waits to be
+// retried, holds back the deliveries of its job that were received after it.
+func (q *webhookQueue) dispatchPending() {
+	pending, err := q.store.ListWebhookDeliveriesByStatus(q.ctx, params.WebhookDeliveryPending)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(q.ctx, "failed to list pending webhook deliveries")
+		return
+	}
+	metrics.WebhookQueueDepth.Set(float64(len(pending)))
+
+	now := time.Now().UTC()
+	heldJobs := map[int64]struct{}{}
+	for _, delivery := range pending {
+		if jobID := deliveryJobID(delivery); jobID != 0 {
+			if _, held := heldJobs[jobID]; held {
+				continue
+			}
+			heldJobs[jobID] = struct{}{}
+		}
+
+		if delivery.NextAttemptAt.After(now) {
+			continue
+		}
+
+		q.mux.Lock()
+		_, ok := q.inFlight[delivery.ID]
+		if !ok {
+			q.inFlight[delivery.ID] = struct{}{}
+		}
+		q.mux.Unlock()
+		if ok {
+			continue
+		}
+
+		select {
+		case q.work <- delivery:
+		case <-q.quit:
+			return
+		case <-q.ctx.Done():
+			return
+		}
+	}
+}
+
+func (q *webhookQueue) worker() {
+	defer q.wg.Done()
+
+	for {
+		select {
+		case delivery := <-q.work:
+			q.handleDelivery(delivery)
+			q.mux.Lock()
+			delete(q.inFlight, delivery.ID)
+			q.mux.Unlock()
+			if deliveryJobID(delivery) != 0 {
+				// Later deliveries of the same job may have been held back.
+				q.Notify()
+			}
+		case <-q.quit:
+			return
+		case <-q.ctx.Done():
+			return
+		}
+	}
+}
+
+func (q *webhookQueue) handleDelivery(delivery params.WebhookDelivery) {
+	attempts := delivery.Attempts + 1
+	update := params.UpdateWebhookDeliveryParams{
+		Attempts: &attempts,
+	}
+
+	var status params.WebhookDeliveryStatus
+	err := q.process(delivery)
+	switch {
+	case err == nil:
+		status = params.WebhookDeliveryCompleted
+		metrics.WebhookDeliveriesProcessed.WithLabelValues("success").Inc()
+	case !isTransientDeliveryError(err) || attempts >= webhookDeliveryMaxAttempts:
+		status = params.WebhookDeliveryFailed
+		metrics.WebhookDeliveriesProcessed.WithLabelValues("failed").Inc()
+		slog.With(slog.Any("error", err)).ErrorContext(
+			q.ctx, "failed to process webhook delivery",
+			"delivery_id", delivery.ID, "attempts", attempts)
+	default:
+		status = params.WebhookDeliveryPending
+		nextAttempt := time.Now().UTC().Add(webhookDeliveryRetryBackoff * time.Duration(1<<(attempts-1)))
+		update.NextAttemptAt = &nextAttempt
+		metrics.WebhookDeliveriesProcessed.WithLabelValues("retry").Inc()
+		slog.With(slog.Any("error", err)).WarnContext(
+			q.ctx, "failed to process webhook delivery; will retry",
+			"delivery_id", delivery.ID, "attempts", attempts, "next_attempt_at", nextAttempt)
+	}
+	update.Status = &status
+	if err != nil {
+		lastError := err.Error()
+		update.LastError = &lastError
+	}
+
+	if _, err := q.store.UpdateWebhookDelivery(q.ctx, delivery.ID, update); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			q.ctx, "failed to update webhook delivery",
+			"delivery_id", delivery.ID)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/database"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing"
+	"github.com/cloudbase/garm/params"
+)
+
+type WebhookQueueTestSuite struct {
+	suite.Suite
+	Store dbCommon.Store
+	ctx   context.Context
+}
+
+func (s *WebhookQueueTestSuite) SetupTest() {
+	db, err := database.NewDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+	s.ctx = context.Background()
+}
+
+func (s *WebhookQueueTestSuite) createDelivery(id string) params.WebhookDelivery {
+	delivery, err := s.Store.CreateWebhookDelivery(s.ctx, params.CreateWebhookDeliveryParams{
+		ID:             id,
+		HookTargetType: string(RepoHook),
+		Payload:        []byte(`{}`),
+	})
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create delivery: %s", err))
+	}
+	return delivery
+}
+
+func (s *WebhookQueueTestSuite) createJobDelivery(id string, jobID int64, action string) params.WebhookDelivery {
+	delivery, err := s.Store.CreateWebhookDelivery(s.ctx, params.CreateWebhookDeliveryParams{
+		ID:             id,
+		HookTargetType: string(RepoHook),
+		Payload:        []byte(fmt.Sprintf(`{"action": %q, "workflow_job": {"id": %d}}`, action, jobID)),
+	})
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create delivery: %s", err))
+	}
+	return delivery
+}
+
+// dispatched returns the IDs of the deliveries dispatchPending sends to the workers.
+func (s *WebhookQueueTestSuite) dispatched(q *webhookQueue) []string {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		q.dispatchPending()
+	}()
+
+	var ids []string
+	for {
+		select {
+		case delivery := <-q.work:
+			ids = append(ids, delivery.ID)
+		case <-done:
+			return ids
+		case <-time.After(5 * time.Second):
+			s.FailNow("timed out waiting for deliveries to be dispatched")
+		}
+	}
+}
+
+func (s *WebhookQueueTestSuite) TestHandleDeliverySuccess() {
+	delivery := s.createDelivery("delivery-1")
+	q := newWebhookQueue(s.ctx, s.Store, func(params.WebhookDelivery) error {
+		return nil
+	})
+
+	q.handleDelivery(delivery)
+
+	delivery, err := s.Store.GetWebhookDelivery(s.ctx, "delivery-1")
+	s.Require().Nil(err)
+	s.Require().Equal(params.WebhookDeliveryCompleted, delivery.Status)
+	s.Require().Equal(1, delivery.Attempts)
+}
+
+func (s *WebhookQueueTestSuite) TestHandleDeliveryTransientErrorIsRetried() {
+	delivery := s.createDelivery("delivery-1")
+	q := newWebhookQueue(s.ctx, s.Store, func(params.WebhookDelivery) error {
+		return fmt.Errorf("database is locked")
+	})
+
+	q.handleDelivery(delivery)
+
+	delivery, err := s.Store.GetWebhookDelivery(s.ctx, "delivery-1")
+	s.Require().Nil(err)
+	s.Require().Equal(params.WebhookDeliveryPending, delivery.Status)
+	s.Require().Equal(1, delivery.Attempts)
+	s.Require().Equal("database is locked", delivery.LastError)
+	s.Require().True(delivery.NextAttemptAt.After(time.Now().UTC()))
+}
+
+func (s *WebhookQueueTestSuite) TestHandleDeliveryTransientErrorMaxAttempts() {
+	delivery := s.createDelivery("delivery-1")
+	delivery.Attempts = webhookDeliveryMaxAttempts - 1
+	q := newWebhookQueue(s.ctx, s.Store, func(params.WebhookDelivery) error {
+		return fmt.Errorf("database is locked")
+	})
+
+	q.handleDelivery(delivery)
+
+	delivery, err := s.Store.GetWebhookDelivery(s.ctx, "delivery-1")
+	s.Require().Nil(err)
+	s.Require().Equal(params.WebhookDeliveryFailed, delivery.Status)
+	s.Require().Equal(webhookDeliveryMaxAttempts, delivery.Attempts)
+}
+
+func (s *WebhookQueueTestSuite) TestHandleDeliveryPermanentError() {
+	delivery := s.createDelivery("delivery-1")
+	q := newWebhookQueue(s.ctx, s.Store, func(params.WebhookDelivery) error {
+		return runnerErrors.NewBadRequestError("invalid job data")
+	})
+
+	q.handleDelivery(delivery)
+
+	delivery, err := s.Store.GetWebhookDelivery(s.ctx, "delivery-1")
+	s.Require().Nil(err)
+	s.Require().Equal(params.WebhookDeliveryFailed, delivery.Status)
+	s.Require().Equal(1, delivery.Attempts)
+}
+
+func (s *WebhookQueueTestSuite) TestQueueProcessesPendingDeliveries() {
+	s.createDelivery("delivery-1")
+	processed := make(chan string, 1)
+	q := newWebhookQueue(s.ctx, s.Store, func(delivery params.WebhookDelivery) error {
+		processed <- delivery.ID
+		return nil
+	})
+
+	q.Start()
+	defer q.Stop()
+
+	select {
+	case id := <-processed:
+		s.Require().Equal("delivery-1", id)
+	case <-time.After(5 * time.Second):
+		s.FailNow("timed out waiting for delivery to be processed")
+	}
+}
+
+func (s *WebhookQueueTestSuite) TestDispatchHoldsBackLaterDeliveriesOfAJob() {
+	s.createJobDelivery("delivery-1", 1, "queued")
+	s.createJobDelivery("delivery-2", 1, "in_progress")
+	s.createJobDelivery("delivery-3", 2, "queued")
+	q := newWebhookQueue(s.ctx, s.Store, nil)
+
+	s.Require().Equal([]string{"delivery-1", "delivery-3"}, s.dispatched(q))
+	// Both deliveries are still in flight.
+	s.Require().Empty(s.dispatched(q))
+
+	// The first delivery of job 1 waits to be retried.
+	nextAttempt := time.Now().UTC().Add(time.Hour)
+	_, err := s.Store.UpdateWebhookDelivery(s.ctx, "delivery-1", params.UpdateWebhookDeliveryParams{NextAttemptAt: &nextAttempt})
+	s.Require().Nil(err)
+	q.inFlight = map[string]struct{}{}
+	s.Require().Equal([]string{"delivery-3"}, s.dispatched(q))
+
+	status := params.WebhookDeliveryCompleted
+	_, err = s.Store.UpdateWebhookDelivery(s.ctx, "delivery-1", params.UpdateWebhookDeliveryParams{Status: &status})
+	s.Require().Nil(err)
+	q.inFlight = map[string]struct{}{}
+	s.Require().Equal([]string{"delivery-2", "delivery-3"}, s.dispatched(q))
+}
+
+func (s *WebhookQueueTestSuite) TestQueueProcessesDeliveriesOfAJobInOrder() {
+	for idx, action := range []string{"queued", "in_progress", "completed"} {
+		s.createJobDelivery(fmt.Sprintf("job-1-%d", idx), 1, action)
+		s.createJobDelivery(fmt.Sprintf("job-2-%d", idx), 2, action)
+	}
+
+	var mux sync.Mutex
+	processed := map[int64][]string{}
+	running := map[int64]bool{}
+	done := make(chan struct{})
+	q := newWebhookQueue(s.ctx, s.Store, func(delivery params.WebhookDelivery) error {
+		jobID := deliveryJobID(delivery)
+		mux.Lock()
+		if running[jobID] {
+			mux.Unlock()
+			return fmt.Errorf("deliveries of job %d processed concurrently", jobID)
+		}
+		running[jobID] = true
+		mux.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mux.Lock()
+		defer mux.Unlock()
+		running[jobID] = false
+		processed[jobID] = append(processed[jobID], delivery.ID)
+		if len(processed[1])+len(processed[2]) == 6 {
+			close(done)
+		}
+		return nil
+	})
+
+	q.Start()
+	defer q.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		s.FailNow("timed out waiting for deliveries to be processed")
+	}
+	s.Require().Equal([]string{"job-1-0", "job-1-1", "job-1-2"}, processed[1])
+	s.Require().Equal([]string{"job-2-0", "job-2-1", "job-2-2"}, processed[2])
+}
+
+func TestWebhookQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookQueueTestSuite))
+}
@@ -30,6 +30,18 @@ const (
 
 	// metrics data update interval
 	DefaultMetricsUpdateInterval = 60 * time.Second
+
+	// DefaultWebhookSecretGracePeriod is the amount of time a rotated webhook
+	// secret is still accepted.
+	DefaultWebhookSecretGracePeriod = 1 * time.Hour
+
+	// ControllerHeartbeatInterval is how often a running GARM server records
+	// a heartbeat in the database.
+	ControllerHeartbeatInterval = 30 * time.Second
+
+	// ControllerHeartbeatTimeout is the amount of time after which a controller
+	// that stopped recording heartbeats is no longer considered active.
+	ControllerHeartbeatTimeout = 4 * ControllerHeartbeatInterval
 )
 
 var Version string
@@ -112,6 +112,30 @@ func (g *githubClient) CreateEntityHook(ctx context.Context, hook *github.Hook)
 	return ret, err
 }
 
+func (g *githubClient) EditEntityHook(ctx context.Context, id int64, hook *github.Hook) (ret *github.Hook, err error) {
+	metrics.GithubOperationCount.WithLabelValues(
+		"EditHook",            // label: operation
+		g.entity.LabelScope(), // label: scope
+	).Inc()
+	defer func() {
+		if err != nil {
+			metrics.GithubOperationFailedCount.WithLabelValues(
+				"EditHook",            // label: operation
+				g.entity.LabelScope(), // label: scope
+			).Inc()
+		}
+	}()
+	switch g.entity.EntityType {
+	case params.GithubEntityTypeRepository:
+		ret, _, err = g.repo.EditHook(ctx, g.entity.Owner, g.entity.Name, id, hook)
+	case params.GithubEntityTypeOrganization:
+		ret, _, err = g.org.EditHook(ctx, g.entity.Owner, id, hook)
+	default:
+		return nil, errors.New("invalid entity type")
+	}
+	return ret, err
+}
+
 func (g *githubClient) DeleteEntityHook(ctx context.Context, id int64) (ret *github.Response, err error) {
 	metrics.GithubOperationCount.WithLabelValues(
 		"DeleteHook",          // label: operation
@@ -160,6 +184,54 @@ func (g *githubClient) PingEntityHook(ctx context.Context, id int64) (ret *githu
 	return ret, err
 }
 
+func (g *githubClient) ListEntityHookDeliveries(ctx context.Context, id int64, opts *github.ListCursorOptions) (ret []*github.HookDelivery, response *github.Response, err error) {
+	metrics.GithubOperationCount.WithLabelValues(
+		"ListHookDeliveries",  // label: operation
+		g.entity.LabelScope(), // label: scope
+	).Inc()
+	defer func() {
+		if err != nil {
+			metrics.GithubOperationFailedCount.WithLabelValues(
+				"ListHookDeliveries",  // label: operation
+				g.entity.LabelScope(), // label: scope
+			).Inc()
+		}
+	}()
+	switch g.entity.EntityType {
+	case params.GithubEntityTypeRepository:
+		ret, response, err = g.repo.ListHookDeliveries(ctx, g.entity.Owner, g.entity.Name, id, opts)
+	case params.GithubEntityTypeOrganization:
+		ret, response, err = g.org.ListHookDeliveries(ctx, g.entity.Owner, id, opts)
+	default:
+		return nil, nil, fmt.Errorf("invalid entity type: %s", g.entity.EntityType)
+	}
+	return ret, response, err
+}
+
+func (g *githubClient) GetEntityHookDelivery(ctx context.Context, hookID, deliveryID int64) (ret *github.HookDelivery, err error) {
+	metrics.GithubOperationCount.WithLabelValues(
+		"GetHookDelivery",     // label: operation
+		g.entity.LabelScope(), // label: scope
+	).Inc()
+	defer func() {
+		if err != nil {
+			metrics.GithubOperationFailedCount.WithLabelValues(
+				"GetHookDelivery",     // label: operation
+				g.entity.LabelScope(), // label: scope
+			).Inc()
+		}
+	}()
+	switch g.entity.EntityType {
+	case params.GithubEntityTypeRepository:
+		ret, _, err = g.repo.GetHookDelivery(ctx, g.entity.Owner, g.entity.Name, hookID, deliveryID)
+	case params.GithubEntityTypeOrganization:
+		ret, _, err = g.org.GetHookDelivery(ctx, g.entity.Owner, hookID, deliveryID)
+	default:
+		return nil, errors.New("invalid entity type")
+	}
+	return ret, err
+}
+
 func (g *githubClient) ListEntityRunners(ctx context.Context, opts *github.ListOptions) (*github.Runners, *github.Response, error) {
 	var ret *github.Runners
 	var response *github.Response
@@ -192,6 +264,65 @@ func (g *githubClient) ListEntityRunners(ctx context.Context, opts *github.ListO
 	return ret, response, err
 }
 
+// GetEntityRunner fetches a runner of the entity by its ID. An error wrapping
+// ErrNotFound is returned if the runner is not registered.
+func (g *githubClient) GetEntityRunner(ctx context.Context, runnerID int64) (*github.Runner, error) {
+	var ret *github.Runner
+	var response *github.Response
+	var err error
+
+	metrics.GithubOperationCount.WithLabelValues(
+		"GetEntityRunner",     // label: operation
+		g.entity.LabelScope(), // label: scope
+	).Inc()
+	defer func() {
+		if err != nil && !errors.Is(err, runnerErrors.ErrNotFound) {
+			metrics.GithubOperationFailedCount.WithLabelValues(
+				"GetEntityRunner",     // label: operation
+				g.entity.LabelScope(), // label: scope
+			).Inc()
+		}
+	}()
+
+	switch g.entity.EntityType {
+	case params.GithubEntityTypeRepository:
+		ret, response, err = g.GetRunner(ctx, g.entity.Owner, g.entity.Name, runnerID)
+	case params.GithubEntityTypeOrganization:
+		ret, response, err = g.GetOrganizationRunner(ctx, g.entity.Owner, runnerID)
+	case params.GithubEntityTypeEnterprise:
+		// The enterprise API has no call to fetch a single runner.
+		ret, err = g.findEnterpriseRunner(ctx, runnerID)
+	default:
+		return nil, errors.New("invalid entity type")
+	}
+
+	if err != nil && response != nil && response.StatusCode == http.StatusNotFound {
+		err = errors.Wrapf(runnerErrors.ErrNotFound, "runner %d", runnerID)
+	}
+	return ret, err
+}
+
+func (g *githubClient) findEnterpriseRunner(ctx context.Context, runnerID int64) (*github.Runner, error) {
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+	for {
+		runners, response, err := g.enterprise.ListRunners(ctx, g.entity.Owner, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, runner := range runners.Runners {
+			if runner.GetID() == runnerID {
+				return runner, nil
+			}
+		}
+		if response.NextPage == 0 {
+			return nil, errors.Wrapf(runnerErrors.ErrNotFound, "runner %d", runnerID)
+		}
+		opts.Page = response.NextPage
+	}
+}
+
 func (g *githubClient) ListEntityRunnerApplicationDownloads(ctx context.Context) ([]*github.RunnerApplicationDownload, *github.Response, error) {
 	var ret []*github.RunnerApplicationDownload
 	var response *github.Response